@@ -72,6 +72,7 @@ func main() {
 			"operation execution error",
 			"phase", report.Error.ErrorPhase,
 			"reason", report.Error.Message,
+			"diff", report.Error.Diff,
 		)
 		os.Exit(1)
 	}
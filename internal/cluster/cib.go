@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	cibShadowPrefix = "workbench-cib-verify"
+	cibShadowEnv    = "CIB_shadow"
+	crmDiffPattern  = "<diff"
+)
+
+// CIB is a copy of the Pacemaker cluster information base as returned by `cibadmin --query`.
+// The CIB version is given by the admin_epoch, epoch and num_updates attributes, in this order.
+type CIB struct {
+	AdminEpoch   int
+	Epoch        int
+	NumUpdates   int
+	ValidateWith string
+	// Content is the complete CIB XML document, including the status section
+	Content []byte
+	// Configuration is the configuration section XML, the only section that is restored,
+	// as the status section is computed by the cluster
+	Configuration []byte
+}
+
+type cibXML struct {
+	AdminEpoch    int    `xml:"admin_epoch,attr"`
+	Epoch         int    `xml:"epoch,attr"`
+	NumUpdates    int    `xml:"num_updates,attr"`
+	ValidateWith  string `xml:"validate-with,attr"`
+	Configuration *struct {
+		Inner []byte `xml:",innerxml"`
+	} `xml:"configuration"`
+}
+
+// Checksum returns the sha256 checksum of the CIB XML document
+func (c *CIB) Checksum() string {
+	sum := sha256.Sum256(c.Content)
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseCIB parses a CIB XML document, as stored in the snapshot files
+func ParseCIB(content []byte) (*CIB, error) {
+	var cib cibXML
+	if err := xml.Unmarshal(content, &cib); err != nil {
+		return nil, err
+	}
+
+	if cib.Configuration == nil {
+		return nil, fmt.Errorf("configuration section not found in CIB")
+	}
+
+	configuration := fmt.Appendf(nil, "<configuration>%s</configuration>", cib.Configuration.Inner)
+
+	return &CIB{
+		AdminEpoch:    cib.AdminEpoch,
+		Epoch:         cib.Epoch,
+		NumUpdates:    cib.NumUpdates,
+		ValidateWith:  cib.ValidateWith,
+		Content:       content,
+		Configuration: configuration,
+	}, nil
+}
+
+// GetCIB returns the live CIB using `cibadmin --query`
+func (c *Client) GetCIB(ctx context.Context) (*CIB, error) {
+	output, err := c.executor.Exec(ctx, "cibadmin", "--query")
+	if err != nil {
+		return nil, fmt.Errorf("error querying CIB: %w, output: %s", err, string(output))
+	}
+
+	cib, err := ParseCIB(output)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CIB: %w", err)
+	}
+
+	return cib, nil
+}
+
+// VerifyCIBInShadow validates the given CIB loading it in an empty shadow CIB and
+// running `crm_verify` against it, so the live CIB is not modified.
+// The shadow CIB has a random name, so concurrent verifications don't overwrite each other,
+// and it is removed once the verification finishes.
+func (c *Client) VerifyCIBInShadow(ctx context.Context, cib *CIB) error {
+	cibFile, cleanup, err := writeTempCIBFile(cib.Content)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	cibShadowName := fmt.Sprintf("%s-%s", cibShadowPrefix, strings.ToLower(rand.Text()))
+
+	output, err := c.executor.Exec(ctx, "crm_shadow", "--batch", "--force", "--create-empty", cibShadowName)
+	if err != nil {
+		return fmt.Errorf("error creating shadow CIB: %w, output: %s", err, string(output))
+	}
+
+	defer func() {
+		output, err := c.executor.Exec(ctx, "crm_shadow", "--batch", "--force", "--delete", cibShadowName)
+		if err != nil {
+			c.logger.Warn("error deleting shadow CIB", "shadow", cibShadowName, "error", err, "output", string(output))
+		}
+	}()
+
+	shadowEnv := fmt.Sprintf("%s=%s", cibShadowEnv, cibShadowName)
+
+	output, err = c.executor.Exec(ctx, "env", shadowEnv, "cibadmin", "--replace", "--xml-file", cibFile)
+	if err != nil {
+		return fmt.Errorf("error loading CIB in shadow CIB: %w, output: %s", err, string(output))
+	}
+
+	output, err = c.executor.Exec(ctx, "env", shadowEnv, "crm_verify", "--live-check")
+	if err != nil {
+		return fmt.Errorf("CIB verification failed: %w, output: %s", err, string(output))
+	}
+
+	c.logger.Info("CIB verified successfully in shadow CIB", "epoch", cib.Epoch, "admin_epoch", cib.AdminEpoch)
+	return nil
+}
+
+// DiffCIBConfigurations returns the differences between the configuration sections of the
+// given CIBs using `crm_diff`, ignoring the version details. An empty diff is returned if
+// both configurations are the same.
+// crm_diff exits with an error code when differences are found, so the output is
+// checked before reporting the error.
+func (c *Client) DiffCIBConfigurations(ctx context.Context, original, updated *CIB) (string, error) {
+	originalFile, cleanupOriginal, err := writeTempCIBFile(original.Configuration)
+	if err != nil {
+		return "", err
+	}
+	defer cleanupOriginal()
+
+	updatedFile, cleanupUpdated, err := writeTempCIBFile(updated.Configuration)
+	if err != nil {
+		return "", err
+	}
+	defer cleanupUpdated()
+
+	output, err := c.executor.Exec(
+		ctx, "crm_diff", "--no-version", "--original", originalFile, "--new", updatedFile,
+	)
+	diff := strings.TrimSpace(string(output))
+	if err != nil && !strings.HasPrefix(diff, crmDiffPattern) {
+		return "", fmt.Errorf("error comparing CIB configurations: %w, output: %s", err, diff)
+	}
+
+	return diff, nil
+}
+
+// ReplaceCIBConfiguration replaces the live CIB configuration section with the given CIB one
+// using `cibadmin --replace --scope configuration`. The cluster increases the CIB epoch,
+// so the replaced configuration is not rejected for being older than the live one.
+func (c *Client) ReplaceCIBConfiguration(ctx context.Context, cib *CIB) error {
+	configurationFile, cleanup, err := writeTempCIBFile(cib.Configuration)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	output, err := c.executor.Exec(
+		ctx, "cibadmin", "--replace", "--scope", "configuration", "--xml-file", configurationFile,
+	)
+	if err != nil {
+		return fmt.Errorf("error replacing CIB configuration: %w, output: %s", err, string(output))
+	}
+
+	c.logger.Info("CIB configuration replaced successfully")
+	return nil
+}
+
+func writeTempCIBFile(content []byte) (string, func(), error) {
+	file, err := os.CreateTemp("", "cib-*.xml")
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating temporary CIB file: %w", err)
+	}
+
+	cleanup := func() {
+		_ = os.Remove(file.Name())
+	}
+
+	_, err = file.Write(content)
+	if err = errors.Join(err, file.Close()); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("error writing temporary CIB file: %w", err)
+	}
+
+	return file.Name(), cleanup, nil
+}
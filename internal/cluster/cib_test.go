@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support/mocks"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+type CIBTestSuite struct {
+	suite.Suite
+	mockExecutor *mocks.MockCmdExecutor
+}
+
+func TestCIB(t *testing.T) {
+	suite.Run(t, new(CIBTestSuite))
+}
+
+func (suite *CIBTestSuite) SetupTest() {
+	suite.mockExecutor = mocks.NewMockCmdExecutor(suite.T())
+}
+
+func (suite *CIBTestSuite) TestGetCIB() {
+	ctx := context.Background()
+
+	content := helpers.ReadFixture("cluster/cibadmin_query.output")
+	suite.mockExecutor.On("Exec", ctx, "cibadmin", "--query").Return(content, nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	cib, err := clusterClient.GetCIB(ctx)
+	suite.NoError(err)
+	suite.Equal(1, cib.AdminEpoch)
+	suite.Equal(42, cib.Epoch)
+	suite.Equal(7, cib.NumUpdates)
+	suite.Equal("pacemaker-3.9", cib.ValidateWith)
+	suite.Equal(content, cib.Content)
+	suite.Contains(string(cib.Configuration), `<configuration>`)
+	suite.Contains(string(cib.Configuration), `<primitive id="rsc_ip_PRD_HDB00"`)
+	suite.NotContains(string(cib.Configuration), `<status>`)
+	suite.Len(cib.Checksum(), 64)
+}
+
+func (suite *CIBTestSuite) TestGetCIBError() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "cibadmin", "--query").
+		Return([]byte("Could not connect to the CIB"), errors.New("exit status 102"))
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	_, err := clusterClient.GetCIB(ctx)
+	suite.EqualError(err, "error querying CIB: exit status 102, output: Could not connect to the CIB")
+}
+
+func (suite *CIBTestSuite) TestParseCIBWithoutConfiguration() {
+	_, err := cluster.ParseCIB([]byte(`<cib epoch="1"><status/></cib>`))
+	suite.EqualError(err, "configuration section not found in CIB")
+}
+
+// expectShadowCIBVerification mocks the shadow CIB commands, checking that all of them
+// use the same randomly named shadow CIB
+func (suite *CIBTestSuite) expectShadowCIBVerification(ctx context.Context, verifyOutput []byte, verifyErr error) {
+	shadowName := ""
+	isShadow := mock.MatchedBy(func(name string) bool {
+		return name == shadowName
+	})
+	isShadowEnv := mock.MatchedBy(func(env string) bool {
+		return env == "CIB_shadow="+shadowName
+	})
+
+	suite.mockExecutor.
+		On("Exec", ctx, "crm_shadow", "--batch", "--force", "--create-empty", mock.MatchedBy(func(name string) bool {
+			// the matcher is evaluated against the arguments of the other calls as well
+			if !strings.HasPrefix(name, "workbench-cib-verify-") || len(name) == len("workbench-cib-verify-") {
+				return false
+			}
+			shadowName = name
+			return true
+		})).
+		Return([]byte(""), nil).Once()
+	suite.mockExecutor.
+		On("Exec", ctx, "env", isShadowEnv, "cibadmin", "--replace", "--xml-file", mock.AnythingOfType("string")).
+		Return([]byte(""), nil).Once()
+	suite.mockExecutor.
+		On("Exec", ctx, "env", isShadowEnv, "crm_verify", "--live-check").
+		Return(verifyOutput, verifyErr).Once()
+	suite.mockExecutor.
+		On("Exec", ctx, "crm_shadow", "--batch", "--force", "--delete", isShadow).
+		Return([]byte(""), nil).Once()
+}
+
+func (suite *CIBTestSuite) TestVerifyCIBInShadow() {
+	ctx := context.Background()
+
+	cib, err := cluster.ParseCIB(helpers.ReadFixture("cluster/cibadmin_query.output"))
+	suite.NoError(err)
+
+	suite.expectShadowCIBVerification(ctx, []byte(""), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	suite.NoError(clusterClient.VerifyCIBInShadow(ctx, cib))
+}
+
+func (suite *CIBTestSuite) TestVerifyCIBInShadowInvalid() {
+	ctx := context.Background()
+
+	cib, err := cluster.ParseCIB(helpers.ReadFixture("cluster/cibadmin_query.output"))
+	suite.NoError(err)
+
+	suite.expectShadowCIBVerification(
+		ctx,
+		[]byte("error: Resource start-up disabled since no STONITH resources have been defined"),
+		errors.New("exit status 78"),
+	)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	err = clusterClient.VerifyCIBInShadow(ctx, cib)
+	suite.EqualError(err, "CIB verification failed: exit status 78, output: "+
+		"error: Resource start-up disabled since no STONITH resources have been defined")
+}
+
+func (suite *CIBTestSuite) TestDiffCIBConfigurations() {
+	ctx := context.Background()
+
+	cib, err := cluster.ParseCIB(helpers.ReadFixture("cluster/cibadmin_query.output"))
+	suite.NoError(err)
+
+	diff := `<diff format="2">
+  <change operation="modify" path="/configuration/crm_config/cluster_property_set[@id='cib-bootstrap-options']">
+  </change>
+</diff>`
+
+	suite.mockExecutor.
+		On("Exec", ctx, "crm_diff", "--no-version", "--original", mock.AnythingOfType("string"),
+			"--new", mock.AnythingOfType("string")).
+		Return([]byte(diff+"\n"), errors.New("exit status 1")).Once()
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	result, err := clusterClient.DiffCIBConfigurations(ctx, cib, cib)
+	suite.NoError(err)
+	suite.Equal(diff, result)
+}
+
+func (suite *CIBTestSuite) TestDiffCIBConfigurationsEqual() {
+	ctx := context.Background()
+
+	cib, err := cluster.ParseCIB(helpers.ReadFixture("cluster/cibadmin_query.output"))
+	suite.NoError(err)
+
+	suite.mockExecutor.
+		On("Exec", ctx, "crm_diff", "--no-version", "--original", mock.AnythingOfType("string"),
+			"--new", mock.AnythingOfType("string")).
+		Return([]byte(""), nil).Once()
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	result, err := clusterClient.DiffCIBConfigurations(ctx, cib, cib)
+	suite.NoError(err)
+	suite.Empty(result)
+}
+
+func (suite *CIBTestSuite) TestDiffCIBConfigurationsError() {
+	ctx := context.Background()
+
+	cib, err := cluster.ParseCIB(helpers.ReadFixture("cluster/cibadmin_query.output"))
+	suite.NoError(err)
+
+	suite.mockExecutor.
+		On("Exec", ctx, "crm_diff", "--no-version", "--original", mock.AnythingOfType("string"),
+			"--new", mock.AnythingOfType("string")).
+		Return([]byte("Could not parse input"), errors.New("exit status 65")).Once()
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	_, err = clusterClient.DiffCIBConfigurations(ctx, cib, cib)
+	suite.EqualError(err, "error comparing CIB configurations: exit status 65, output: Could not parse input")
+}
+
+func (suite *CIBTestSuite) TestReplaceCIBConfiguration() {
+	ctx := context.Background()
+
+	cib, err := cluster.ParseCIB(helpers.ReadFixture("cluster/cibadmin_query.output"))
+	suite.NoError(err)
+
+	suite.mockExecutor.
+		On("Exec", ctx, "cibadmin", "--replace", "--scope", "configuration", "--xml-file",
+			mock.AnythingOfType("string")).
+		Return([]byte(""), nil).Once()
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	suite.NoError(clusterClient.ReplaceCIBConfiguration(ctx, cib))
+}
+
+func (suite *CIBTestSuite) TestVerifyCIBInShadowUniqueName() {
+	ctx := context.Background()
+
+	cib, err := cluster.ParseCIB(helpers.ReadFixture("cluster/cibadmin_query.output"))
+	suite.NoError(err)
+
+	shadowNames := []string{}
+	suite.mockExecutor.
+		On("Exec", ctx, "crm_shadow", "--batch", "--force", "--create-empty", mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) {
+			shadowNames = append(shadowNames, args.String(5))
+		}).
+		Return([]byte("shadow exists"), errors.New("exit status 1")).Twice()
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	suite.Error(clusterClient.VerifyCIBInShadow(ctx, cib))
+	suite.Error(clusterClient.VerifyCIBInShadow(ctx, cib))
+	suite.Len(shadowNames, 2)
+	suite.NotEqual(shadowNames[0], shadowNames[1])
+}
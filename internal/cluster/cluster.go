@@ -5,121 +5,223 @@ package cluster
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
+	"os/exec"
 	"regexp"
 	"strings"
 
 	"github.com/trento-project/workbench/internal/support"
 )
 
-const resourceRefreshedMessage = "got reply (done)"
+// Toolchain is the cluster management command line tool used to manage the cluster
+type Toolchain string
 
-var clusterIdlePatternCompiled = regexp.MustCompile("S_IDLE")
+const (
+	CrmshToolchain Toolchain = "crmsh"
+	PcsToolchain   Toolchain = "pcs"
+)
+
+const (
+	crmCommand               = "crm"
+	pcsCommand               = "pcs"
+	resourceRefreshedMessage = "got reply (done)"
+)
+
+var (
+	clusterIdlePatternCompiled = regexp.MustCompile("S_IDLE")
+	// stonith_admin summary line, e.g. 1 fence device found
+	fenceDevicesFoundPatternCompiled = regexp.MustCompile(`^(\d+|No) fence devices? found$`)
+)
 
 type Cluster interface {
 	IsHostOnline(ctx context.Context) bool
 	IsIdle(ctx context.Context) (bool, error)
+	WaitForIdle(ctx context.Context, options WaitForIdleOptions) error
 	ResourceRefresh(ctx context.Context, resourceID, nodeID string) error
+	ResourceCleanup(ctx context.Context, resourceID, nodeID string) error
 	StartCluster(ctx context.Context) error
 	StopCluster(ctx context.Context) error
+	StartClusterNodes(ctx context.Context, nodes ...string) error
+	StopClusterNodes(ctx context.Context, nodes ...string) error
+	GetClusterStackStates(ctx context.Context) (map[string]bool, error)
+	GetCIB(ctx context.Context) (*CIB, error)
+	VerifyCIBInShadow(ctx context.Context, cib *CIB) error
+	DiffCIBConfigurations(ctx context.Context, original, updated *CIB) (string, error)
+	ReplaceCIBConfiguration(ctx context.Context, cib *CIB) error
+	GetProperty(ctx context.Context, set PropertySet, name string) (string, bool, error)
+	SetProperty(ctx context.Context, set PropertySet, name, value string) error
+	DeleteProperty(ctx context.Context, set PropertySet, name string) error
+	GetHanaSRAttributes(ctx context.Context) (*HanaSRAttributes, error)
+	GetStatus(ctx context.Context) (*Status, error)
+	LocalNodeName(ctx context.Context) (string, error)
+	RunPreflightChecks(ctx context.Context, options PreflightOptions) (*PreflightReport, error)
+	ListStonithDevices(ctx context.Context) ([]string, error)
+	GetCorosyncLinks(ctx context.Context) (*CorosyncLinks, error)
+	GetQuorumStatus(ctx context.Context) (*QuorumStatus, error)
+	GetLocationConstraints(ctx context.Context) ([]LocationConstraint, error)
+	AddLocationConstraint(ctx context.Context, constraint LocationConstraint) error
+	DeleteConstraint(ctx context.Context, constraintID string) error
+	GetMaintenanceState(ctx context.Context, resourceID, nodeID string) (bool, error)
+	SetMaintenanceState(ctx context.Context, resourceID, nodeID string, maintenance bool) error
+	GetNodeAttribute(
+		ctx context.Context, nodeID string, attributeType NodeAttributeType, name string,
+	) (string, bool, error)
+	SetNodeAttribute(ctx context.Context, nodeID string, attributeType NodeAttributeType, name, value string) error
+	DeleteNodeAttribute(ctx context.Context, nodeID string, attributeType NodeAttributeType, name string) error
+}
+
+// backend runs the commands that depend on the cluster management toolchain, crmsh or pcs.
+// The pacemaker and corosync command line tools are used directly by the client,
+// as they are available with both toolchains.
+type backend interface {
+	isHostOnline(ctx context.Context) bool
+	startCluster(ctx context.Context) error
+	stopCluster(ctx context.Context) error
+	startClusterNodes(ctx context.Context, nodes []string) error
+	stopClusterNodes(ctx context.Context, nodes []string) error
+	getClusterStackStates(ctx context.Context) (map[string]bool, error)
+	isIdle(ctx context.Context) (bool, error)
+	resourceRefresh(ctx context.Context, resourceID, nodeID string) error
+	addLocationConstraint(ctx context.Context, constraint LocationConstraint) error
+	deleteConstraint(ctx context.Context, constraintID string) error
+	getMaintenanceState(ctx context.Context, resourceID, nodeID string) (bool, error)
+	setMaintenanceState(ctx context.Context, resourceID, nodeID string, maintenance bool) error
+	getNodeAttribute(
+		ctx context.Context, nodeID string, attributeType NodeAttributeType, name string,
+	) (string, bool, error)
+	setNodeAttribute(ctx context.Context, nodeID string, attributeType NodeAttributeType, name, value string) error
+	deleteNodeAttribute(ctx context.Context, nodeID string, attributeType NodeAttributeType, name string) error
 }
 
 type Client struct {
 	executor support.CmdExecutor
 	logger   *slog.Logger
+	backend  backend
 }
 
+// NewDefaultClusterClient returns a cluster client using the cluster management toolchain
+// installed in the host
 func NewDefaultClusterClient() Cluster {
-	return NewClusterClient(
+	return NewClusterClientWithToolchain(
+		DetectToolchain(exec.LookPath),
 		support.CliExecutor{},
 		slog.Default(),
 	)
 }
 
+// NewClusterClient returns a cluster client using the crmsh toolchain
 func NewClusterClient(executor support.CmdExecutor, logger *slog.Logger) Cluster {
+	return NewClusterClientWithToolchain(CrmshToolchain, executor, logger)
+}
+
+func NewClusterClientWithToolchain(toolchain Toolchain, executor support.CmdExecutor, logger *slog.Logger) Cluster {
+	var clusterBackend backend = &crmshBackend{executor: executor, logger: logger}
+	if toolchain == PcsToolchain {
+		clusterBackend = &pcsBackend{executor: executor, logger: logger}
+	}
+
 	return &Client{
 		executor: executor,
 		logger:   logger,
+		backend:  clusterBackend,
 	}
 }
 
-func (c *Client) IsHostOnline(ctx context.Context) bool {
-	output, err := c.executor.Exec(ctx, "crm", "status")
-	if err != nil {
-		return false
+// DetectToolchain returns the cluster management toolchain installed in the host,
+// looking for the crm and pcs commands. crmsh is used if none of them is found.
+func DetectToolchain(lookPath func(file string) (string, error)) Toolchain {
+	if _, err := lookPath(crmCommand); err == nil {
+		return CrmshToolchain
 	}
 
-	c.logger.Debug("CRM status output", "output", string(output))
+	if _, err := lookPath(pcsCommand); err == nil {
+		return PcsToolchain
+	}
 
-	return true
+	return CrmshToolchain
 }
 
-func (c *Client) StartCluster(ctx context.Context) error {
-	c.logger.Info("Starting CRM cluster")
-	output, err := c.executor.Exec(ctx, "crm", "cluster", "start")
-	if err != nil {
-		return fmt.Errorf("failed to start CRM cluster: %w, output: %s", err, string(output))
-	}
+func (c *Client) IsHostOnline(ctx context.Context) bool {
+	return c.backend.isHostOnline(ctx)
+}
 
-	c.logger.Info("CRM cluster started successfully")
-	return nil
+func (c *Client) StartCluster(ctx context.Context) error {
+	return c.backend.startCluster(ctx)
 }
 
 func (c *Client) StopCluster(ctx context.Context) error {
-	c.logger.Info("Stopping CRM cluster")
-	output, err := c.executor.Exec(ctx, "crm", "cluster", "stop")
-	if err != nil {
-		return fmt.Errorf("failed to stop CRM cluster: %w, output: %s", err, string(output))
-	}
+	return c.backend.stopCluster(ctx)
+}
 
-	c.logger.Info("CRM cluster stopped successfully")
-	return nil
+// StartClusterNodes starts the cluster stack in the given nodes.
+// The cluster stack is started in all the nodes if no node is given.
+func (c *Client) StartClusterNodes(ctx context.Context, nodes ...string) error {
+	return c.backend.startClusterNodes(ctx, nodes)
 }
 
+// StopClusterNodes stops the cluster stack in the given nodes.
+// The cluster stack is stopped in all the nodes if no node is given.
+func (c *Client) StopClusterNodes(ctx context.Context, nodes ...string) error {
+	return c.backend.stopClusterNodes(ctx, nodes)
+}
+
+// GetClusterStackStates returns whether the pacemaker service is active in each of the cluster nodes
+func (c *Client) GetClusterStackStates(ctx context.Context) (map[string]bool, error) {
+	return c.backend.getClusterStackStates(ctx)
+}
+
+// IsIdle returns true if the cluster controller is in the S_IDLE state
 func (c *Client) IsIdle(ctx context.Context) (bool, error) {
-	idleOutput, err := c.executor.Exec(ctx, "cs_clusterstate", "-i")
+	return c.backend.isIdle(ctx)
+}
+
+// ResourceRefresh refreshes the state of the given resource in the given node.
+// All the resources and nodes are refreshed if no resource or node is given.
+func (c *Client) ResourceRefresh(ctx context.Context, resourceID, nodeID string) error {
+	return c.backend.resourceRefresh(ctx, resourceID, nodeID)
+}
+
+// ListStonithDevices returns the fencing devices registered in the local fencer
+// using `stonith_admin --list-registered`
+func (c *Client) ListStonithDevices(ctx context.Context) ([]string, error) {
+	output, err := c.executor.Exec(ctx, "stonith_admin", "--list-registered")
 	if err != nil {
-		return false, fmt.Errorf("error running cs_clusterstate: %w", err)
+		return nil, fmt.Errorf("error listing registered fencing devices: %w, output: %s", err, string(output))
 	}
 
-	if !clusterIdlePatternCompiled.Match(idleOutput) {
-		return false, nil
+	devices := []string{}
+	for line := range strings.Lines(string(output)) {
+		line = strings.TrimSpace(line)
+		if line == "" || fenceDevicesFoundPatternCompiled.MatchString(line) {
+			continue
+		}
+		devices = append(devices, line)
 	}
 
-	return true, nil
+	return devices, nil
 }
 
-// ResourceRefresh runs the `crm resource refresh [<rsc>] [<node>]` command.
-// https://crmsh.github.io/man-5.0/#cmdhelp.resource.refresh
-// The node argument requires the resource beforehand.
-// If the given node is not found, the command does not return -1, so the
-// std output must be compared to see if it returns a correct value.
-func (c *Client) ResourceRefresh(ctx context.Context, resourceID, nodeID string) error {
-	if nodeID != "" && resourceID == "" {
-		return errors.New("nodeID cannot be provided without a resourceID")
-	}
-
-	args := []string{"resource", "refresh"}
+// ResourceCleanup clears the failed operations and fail counts of the resources
+// using `crm_resource --cleanup [--resource <rsc>] [--node <node>]`.
+// Only the resources with failures are cleaned, and the cluster reprobes them afterwards.
+// All the resources and nodes are cleaned up if no resource or node is given.
+func (c *Client) ResourceCleanup(ctx context.Context, resourceID, nodeID string) error {
+	args := []string{"--cleanup"}
 	if resourceID != "" {
-		args = append(args, resourceID)
+		args = append(args, "--resource", resourceID)
 	}
 
 	if nodeID != "" {
-		args = append(args, nodeID)
+		args = append(args, "--node", nodeID)
 	}
 
-	c.logger.Info("Refreshing cluster resource", "resourceID", resourceID, "nodeID", nodeID)
-	output, err := c.executor.Exec(ctx, "crm", args...)
+	c.logger.Info("Cleaning up cluster resource", "resourceID", resourceID, "nodeID", nodeID)
+	output, err := c.executor.Exec(ctx, "crm_resource", args...)
 	if err != nil {
-		return fmt.Errorf("failed to refresh resource: %w, output: %s", err, string(output))
-	}
-
-	if !strings.Contains(string(output), resourceRefreshedMessage) {
-		return fmt.Errorf("failed to refresh resource, unexpected output: %s", string(output))
+		return fmt.Errorf("failed to clean up resource: %w, output: %s", err, string(output))
 	}
 
-	c.logger.Info("Cluster resource refreshed successfully")
+	c.logger.Info("Cluster resource cleaned up successfully", "output", string(output))
 	return nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/trento-project/workbench/internal/cluster"
 	"github.com/trento-project/workbench/internal/support/mocks"
+	"github.com/trento-project/workbench/test/helpers"
 )
 
 type CrmTestSuite struct {
@@ -163,3 +164,167 @@ func (suite *CrmTestSuite) TestResourceRefreshUnexpectedOutputError() {
 	suite.Contains(err.Error(), "failed to refresh resource, unexpected output")
 	suite.Contains(err.Error(), "unexpected output")
 }
+
+func (suite *CrmTestSuite) TestStartClusterNodes() {
+	ctx := context.Background()
+
+	mockExecutor := mocks.NewMockCmdExecutor(suite.T())
+	mockExecutor.On("Exec", ctx, "crm", "cluster", "start", "vmhana01", "vmhana02").Return([]byte(""), nil)
+
+	crmClient := cluster.NewClusterClient(mockExecutor, slog.Default())
+
+	err := crmClient.StartClusterNodes(ctx, "vmhana01", "vmhana02")
+	suite.NoError(err)
+}
+
+func (suite *CrmTestSuite) TestStartClusterNodesAll() {
+	ctx := context.Background()
+
+	mockExecutor := mocks.NewMockCmdExecutor(suite.T())
+	mockExecutor.On("Exec", ctx, "crm", "cluster", "start", "--all").Return([]byte(""), nil)
+
+	crmClient := cluster.NewClusterClient(mockExecutor, slog.Default())
+
+	err := crmClient.StartClusterNodes(ctx)
+	suite.NoError(err)
+}
+
+func (suite *CrmTestSuite) TestStopClusterNodesAll() {
+	ctx := context.Background()
+
+	mockExecutor := mocks.NewMockCmdExecutor(suite.T())
+	mockExecutor.On("Exec", ctx, "crm", "cluster", "stop", "--all").Return([]byte(""), nil)
+
+	crmClient := cluster.NewClusterClient(mockExecutor, slog.Default())
+
+	err := crmClient.StopClusterNodes(ctx)
+	suite.NoError(err)
+}
+
+func (suite *CrmTestSuite) TestStopClusterNodesError() {
+	ctx := context.Background()
+
+	mockExecutor := mocks.NewMockCmdExecutor(suite.T())
+	mockExecutor.On("Exec", ctx, "crm", "cluster", "stop", "vmhana02").
+		Return([]byte("ssh error"), errors.New("exit status 1"))
+
+	crmClient := cluster.NewClusterClient(mockExecutor, slog.Default())
+
+	err := crmClient.StopClusterNodes(ctx, "vmhana02")
+	suite.EqualError(err, "failed to stop CRM cluster in nodes [vmhana02]: exit status 1, output: ssh error")
+}
+
+func (suite *CrmTestSuite) TestGetClusterStackStates() {
+	ctx := context.Background()
+
+	mockExecutor := mocks.NewMockCmdExecutor(suite.T())
+	mockExecutor.On("Exec", ctx, "crm", "cluster", "run", "systemctl is-active pacemaker.service").
+		Return(helpers.ReadFixture("cluster/crm_cluster_run_pacemaker_active.output"), errors.New("exit status 1"))
+
+	crmClient := cluster.NewClusterClient(mockExecutor, slog.Default())
+
+	states, err := crmClient.GetClusterStackStates(ctx)
+	suite.NoError(err)
+	suite.Equal(map[string]bool{"vmhana01": true, "vmhana02": false}, states)
+}
+
+func (suite *CrmTestSuite) TestGetClusterStackStatesUnreachableNode() {
+	ctx := context.Background()
+
+	output := `INFO: [vmhana01]
+active
+ERROR: [vmhana02]: ssh: connect to host vmhana02 port 22: No route to host`
+
+	mockExecutor := mocks.NewMockCmdExecutor(suite.T())
+	mockExecutor.On("Exec", ctx, "crm", "cluster", "run", "systemctl is-active pacemaker.service").
+		Return([]byte(output), errors.New("exit status 1"))
+
+	crmClient := cluster.NewClusterClient(mockExecutor, slog.Default())
+
+	_, err := crmClient.GetClusterStackStates(ctx)
+	suite.ErrorContains(err, "could not run command in node vmhana02: ssh: connect to host vmhana02 port 22")
+}
+
+func (suite *CrmTestSuite) TestGetClusterStackStatesNoNodes() {
+	ctx := context.Background()
+
+	mockExecutor := mocks.NewMockCmdExecutor(suite.T())
+	mockExecutor.On("Exec", ctx, "crm", "cluster", "run", "systemctl is-active pacemaker.service").
+		Return([]byte("ERROR: cluster.run: No nodes found"), errors.New("exit status 1"))
+
+	crmClient := cluster.NewClusterClient(mockExecutor, slog.Default())
+
+	_, err := crmClient.GetClusterStackStates(ctx)
+	suite.EqualError(err, "error getting cluster stack states, no node found: exit status 1, "+
+		"output: ERROR: cluster.run: No nodes found")
+}
+
+func (suite *CrmTestSuite) TestListStonithDevices() {
+	ctx := context.Background()
+
+	mockExecutor := mocks.NewMockCmdExecutor(suite.T())
+	mockExecutor.On("Exec", ctx, "stonith_admin", "--list-registered").
+		Return(helpers.ReadFixture("cluster/stonith_admin_list_registered.output"), nil)
+
+	crmClient := cluster.NewClusterClient(mockExecutor, slog.Default())
+
+	devices, err := crmClient.ListStonithDevices(ctx)
+	suite.NoError(err)
+	suite.Equal([]string{"stonith-sbd"}, devices)
+}
+
+func (suite *CrmTestSuite) TestListStonithDevicesNoDevices() {
+	ctx := context.Background()
+
+	mockExecutor := mocks.NewMockCmdExecutor(suite.T())
+	mockExecutor.On("Exec", ctx, "stonith_admin", "--list-registered").
+		Return([]byte("0 fence devices found\n"), nil)
+
+	crmClient := cluster.NewClusterClient(mockExecutor, slog.Default())
+
+	devices, err := crmClient.ListStonithDevices(ctx)
+	suite.NoError(err)
+	suite.Empty(devices)
+}
+
+func (suite *CrmTestSuite) TestResourceCleanup() {
+	ctx := context.Background()
+
+	mockExecutor := mocks.NewMockCmdExecutor(suite.T())
+	mockExecutor.On("Exec", ctx, "crm_resource", "--cleanup").Return([]byte("Cleaned up all resources on all nodes"), nil)
+
+	crmClient := cluster.NewClusterClient(mockExecutor, slog.Default())
+
+	err := crmClient.ResourceCleanup(ctx, "", "")
+	suite.NoError(err)
+}
+
+func (suite *CrmTestSuite) TestResourceCleanupWithResourceAndNode() {
+	ctx := context.Background()
+	commandOutput := `Cleaned up rsc_SAPHana_PRD_HDB00:0 on vmhana01
+Waiting for 1 reply from the controller
+... got reply (done)`
+
+	mockExecutor := mocks.NewMockCmdExecutor(suite.T())
+	mockExecutor.On(
+		"Exec", ctx, "crm_resource", "--cleanup", "--resource", "msl_SAPHana_PRD_HDB00", "--node", "vmhana01",
+	).Return([]byte(commandOutput), nil)
+
+	crmClient := cluster.NewClusterClient(mockExecutor, slog.Default())
+
+	err := crmClient.ResourceCleanup(ctx, "msl_SAPHana_PRD_HDB00", "vmhana01")
+	suite.NoError(err)
+}
+
+func (suite *CrmTestSuite) TestResourceCleanupError() {
+	ctx := context.Background()
+
+	mockExecutor := mocks.NewMockCmdExecutor(suite.T())
+	mockExecutor.On("Exec", ctx, "crm_resource", "--cleanup", "--node", "vmhana03").
+		Return([]byte("crm_resource: Node 'vmhana03' not found"), errors.New("exit status 105"))
+
+	crmClient := cluster.NewClusterClient(mockExecutor, slog.Default())
+
+	err := crmClient.ResourceCleanup(ctx, "", "vmhana03")
+	suite.EqualError(err, "failed to clean up resource: exit status 105, output: crm_resource: Node 'vmhana03' not found")
+}
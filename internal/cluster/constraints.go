@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type ConstraintKind string
+
+const (
+	// BanConstraintKind is the constraint created by `crm resource ban` to keep a resource away from a node
+	BanConstraintKind ConstraintKind = "ban"
+	// PreferConstraintKind is the constraint created by `crm resource move` to move a resource to a node
+	PreferConstraintKind ConstraintKind = "prefer"
+	// LocationConstraintKind is a named location constraint created in the cluster configuration
+	LocationConstraintKind ConstraintKind = "location"
+)
+
+const (
+	banConstraintPrefix    = "cli-ban-"
+	preferConstraintPrefix = "cli-prefer-"
+	banScore               = "-INFINITY"
+	preferScore            = "INFINITY"
+)
+
+var constraintRoles = []string{"Started", "Stopped", "Promoted", "Unpromoted", "Master", "Slave"}
+
+// LocationConstraint is a location constraint of a resource in a node.
+// Constraints defined with rules cannot be managed, so they are only flagged.
+type LocationConstraint struct {
+	ID       string
+	Resource string
+	Node     string
+	Score    string
+	Role     string
+	Rules    bool
+}
+
+type constraintsXML struct {
+	Locations []struct {
+		ID       string `xml:"id,attr"`
+		Resource string `xml:"rsc,attr"`
+		Node     string `xml:"node,attr"`
+		Score    string `xml:"score,attr"`
+		Role     string `xml:"role,attr"`
+		Rules    []struct {
+			ID string `xml:"id,attr"`
+		} `xml:"rule"`
+	} `xml:"rsc_location"`
+}
+
+// BanConstraintID returns the ID given by crm_resource to the constraint banning a resource from a node
+func BanConstraintID(resourceID, nodeID string) string {
+	return fmt.Sprintf("%s%s-on-%s", banConstraintPrefix, resourceID, nodeID)
+}
+
+// PreferConstraintID returns the ID given by crm_resource to the constraint moving a resource to a node
+func PreferConstraintID(resourceID string) string {
+	return preferConstraintPrefix + resourceID
+}
+
+// NewBanConstraint returns the constraint that keeps the resource away from the node
+func NewBanConstraint(resourceID, nodeID string) LocationConstraint {
+	return LocationConstraint{
+		ID:       BanConstraintID(resourceID, nodeID),
+		Resource: resourceID,
+		Node:     nodeID,
+		Score:    banScore,
+	}
+}
+
+// NewPreferConstraint returns the constraint that moves the resource to the node
+func NewPreferConstraint(resourceID, nodeID string) LocationConstraint {
+	return LocationConstraint{
+		ID:       PreferConstraintID(resourceID),
+		Resource: resourceID,
+		Node:     nodeID,
+		Score:    preferScore,
+	}
+}
+
+// Kind returns the kind of the constraint, based on the IDs used by crm_resource
+func (l *LocationConstraint) Kind() ConstraintKind {
+	switch {
+	case strings.HasPrefix(l.ID, banConstraintPrefix):
+		return BanConstraintKind
+	case strings.HasPrefix(l.ID, preferConstraintPrefix):
+		return PreferConstraintKind
+	default:
+		return LocationConstraintKind
+	}
+}
+
+// ValidateLocationConstraint checks that the constraint can be created with a node preference
+func ValidateLocationConstraint(constraint LocationConstraint) error {
+	if constraint.ID == "" {
+		return errors.New("constraint ID cannot be empty")
+	}
+
+	if constraint.Resource == "" || constraint.Node == "" {
+		return fmt.Errorf("constraint %s requires a resource and a node", constraint.ID)
+	}
+
+	if strings.ContainsAny(constraint.ID, " :") {
+		return fmt.Errorf("invalid constraint ID %s", constraint.ID)
+	}
+
+	if !scorePatternCompiled.MatchString(constraint.Score) {
+		return fmt.Errorf("invalid score %s for constraint %s", constraint.Score, constraint.ID)
+	}
+
+	if constraint.Role != "" && !slices.Contains(constraintRoles, constraint.Role) {
+		return fmt.Errorf("invalid role %s for constraint %s", constraint.Role, constraint.ID)
+	}
+
+	return nil
+}
+
+// GetLocationConstraints returns the location constraints of the cluster using
+// `cibadmin --query --scope constraints`
+func (c *Client) GetLocationConstraints(ctx context.Context) ([]LocationConstraint, error) {
+	output, err := c.executor.Exec(ctx, "cibadmin", "--query", "--scope", "constraints")
+	if err != nil {
+		return nil, fmt.Errorf("error querying cluster constraints: %w, output: %s", err, string(output))
+	}
+
+	var constraints constraintsXML
+	if err := xml.Unmarshal(output, &constraints); err != nil {
+		return nil, fmt.Errorf("error parsing cluster constraints: %w", err)
+	}
+
+	locations := make([]LocationConstraint, 0, len(constraints.Locations))
+	for _, location := range constraints.Locations {
+		locations = append(locations, LocationConstraint{
+			ID:       location.ID,
+			Resource: location.Resource,
+			Node:     location.Node,
+			Score:    location.Score,
+			Role:     location.Role,
+			Rules:    len(location.Rules) > 0,
+		})
+	}
+
+	return locations, nil
+}
+
+// AddLocationConstraint creates a location constraint with a node preference.
+// The ID of ban and prefer constraints must match the crm_resource ones,
+// so they can be removed with `crm_resource --clear` as well.
+func (c *Client) AddLocationConstraint(ctx context.Context, constraint LocationConstraint) error {
+	if err := ValidateLocationConstraint(constraint); err != nil {
+		return err
+	}
+
+	if err := c.backend.addLocationConstraint(ctx, constraint); err != nil {
+		return err
+	}
+
+	c.logger.Info("Location constraint added",
+		"id", constraint.ID, "resource", constraint.Resource, "node", constraint.Node, "score", constraint.Score)
+	return nil
+}
+
+// DeleteConstraint removes a constraint from the cluster configuration
+func (c *Client) DeleteConstraint(ctx context.Context, constraintID string) error {
+	if err := c.backend.deleteConstraint(ctx, constraintID); err != nil {
+		return err
+	}
+
+	c.logger.Info("Constraint deleted", "id", constraintID)
+	return nil
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support/mocks"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+type ConstraintsTestSuite struct {
+	suite.Suite
+	mockExecutor *mocks.MockCmdExecutor
+}
+
+func TestConstraints(t *testing.T) {
+	suite.Run(t, new(ConstraintsTestSuite))
+}
+
+func (suite *ConstraintsTestSuite) SetupTest() {
+	suite.mockExecutor = mocks.NewMockCmdExecutor(suite.T())
+}
+
+func (suite *ConstraintsTestSuite) TestConstraintKind() {
+	ban := cluster.NewBanConstraint("rsc_ip_PRD_HDB00", "vmhana01")
+	suite.Equal("cli-ban-rsc_ip_PRD_HDB00-on-vmhana01", ban.ID)
+	suite.Equal("-INFINITY", ban.Score)
+	suite.Equal(cluster.BanConstraintKind, ban.Kind())
+
+	prefer := cluster.NewPreferConstraint("rsc_ip_PRD_HDB00", "vmhana01")
+	suite.Equal("cli-prefer-rsc_ip_PRD_HDB00", prefer.ID)
+	suite.Equal("INFINITY", prefer.Score)
+	suite.Equal(cluster.PreferConstraintKind, prefer.Kind())
+
+	location := cluster.LocationConstraint{ID: "loc_ip_PRD_HDB00_vmhana01"}
+	suite.Equal(cluster.LocationConstraintKind, location.Kind())
+}
+
+func (suite *ConstraintsTestSuite) TestValidateLocationConstraint() {
+	cases := []struct {
+		constraint cluster.LocationConstraint
+		err        string
+	}{
+		{
+			constraint: cluster.LocationConstraint{ID: "loc", Resource: "rsc", Node: "vmhana01", Score: "-100"},
+		},
+		{
+			constraint: cluster.LocationConstraint{
+				ID: "loc", Resource: "rsc", Node: "vmhana01", Score: "+INFINITY", Role: "Promoted",
+			},
+		},
+		{
+			constraint: cluster.LocationConstraint{Resource: "rsc", Node: "vmhana01", Score: "100"},
+			err:        "constraint ID cannot be empty",
+		},
+		{
+			constraint: cluster.LocationConstraint{ID: "loc", Resource: "rsc", Score: "100"},
+			err:        "constraint loc requires a resource and a node",
+		},
+		{
+			constraint: cluster.LocationConstraint{ID: "loc 1", Resource: "rsc", Node: "vmhana01", Score: "100"},
+			err:        "invalid constraint ID loc 1",
+		},
+		{
+			constraint: cluster.LocationConstraint{ID: "loc", Resource: "rsc", Node: "vmhana01", Score: "high"},
+			err:        "invalid score high for constraint loc",
+		},
+		{
+			constraint: cluster.LocationConstraint{
+				ID: "loc", Resource: "rsc", Node: "vmhana01", Score: "100", Role: "Leader",
+			},
+			err: "invalid role Leader for constraint loc",
+		},
+	}
+
+	for _, tt := range cases {
+		err := cluster.ValidateLocationConstraint(tt.constraint)
+		if tt.err == "" {
+			suite.NoError(err)
+		} else {
+			suite.EqualError(err, tt.err)
+		}
+	}
+}
+
+func (suite *ConstraintsTestSuite) TestGetLocationConstraints() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "cibadmin", "--query", "--scope", "constraints").
+		Return(helpers.ReadFixture("cluster/cibadmin_constraints.output"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	constraints, err := clusterClient.GetLocationConstraints(ctx)
+	suite.NoError(err)
+	suite.Equal([]cluster.LocationConstraint{
+		{
+			ID:       "cli-ban-msl_SAPHana_PRD_HDB00-on-vmhana01",
+			Resource: "msl_SAPHana_PRD_HDB00",
+			Node:     "vmhana01",
+			Score:    "-INFINITY",
+			Role:     "Started",
+		},
+		{
+			ID:       "cli-prefer-rsc_ip_PRD_HDB00",
+			Resource: "rsc_ip_PRD_HDB00",
+			Node:     "vmhana02",
+			Score:    "INFINITY",
+			Role:     "Started",
+		},
+		{
+			ID:       "loc_ip_PRD_HDB00_vmhana01",
+			Resource: "rsc_ip_PRD_HDB00",
+			Node:     "vmhana01",
+			Score:    "100",
+		},
+		{
+			ID:       "loc_SAPHanaCon_ping",
+			Resource: "msl_SAPHana_PRD_HDB00",
+			Rules:    true,
+		},
+	}, constraints)
+}
+
+func (suite *ConstraintsTestSuite) TestGetLocationConstraintsError() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "cibadmin", "--query", "--scope", "constraints").
+		Return([]byte("Signon to CIB failed: Transport endpoint is not connected"), errors.New("exit status 102"))
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	constraints, err := clusterClient.GetLocationConstraints(ctx)
+	suite.Nil(constraints)
+	suite.EqualError(err, "error querying cluster constraints: exit status 102, "+
+		"output: Signon to CIB failed: Transport endpoint is not connected")
+}
+
+func (suite *ConstraintsTestSuite) TestAddLocationConstraint() {
+	ctx := context.Background()
+	suite.mockExecutor.On(
+		"Exec", ctx, "crm", "configure", "location", "loc_ip_PRD_HDB00_vmhana01", "rsc_ip_PRD_HDB00",
+		"role=Started", "100:", "vmhana01",
+	).Return([]byte{}, nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	err := clusterClient.AddLocationConstraint(ctx, cluster.LocationConstraint{
+		ID:       "loc_ip_PRD_HDB00_vmhana01",
+		Resource: "rsc_ip_PRD_HDB00",
+		Node:     "vmhana01",
+		Score:    "100",
+		Role:     "Started",
+	})
+	suite.NoError(err)
+}
+
+func (suite *ConstraintsTestSuite) TestAddBanConstraint() {
+	ctx := context.Background()
+	suite.mockExecutor.On(
+		"Exec", ctx, "crm", "configure", "location", "cli-ban-rsc_ip_PRD_HDB00-on-vmhana01", "rsc_ip_PRD_HDB00",
+		"-INFINITY:", "vmhana01",
+	).Return([]byte("ERROR: resource rsc_ip_PRD_HDB00 does not exist"), errors.New("exit status 1"))
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	err := clusterClient.AddLocationConstraint(ctx, cluster.NewBanConstraint("rsc_ip_PRD_HDB00", "vmhana01"))
+	suite.EqualError(err, "error adding constraint cli-ban-rsc_ip_PRD_HDB00-on-vmhana01: exit status 1, "+
+		"output: ERROR: resource rsc_ip_PRD_HDB00 does not exist")
+}
+
+func (suite *ConstraintsTestSuite) TestAddLocationConstraintInvalid() {
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	err := clusterClient.AddLocationConstraint(context.Background(), cluster.LocationConstraint{
+		ID:       "loc_ip_PRD_HDB00_vmhana01",
+		Resource: "rsc_ip_PRD_HDB00",
+	})
+	suite.EqualError(err, "constraint loc_ip_PRD_HDB00_vmhana01 requires a resource and a node")
+}
+
+func (suite *ConstraintsTestSuite) TestDeleteConstraint() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm", "configure", "delete", "cli-prefer-rsc_ip_PRD_HDB00").
+		Return([]byte{}, nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm", "configure", "delete", "unknown").
+		Return([]byte("ERROR: object unknown does not exist"), errors.New("exit status 1")).Once()
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	suite.NoError(clusterClient.DeleteConstraint(ctx, "cli-prefer-rsc_ip_PRD_HDB00"))
+	suite.EqualError(clusterClient.DeleteConstraint(ctx, "unknown"),
+		"error deleting constraint unknown: exit status 1, output: ERROR: object unknown does not exist")
+}
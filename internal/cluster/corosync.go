@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	qdeviceFlag     = "Qdevice"
+	qdeviceNodeName = "Qdevice"
+	localNodeSuffix = "(local)"
+)
+
+var (
+	// corosync-cfgtool -s local node line, e.g. Local node ID 1, transport knet
+	localNodeIDPatternCompiled = regexp.MustCompile(`^Local node ID (\d+)(?:, transport (\S+))?`)
+	// corosync-cfgtool -s link header line, e.g. LINK ID 0 udp
+	linkIDPatternCompiled = regexp.MustCompile(`^LINK ID (\d+)`)
+	// corosync-cfgtool -s link node status line, in the corosync >= 3.1 and the older format:
+	// nodeid:          2:	connected
+	// nodeid   2:	link enabled:1	link connected:1
+	linkNodePatternCompiled = regexp.MustCompile(`^nodeid:?\s+(\d+):\s+(.*)$`)
+)
+
+// CorosyncLinks is the corosync links status as reported by `corosync-cfgtool -s`
+type CorosyncLinks struct {
+	LocalNodeID int
+	Transport   string
+	Links       []CorosyncLink
+}
+
+type CorosyncLink struct {
+	ID      int
+	Address string
+	Nodes   []CorosyncLinkNode
+}
+
+// CorosyncLinkNode is the status of the link with a node.
+// The local node is flagged as connected.
+type CorosyncLinkNode struct {
+	NodeID    int
+	Local     bool
+	Connected bool
+	Status    string
+}
+
+// QuorumStatus is the corosync votequorum status as reported by `corosync-quorumtool -s`
+type QuorumStatus struct {
+	Quorate         bool
+	ExpectedVotes   int
+	HighestExpected int
+	TotalVotes      int
+	// Quorum is the number of votes required to be quorate
+	Quorum  int
+	Flags   []string
+	Qdevice bool
+	// QdeviceVotes are the votes given by the quorum device, included in the total votes
+	QdeviceVotes int
+	Nodes        []QuorumNode
+}
+
+type QuorumNode struct {
+	ID    int
+	Votes int
+	Name  string
+	Local bool
+}
+
+// LosesQuorumWithout returns true if the cluster loses quorum when the given node leaves the membership
+func (q *QuorumStatus) LosesQuorumWithout(nodeName string) bool {
+	remainingVotes := q.TotalVotes
+	for _, node := range q.Nodes {
+		if node.Name == nodeName {
+			remainingVotes -= node.Votes
+		}
+	}
+	return remainingVotes < q.Quorum
+}
+
+// GetCorosyncLinks returns the status of the corosync links with every node using `corosync-cfgtool -s`.
+// A non connected link makes the command fail, so the output is parsed even if an error is returned.
+func (c *Client) GetCorosyncLinks(ctx context.Context) (*CorosyncLinks, error) {
+	output, err := c.executor.Exec(ctx, "corosync-cfgtool", "-s")
+
+	links, parseErr := parseCorosyncCfgtoolOutput(output)
+	if parseErr != nil {
+		return nil, fmt.Errorf("error getting corosync links status: %w, output: %s",
+			errors.Join(err, parseErr), string(output))
+	}
+
+	return links, nil
+}
+
+// GetQuorumStatus returns the corosync quorum status using `corosync-quorumtool -s`.
+// The command fails if the partition is not quorate, so the output is parsed even if an error is returned.
+func (c *Client) GetQuorumStatus(ctx context.Context) (*QuorumStatus, error) {
+	output, err := c.executor.Exec(ctx, "corosync-quorumtool", "-s")
+
+	status, parseErr := parseCorosyncQuorumtoolOutput(output)
+	if parseErr != nil {
+		return nil, fmt.Errorf("error getting corosync quorum status: %w, output: %s",
+			errors.Join(err, parseErr), string(output))
+	}
+
+	return status, nil
+}
+
+func parseCorosyncCfgtoolOutput(output []byte) (*CorosyncLinks, error) {
+	links := &CorosyncLinks{Links: []CorosyncLink{}}
+	localNodeFound := false
+	var current *CorosyncLink
+
+	for line := range strings.Lines(string(output)) {
+		line = strings.TrimSpace(line)
+
+		if matches := localNodeIDPatternCompiled.FindStringSubmatch(line); matches != nil {
+			links.LocalNodeID, _ = strconv.Atoi(matches[1])
+			links.Transport = matches[2]
+			localNodeFound = true
+			continue
+		}
+
+		if matches := linkIDPatternCompiled.FindStringSubmatch(line); matches != nil {
+			id, _ := strconv.Atoi(matches[1])
+			links.Links = append(links.Links, CorosyncLink{ID: id, Nodes: []CorosyncLinkNode{}})
+			current = &links.Links[len(links.Links)-1]
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		if address, found := strings.CutPrefix(line, "addr"); found {
+			current.Address = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(address), "="))
+			continue
+		}
+
+		if matches := linkNodePatternCompiled.FindStringSubmatch(line); matches != nil {
+			nodeID, _ := strconv.Atoi(matches[1])
+			status := strings.Join(strings.Fields(matches[2]), " ")
+			local := nodeID == links.LocalNodeID
+			current.Nodes = append(current.Nodes, CorosyncLinkNode{
+				NodeID:    nodeID,
+				Local:     local,
+				Connected: local || status == "connected" || strings.Contains(status, "link connected:1"),
+				Status:    status,
+			})
+		}
+	}
+
+	if !localNodeFound {
+		return nil, errors.New("local node ID not found")
+	}
+
+	return links, nil
+}
+
+func parseCorosyncQuorumtoolOutput(output []byte) (*QuorumStatus, error) {
+	status := &QuorumStatus{Flags: []string{}, Nodes: []QuorumNode{}}
+	quorumFound := false
+	membership := false
+
+	integerFields := map[string]*int{
+		"Expected votes":   &status.ExpectedVotes,
+		"Highest expected": &status.HighestExpected,
+		"Total votes":      &status.TotalVotes,
+		"Quorum":           &status.Quorum,
+	}
+
+	for line := range strings.Lines(string(output)) {
+		line = strings.TrimSpace(line)
+
+		if strings.HasPrefix(line, "Nodeid") {
+			membership = true
+			continue
+		}
+
+		if membership {
+			if node, ok := parseQuorumMembershipLine(line); ok {
+				if node.Name == qdeviceNodeName && node.ID == 0 {
+					status.QdeviceVotes = node.Votes
+					continue
+				}
+				status.Nodes = append(status.Nodes, node)
+			}
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch key {
+		case "Quorate":
+			status.Quorate = value == "Yes"
+		case "Flags":
+			status.Flags = strings.Fields(value)
+			status.Qdevice = slices.Contains(status.Flags, qdeviceFlag)
+		default:
+			field, ok := integerFields[key]
+			if !ok {
+				continue
+			}
+			// the quorum value is followed by the blocked mark if the partition is not quorate
+			number, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(value, "Activity blocked")))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value %s", key, value)
+			}
+			*field = number
+			quorumFound = quorumFound || key == "Quorum"
+		}
+	}
+
+	if !quorumFound {
+		return nil, errors.New("votequorum information not found")
+	}
+
+	return status, nil
+}
+
+// parseQuorumMembershipLine parses a membership line, with or without the qdevice column:
+// 1          1 vmhana01 (local)
+// 1          1    A,V,NMW vmhana01 (local)
+func parseQuorumMembershipLine(line string) (QuorumNode, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return QuorumNode{}, false
+	}
+
+	id, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return QuorumNode{}, false
+	}
+
+	votes, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return QuorumNode{}, false
+	}
+
+	node := QuorumNode{ID: id, Votes: votes}
+	if fields[len(fields)-1] == localNodeSuffix {
+		node.Local = true
+		fields = fields[:len(fields)-1]
+	}
+	node.Name = fields[len(fields)-1]
+
+	return node, true
+}
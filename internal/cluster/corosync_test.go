@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support/mocks"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+type CorosyncTestSuite struct {
+	suite.Suite
+	mockExecutor *mocks.MockCmdExecutor
+}
+
+func TestCorosync(t *testing.T) {
+	suite.Run(t, new(CorosyncTestSuite))
+}
+
+func (suite *CorosyncTestSuite) SetupTest() {
+	suite.mockExecutor = mocks.NewMockCmdExecutor(suite.T())
+}
+
+func (suite *CorosyncTestSuite) TestGetCorosyncLinks() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "corosync-cfgtool", "-s").
+		Return(helpers.ReadFixture("cluster/corosync_cfgtool.output"), errors.New("exit status 1"))
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	links, err := clusterClient.GetCorosyncLinks(ctx)
+	suite.NoError(err)
+	suite.Equal(&cluster.CorosyncLinks{
+		LocalNodeID: 1,
+		Transport:   "knet",
+		Links: []cluster.CorosyncLink{
+			{
+				ID:      0,
+				Address: "10.0.0.10",
+				Nodes: []cluster.CorosyncLinkNode{
+					{NodeID: 1, Local: true, Connected: true, Status: "localhost"},
+					{NodeID: 2, Local: false, Connected: true, Status: "connected"},
+				},
+			},
+			{
+				ID:      1,
+				Address: "10.0.1.10",
+				Nodes: []cluster.CorosyncLinkNode{
+					{NodeID: 1, Local: true, Connected: true, Status: "localhost"},
+					{NodeID: 2, Local: false, Connected: false, Status: "disconnected"},
+				},
+			},
+		},
+	}, links)
+}
+
+func (suite *CorosyncTestSuite) TestGetCorosyncLinksLegacyFormat() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "corosync-cfgtool", "-s").
+		Return(helpers.ReadFixture("cluster/corosync_cfgtool_legacy.output"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	links, err := clusterClient.GetCorosyncLinks(ctx)
+	suite.NoError(err)
+	suite.Equal(&cluster.CorosyncLinks{
+		LocalNodeID: 1,
+		Links: []cluster.CorosyncLink{
+			{
+				ID:      0,
+				Address: "10.0.0.10",
+				Nodes: []cluster.CorosyncLinkNode{
+					{NodeID: 1, Local: true, Connected: true, Status: "link enabled:1 link connected:1"},
+					{NodeID: 2, Local: false, Connected: true, Status: "link enabled:1 link connected:1"},
+				},
+			},
+		},
+	}, links)
+}
+
+func (suite *CorosyncTestSuite) TestGetCorosyncLinksError() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "corosync-cfgtool", "-s").
+		Return([]byte("Could not initialize corosync configuration API error 2"), errors.New("exit status 1"))
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	links, err := clusterClient.GetCorosyncLinks(ctx)
+	suite.Nil(links)
+	suite.EqualError(err, "error getting corosync links status: exit status 1\nlocal node ID not found, "+
+		"output: Could not initialize corosync configuration API error 2")
+}
+
+func (suite *CorosyncTestSuite) TestGetQuorumStatus() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "corosync-quorumtool", "-s").
+		Return(helpers.ReadFixture("cluster/corosync_quorumtool.output"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	status, err := clusterClient.GetQuorumStatus(ctx)
+	suite.NoError(err)
+	suite.Equal(&cluster.QuorumStatus{
+		Quorate:         true,
+		ExpectedVotes:   3,
+		HighestExpected: 3,
+		TotalVotes:      3,
+		Quorum:          2,
+		Flags:           []string{"Quorate"},
+		Nodes: []cluster.QuorumNode{
+			{ID: 1, Votes: 1, Name: "vmhana01", Local: true},
+			{ID: 2, Votes: 1, Name: "vmhana02"},
+			{ID: 3, Votes: 1, Name: "vmmajority"},
+		},
+	}, status)
+	suite.False(status.LosesQuorumWithout("vmhana01"))
+}
+
+func (suite *CorosyncTestSuite) TestGetQuorumStatusQdevice() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "corosync-quorumtool", "-s").
+		Return(helpers.ReadFixture("cluster/corosync_quorumtool_qdevice.output"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	status, err := clusterClient.GetQuorumStatus(ctx)
+	suite.NoError(err)
+	suite.Equal(&cluster.QuorumStatus{
+		Quorate:         true,
+		ExpectedVotes:   3,
+		HighestExpected: 3,
+		TotalVotes:      3,
+		Quorum:          2,
+		Flags:           []string{"Quorate", "Qdevice"},
+		Qdevice:         true,
+		QdeviceVotes:    1,
+		Nodes: []cluster.QuorumNode{
+			{ID: 1, Votes: 1, Name: "vmhana01", Local: true},
+			{ID: 2, Votes: 1, Name: "vmhana02"},
+		},
+	}, status)
+	suite.False(status.LosesQuorumWithout("vmhana02"))
+}
+
+func (suite *CorosyncTestSuite) TestGetQuorumStatusNotQuorate() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "corosync-quorumtool", "-s").
+		Return(helpers.ReadFixture("cluster/corosync_quorumtool_blocked.output"), errors.New("exit status 2"))
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	status, err := clusterClient.GetQuorumStatus(ctx)
+	suite.NoError(err)
+	suite.False(status.Quorate)
+	suite.Equal(1, status.TotalVotes)
+	suite.Equal(2, status.Quorum)
+	suite.Empty(status.Flags)
+	suite.True(status.LosesQuorumWithout("vmhana01"))
+}
+
+func (suite *CorosyncTestSuite) TestGetQuorumStatusError() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "corosync-quorumtool", "-s").
+		Return([]byte("Cannot initialize QUORUM service"), errors.New("exit status 1"))
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	status, err := clusterClient.GetQuorumStatus(ctx)
+	suite.Nil(status)
+	suite.EqualError(err, "error getting corosync quorum status: exit status 1\nvotequorum information not found, "+
+		"output: Cannot initialize QUORUM service")
+}
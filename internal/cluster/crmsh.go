@@ -0,0 +1,395 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/trento-project/workbench/internal/support"
+)
+
+const (
+	pacemakerActiveCommand  = "systemctl is-active pacemaker.service"
+	activeState             = "active"
+	remoteCommandFailed     = "Exited with error code"
+	crmAttributeNotFound    = "not found"
+	crmMaintenanceOn        = "on"
+	crmMaintenanceOff       = "off"
+	crmNodeAttributePattern = "value=(.*)"
+	crmNodeMaintenanceValue = "true"
+)
+
+var (
+	// crm cluster run output header line, followed by the command output for successful executions:
+	// INFO: [vmhana01]
+	// ERROR: [vmhana02]: Exited with error code 3, Error output:
+	clusterRunHeaderPatternCompiled = regexp.MustCompile(`^(INFO|ERROR): \[([^\]]+)\](.*)$`)
+	crmNodeAttributePatternCompiled = regexp.MustCompile(crmNodeAttributePattern)
+)
+
+// crmshBackend manages the cluster using crmsh, the SUSE cluster management toolchain.
+// https://crmsh.github.io/man-4.6/
+type crmshBackend struct {
+	executor support.CmdExecutor
+	logger   *slog.Logger
+}
+
+func (b *crmshBackend) isHostOnline(ctx context.Context) bool {
+	output, err := b.executor.Exec(ctx, crmCommand, "status")
+	if err != nil {
+		return false
+	}
+
+	b.logger.Debug("CRM status output", "output", string(output))
+
+	return true
+}
+
+func (b *crmshBackend) startCluster(ctx context.Context) error {
+	b.logger.Info("Starting CRM cluster")
+	output, err := b.executor.Exec(ctx, crmCommand, "cluster", "start")
+	if err != nil {
+		return fmt.Errorf("failed to start CRM cluster: %w, output: %s", err, string(output))
+	}
+
+	b.logger.Info("CRM cluster started successfully")
+	return nil
+}
+
+func (b *crmshBackend) stopCluster(ctx context.Context) error {
+	b.logger.Info("Stopping CRM cluster")
+	output, err := b.executor.Exec(ctx, crmCommand, "cluster", "stop")
+	if err != nil {
+		return fmt.Errorf("failed to stop CRM cluster: %w, output: %s", err, string(output))
+	}
+
+	b.logger.Info("CRM cluster stopped successfully")
+	return nil
+}
+
+// startClusterNodes runs `crm cluster start <node>...`, using the --all flag if no node is given
+func (b *crmshBackend) startClusterNodes(ctx context.Context, nodes []string) error {
+	args := clusterNodesArguments("start", nodes)
+
+	b.logger.Info("Starting CRM cluster in nodes", "nodes", nodes)
+	output, err := b.executor.Exec(ctx, crmCommand, args...)
+	if err != nil {
+		return fmt.Errorf("failed to start CRM cluster in nodes %v: %w, output: %s", nodes, err, string(output))
+	}
+
+	b.logger.Info("CRM cluster started successfully in nodes", "nodes", nodes)
+	return nil
+}
+
+// stopClusterNodes runs `crm cluster stop <node>...`, using the --all flag if no node is given
+func (b *crmshBackend) stopClusterNodes(ctx context.Context, nodes []string) error {
+	args := clusterNodesArguments("stop", nodes)
+
+	b.logger.Info("Stopping CRM cluster in nodes", "nodes", nodes)
+	output, err := b.executor.Exec(ctx, crmCommand, args...)
+	if err != nil {
+		return fmt.Errorf("failed to stop CRM cluster in nodes %v: %w, output: %s", nodes, err, string(output))
+	}
+
+	b.logger.Info("CRM cluster stopped successfully in nodes", "nodes", nodes)
+	return nil
+}
+
+// getClusterStackStates queries the pacemaker service state running
+// `systemctl is-active pacemaker.service` in all the nodes with `crm cluster run`, as the local
+// cluster stack might be stopped. crmsh gets the nodes list from the corosync configuration
+// when the cluster is not running.
+// The command fails if the service is not active in any of the nodes, so the output is parsed
+// even if an error is returned.
+func (b *crmshBackend) getClusterStackStates(ctx context.Context) (map[string]bool, error) {
+	output, err := b.executor.Exec(ctx, crmCommand, "cluster", "run", pacemakerActiveCommand)
+	states, parseErr := parseClusterRunStates(output)
+	if parseErr != nil {
+		return nil, fmt.Errorf("error getting cluster stack states: %w, output: %s", parseErr, string(output))
+	}
+
+	if len(states) == 0 {
+		return nil, fmt.Errorf("error getting cluster stack states, no node found: %v, output: %s", err, string(output))
+	}
+
+	return states, nil
+}
+
+// isIdle uses `cs_clusterstate -i`, from the ClusterTools2 package
+func (b *crmshBackend) isIdle(ctx context.Context) (bool, error) {
+	idleOutput, err := b.executor.Exec(ctx, "cs_clusterstate", "-i")
+	if err != nil {
+		return false, fmt.Errorf("error running cs_clusterstate: %w", err)
+	}
+
+	if !clusterIdlePatternCompiled.Match(idleOutput) {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// resourceRefresh runs the `crm resource refresh [<rsc>] [<node>]` command.
+// https://crmsh.github.io/man-5.0/#cmdhelp.resource.refresh
+// The node argument requires the resource beforehand.
+// If the given node is not found, the command does not return -1, so the
+// std output must be compared to see if it returns a correct value.
+func (b *crmshBackend) resourceRefresh(ctx context.Context, resourceID, nodeID string) error {
+	if nodeID != "" && resourceID == "" {
+		return errors.New("nodeID cannot be provided without a resourceID")
+	}
+
+	args := []string{"resource", "refresh"}
+	if resourceID != "" {
+		args = append(args, resourceID)
+	}
+
+	if nodeID != "" {
+		args = append(args, nodeID)
+	}
+
+	b.logger.Info("Refreshing cluster resource", "resourceID", resourceID, "nodeID", nodeID)
+	output, err := b.executor.Exec(ctx, crmCommand, args...)
+	if err != nil {
+		return fmt.Errorf("failed to refresh resource: %w, output: %s", err, string(output))
+	}
+
+	if !strings.Contains(string(output), resourceRefreshedMessage) {
+		return fmt.Errorf("failed to refresh resource, unexpected output: %s", string(output))
+	}
+
+	b.logger.Info("Cluster resource refreshed successfully")
+	return nil
+}
+
+// addLocationConstraint runs `crm configure location <id> <rsc> [role=<role>] <score>: <node>`
+func (b *crmshBackend) addLocationConstraint(ctx context.Context, constraint LocationConstraint) error {
+	args := []string{"configure", "location", constraint.ID, constraint.Resource}
+	if constraint.Role != "" {
+		args = append(args, "role="+constraint.Role)
+	}
+	args = append(args, constraint.Score+":", constraint.Node)
+
+	output, err := b.executor.Exec(ctx, crmCommand, args...)
+	if err != nil {
+		return fmt.Errorf("error adding constraint %s: %w, output: %s", constraint.ID, err, string(output))
+	}
+
+	return nil
+}
+
+// deleteConstraint runs `crm configure delete <id>`
+func (b *crmshBackend) deleteConstraint(ctx context.Context, constraintID string) error {
+	output, err := b.executor.Exec(ctx, crmCommand, "configure", "delete", constraintID)
+	if err != nil {
+		return fmt.Errorf("error deleting constraint %s: %w, output: %s", constraintID, err, string(output))
+	}
+
+	return nil
+}
+
+// getMaintenanceState returns the maintenance state of the resource, node or cluster.
+// Find additional information here:
+// https://clusterlabs.org/projects/pacemaker/doc/2.1/Pacemaker_Explained/html/resources.html#resource-meta-attributes
+func (b *crmshBackend) getMaintenanceState(ctx context.Context, resourceID, nodeID string) (bool, error) {
+	switch {
+	case resourceID != "":
+		// get "maintenance" attribute of the resource. This has preference over is-managed attribute
+		output, err := b.executor.Exec(ctx, crmCommand, "resource", "meta", resourceID, "show", "maintenance")
+		if err != nil {
+			return false, fmt.Errorf("error getting maintenance attribute: %w", err)
+		}
+
+		if !strings.Contains(string(output), crmAttributeNotFound) {
+			boolValue, err := parseStateOutput(output)
+			if err != nil {
+				return false, fmt.Errorf("error decoding maintenance attribute: %w", err)
+			}
+
+			return boolValue, nil
+		}
+
+		// get "is-managed" attribute of the resource
+		output, err = b.executor.Exec(ctx, crmCommand, "resource", "meta", resourceID, "show", "is-managed")
+		if err != nil {
+			return false, fmt.Errorf("error getting is-managed attribute: %w", err)
+		}
+
+		// none of maintenance or is-managed attributes found. Defaulting to not in maintenance
+		if strings.Contains(string(output), crmAttributeNotFound) {
+			return false, nil
+		}
+
+		boolValue, err := parseStateOutput(output)
+		if err != nil {
+			return false, fmt.Errorf("error decoding is-managed attribute: %w", err)
+		}
+
+		// is-managed has the opposite logic than maintenance attribute
+		return !boolValue, nil
+	case nodeID != "":
+		// this command fails if the node is unknown. Check the output to see if the node is recognized
+		// possible outputs:
+		// maintenance on: scope=nodes  name=maintenance value=true
+		// maintenance off: scope=nodes  name=maintenance value=off
+		// yes, it returns true/off instead of true/false, on/off...
+		// node not found output:
+		// Could not map name=node-name to a UUID
+		output, err := b.executor.Exec(ctx, crmCommand, "node", "attribute", nodeID, "show", "maintenance")
+		if err != nil && strings.Contains(string(output), crmNodeNotFound) {
+			return false, fmt.Errorf("error getting node maintenance attribute: %w", err)
+		}
+
+		values := crmNodeAttributePatternCompiled.FindSubmatch(output)
+		if len(values) == 2 && string(values[1]) == crmNodeMaintenanceValue {
+			return true, nil
+		}
+
+		return false, nil
+	default:
+		output, err := b.executor.Exec(ctx, crmCommand, "configure", "get_property", "-t", "maintenance-mode")
+		if err != nil {
+			return false, fmt.Errorf("error getting maintenance-mode: %w", err)
+		}
+
+		boolValue, err := parseStateOutput(output)
+		if err != nil {
+			return false, fmt.Errorf("error decoding maintenance-mode attribute: %w", err)
+		}
+
+		return boolValue, nil
+	}
+}
+
+// setMaintenanceState uses `crm maintenance on|off [<rsc>]` for the cluster and resources,
+// and `crm node maintenance|ready <node>` for the nodes
+func (b *crmshBackend) setMaintenanceState(ctx context.Context, resourceID, nodeID string, maintenance bool) error {
+	state := crmMaintenanceOff
+	if maintenance {
+		state = crmMaintenanceOn
+	}
+
+	var args []string
+	switch {
+	case resourceID != "":
+		args = []string{"maintenance", state, resourceID}
+	case nodeID != "" && maintenance:
+		args = []string{"--force", "node", "maintenance", nodeID}
+	case nodeID != "":
+		args = []string{"--force", "node", "ready", nodeID}
+	default:
+		args = []string{"maintenance", state}
+	}
+
+	output, err := b.executor.Exec(ctx, crmCommand, args...)
+	if err != nil {
+		return fmt.Errorf("error setting maintenance state: %w, output: %s", err, string(output))
+	}
+
+	return nil
+}
+
+// getNodeAttribute runs `crm node attribute|status-attr|utilization <node> show <name>`.
+// Example output:
+// scope=nodes  name=hana_prd_site value=NUREMBERG
+func (b *crmshBackend) getNodeAttribute(
+	ctx context.Context,
+	nodeID string,
+	attributeType NodeAttributeType,
+	name string,
+) (string, bool, error) {
+	output, err := b.executor.Exec(ctx, crmCommand, "node", crmNodeAttributeCommand(attributeType), nodeID, "show", name)
+	if err != nil {
+		return "", false, nodeAttributeQueryError(nodeID, name, output, err)
+	}
+
+	values := crmNodeAttributePatternCompiled.FindSubmatch(output)
+	if len(values) != 2 {
+		return "", false, fmt.Errorf("error decoding node attribute %s, output: %s", name, string(output))
+	}
+
+	return strings.TrimSpace(string(values[1])), true, nil
+}
+
+// setNodeAttribute runs `crm node attribute|status-attr|utilization <node> set <name> <value>`
+func (b *crmshBackend) setNodeAttribute(
+	ctx context.Context,
+	nodeID string,
+	attributeType NodeAttributeType,
+	name, value string,
+) error {
+	output, err := b.executor.Exec(
+		ctx, crmCommand, "node", crmNodeAttributeCommand(attributeType), nodeID, "set", name, value,
+	)
+	if err != nil {
+		return fmt.Errorf("error setting node attribute %s: %w, output: %s", name, err, string(output))
+	}
+
+	return nil
+}
+
+// deleteNodeAttribute runs `crm node attribute|status-attr|utilization <node> delete <name>`
+func (b *crmshBackend) deleteNodeAttribute(
+	ctx context.Context,
+	nodeID string,
+	attributeType NodeAttributeType,
+	name string,
+) error {
+	output, err := b.executor.Exec(ctx, crmCommand, "node", crmNodeAttributeCommand(attributeType), nodeID, "delete", name)
+	if err != nil {
+		return fmt.Errorf("error deleting node attribute %s: %w, output: %s", name, err, string(output))
+	}
+
+	return nil
+}
+
+func crmNodeAttributeCommand(attributeType NodeAttributeType) string {
+	switch attributeType {
+	case TransientNodeAttribute:
+		return "status-attr"
+	case UtilizationNodeAttribute:
+		return "utilization"
+	default:
+		return "attribute"
+	}
+}
+
+func clusterNodesArguments(action string, nodes []string) []string {
+	args := []string{"cluster", action}
+	if len(nodes) == 0 {
+		return append(args, "--all")
+	}
+	return append(args, nodes...)
+}
+
+func parseClusterRunStates(output []byte) (map[string]bool, error) {
+	states := make(map[string]bool)
+	lines := strings.Split(string(output), "\n")
+
+	for index, line := range lines {
+		matches := clusterRunHeaderPatternCompiled.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+		level, node, details := matches[1], matches[2], matches[3]
+
+		if level == "ERROR" {
+			// the command was executed but the service is not active
+			if strings.Contains(details, remoteCommandFailed) {
+				states[node] = false
+				continue
+			}
+			return nil, fmt.Errorf("could not run command in node %s%s", node, details)
+		}
+
+		states[node] = index+1 < len(lines) && strings.TrimSpace(lines[index+1]) == activeState
+	}
+
+	return states, nil
+}
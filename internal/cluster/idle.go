@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultIdlePollInterval    = 2 * time.Second
+	defaultIdleMaxPollInterval = 15 * time.Second
+	defaultIdlePollFactor      = 2
+)
+
+type WaitForIdleOptions struct {
+	// Timeout is the maximum time to wait until the cluster is idle.
+	Timeout time.Duration
+	// PollInterval is the initial delay between idle checks. Defaults to 2 seconds.
+	PollInterval time.Duration
+	// MaxPollInterval is the maximum delay between idle checks. Defaults to 15 seconds.
+	MaxPollInterval time.Duration
+	// Factor is the multiplier for the poll interval after every check. Set 1 for a fixed interval.
+	// Defaults to 2.
+	Factor int
+}
+
+// Transition is the ongoing cluster transition, as reported by the DC controller and crm_mon
+type Transition struct {
+	DC              string
+	ControllerState string
+	PendingActions  []PendingAction
+}
+
+// PendingAction is a resource action in flight in a node
+type PendingAction struct {
+	Resource string
+	Node     string
+	Action   string
+}
+
+// IdleTimeoutError is returned by WaitForIdle if the cluster is not idle before the timeout.
+// The transition is nil if its details could not be retrieved.
+type IdleTimeoutError struct {
+	Timeout    time.Duration
+	Transition *Transition
+}
+
+func (e *IdleTimeoutError) Error() string {
+	message := fmt.Sprintf("cluster is not in S_IDLE state after %s", e.Timeout)
+	if e.Transition == nil {
+		return message
+	}
+
+	message = fmt.Sprintf("%s, controller state: %s", message, e.Transition.ControllerState)
+	if len(e.Transition.PendingActions) == 0 {
+		return message
+	}
+
+	actions := make([]string, 0, len(e.Transition.PendingActions))
+	for _, action := range e.Transition.PendingActions {
+		actions = append(actions, fmt.Sprintf("%s %s on %s", action.Resource, action.Action, action.Node))
+	}
+
+	return fmt.Sprintf("%s, pending actions: %s", message, strings.Join(actions, ", "))
+}
+
+// WaitForIdle polls the cluster until it is in the S_IDLE state, increasing the interval
+// between checks with the given factor.
+// An IdleTimeoutError with the pending transition details is returned if the timeout is reached.
+func (c *Client) WaitForIdle(ctx context.Context, options WaitForIdleOptions) error {
+	options = withIdleDefaults(options)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	interval := options.PollInterval
+	for {
+		isIdle, err := c.IsIdle(timeoutCtx)
+		switch {
+		case timeoutCtx.Err() != nil:
+			return c.idleTimeoutError(ctx, options.Timeout)
+		case err != nil:
+			return fmt.Errorf("error checking if cluster is idle: %w", err)
+		case isIdle:
+			return nil
+		}
+
+		c.logger.Info("Waiting for the cluster to be idle", "interval", interval)
+		select {
+		case <-timeoutCtx.Done():
+			return c.idleTimeoutError(ctx, options.Timeout)
+		case <-time.After(interval):
+		}
+
+		interval = min(interval*time.Duration(options.Factor), options.MaxPollInterval)
+	}
+}
+
+// idleTimeoutError builds the timeout error with the details of the ongoing transition.
+// The parent context error is returned if it was cancelled.
+func (c *Client) idleTimeoutError(ctx context.Context, timeout time.Duration) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	transition, err := c.getTransition(ctx)
+	if err != nil {
+		c.logger.Warn("Could not get the cluster transition details", "error", err)
+	}
+
+	return &IdleTimeoutError{Timeout: timeout, Transition: transition}
+}
+
+// getTransition gets the DC controller state using `crmadmin --status <dc>`
+// and the resource actions in flight from crm_mon
+func (c *Client) getTransition(ctx context.Context) (*Transition, error) {
+	status, err := c.GetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !status.DC.Present {
+		return &Transition{ControllerState: "no DC elected", PendingActions: []PendingAction{}}, nil
+	}
+
+	output, err := c.executor.Exec(ctx, "crmadmin", "--status", status.DC.Name, "--quiet")
+	if err != nil {
+		return nil, fmt.Errorf("error getting the DC node state: %w, output: %s", err, string(output))
+	}
+
+	transition := &Transition{
+		DC:              status.DC.Name,
+		ControllerState: strings.TrimSpace(string(output)),
+		PendingActions:  []PendingAction{},
+	}
+
+	for _, resource := range status.Resources {
+		if resource.Pending == "" {
+			continue
+		}
+
+		for _, node := range resource.Nodes {
+			transition.PendingActions = append(transition.PendingActions, PendingAction{
+				Resource: resource.ID,
+				Node:     node,
+				Action:   resource.Pending,
+			})
+		}
+	}
+
+	return transition, nil
+}
+
+func withIdleDefaults(options WaitForIdleOptions) WaitForIdleOptions {
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaultIdlePollInterval
+	}
+
+	if options.MaxPollInterval < options.PollInterval {
+		options.MaxPollInterval = max(defaultIdleMaxPollInterval, options.PollInterval)
+	}
+
+	if options.Factor < 1 {
+		options.Factor = defaultIdlePollFactor
+	}
+
+	return options
+}
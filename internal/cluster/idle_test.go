@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support/mocks"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+type WaitForIdleTestSuite struct {
+	suite.Suite
+	mockExecutor *mocks.MockCmdExecutor
+	options      cluster.WaitForIdleOptions
+}
+
+func TestWaitForIdle(t *testing.T) {
+	suite.Run(t, new(WaitForIdleTestSuite))
+}
+
+func (suite *WaitForIdleTestSuite) SetupTest() {
+	suite.mockExecutor = mocks.NewMockCmdExecutor(suite.T())
+	suite.options = cluster.WaitForIdleOptions{
+		Timeout:         100 * time.Millisecond,
+		PollInterval:    5 * time.Millisecond,
+		MaxPollInterval: 20 * time.Millisecond,
+		Factor:          2,
+	}
+}
+
+func (suite *WaitForIdleTestSuite) TestWaitForIdle() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", mock.Anything, "cs_clusterstate", "-i").
+		Return([]byte("Cluster state: S_TRANSITION_ENGINE"), nil).Twice()
+	suite.mockExecutor.On("Exec", mock.Anything, "cs_clusterstate", "-i").
+		Return([]byte("Cluster state: S_IDLE"), nil).Once()
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	suite.NoError(clusterClient.WaitForIdle(ctx, suite.options))
+}
+
+func (suite *WaitForIdleTestSuite) TestWaitForIdleError() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", mock.Anything, "cs_clusterstate", "-i").
+		Return([]byte(""), errors.New("cs_clusterstate not found")).Once()
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	err := clusterClient.WaitForIdle(ctx, suite.options)
+	suite.EqualError(err, "error checking if cluster is idle: error running cs_clusterstate: cs_clusterstate not found")
+}
+
+func (suite *WaitForIdleTestSuite) TestWaitForIdleTimeout() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", mock.Anything, "cs_clusterstate", "-i").
+		Return([]byte("Cluster state: S_TRANSITION_ENGINE"), nil)
+	suite.mockExecutor.On(
+		"Exec", ctx, "crm_mon", "--output-as=xml", "--inactive", "--failcounts", "--fence-history=1",
+	).Return(helpers.ReadFixture("cluster/crm_mon_transition.output"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crmadmin", "--status", "vmhana01", "--quiet").
+		Return([]byte("S_TRANSITION_ENGINE\n"), nil).Once()
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	err := clusterClient.WaitForIdle(ctx, suite.options)
+
+	var timeoutErr *cluster.IdleTimeoutError
+	suite.Require().ErrorAs(err, &timeoutErr)
+	suite.Equal(&cluster.Transition{
+		DC:              "vmhana01",
+		ControllerState: "S_TRANSITION_ENGINE",
+		PendingActions: []cluster.PendingAction{
+			{Resource: "rsc_ip_PRD_HDB00", Node: "vmhana02", Action: "Starting"},
+			{Resource: "rsc_SAPHana_PRD_HDB00", Node: "vmhana02", Action: "Promoting"},
+		},
+	}, timeoutErr.Transition)
+	suite.EqualError(err, "cluster is not in S_IDLE state after 100ms, controller state: S_TRANSITION_ENGINE, "+
+		"pending actions: rsc_ip_PRD_HDB00 Starting on vmhana02, rsc_SAPHana_PRD_HDB00 Promoting on vmhana02")
+}
+
+func (suite *WaitForIdleTestSuite) TestWaitForIdleTimeoutWithoutTransition() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", mock.Anything, "cs_clusterstate", "-i").
+		Return([]byte("Cluster state: S_POLICY_ENGINE"), nil)
+	suite.mockExecutor.On(
+		"Exec", ctx, "crm_mon", "--output-as=xml", "--inactive", "--failcounts", "--fence-history=1",
+	).Return([]byte("crm_mon: Connection to cluster failed"), errors.New("exit status 102")).Once()
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	err := clusterClient.WaitForIdle(ctx, suite.options)
+	suite.EqualError(err, "cluster is not in S_IDLE state after 100ms")
+}
+
+func (suite *WaitForIdleTestSuite) TestWaitForIdleCancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	suite.mockExecutor.On("Exec", mock.Anything, "cs_clusterstate", "-i").
+		Return([]byte("Cluster state: S_TRANSITION_ENGINE"), nil).
+		Run(func(_ mock.Arguments) { cancel() }).Once()
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	err := clusterClient.WaitForIdle(ctx, suite.options)
+	suite.ErrorIs(err, context.Canceled)
+}
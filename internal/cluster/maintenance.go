@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var pacemakerTrueValues = []string{"true", "on", "yes", "y", "1"}
+
+// GetMaintenanceState returns whether the resource or the node are in maintenance.
+// The general cluster maintenance mode is returned if no resource or node are given.
+// resourceID and nodeID are mutually exclusive.
+func (c *Client) GetMaintenanceState(ctx context.Context, resourceID, nodeID string) (bool, error) {
+	if resourceID != "" && nodeID != "" {
+		return false, errors.New("resourceID and nodeID are mutually exclusive")
+	}
+
+	return c.backend.getMaintenanceState(ctx, resourceID, nodeID)
+}
+
+// SetMaintenanceState sets or removes the maintenance state of the resource or the node.
+// The general cluster maintenance mode is changed if no resource or node are given.
+// resourceID and nodeID are mutually exclusive.
+func (c *Client) SetMaintenanceState(ctx context.Context, resourceID, nodeID string, maintenance bool) error {
+	if resourceID != "" && nodeID != "" {
+		return errors.New("resourceID and nodeID are mutually exclusive")
+	}
+
+	c.logger.Info("Setting maintenance state",
+		"resourceID", resourceID, "nodeID", nodeID, "maintenance", maintenance)
+	return c.backend.setMaintenanceState(ctx, resourceID, nodeID, maintenance)
+}
+
+// Depending on the queried resource, the crm command might print some "debug" lines
+// before returning the actual state of the attribute.
+// The actual state is always a boolean value, either 'true' or 'false'
+// The debug lines are cleaned up before parsing the final boolean state of the attribute.
+// Example output:
+// linux # crm resource meta msl_SAPHana_PRD_HDB00 show maintenance
+// msl_SAPHana_PRD_HDB00 is active on more than one node, returning the default value for maintenance
+// false
+func parseStateOutput(output []byte) (bool, error) {
+	trimmedString := strings.TrimSpace(string(output))
+	if len(trimmedString) == 0 {
+		return false, fmt.Errorf("empty command output")
+	}
+
+	lines := strings.Split(trimmedString, "\n")
+	lastLine := lines[len(lines)-1]
+
+	boolValue, err := strconv.ParseBool(lastLine)
+	if err != nil {
+		return false, err
+	}
+	return boolValue, nil
+}
+
+// isPacemakerTrue returns true if the value is one of the values pacemaker considers true.
+// Node attributes set by pcs use on and off, for instance.
+func isPacemakerTrue(value string) bool {
+	return slices.Contains(pacemakerTrueValues, strings.ToLower(strings.TrimSpace(value)))
+}
@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support/mocks"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+type MaintenanceTestSuite struct {
+	suite.Suite
+	mockExecutor *mocks.MockCmdExecutor
+}
+
+func TestMaintenance(t *testing.T) {
+	suite.Run(t, new(MaintenanceTestSuite))
+}
+
+func (suite *MaintenanceTestSuite) SetupTest() {
+	suite.mockExecutor = mocks.NewMockCmdExecutor(suite.T())
+}
+
+func (suite *MaintenanceTestSuite) crmClient() cluster.Cluster {
+	return cluster.NewClusterClientWithToolchain(cluster.CrmshToolchain, suite.mockExecutor, slog.Default())
+}
+
+func (suite *MaintenanceTestSuite) pcsClient() cluster.Cluster {
+	return cluster.NewClusterClientWithToolchain(cluster.PcsToolchain, suite.mockExecutor, slog.Default())
+}
+
+func (suite *MaintenanceTestSuite) TestGetMaintenanceStateMutuallyExclusive() {
+	state, err := suite.crmClient().GetMaintenanceState(context.Background(), "rsc", "vmhana01")
+	suite.False(state)
+	suite.EqualError(err, "resourceID and nodeID are mutually exclusive")
+
+	err = suite.pcsClient().SetMaintenanceState(context.Background(), "rsc", "vmhana01", true)
+	suite.EqualError(err, "resourceID and nodeID are mutually exclusive")
+}
+
+func (suite *MaintenanceTestSuite) TestCrmGetClusterMaintenanceState() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm", "configure", "get_property", "-t", "maintenance-mode").
+		Return([]byte("true\n"), nil).Once()
+
+	state, err := suite.crmClient().GetMaintenanceState(ctx, "", "")
+	suite.NoError(err)
+	suite.True(state)
+}
+
+func (suite *MaintenanceTestSuite) TestCrmGetClusterMaintenanceStateError() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm", "configure", "get_property", "-t", "maintenance-mode").
+		Return([]byte("error"), errors.New("cannot get state")).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm", "configure", "get_property", "-t", "maintenance-mode").
+		Return([]byte(""), nil).Once()
+
+	_, err := suite.crmClient().GetMaintenanceState(ctx, "", "")
+	suite.EqualError(err, "error getting maintenance-mode: cannot get state")
+
+	_, err = suite.crmClient().GetMaintenanceState(ctx, "", "")
+	suite.EqualError(err, "error decoding maintenance-mode attribute: empty command output")
+}
+
+func (suite *MaintenanceTestSuite) TestCrmGetResourceMaintenanceState() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm", "resource", "meta", "msl_SAPHana_PRD_HDB00", "show", "maintenance").
+		Return(helpers.ReadFixture("cluster/crm_resource_meta_maintenance.output"), nil).Once()
+
+	state, err := suite.crmClient().GetMaintenanceState(ctx, "msl_SAPHana_PRD_HDB00", "")
+	suite.NoError(err)
+	suite.True(state)
+}
+
+func (suite *MaintenanceTestSuite) TestCrmGetResourceMaintenanceStateIsManaged() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm", "resource", "meta", "rsc_ip_PRD_HDB00", "show", "maintenance").
+		Return([]byte("ERROR: attribute maintenance not found"), nil)
+	suite.mockExecutor.On("Exec", ctx, "crm", "resource", "meta", "rsc_ip_PRD_HDB00", "show", "is-managed").
+		Return([]byte("false"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm", "resource", "meta", "rsc_ip_PRD_HDB00", "show", "is-managed").
+		Return([]byte("ERROR: attribute is-managed not found"), nil).Once()
+
+	state, err := suite.crmClient().GetMaintenanceState(ctx, "rsc_ip_PRD_HDB00", "")
+	suite.NoError(err)
+	suite.True(state)
+
+	// none of the attributes is set, so the resource is not in maintenance
+	state, err = suite.crmClient().GetMaintenanceState(ctx, "rsc_ip_PRD_HDB00", "")
+	suite.NoError(err)
+	suite.False(state)
+}
+
+func (suite *MaintenanceTestSuite) TestCrmGetNodeMaintenanceState() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm", "node", "attribute", "vmhana01", "show", "maintenance").
+		Return([]byte("scope=nodes  name=maintenance value=true"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm", "node", "attribute", "vmhana01", "show", "maintenance").
+		Return([]byte("scope=nodes  name=maintenance value=off"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm", "node", "attribute", "vmhana01", "show", "maintenance").
+		Return([]byte("scope=nodes  name=maintenance value=(null)"), errors.New("exit status 105")).Once()
+
+	state, err := suite.crmClient().GetMaintenanceState(ctx, "", "vmhana01")
+	suite.NoError(err)
+	suite.True(state)
+
+	state, err = suite.crmClient().GetMaintenanceState(ctx, "", "vmhana01")
+	suite.NoError(err)
+	suite.False(state)
+
+	// the attribute was never set in the node
+	state, err = suite.crmClient().GetMaintenanceState(ctx, "", "vmhana01")
+	suite.NoError(err)
+	suite.False(state)
+}
+
+func (suite *MaintenanceTestSuite) TestCrmGetNodeMaintenanceStateNodeNotFound() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm", "node", "attribute", "unknown", "show", "maintenance").
+		Return([]byte("Could not map name=unknown to a UUID"), errors.New("exit status 105")).Once()
+
+	_, err := suite.crmClient().GetMaintenanceState(ctx, "", "unknown")
+	suite.EqualError(err, "error getting node maintenance attribute: exit status 105")
+}
+
+func (suite *MaintenanceTestSuite) TestCrmSetMaintenanceState() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm", "maintenance", "on").Return([]byte(""), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm", "maintenance", "off", "rsc_ip_PRD_HDB00").Return([]byte(""), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm", "--force", "node", "maintenance", "vmhana01").
+		Return([]byte(""), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm", "--force", "node", "ready", "vmhana01").
+		Return([]byte("ERROR: node vmhana01 is offline"), errors.New("exit status 1")).Once()
+
+	client := suite.crmClient()
+	suite.NoError(client.SetMaintenanceState(ctx, "", "", true))
+	suite.NoError(client.SetMaintenanceState(ctx, "rsc_ip_PRD_HDB00", "", false))
+	suite.NoError(client.SetMaintenanceState(ctx, "", "vmhana01", true))
+	suite.EqualError(client.SetMaintenanceState(ctx, "", "vmhana01", false),
+		"error setting maintenance state: exit status 1, output: ERROR: node vmhana01 is offline")
+}
+
+func (suite *MaintenanceTestSuite) TestPcsGetClusterMaintenanceState() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm_attribute", "--type", "crm_config", "--name", "maintenance-mode",
+		"--query", "--quiet").Return([]byte("true\n"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm_attribute", "--type", "crm_config", "--name", "maintenance-mode",
+		"--query", "--quiet").Return([]byte("crm_attribute: Error performing operation: No such device or address"),
+		errors.New("exit status 105")).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm_attribute", "--type", "crm_config", "--name", "maintenance-mode",
+		"--query", "--quiet").Return([]byte("Could not connect to the CIB"), errors.New("exit status 102")).Once()
+
+	client := suite.pcsClient()
+
+	state, err := client.GetMaintenanceState(ctx, "", "")
+	suite.NoError(err)
+	suite.True(state)
+
+	state, err = client.GetMaintenanceState(ctx, "", "")
+	suite.NoError(err)
+	suite.False(state)
+
+	_, err = client.GetMaintenanceState(ctx, "", "")
+	suite.EqualError(err, "error getting maintenance-mode: exit status 102, output: Could not connect to the CIB")
+}
+
+func (suite *MaintenanceTestSuite) TestPcsGetResourceMaintenanceState() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm_resource", "--resource", "rsc_ip_PRD_HDB00", "--meta",
+		"--get-parameter", "maintenance", "--quiet").
+		Return([]byte("Error performing operation: No such device or address"), errors.New("exit status 105"))
+	suite.mockExecutor.On("Exec", ctx, "crm_resource", "--resource", "rsc_ip_PRD_HDB00", "--meta",
+		"--get-parameter", "is-managed", "--quiet").Return([]byte("false\n"), nil).Once()
+
+	state, err := suite.pcsClient().GetMaintenanceState(ctx, "rsc_ip_PRD_HDB00", "")
+	suite.NoError(err)
+	suite.True(state)
+}
+
+func (suite *MaintenanceTestSuite) TestPcsGetNodeMaintenanceState() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm_attribute", "--type", "nodes", "--node", "vmhana01",
+		"--name", "maintenance", "--query", "--quiet").Return([]byte("on\n"), nil).Once()
+
+	state, err := suite.pcsClient().GetMaintenanceState(ctx, "", "vmhana01")
+	suite.NoError(err)
+	suite.True(state)
+}
+
+func (suite *MaintenanceTestSuite) TestPcsSetMaintenanceState() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "property", "set", "maintenance-mode=true").
+		Return([]byte(""), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "resource", "meta", "rsc_ip_PRD_HDB00", "maintenance=false").
+		Return([]byte(""), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "node", "maintenance", "vmhana01").Return([]byte(""), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "node", "unmaintenance", "vmhana01").
+		Return([]byte("Error: Node 'vmhana01' does not appear to exist in configuration"), errors.New("exit status 1")).
+		Once()
+
+	client := suite.pcsClient()
+	suite.NoError(client.SetMaintenanceState(ctx, "", "", true))
+	suite.NoError(client.SetMaintenanceState(ctx, "rsc_ip_PRD_HDB00", "", false))
+	suite.NoError(client.SetMaintenanceState(ctx, "", "vmhana01", true))
+	suite.EqualError(client.SetMaintenanceState(ctx, "", "vmhana01", false),
+		"error setting maintenance state: exit status 1, "+
+			"output: Error: Node 'vmhana01' does not appear to exist in configuration")
+}
@@ -8,6 +8,8 @@ package mocks
 import (
 	context "context"
 
+	cluster "github.com/trento-project/workbench/internal/cluster"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -24,73 +26,1232 @@ func (_m *MockCluster) EXPECT() *MockCluster_Expecter {
 	return &MockCluster_Expecter{mock: &_m.Mock}
 }
 
-// IsHostOnline provides a mock function with given fields: ctx
-func (_m *MockCluster) IsHostOnline(ctx context.Context) bool {
+// AddLocationConstraint provides a mock function with given fields: ctx, constraint
+func (_m *MockCluster) AddLocationConstraint(ctx context.Context, constraint cluster.LocationConstraint) error {
+	ret := _m.Called(ctx, constraint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLocationConstraint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, cluster.LocationConstraint) error); ok {
+		r0 = rf(ctx, constraint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCluster_AddLocationConstraint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddLocationConstraint'
+type MockCluster_AddLocationConstraint_Call struct {
+	*mock.Call
+}
+
+// AddLocationConstraint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - constraint cluster.LocationConstraint
+func (_e *MockCluster_Expecter) AddLocationConstraint(ctx interface{}, constraint interface{}) *MockCluster_AddLocationConstraint_Call {
+	return &MockCluster_AddLocationConstraint_Call{Call: _e.mock.On("AddLocationConstraint", ctx, constraint)}
+}
+
+func (_c *MockCluster_AddLocationConstraint_Call) Run(run func(ctx context.Context, constraint cluster.LocationConstraint)) *MockCluster_AddLocationConstraint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(cluster.LocationConstraint))
+	})
+	return _c
+}
+
+func (_c *MockCluster_AddLocationConstraint_Call) Return(_a0 error) *MockCluster_AddLocationConstraint_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCluster_AddLocationConstraint_Call) RunAndReturn(run func(context.Context, cluster.LocationConstraint) error) *MockCluster_AddLocationConstraint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteConstraint provides a mock function with given fields: ctx, constraintID
+func (_m *MockCluster) DeleteConstraint(ctx context.Context, constraintID string) error {
+	ret := _m.Called(ctx, constraintID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteConstraint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, constraintID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCluster_DeleteConstraint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteConstraint'
+type MockCluster_DeleteConstraint_Call struct {
+	*mock.Call
+}
+
+// DeleteConstraint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - constraintID string
+func (_e *MockCluster_Expecter) DeleteConstraint(ctx interface{}, constraintID interface{}) *MockCluster_DeleteConstraint_Call {
+	return &MockCluster_DeleteConstraint_Call{Call: _e.mock.On("DeleteConstraint", ctx, constraintID)}
+}
+
+func (_c *MockCluster_DeleteConstraint_Call) Run(run func(ctx context.Context, constraintID string)) *MockCluster_DeleteConstraint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCluster_DeleteConstraint_Call) Return(_a0 error) *MockCluster_DeleteConstraint_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCluster_DeleteConstraint_Call) RunAndReturn(run func(context.Context, string) error) *MockCluster_DeleteConstraint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteNodeAttribute provides a mock function with given fields: ctx, nodeID, attributeType, name
+func (_m *MockCluster) DeleteNodeAttribute(ctx context.Context, nodeID string, attributeType cluster.NodeAttributeType, name string) error {
+	ret := _m.Called(ctx, nodeID, attributeType, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNodeAttribute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, cluster.NodeAttributeType, string) error); ok {
+		r0 = rf(ctx, nodeID, attributeType, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCluster_DeleteNodeAttribute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNodeAttribute'
+type MockCluster_DeleteNodeAttribute_Call struct {
+	*mock.Call
+}
+
+// DeleteNodeAttribute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodeID string
+//   - attributeType cluster.NodeAttributeType
+//   - name string
+func (_e *MockCluster_Expecter) DeleteNodeAttribute(ctx interface{}, nodeID interface{}, attributeType interface{}, name interface{}) *MockCluster_DeleteNodeAttribute_Call {
+	return &MockCluster_DeleteNodeAttribute_Call{Call: _e.mock.On("DeleteNodeAttribute", ctx, nodeID, attributeType, name)}
+}
+
+func (_c *MockCluster_DeleteNodeAttribute_Call) Run(run func(ctx context.Context, nodeID string, attributeType cluster.NodeAttributeType, name string)) *MockCluster_DeleteNodeAttribute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(cluster.NodeAttributeType), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockCluster_DeleteNodeAttribute_Call) Return(_a0 error) *MockCluster_DeleteNodeAttribute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCluster_DeleteNodeAttribute_Call) RunAndReturn(run func(context.Context, string, cluster.NodeAttributeType, string) error) *MockCluster_DeleteNodeAttribute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteProperty provides a mock function with given fields: ctx, set, name
+func (_m *MockCluster) DeleteProperty(ctx context.Context, set cluster.PropertySet, name string) error {
+	ret := _m.Called(ctx, set, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProperty")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, cluster.PropertySet, string) error); ok {
+		r0 = rf(ctx, set, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCluster_DeleteProperty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProperty'
+type MockCluster_DeleteProperty_Call struct {
+	*mock.Call
+}
+
+// DeleteProperty is a helper method to define mock.On call
+//   - ctx context.Context
+//   - set cluster.PropertySet
+//   - name string
+func (_e *MockCluster_Expecter) DeleteProperty(ctx interface{}, set interface{}, name interface{}) *MockCluster_DeleteProperty_Call {
+	return &MockCluster_DeleteProperty_Call{Call: _e.mock.On("DeleteProperty", ctx, set, name)}
+}
+
+func (_c *MockCluster_DeleteProperty_Call) Run(run func(ctx context.Context, set cluster.PropertySet, name string)) *MockCluster_DeleteProperty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(cluster.PropertySet), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockCluster_DeleteProperty_Call) Return(_a0 error) *MockCluster_DeleteProperty_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCluster_DeleteProperty_Call) RunAndReturn(run func(context.Context, cluster.PropertySet, string) error) *MockCluster_DeleteProperty_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DiffCIBConfigurations provides a mock function with given fields: ctx, original, updated
+func (_m *MockCluster) DiffCIBConfigurations(ctx context.Context, original *cluster.CIB, updated *cluster.CIB) (string, error) {
+	ret := _m.Called(ctx, original, updated)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffCIBConfigurations")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *cluster.CIB, *cluster.CIB) (string, error)); ok {
+		return rf(ctx, original, updated)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *cluster.CIB, *cluster.CIB) string); ok {
+		r0 = rf(ctx, original, updated)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *cluster.CIB, *cluster.CIB) error); ok {
+		r1 = rf(ctx, original, updated)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCluster_DiffCIBConfigurations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffCIBConfigurations'
+type MockCluster_DiffCIBConfigurations_Call struct {
+	*mock.Call
+}
+
+// DiffCIBConfigurations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - original *cluster.CIB
+//   - updated *cluster.CIB
+func (_e *MockCluster_Expecter) DiffCIBConfigurations(ctx interface{}, original interface{}, updated interface{}) *MockCluster_DiffCIBConfigurations_Call {
+	return &MockCluster_DiffCIBConfigurations_Call{Call: _e.mock.On("DiffCIBConfigurations", ctx, original, updated)}
+}
+
+func (_c *MockCluster_DiffCIBConfigurations_Call) Run(run func(ctx context.Context, original *cluster.CIB, updated *cluster.CIB)) *MockCluster_DiffCIBConfigurations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*cluster.CIB), args[2].(*cluster.CIB))
+	})
+	return _c
+}
+
+func (_c *MockCluster_DiffCIBConfigurations_Call) Return(_a0 string, _a1 error) *MockCluster_DiffCIBConfigurations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCluster_DiffCIBConfigurations_Call) RunAndReturn(run func(context.Context, *cluster.CIB, *cluster.CIB) (string, error)) *MockCluster_DiffCIBConfigurations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCIB provides a mock function with given fields: ctx
+func (_m *MockCluster) GetCIB(ctx context.Context) (*cluster.CIB, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCIB")
+	}
+
+	var r0 *cluster.CIB
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*cluster.CIB, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *cluster.CIB); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cluster.CIB)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCluster_GetCIB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCIB'
+type MockCluster_GetCIB_Call struct {
+	*mock.Call
+}
+
+// GetCIB is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCluster_Expecter) GetCIB(ctx interface{}) *MockCluster_GetCIB_Call {
+	return &MockCluster_GetCIB_Call{Call: _e.mock.On("GetCIB", ctx)}
+}
+
+func (_c *MockCluster_GetCIB_Call) Run(run func(ctx context.Context)) *MockCluster_GetCIB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCluster_GetCIB_Call) Return(_a0 *cluster.CIB, _a1 error) *MockCluster_GetCIB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCluster_GetCIB_Call) RunAndReturn(run func(context.Context) (*cluster.CIB, error)) *MockCluster_GetCIB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetClusterStackStates provides a mock function with given fields: ctx
+func (_m *MockCluster) GetClusterStackStates(ctx context.Context) (map[string]bool, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClusterStackStates")
+	}
+
+	var r0 map[string]bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string]bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]bool); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]bool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCluster_GetClusterStackStates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClusterStackStates'
+type MockCluster_GetClusterStackStates_Call struct {
+	*mock.Call
+}
+
+// GetClusterStackStates is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCluster_Expecter) GetClusterStackStates(ctx interface{}) *MockCluster_GetClusterStackStates_Call {
+	return &MockCluster_GetClusterStackStates_Call{Call: _e.mock.On("GetClusterStackStates", ctx)}
+}
+
+func (_c *MockCluster_GetClusterStackStates_Call) Run(run func(ctx context.Context)) *MockCluster_GetClusterStackStates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCluster_GetClusterStackStates_Call) Return(_a0 map[string]bool, _a1 error) *MockCluster_GetClusterStackStates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCluster_GetClusterStackStates_Call) RunAndReturn(run func(context.Context) (map[string]bool, error)) *MockCluster_GetClusterStackStates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCorosyncLinks provides a mock function with given fields: ctx
+func (_m *MockCluster) GetCorosyncLinks(ctx context.Context) (*cluster.CorosyncLinks, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsHostOnline")
+		panic("no return value specified for GetCorosyncLinks")
+	}
+
+	var r0 *cluster.CorosyncLinks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*cluster.CorosyncLinks, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *cluster.CorosyncLinks); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cluster.CorosyncLinks)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCluster_GetCorosyncLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCorosyncLinks'
+type MockCluster_GetCorosyncLinks_Call struct {
+	*mock.Call
+}
+
+// GetCorosyncLinks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCluster_Expecter) GetCorosyncLinks(ctx interface{}) *MockCluster_GetCorosyncLinks_Call {
+	return &MockCluster_GetCorosyncLinks_Call{Call: _e.mock.On("GetCorosyncLinks", ctx)}
+}
+
+func (_c *MockCluster_GetCorosyncLinks_Call) Run(run func(ctx context.Context)) *MockCluster_GetCorosyncLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCluster_GetCorosyncLinks_Call) Return(_a0 *cluster.CorosyncLinks, _a1 error) *MockCluster_GetCorosyncLinks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCluster_GetCorosyncLinks_Call) RunAndReturn(run func(context.Context) (*cluster.CorosyncLinks, error)) *MockCluster_GetCorosyncLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHanaSRAttributes provides a mock function with given fields: ctx
+func (_m *MockCluster) GetHanaSRAttributes(ctx context.Context) (*cluster.HanaSRAttributes, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHanaSRAttributes")
+	}
+
+	var r0 *cluster.HanaSRAttributes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*cluster.HanaSRAttributes, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *cluster.HanaSRAttributes); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cluster.HanaSRAttributes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCluster_GetHanaSRAttributes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHanaSRAttributes'
+type MockCluster_GetHanaSRAttributes_Call struct {
+	*mock.Call
+}
+
+// GetHanaSRAttributes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCluster_Expecter) GetHanaSRAttributes(ctx interface{}) *MockCluster_GetHanaSRAttributes_Call {
+	return &MockCluster_GetHanaSRAttributes_Call{Call: _e.mock.On("GetHanaSRAttributes", ctx)}
+}
+
+func (_c *MockCluster_GetHanaSRAttributes_Call) Run(run func(ctx context.Context)) *MockCluster_GetHanaSRAttributes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCluster_GetHanaSRAttributes_Call) Return(_a0 *cluster.HanaSRAttributes, _a1 error) *MockCluster_GetHanaSRAttributes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCluster_GetHanaSRAttributes_Call) RunAndReturn(run func(context.Context) (*cluster.HanaSRAttributes, error)) *MockCluster_GetHanaSRAttributes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLocationConstraints provides a mock function with given fields: ctx
+func (_m *MockCluster) GetLocationConstraints(ctx context.Context) ([]cluster.LocationConstraint, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLocationConstraints")
+	}
+
+	var r0 []cluster.LocationConstraint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]cluster.LocationConstraint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []cluster.LocationConstraint); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]cluster.LocationConstraint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCluster_GetLocationConstraints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLocationConstraints'
+type MockCluster_GetLocationConstraints_Call struct {
+	*mock.Call
+}
+
+// GetLocationConstraints is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCluster_Expecter) GetLocationConstraints(ctx interface{}) *MockCluster_GetLocationConstraints_Call {
+	return &MockCluster_GetLocationConstraints_Call{Call: _e.mock.On("GetLocationConstraints", ctx)}
+}
+
+func (_c *MockCluster_GetLocationConstraints_Call) Run(run func(ctx context.Context)) *MockCluster_GetLocationConstraints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCluster_GetLocationConstraints_Call) Return(_a0 []cluster.LocationConstraint, _a1 error) *MockCluster_GetLocationConstraints_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCluster_GetLocationConstraints_Call) RunAndReturn(run func(context.Context) ([]cluster.LocationConstraint, error)) *MockCluster_GetLocationConstraints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMaintenanceState provides a mock function with given fields: ctx, resourceID, nodeID
+func (_m *MockCluster) GetMaintenanceState(ctx context.Context, resourceID string, nodeID string) (bool, error) {
+	ret := _m.Called(ctx, resourceID, nodeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMaintenanceState")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, resourceID, nodeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, resourceID, nodeID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, resourceID, nodeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCluster_GetMaintenanceState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMaintenanceState'
+type MockCluster_GetMaintenanceState_Call struct {
+	*mock.Call
+}
+
+// GetMaintenanceState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - resourceID string
+//   - nodeID string
+func (_e *MockCluster_Expecter) GetMaintenanceState(ctx interface{}, resourceID interface{}, nodeID interface{}) *MockCluster_GetMaintenanceState_Call {
+	return &MockCluster_GetMaintenanceState_Call{Call: _e.mock.On("GetMaintenanceState", ctx, resourceID, nodeID)}
+}
+
+func (_c *MockCluster_GetMaintenanceState_Call) Run(run func(ctx context.Context, resourceID string, nodeID string)) *MockCluster_GetMaintenanceState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockCluster_GetMaintenanceState_Call) Return(_a0 bool, _a1 error) *MockCluster_GetMaintenanceState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCluster_GetMaintenanceState_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *MockCluster_GetMaintenanceState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNodeAttribute provides a mock function with given fields: ctx, nodeID, attributeType, name
+func (_m *MockCluster) GetNodeAttribute(ctx context.Context, nodeID string, attributeType cluster.NodeAttributeType, name string) (string, bool, error) {
+	ret := _m.Called(ctx, nodeID, attributeType, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNodeAttribute")
+	}
+
+	var r0 string
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, cluster.NodeAttributeType, string) (string, bool, error)); ok {
+		return rf(ctx, nodeID, attributeType, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, cluster.NodeAttributeType, string) string); ok {
+		r0 = rf(ctx, nodeID, attributeType, name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, cluster.NodeAttributeType, string) bool); ok {
+		r1 = rf(ctx, nodeID, attributeType, name)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, cluster.NodeAttributeType, string) error); ok {
+		r2 = rf(ctx, nodeID, attributeType, name)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockCluster_GetNodeAttribute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNodeAttribute'
+type MockCluster_GetNodeAttribute_Call struct {
+	*mock.Call
+}
+
+// GetNodeAttribute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodeID string
+//   - attributeType cluster.NodeAttributeType
+//   - name string
+func (_e *MockCluster_Expecter) GetNodeAttribute(ctx interface{}, nodeID interface{}, attributeType interface{}, name interface{}) *MockCluster_GetNodeAttribute_Call {
+	return &MockCluster_GetNodeAttribute_Call{Call: _e.mock.On("GetNodeAttribute", ctx, nodeID, attributeType, name)}
+}
+
+func (_c *MockCluster_GetNodeAttribute_Call) Run(run func(ctx context.Context, nodeID string, attributeType cluster.NodeAttributeType, name string)) *MockCluster_GetNodeAttribute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(cluster.NodeAttributeType), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockCluster_GetNodeAttribute_Call) Return(_a0 string, _a1 bool, _a2 error) *MockCluster_GetNodeAttribute_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockCluster_GetNodeAttribute_Call) RunAndReturn(run func(context.Context, string, cluster.NodeAttributeType, string) (string, bool, error)) *MockCluster_GetNodeAttribute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProperty provides a mock function with given fields: ctx, set, name
+func (_m *MockCluster) GetProperty(ctx context.Context, set cluster.PropertySet, name string) (string, bool, error) {
+	ret := _m.Called(ctx, set, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProperty")
+	}
+
+	var r0 string
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, cluster.PropertySet, string) (string, bool, error)); ok {
+		return rf(ctx, set, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, cluster.PropertySet, string) string); ok {
+		r0 = rf(ctx, set, name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, cluster.PropertySet, string) bool); ok {
+		r1 = rf(ctx, set, name)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, cluster.PropertySet, string) error); ok {
+		r2 = rf(ctx, set, name)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockCluster_GetProperty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProperty'
+type MockCluster_GetProperty_Call struct {
+	*mock.Call
+}
+
+// GetProperty is a helper method to define mock.On call
+//   - ctx context.Context
+//   - set cluster.PropertySet
+//   - name string
+func (_e *MockCluster_Expecter) GetProperty(ctx interface{}, set interface{}, name interface{}) *MockCluster_GetProperty_Call {
+	return &MockCluster_GetProperty_Call{Call: _e.mock.On("GetProperty", ctx, set, name)}
+}
+
+func (_c *MockCluster_GetProperty_Call) Run(run func(ctx context.Context, set cluster.PropertySet, name string)) *MockCluster_GetProperty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(cluster.PropertySet), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockCluster_GetProperty_Call) Return(_a0 string, _a1 bool, _a2 error) *MockCluster_GetProperty_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockCluster_GetProperty_Call) RunAndReturn(run func(context.Context, cluster.PropertySet, string) (string, bool, error)) *MockCluster_GetProperty_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQuorumStatus provides a mock function with given fields: ctx
+func (_m *MockCluster) GetQuorumStatus(ctx context.Context) (*cluster.QuorumStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuorumStatus")
+	}
+
+	var r0 *cluster.QuorumStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*cluster.QuorumStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *cluster.QuorumStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cluster.QuorumStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCluster_GetQuorumStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuorumStatus'
+type MockCluster_GetQuorumStatus_Call struct {
+	*mock.Call
+}
+
+// GetQuorumStatus is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCluster_Expecter) GetQuorumStatus(ctx interface{}) *MockCluster_GetQuorumStatus_Call {
+	return &MockCluster_GetQuorumStatus_Call{Call: _e.mock.On("GetQuorumStatus", ctx)}
+}
+
+func (_c *MockCluster_GetQuorumStatus_Call) Run(run func(ctx context.Context)) *MockCluster_GetQuorumStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCluster_GetQuorumStatus_Call) Return(_a0 *cluster.QuorumStatus, _a1 error) *MockCluster_GetQuorumStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCluster_GetQuorumStatus_Call) RunAndReturn(run func(context.Context) (*cluster.QuorumStatus, error)) *MockCluster_GetQuorumStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStatus provides a mock function with given fields: ctx
+func (_m *MockCluster) GetStatus(ctx context.Context) (*cluster.Status, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatus")
+	}
+
+	var r0 *cluster.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*cluster.Status, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *cluster.Status); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cluster.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCluster_GetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatus'
+type MockCluster_GetStatus_Call struct {
+	*mock.Call
+}
+
+// GetStatus is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCluster_Expecter) GetStatus(ctx interface{}) *MockCluster_GetStatus_Call {
+	return &MockCluster_GetStatus_Call{Call: _e.mock.On("GetStatus", ctx)}
+}
+
+func (_c *MockCluster_GetStatus_Call) Run(run func(ctx context.Context)) *MockCluster_GetStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCluster_GetStatus_Call) Return(_a0 *cluster.Status, _a1 error) *MockCluster_GetStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCluster_GetStatus_Call) RunAndReturn(run func(context.Context) (*cluster.Status, error)) *MockCluster_GetStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsHostOnline provides a mock function with given fields: ctx
+func (_m *MockCluster) IsHostOnline(ctx context.Context) bool {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsHostOnline")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockCluster_IsHostOnline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsHostOnline'
+type MockCluster_IsHostOnline_Call struct {
+	*mock.Call
+}
+
+// IsHostOnline is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCluster_Expecter) IsHostOnline(ctx interface{}) *MockCluster_IsHostOnline_Call {
+	return &MockCluster_IsHostOnline_Call{Call: _e.mock.On("IsHostOnline", ctx)}
+}
+
+func (_c *MockCluster_IsHostOnline_Call) Run(run func(ctx context.Context)) *MockCluster_IsHostOnline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCluster_IsHostOnline_Call) Return(_a0 bool) *MockCluster_IsHostOnline_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCluster_IsHostOnline_Call) RunAndReturn(run func(context.Context) bool) *MockCluster_IsHostOnline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsIdle provides a mock function with given fields: ctx
+func (_m *MockCluster) IsIdle(ctx context.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsIdle")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCluster_IsIdle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsIdle'
+type MockCluster_IsIdle_Call struct {
+	*mock.Call
+}
+
+// IsIdle is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCluster_Expecter) IsIdle(ctx interface{}) *MockCluster_IsIdle_Call {
+	return &MockCluster_IsIdle_Call{Call: _e.mock.On("IsIdle", ctx)}
+}
+
+func (_c *MockCluster_IsIdle_Call) Run(run func(ctx context.Context)) *MockCluster_IsIdle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCluster_IsIdle_Call) Return(_a0 bool, _a1 error) *MockCluster_IsIdle_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCluster_IsIdle_Call) RunAndReturn(run func(context.Context) (bool, error)) *MockCluster_IsIdle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStonithDevices provides a mock function with given fields: ctx
+func (_m *MockCluster) ListStonithDevices(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStonithDevices")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCluster_ListStonithDevices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStonithDevices'
+type MockCluster_ListStonithDevices_Call struct {
+	*mock.Call
+}
+
+// ListStonithDevices is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCluster_Expecter) ListStonithDevices(ctx interface{}) *MockCluster_ListStonithDevices_Call {
+	return &MockCluster_ListStonithDevices_Call{Call: _e.mock.On("ListStonithDevices", ctx)}
+}
+
+func (_c *MockCluster_ListStonithDevices_Call) Run(run func(ctx context.Context)) *MockCluster_ListStonithDevices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCluster_ListStonithDevices_Call) Return(_a0 []string, _a1 error) *MockCluster_ListStonithDevices_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCluster_ListStonithDevices_Call) RunAndReturn(run func(context.Context) ([]string, error)) *MockCluster_ListStonithDevices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LocalNodeName provides a mock function with given fields: ctx
+func (_m *MockCluster) LocalNodeName(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LocalNodeName")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCluster_LocalNodeName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LocalNodeName'
+type MockCluster_LocalNodeName_Call struct {
+	*mock.Call
+}
+
+// LocalNodeName is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCluster_Expecter) LocalNodeName(ctx interface{}) *MockCluster_LocalNodeName_Call {
+	return &MockCluster_LocalNodeName_Call{Call: _e.mock.On("LocalNodeName", ctx)}
+}
+
+func (_c *MockCluster_LocalNodeName_Call) Run(run func(ctx context.Context)) *MockCluster_LocalNodeName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCluster_LocalNodeName_Call) Return(_a0 string, _a1 error) *MockCluster_LocalNodeName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCluster_LocalNodeName_Call) RunAndReturn(run func(context.Context) (string, error)) *MockCluster_LocalNodeName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceCIBConfiguration provides a mock function with given fields: ctx, cib
+func (_m *MockCluster) ReplaceCIBConfiguration(ctx context.Context, cib *cluster.CIB) error {
+	ret := _m.Called(ctx, cib)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceCIBConfiguration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *cluster.CIB) error); ok {
+		r0 = rf(ctx, cib)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCluster_ReplaceCIBConfiguration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceCIBConfiguration'
+type MockCluster_ReplaceCIBConfiguration_Call struct {
+	*mock.Call
+}
+
+// ReplaceCIBConfiguration is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cib *cluster.CIB
+func (_e *MockCluster_Expecter) ReplaceCIBConfiguration(ctx interface{}, cib interface{}) *MockCluster_ReplaceCIBConfiguration_Call {
+	return &MockCluster_ReplaceCIBConfiguration_Call{Call: _e.mock.On("ReplaceCIBConfiguration", ctx, cib)}
+}
+
+func (_c *MockCluster_ReplaceCIBConfiguration_Call) Run(run func(ctx context.Context, cib *cluster.CIB)) *MockCluster_ReplaceCIBConfiguration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*cluster.CIB))
+	})
+	return _c
+}
+
+func (_c *MockCluster_ReplaceCIBConfiguration_Call) Return(_a0 error) *MockCluster_ReplaceCIBConfiguration_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCluster_ReplaceCIBConfiguration_Call) RunAndReturn(run func(context.Context, *cluster.CIB) error) *MockCluster_ReplaceCIBConfiguration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResourceCleanup provides a mock function with given fields: ctx, resourceID, nodeID
+func (_m *MockCluster) ResourceCleanup(ctx context.Context, resourceID string, nodeID string) error {
+	ret := _m.Called(ctx, resourceID, nodeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResourceCleanup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, resourceID, nodeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCluster_ResourceCleanup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResourceCleanup'
+type MockCluster_ResourceCleanup_Call struct {
+	*mock.Call
+}
+
+// ResourceCleanup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - resourceID string
+//   - nodeID string
+func (_e *MockCluster_Expecter) ResourceCleanup(ctx interface{}, resourceID interface{}, nodeID interface{}) *MockCluster_ResourceCleanup_Call {
+	return &MockCluster_ResourceCleanup_Call{Call: _e.mock.On("ResourceCleanup", ctx, resourceID, nodeID)}
+}
+
+func (_c *MockCluster_ResourceCleanup_Call) Run(run func(ctx context.Context, resourceID string, nodeID string)) *MockCluster_ResourceCleanup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockCluster_ResourceCleanup_Call) Return(_a0 error) *MockCluster_ResourceCleanup_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCluster_ResourceCleanup_Call) RunAndReturn(run func(context.Context, string, string) error) *MockCluster_ResourceCleanup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResourceRefresh provides a mock function with given fields: ctx, resourceID, nodeID
+func (_m *MockCluster) ResourceRefresh(ctx context.Context, resourceID string, nodeID string) error {
+	ret := _m.Called(ctx, resourceID, nodeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResourceRefresh")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
-		r0 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, resourceID, nodeID)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// MockCluster_IsHostOnline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsHostOnline'
-type MockCluster_IsHostOnline_Call struct {
+// MockCluster_ResourceRefresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResourceRefresh'
+type MockCluster_ResourceRefresh_Call struct {
 	*mock.Call
 }
 
-// IsHostOnline is a helper method to define mock.On call
+// ResourceRefresh is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockCluster_Expecter) IsHostOnline(ctx interface{}) *MockCluster_IsHostOnline_Call {
-	return &MockCluster_IsHostOnline_Call{Call: _e.mock.On("IsHostOnline", ctx)}
+//   - resourceID string
+//   - nodeID string
+func (_e *MockCluster_Expecter) ResourceRefresh(ctx interface{}, resourceID interface{}, nodeID interface{}) *MockCluster_ResourceRefresh_Call {
+	return &MockCluster_ResourceRefresh_Call{Call: _e.mock.On("ResourceRefresh", ctx, resourceID, nodeID)}
 }
 
-func (_c *MockCluster_IsHostOnline_Call) Run(run func(ctx context.Context)) *MockCluster_IsHostOnline_Call {
+func (_c *MockCluster_ResourceRefresh_Call) Run(run func(ctx context.Context, resourceID string, nodeID string)) *MockCluster_ResourceRefresh_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockCluster_IsHostOnline_Call) Return(_a0 bool) *MockCluster_IsHostOnline_Call {
+func (_c *MockCluster_ResourceRefresh_Call) Return(_a0 error) *MockCluster_ResourceRefresh_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockCluster_IsHostOnline_Call) RunAndReturn(run func(context.Context) bool) *MockCluster_IsHostOnline_Call {
+func (_c *MockCluster_ResourceRefresh_Call) RunAndReturn(run func(context.Context, string, string) error) *MockCluster_ResourceRefresh_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IsIdle provides a mock function with given fields: ctx
-func (_m *MockCluster) IsIdle(ctx context.Context) (bool, error) {
-	ret := _m.Called(ctx)
+// RunPreflightChecks provides a mock function with given fields: ctx, options
+func (_m *MockCluster) RunPreflightChecks(ctx context.Context, options cluster.PreflightOptions) (*cluster.PreflightReport, error) {
+	ret := _m.Called(ctx, options)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsIdle")
+		panic("no return value specified for RunPreflightChecks")
 	}
 
-	var r0 bool
+	var r0 *cluster.PreflightReport
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, cluster.PreflightOptions) (*cluster.PreflightReport, error)); ok {
+		return rf(ctx, options)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, cluster.PreflightOptions) *cluster.PreflightReport); ok {
+		r0 = rf(ctx, options)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cluster.PreflightReport)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, cluster.PreflightOptions) error); ok {
+		r1 = rf(ctx, options)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -98,45 +1259,46 @@ func (_m *MockCluster) IsIdle(ctx context.Context) (bool, error) {
 	return r0, r1
 }
 
-// MockCluster_IsIdle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsIdle'
-type MockCluster_IsIdle_Call struct {
+// MockCluster_RunPreflightChecks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunPreflightChecks'
+type MockCluster_RunPreflightChecks_Call struct {
 	*mock.Call
 }
 
-// IsIdle is a helper method to define mock.On call
+// RunPreflightChecks is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockCluster_Expecter) IsIdle(ctx interface{}) *MockCluster_IsIdle_Call {
-	return &MockCluster_IsIdle_Call{Call: _e.mock.On("IsIdle", ctx)}
+//   - options cluster.PreflightOptions
+func (_e *MockCluster_Expecter) RunPreflightChecks(ctx interface{}, options interface{}) *MockCluster_RunPreflightChecks_Call {
+	return &MockCluster_RunPreflightChecks_Call{Call: _e.mock.On("RunPreflightChecks", ctx, options)}
 }
 
-func (_c *MockCluster_IsIdle_Call) Run(run func(ctx context.Context)) *MockCluster_IsIdle_Call {
+func (_c *MockCluster_RunPreflightChecks_Call) Run(run func(ctx context.Context, options cluster.PreflightOptions)) *MockCluster_RunPreflightChecks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(cluster.PreflightOptions))
 	})
 	return _c
 }
 
-func (_c *MockCluster_IsIdle_Call) Return(_a0 bool, _a1 error) *MockCluster_IsIdle_Call {
+func (_c *MockCluster_RunPreflightChecks_Call) Return(_a0 *cluster.PreflightReport, _a1 error) *MockCluster_RunPreflightChecks_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockCluster_IsIdle_Call) RunAndReturn(run func(context.Context) (bool, error)) *MockCluster_IsIdle_Call {
+func (_c *MockCluster_RunPreflightChecks_Call) RunAndReturn(run func(context.Context, cluster.PreflightOptions) (*cluster.PreflightReport, error)) *MockCluster_RunPreflightChecks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ResourceRefresh provides a mock function with given fields: ctx, resourceID, nodeID
-func (_m *MockCluster) ResourceRefresh(ctx context.Context, resourceID string, nodeID string) error {
-	ret := _m.Called(ctx, resourceID, nodeID)
+// SetMaintenanceState provides a mock function with given fields: ctx, resourceID, nodeID, maintenance
+func (_m *MockCluster) SetMaintenanceState(ctx context.Context, resourceID string, nodeID string, maintenance bool) error {
+	ret := _m.Called(ctx, resourceID, nodeID, maintenance)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ResourceRefresh")
+		panic("no return value specified for SetMaintenanceState")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, resourceID, nodeID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
+		r0 = rf(ctx, resourceID, nodeID, maintenance)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -144,32 +1306,132 @@ func (_m *MockCluster) ResourceRefresh(ctx context.Context, resourceID string, n
 	return r0
 }
 
-// MockCluster_ResourceRefresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResourceRefresh'
-type MockCluster_ResourceRefresh_Call struct {
+// MockCluster_SetMaintenanceState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMaintenanceState'
+type MockCluster_SetMaintenanceState_Call struct {
 	*mock.Call
 }
 
-// ResourceRefresh is a helper method to define mock.On call
+// SetMaintenanceState is a helper method to define mock.On call
 //   - ctx context.Context
 //   - resourceID string
 //   - nodeID string
-func (_e *MockCluster_Expecter) ResourceRefresh(ctx interface{}, resourceID interface{}, nodeID interface{}) *MockCluster_ResourceRefresh_Call {
-	return &MockCluster_ResourceRefresh_Call{Call: _e.mock.On("ResourceRefresh", ctx, resourceID, nodeID)}
+//   - maintenance bool
+func (_e *MockCluster_Expecter) SetMaintenanceState(ctx interface{}, resourceID interface{}, nodeID interface{}, maintenance interface{}) *MockCluster_SetMaintenanceState_Call {
+	return &MockCluster_SetMaintenanceState_Call{Call: _e.mock.On("SetMaintenanceState", ctx, resourceID, nodeID, maintenance)}
 }
 
-func (_c *MockCluster_ResourceRefresh_Call) Run(run func(ctx context.Context, resourceID string, nodeID string)) *MockCluster_ResourceRefresh_Call {
+func (_c *MockCluster_SetMaintenanceState_Call) Run(run func(ctx context.Context, resourceID string, nodeID string, maintenance bool)) *MockCluster_SetMaintenanceState_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool))
 	})
 	return _c
 }
 
-func (_c *MockCluster_ResourceRefresh_Call) Return(_a0 error) *MockCluster_ResourceRefresh_Call {
+func (_c *MockCluster_SetMaintenanceState_Call) Return(_a0 error) *MockCluster_SetMaintenanceState_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockCluster_ResourceRefresh_Call) RunAndReturn(run func(context.Context, string, string) error) *MockCluster_ResourceRefresh_Call {
+func (_c *MockCluster_SetMaintenanceState_Call) RunAndReturn(run func(context.Context, string, string, bool) error) *MockCluster_SetMaintenanceState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetNodeAttribute provides a mock function with given fields: ctx, nodeID, attributeType, name, value
+func (_m *MockCluster) SetNodeAttribute(ctx context.Context, nodeID string, attributeType cluster.NodeAttributeType, name string, value string) error {
+	ret := _m.Called(ctx, nodeID, attributeType, name, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNodeAttribute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, cluster.NodeAttributeType, string, string) error); ok {
+		r0 = rf(ctx, nodeID, attributeType, name, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCluster_SetNodeAttribute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetNodeAttribute'
+type MockCluster_SetNodeAttribute_Call struct {
+	*mock.Call
+}
+
+// SetNodeAttribute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodeID string
+//   - attributeType cluster.NodeAttributeType
+//   - name string
+//   - value string
+func (_e *MockCluster_Expecter) SetNodeAttribute(ctx interface{}, nodeID interface{}, attributeType interface{}, name interface{}, value interface{}) *MockCluster_SetNodeAttribute_Call {
+	return &MockCluster_SetNodeAttribute_Call{Call: _e.mock.On("SetNodeAttribute", ctx, nodeID, attributeType, name, value)}
+}
+
+func (_c *MockCluster_SetNodeAttribute_Call) Run(run func(ctx context.Context, nodeID string, attributeType cluster.NodeAttributeType, name string, value string)) *MockCluster_SetNodeAttribute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(cluster.NodeAttributeType), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockCluster_SetNodeAttribute_Call) Return(_a0 error) *MockCluster_SetNodeAttribute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCluster_SetNodeAttribute_Call) RunAndReturn(run func(context.Context, string, cluster.NodeAttributeType, string, string) error) *MockCluster_SetNodeAttribute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetProperty provides a mock function with given fields: ctx, set, name, value
+func (_m *MockCluster) SetProperty(ctx context.Context, set cluster.PropertySet, name string, value string) error {
+	ret := _m.Called(ctx, set, name, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetProperty")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, cluster.PropertySet, string, string) error); ok {
+		r0 = rf(ctx, set, name, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCluster_SetProperty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetProperty'
+type MockCluster_SetProperty_Call struct {
+	*mock.Call
+}
+
+// SetProperty is a helper method to define mock.On call
+//   - ctx context.Context
+//   - set cluster.PropertySet
+//   - name string
+//   - value string
+func (_e *MockCluster_Expecter) SetProperty(ctx interface{}, set interface{}, name interface{}, value interface{}) *MockCluster_SetProperty_Call {
+	return &MockCluster_SetProperty_Call{Call: _e.mock.On("SetProperty", ctx, set, name, value)}
+}
+
+func (_c *MockCluster_SetProperty_Call) Run(run func(ctx context.Context, set cluster.PropertySet, name string, value string)) *MockCluster_SetProperty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(cluster.PropertySet), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockCluster_SetProperty_Call) Return(_a0 error) *MockCluster_SetProperty_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCluster_SetProperty_Call) RunAndReturn(run func(context.Context, cluster.PropertySet, string, string) error) *MockCluster_SetProperty_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -220,6 +1482,67 @@ func (_c *MockCluster_StartCluster_Call) RunAndReturn(run func(context.Context)
 	return _c
 }
 
+// StartClusterNodes provides a mock function with given fields: ctx, nodes
+func (_m *MockCluster) StartClusterNodes(ctx context.Context, nodes ...string) error {
+	_va := make([]interface{}, len(nodes))
+	for _i := range nodes {
+		_va[_i] = nodes[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartClusterNodes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = rf(ctx, nodes...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCluster_StartClusterNodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartClusterNodes'
+type MockCluster_StartClusterNodes_Call struct {
+	*mock.Call
+}
+
+// StartClusterNodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodes ...string
+func (_e *MockCluster_Expecter) StartClusterNodes(ctx interface{}, nodes ...interface{}) *MockCluster_StartClusterNodes_Call {
+	return &MockCluster_StartClusterNodes_Call{Call: _e.mock.On("StartClusterNodes",
+		append([]interface{}{ctx}, nodes...)...)}
+}
+
+func (_c *MockCluster_StartClusterNodes_Call) Run(run func(ctx context.Context, nodes ...string)) *MockCluster_StartClusterNodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockCluster_StartClusterNodes_Call) Return(_a0 error) *MockCluster_StartClusterNodes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCluster_StartClusterNodes_Call) RunAndReturn(run func(context.Context, ...string) error) *MockCluster_StartClusterNodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StopCluster provides a mock function with given fields: ctx
 func (_m *MockCluster) StopCluster(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -266,6 +1589,161 @@ func (_c *MockCluster_StopCluster_Call) RunAndReturn(run func(context.Context) e
 	return _c
 }
 
+// StopClusterNodes provides a mock function with given fields: ctx, nodes
+func (_m *MockCluster) StopClusterNodes(ctx context.Context, nodes ...string) error {
+	_va := make([]interface{}, len(nodes))
+	for _i := range nodes {
+		_va[_i] = nodes[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopClusterNodes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = rf(ctx, nodes...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCluster_StopClusterNodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopClusterNodes'
+type MockCluster_StopClusterNodes_Call struct {
+	*mock.Call
+}
+
+// StopClusterNodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodes ...string
+func (_e *MockCluster_Expecter) StopClusterNodes(ctx interface{}, nodes ...interface{}) *MockCluster_StopClusterNodes_Call {
+	return &MockCluster_StopClusterNodes_Call{Call: _e.mock.On("StopClusterNodes",
+		append([]interface{}{ctx}, nodes...)...)}
+}
+
+func (_c *MockCluster_StopClusterNodes_Call) Run(run func(ctx context.Context, nodes ...string)) *MockCluster_StopClusterNodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockCluster_StopClusterNodes_Call) Return(_a0 error) *MockCluster_StopClusterNodes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCluster_StopClusterNodes_Call) RunAndReturn(run func(context.Context, ...string) error) *MockCluster_StopClusterNodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyCIBInShadow provides a mock function with given fields: ctx, cib
+func (_m *MockCluster) VerifyCIBInShadow(ctx context.Context, cib *cluster.CIB) error {
+	ret := _m.Called(ctx, cib)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyCIBInShadow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *cluster.CIB) error); ok {
+		r0 = rf(ctx, cib)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCluster_VerifyCIBInShadow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyCIBInShadow'
+type MockCluster_VerifyCIBInShadow_Call struct {
+	*mock.Call
+}
+
+// VerifyCIBInShadow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cib *cluster.CIB
+func (_e *MockCluster_Expecter) VerifyCIBInShadow(ctx interface{}, cib interface{}) *MockCluster_VerifyCIBInShadow_Call {
+	return &MockCluster_VerifyCIBInShadow_Call{Call: _e.mock.On("VerifyCIBInShadow", ctx, cib)}
+}
+
+func (_c *MockCluster_VerifyCIBInShadow_Call) Run(run func(ctx context.Context, cib *cluster.CIB)) *MockCluster_VerifyCIBInShadow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*cluster.CIB))
+	})
+	return _c
+}
+
+func (_c *MockCluster_VerifyCIBInShadow_Call) Return(_a0 error) *MockCluster_VerifyCIBInShadow_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCluster_VerifyCIBInShadow_Call) RunAndReturn(run func(context.Context, *cluster.CIB) error) *MockCluster_VerifyCIBInShadow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WaitForIdle provides a mock function with given fields: ctx, options
+func (_m *MockCluster) WaitForIdle(ctx context.Context, options cluster.WaitForIdleOptions) error {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitForIdle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, cluster.WaitForIdleOptions) error); ok {
+		r0 = rf(ctx, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCluster_WaitForIdle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WaitForIdle'
+type MockCluster_WaitForIdle_Call struct {
+	*mock.Call
+}
+
+// WaitForIdle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options cluster.WaitForIdleOptions
+func (_e *MockCluster_Expecter) WaitForIdle(ctx interface{}, options interface{}) *MockCluster_WaitForIdle_Call {
+	return &MockCluster_WaitForIdle_Call{Call: _e.mock.On("WaitForIdle", ctx, options)}
+}
+
+func (_c *MockCluster_WaitForIdle_Call) Run(run func(ctx context.Context, options cluster.WaitForIdleOptions)) *MockCluster_WaitForIdle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(cluster.WaitForIdleOptions))
+	})
+	return _c
+}
+
+func (_c *MockCluster_WaitForIdle_Call) Return(_a0 error) *MockCluster_WaitForIdle_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCluster_WaitForIdle_Call) RunAndReturn(run func(context.Context, cluster.WaitForIdleOptions) error) *MockCluster_WaitForIdle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockCluster creates a new instance of MockCluster. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockCluster(t interface {
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// NodeAttributeType is the kind of node attribute. Permanent attributes are stored in the
+// node configuration, transient attributes in the status section and are cleared when the
+// node leaves the cluster, and utilization attributes define the node capacity.
+type NodeAttributeType string
+
+const (
+	PermanentNodeAttribute   NodeAttributeType = "permanent"
+	TransientNodeAttribute   NodeAttributeType = "transient"
+	UtilizationNodeAttribute NodeAttributeType = "utilization"
+)
+
+// crmNodeNotFound is printed by crm_attribute if the node name cannot be mapped to a node ID:
+// Could not map name=node-name to a UUID
+const crmNodeNotFound = "Could not map"
+
+var (
+	nodeAttributeNamePatternCompiled = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.:-]*$`)
+	utilizationPatternCompiled       = regexp.MustCompile(`^\d+$`)
+	// reservedNodeAttributes are managed by the cluster or by specific operators
+	reservedNodeAttributes = []string{"maintenance", "standby"}
+)
+
+// ValidateNodeAttributeName checks that the node attribute can be changed or deleted
+func ValidateNodeAttributeName(attributeType NodeAttributeType, name string) error {
+	if !slices.Contains(
+		[]NodeAttributeType{PermanentNodeAttribute, TransientNodeAttribute, UtilizationNodeAttribute},
+		attributeType,
+	) {
+		return fmt.Errorf("invalid node attribute type %s", attributeType)
+	}
+
+	if !nodeAttributeNamePatternCompiled.MatchString(name) {
+		return fmt.Errorf("invalid node attribute name %s", name)
+	}
+
+	if slices.Contains(reservedNodeAttributes, name) {
+		return fmt.Errorf("node attribute %s cannot be changed", name)
+	}
+
+	return nil
+}
+
+// ValidateNodeAttribute checks that the node attribute can be changed and that utilization
+// values are non negative integers
+func ValidateNodeAttribute(attributeType NodeAttributeType, name, value string) error {
+	if err := ValidateNodeAttributeName(attributeType, name); err != nil {
+		return err
+	}
+
+	if attributeType == UtilizationNodeAttribute && !utilizationPatternCompiled.MatchString(value) {
+		return fmt.Errorf("invalid utilization value %s for %s, it must be a non negative integer", value, name)
+	}
+
+	return nil
+}
+
+// GetNodeAttribute returns the value of a node attribute.
+// The found return value is false if the attribute is not set in the node.
+// An error is returned if the node doesn't exist.
+func (c *Client) GetNodeAttribute(
+	ctx context.Context,
+	nodeID string,
+	attributeType NodeAttributeType,
+	name string,
+) (string, bool, error) {
+	return c.backend.getNodeAttribute(ctx, nodeID, attributeType, name)
+}
+
+// SetNodeAttribute sets the value of a node attribute
+func (c *Client) SetNodeAttribute(
+	ctx context.Context,
+	nodeID string,
+	attributeType NodeAttributeType,
+	name, value string,
+) error {
+	if err := c.backend.setNodeAttribute(ctx, nodeID, attributeType, name, value); err != nil {
+		return err
+	}
+
+	c.logger.Info("Node attribute set", "node", nodeID, "type", attributeType, "name", name, "value", value)
+	return nil
+}
+
+// DeleteNodeAttribute removes a node attribute
+func (c *Client) DeleteNodeAttribute(
+	ctx context.Context,
+	nodeID string,
+	attributeType NodeAttributeType,
+	name string,
+) error {
+	if err := c.backend.deleteNodeAttribute(ctx, nodeID, attributeType, name); err != nil {
+		return err
+	}
+
+	c.logger.Info("Node attribute deleted", "node", nodeID, "type", attributeType, "name", name)
+	return nil
+}
+
+// nodeAttributeQueryError checks the output of a failed node attribute query.
+// nil is returned if the attribute is not set in the node.
+func nodeAttributeQueryError(nodeID, name string, output []byte, err error) error {
+	switch {
+	case strings.Contains(string(output), crmNodeNotFound):
+		return fmt.Errorf("node %s not found", nodeID)
+	case strings.Contains(string(output), propertyNotFoundMessage):
+		return nil
+	default:
+		return fmt.Errorf("error getting node attribute %s: %w, output: %s", name, err, string(output))
+	}
+}
+
+// crmAttributeNodeArguments returns the crm_attribute arguments to select the node attribute type
+func crmAttributeNodeArguments(attributeType NodeAttributeType) []string {
+	switch attributeType {
+	case TransientNodeAttribute:
+		return []string{"--lifetime", "reboot"}
+	case UtilizationNodeAttribute:
+		return []string{"--utilization"}
+	default:
+		return []string{"--lifetime", "forever"}
+	}
+}
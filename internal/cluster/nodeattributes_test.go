@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support/mocks"
+)
+
+const crmAttributeNotSetOutput = "scope=nodes  name=hana_prd_site value=(null)\n" +
+	"Error performing operation: No such device or address"
+
+type NodeAttributesTestSuite struct {
+	suite.Suite
+	mockExecutor *mocks.MockCmdExecutor
+}
+
+func TestNodeAttributes(t *testing.T) {
+	suite.Run(t, new(NodeAttributesTestSuite))
+}
+
+func (suite *NodeAttributesTestSuite) SetupTest() {
+	suite.mockExecutor = mocks.NewMockCmdExecutor(suite.T())
+}
+
+func (suite *NodeAttributesTestSuite) crmClient() cluster.Cluster {
+	return cluster.NewClusterClientWithToolchain(cluster.CrmshToolchain, suite.mockExecutor, slog.Default())
+}
+
+func (suite *NodeAttributesTestSuite) pcsClient() cluster.Cluster {
+	return cluster.NewClusterClientWithToolchain(cluster.PcsToolchain, suite.mockExecutor, slog.Default())
+}
+
+func (suite *NodeAttributesTestSuite) TestValidateNodeAttribute() {
+	cases := []struct {
+		attributeType cluster.NodeAttributeType
+		name          string
+		value         string
+		err           string
+	}{
+		{attributeType: cluster.PermanentNodeAttribute, name: "hana_prd_site", value: "NUREMBERG"},
+		{attributeType: cluster.TransientNodeAttribute, name: "hana_prd_clone_state", value: "PROMOTED"},
+		{attributeType: cluster.UtilizationNodeAttribute, name: "memory", value: "32768"},
+		{
+			attributeType: "status",
+			name:          "hana_prd_site",
+			err:           "invalid node attribute type status",
+		},
+		{
+			attributeType: cluster.PermanentNodeAttribute,
+			name:          "#uname",
+			err:           "invalid node attribute name #uname",
+		},
+		{
+			attributeType: cluster.PermanentNodeAttribute,
+			name:          "standby",
+			value:         "on",
+			err:           "node attribute standby cannot be changed",
+		},
+		{
+			attributeType: cluster.UtilizationNodeAttribute,
+			name:          "cpu",
+			value:         "-2",
+			err:           "invalid utilization value -2 for cpu, it must be a non negative integer",
+		},
+	}
+
+	for _, tt := range cases {
+		err := cluster.ValidateNodeAttribute(tt.attributeType, tt.name, tt.value)
+		if tt.err == "" {
+			suite.NoError(err)
+		} else {
+			suite.EqualError(err, tt.err)
+		}
+	}
+}
+
+func (suite *NodeAttributesTestSuite) TestCrmGetNodeAttribute() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm", "node", "attribute", "vmhana01", "show", "hana_prd_site").
+		Return([]byte("scope=nodes  name=hana_prd_site value=NUREMBERG\n"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm", "node", "status-attr", "vmhana01", "show", "hana_prd_site").
+		Return([]byte(crmAttributeNotSetOutput), errors.New("exit status 105")).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm", "node", "utilization", "vmhana01", "show", "memory").
+		Return([]byte("scope=nodes  name=memory value=32768\n"), nil).Once()
+
+	client := suite.crmClient()
+
+	value, found, err := client.GetNodeAttribute(ctx, "vmhana01", cluster.PermanentNodeAttribute, "hana_prd_site")
+	suite.NoError(err)
+	suite.True(found)
+	suite.Equal("NUREMBERG", value)
+
+	value, found, err = client.GetNodeAttribute(ctx, "vmhana01", cluster.TransientNodeAttribute, "hana_prd_site")
+	suite.NoError(err)
+	suite.False(found)
+	suite.Empty(value)
+
+	value, found, err = client.GetNodeAttribute(ctx, "vmhana01", cluster.UtilizationNodeAttribute, "memory")
+	suite.NoError(err)
+	suite.True(found)
+	suite.Equal("32768", value)
+}
+
+func (suite *NodeAttributesTestSuite) TestCrmGetNodeAttributeNodeNotFound() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm", "node", "attribute", "vmhana03", "show", "hana_prd_site").
+		Return([]byte("Could not map name=vmhana03 to a UUID"), errors.New("exit status 105")).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm", "node", "attribute", "vmhana01", "show", "hana_prd_site").
+		Return([]byte("Could not connect to the CIB"), errors.New("exit status 102")).Once()
+
+	client := suite.crmClient()
+
+	_, _, err := client.GetNodeAttribute(ctx, "vmhana03", cluster.PermanentNodeAttribute, "hana_prd_site")
+	suite.EqualError(err, "node vmhana03 not found")
+
+	_, _, err = client.GetNodeAttribute(ctx, "vmhana01", cluster.PermanentNodeAttribute, "hana_prd_site")
+	suite.EqualError(err, "error getting node attribute hana_prd_site: exit status 102, output: Could not connect to the CIB")
+}
+
+func (suite *NodeAttributesTestSuite) TestCrmSetDeleteNodeAttribute() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm", "node", "attribute", "vmhana01", "set", "hana_prd_site", "PRAGUE").
+		Return([]byte(""), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm", "node", "utilization", "vmhana01", "set", "cpu", "8").
+		Return([]byte("ERROR: node vmhana01 is offline"), errors.New("exit status 1")).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm", "node", "status-attr", "vmhana01", "delete", "hana_prd_clone_state").
+		Return([]byte(""), nil).Once()
+
+	client := suite.crmClient()
+
+	suite.NoError(client.SetNodeAttribute(ctx, "vmhana01", cluster.PermanentNodeAttribute, "hana_prd_site", "PRAGUE"))
+	suite.EqualError(client.SetNodeAttribute(ctx, "vmhana01", cluster.UtilizationNodeAttribute, "cpu", "8"),
+		"error setting node attribute cpu: exit status 1, output: ERROR: node vmhana01 is offline")
+	suite.NoError(client.DeleteNodeAttribute(ctx, "vmhana01", cluster.TransientNodeAttribute, "hana_prd_clone_state"))
+}
+
+func (suite *NodeAttributesTestSuite) TestPcsGetNodeAttribute() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm_attribute", "--node", "vmhana01", "--name", "hana_prd_site",
+		"--lifetime", "forever", "--query", "--quiet").Return([]byte("NUREMBERG\n"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm_attribute", "--node", "vmhana01", "--name", "cpu",
+		"--utilization", "--query", "--quiet").
+		Return([]byte("Error performing operation: No such device or address"), errors.New("exit status 105")).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm_attribute", "--node", "vmhana03", "--name", "hana_prd_clone_state",
+		"--lifetime", "reboot", "--query", "--quiet").
+		Return([]byte("Could not map name=vmhana03 to a UUID"), errors.New("exit status 105")).Once()
+
+	client := suite.pcsClient()
+
+	value, found, err := client.GetNodeAttribute(ctx, "vmhana01", cluster.PermanentNodeAttribute, "hana_prd_site")
+	suite.NoError(err)
+	suite.True(found)
+	suite.Equal("NUREMBERG", value)
+
+	_, found, err = client.GetNodeAttribute(ctx, "vmhana01", cluster.UtilizationNodeAttribute, "cpu")
+	suite.NoError(err)
+	suite.False(found)
+
+	_, _, err = client.GetNodeAttribute(ctx, "vmhana03", cluster.TransientNodeAttribute, "hana_prd_clone_state")
+	suite.EqualError(err, "node vmhana03 not found")
+}
+
+func (suite *NodeAttributesTestSuite) TestPcsSetDeleteNodeAttribute() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "node", "attribute", "vmhana01", "hana_prd_site=PRAGUE").
+		Return([]byte(""), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "node", "utilization", "vmhana01", "cpu=").
+		Return([]byte(""), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm_attribute", "--node", "vmhana01", "--name", "hana_prd_clone_state",
+		"--lifetime", "reboot", "--update", "PROMOTED").Return([]byte(""), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm_attribute", "--node", "vmhana01", "--name", "hana_prd_clone_state",
+		"--lifetime", "reboot", "--delete").
+		Return([]byte("Could not connect to the CIB"), errors.New("exit status 102")).Once()
+
+	client := suite.pcsClient()
+
+	suite.NoError(client.SetNodeAttribute(ctx, "vmhana01", cluster.PermanentNodeAttribute, "hana_prd_site", "PRAGUE"))
+	suite.NoError(client.DeleteNodeAttribute(ctx, "vmhana01", cluster.UtilizationNodeAttribute, "cpu"))
+	suite.NoError(
+		client.SetNodeAttribute(ctx, "vmhana01", cluster.TransientNodeAttribute, "hana_prd_clone_state", "PROMOTED"),
+	)
+	suite.EqualError(client.DeleteNodeAttribute(ctx, "vmhana01", cluster.TransientNodeAttribute, "hana_prd_clone_state"),
+		"error deleting node attribute hana_prd_clone_state: exit status 102, output: Could not connect to the CIB")
+}
@@ -0,0 +1,381 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/trento-project/workbench/internal/support"
+)
+
+// corosync node name line of `pcs cluster corosync`, e.g. name: node1
+var corosyncNodeNamePatternCompiled = regexp.MustCompile(`(?m)^\s*name:\s*(\S+)\s*$`)
+
+// pcsBackend manages the cluster using pcs, the Red Hat cluster management toolchain.
+// The pcs output format changes between versions, so the state is queried using
+// the pacemaker command line tools when possible.
+// https://clusterlabs.org/projects/pacemaker/doc/2.1/Clusters_from_Scratch/html/apb-pcs.html
+type pcsBackend struct {
+	executor support.CmdExecutor
+	logger   *slog.Logger
+}
+
+func (b *pcsBackend) isHostOnline(ctx context.Context) bool {
+	output, err := b.executor.Exec(ctx, pcsCommand, "status")
+	if err != nil {
+		return false
+	}
+
+	b.logger.Debug("pcs status output", "output", string(output))
+
+	return true
+}
+
+func (b *pcsBackend) startCluster(ctx context.Context) error {
+	b.logger.Info("Starting pcs cluster")
+	output, err := b.executor.Exec(ctx, pcsCommand, "cluster", "start")
+	if err != nil {
+		return fmt.Errorf("failed to start pcs cluster: %w, output: %s", err, string(output))
+	}
+
+	b.logger.Info("pcs cluster started successfully")
+	return nil
+}
+
+func (b *pcsBackend) stopCluster(ctx context.Context) error {
+	b.logger.Info("Stopping pcs cluster")
+	output, err := b.executor.Exec(ctx, pcsCommand, "cluster", "stop")
+	if err != nil {
+		return fmt.Errorf("failed to stop pcs cluster: %w, output: %s", err, string(output))
+	}
+
+	b.logger.Info("pcs cluster stopped successfully")
+	return nil
+}
+
+// startClusterNodes runs `pcs cluster start <node>...`, using the --all flag if no node is given
+func (b *pcsBackend) startClusterNodes(ctx context.Context, nodes []string) error {
+	args := clusterNodesArguments("start", nodes)
+
+	b.logger.Info("Starting pcs cluster in nodes", "nodes", nodes)
+	output, err := b.executor.Exec(ctx, pcsCommand, args...)
+	if err != nil {
+		return fmt.Errorf("failed to start pcs cluster in nodes %v: %w, output: %s", nodes, err, string(output))
+	}
+
+	b.logger.Info("pcs cluster started successfully in nodes", "nodes", nodes)
+	return nil
+}
+
+// stopClusterNodes runs `pcs cluster stop <node>...`, using the --all flag if no node is given
+func (b *pcsBackend) stopClusterNodes(ctx context.Context, nodes []string) error {
+	args := clusterNodesArguments("stop", nodes)
+
+	b.logger.Info("Stopping pcs cluster in nodes", "nodes", nodes)
+	output, err := b.executor.Exec(ctx, pcsCommand, args...)
+	if err != nil {
+		return fmt.Errorf("failed to stop pcs cluster in nodes %v: %w, output: %s", nodes, err, string(output))
+	}
+
+	b.logger.Info("pcs cluster stopped successfully in nodes", "nodes", nodes)
+	return nil
+}
+
+// getClusterStackStates parses the Pacemaker Nodes section of the `pcs status nodes` output.
+// Standby and maintenance nodes are online, as their cluster stack is running.
+// pcs queries the state from the local pacemaker instance, so if the local cluster stack is stopped,
+// the nodes are taken from the corosync configuration, `pcs cluster corosync`, and reported as offline.
+// Starting an already online node with pcs doesn't have any effect.
+func (b *pcsBackend) getClusterStackStates(ctx context.Context) (map[string]bool, error) {
+	output, err := b.executor.Exec(ctx, pcsCommand, "status", "nodes")
+	if err != nil {
+		b.logger.Debug("pcs status nodes failed, getting the nodes from the corosync configuration",
+			"error", err, "output", string(output))
+		return b.getCorosyncNodesStates(ctx)
+	}
+
+	states := parsePcsStatusNodes(output)
+	if len(states) == 0 {
+		return nil, fmt.Errorf("error getting cluster stack states, no node found, output: %s", string(output))
+	}
+
+	return states, nil
+}
+
+func (b *pcsBackend) getCorosyncNodesStates(ctx context.Context) (map[string]bool, error) {
+	output, err := b.executor.Exec(ctx, pcsCommand, "cluster", "corosync")
+	if err != nil {
+		return nil, fmt.Errorf("error getting corosync configuration: %w, output: %s", err, string(output))
+	}
+
+	states := make(map[string]bool)
+	for _, matches := range corosyncNodeNamePatternCompiled.FindAllStringSubmatch(string(output), -1) {
+		states[matches[1]] = false
+	}
+
+	if len(states) == 0 {
+		return nil, errors.New("error getting cluster stack states, no node found in corosync configuration")
+	}
+
+	return states, nil
+}
+
+func parsePcsStatusNodes(output []byte) map[string]bool {
+	states := make(map[string]bool)
+	pacemakerNodes := false
+
+	for _, line := range strings.Split(string(output), "\n") {
+		if !strings.HasPrefix(line, " ") {
+			pacemakerNodes = strings.TrimSpace(line) == "Pacemaker Nodes:"
+			continue
+		}
+
+		state, nodes, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !pacemakerNodes || !found {
+			continue
+		}
+
+		for _, node := range strings.Fields(nodes) {
+			states[node] = state != "Offline"
+		}
+	}
+
+	return states
+}
+
+// isIdle looks for the DC node using `crmadmin --dc_lookup` and gets its controller state
+// using `crmadmin --status <dc>`, as cs_clusterstate is not available
+func (b *pcsBackend) isIdle(ctx context.Context) (bool, error) {
+	dcOutput, err := b.executor.Exec(ctx, "crmadmin", "--dc_lookup", "--quiet")
+	if err != nil {
+		return false, fmt.Errorf("error looking for the DC node: %w, output: %s", err, string(dcOutput))
+	}
+
+	dc := strings.TrimSpace(string(dcOutput))
+	if dc == "" {
+		return false, nil
+	}
+
+	stateOutput, err := b.executor.Exec(ctx, "crmadmin", "--status", dc, "--quiet")
+	if err != nil {
+		return false, fmt.Errorf("error getting the DC node state: %w, output: %s", err, string(stateOutput))
+	}
+
+	return clusterIdlePatternCompiled.Match(stateOutput), nil
+}
+
+// resourceRefresh runs the `pcs resource refresh [<rsc>] [node=<node>]` command.
+// pcs prints the crm_resource output, so it is checked like in crmsh.
+func (b *pcsBackend) resourceRefresh(ctx context.Context, resourceID, nodeID string) error {
+	args := []string{"resource", "refresh"}
+	if resourceID != "" {
+		args = append(args, resourceID)
+	}
+
+	if nodeID != "" {
+		args = append(args, "node="+nodeID)
+	}
+
+	b.logger.Info("Refreshing cluster resource", "resourceID", resourceID, "nodeID", nodeID)
+	output, err := b.executor.Exec(ctx, pcsCommand, args...)
+	if err != nil {
+		return fmt.Errorf("failed to refresh resource: %w, output: %s", err, string(output))
+	}
+
+	if !strings.Contains(string(output), resourceRefreshedMessage) {
+		return fmt.Errorf("failed to refresh resource, unexpected output: %s", string(output))
+	}
+
+	b.logger.Info("Cluster resource refreshed successfully")
+	return nil
+}
+
+// addLocationConstraint runs `pcs constraint location add <id> <rsc> <node> <score>`.
+// The command doesn't accept a role, which requires a rule based constraint.
+func (b *pcsBackend) addLocationConstraint(ctx context.Context, constraint LocationConstraint) error {
+	if constraint.Role != "" {
+		return fmt.Errorf("constraint %s: roles are not supported with pcs", constraint.ID)
+	}
+
+	output, err := b.executor.Exec(
+		ctx, pcsCommand, "constraint", "location", "add",
+		constraint.ID, constraint.Resource, constraint.Node, constraint.Score,
+	)
+	if err != nil {
+		return fmt.Errorf("error adding constraint %s: %w, output: %s", constraint.ID, err, string(output))
+	}
+
+	return nil
+}
+
+// deleteConstraint runs `pcs constraint delete <id>`
+func (b *pcsBackend) deleteConstraint(ctx context.Context, constraintID string) error {
+	output, err := b.executor.Exec(ctx, pcsCommand, "constraint", "delete", constraintID)
+	if err != nil {
+		return fmt.Errorf("error deleting constraint %s: %w, output: %s", constraintID, err, string(output))
+	}
+
+	return nil
+}
+
+// getMaintenanceState queries the maintenance attributes using crm_resource and crm_attribute.
+// As in crmsh, the maintenance meta attribute of the resources has preference over is-managed.
+func (b *pcsBackend) getMaintenanceState(ctx context.Context, resourceID, nodeID string) (bool, error) {
+	switch {
+	case resourceID != "":
+		for _, attribute := range []string{"maintenance", "is-managed"} {
+			value, found, err := b.queryAttribute(
+				ctx, "crm_resource", "--resource", resourceID, "--meta", "--get-parameter", attribute, "--quiet",
+			)
+			if err != nil {
+				return false, fmt.Errorf("error getting %s attribute: %w", attribute, err)
+			}
+
+			if !found {
+				continue
+			}
+
+			// is-managed has the opposite logic than maintenance attribute
+			return isPacemakerTrue(value) == (attribute == "maintenance"), nil
+		}
+
+		return false, nil
+	case nodeID != "":
+		value, found, err := b.queryAttribute(
+			ctx, "crm_attribute", "--type", "nodes", "--node", nodeID, "--name", "maintenance", "--query", "--quiet",
+		)
+		if err != nil {
+			return false, fmt.Errorf("error getting node maintenance attribute: %w", err)
+		}
+
+		return found && isPacemakerTrue(value), nil
+	default:
+		value, found, err := b.queryAttribute(
+			ctx, "crm_attribute", "--type", string(ClusterOptionsSet), "--name", "maintenance-mode", "--query", "--quiet",
+		)
+		if err != nil {
+			return false, fmt.Errorf("error getting maintenance-mode: %w", err)
+		}
+
+		return found && isPacemakerTrue(value), nil
+	}
+}
+
+// setMaintenanceState uses `pcs property set maintenance-mode=<state>` for the cluster,
+// `pcs resource meta <rsc> maintenance=<state>` for the resources and
+// `pcs node maintenance|unmaintenance <node>` for the nodes
+func (b *pcsBackend) setMaintenanceState(ctx context.Context, resourceID, nodeID string, maintenance bool) error {
+	state := strconv.FormatBool(maintenance)
+
+	var args []string
+	switch {
+	case resourceID != "":
+		args = []string{"resource", "meta", resourceID, "maintenance=" + state}
+	case nodeID != "" && maintenance:
+		args = []string{"node", "maintenance", nodeID}
+	case nodeID != "":
+		args = []string{"node", "unmaintenance", nodeID}
+	default:
+		args = []string{"property", "set", "maintenance-mode=" + state}
+	}
+
+	output, err := b.executor.Exec(ctx, pcsCommand, args...)
+	if err != nil {
+		return fmt.Errorf("error setting maintenance state: %w, output: %s", err, string(output))
+	}
+
+	return nil
+}
+
+// getNodeAttribute queries the node attribute using `crm_attribute --node <node> --name <name> --query`
+func (b *pcsBackend) getNodeAttribute(
+	ctx context.Context,
+	nodeID string,
+	attributeType NodeAttributeType,
+	name string,
+) (string, bool, error) {
+	args := append(
+		[]string{"--node", nodeID, "--name", name},
+		append(crmAttributeNodeArguments(attributeType), "--query", "--quiet")...,
+	)
+	output, err := b.executor.Exec(ctx, "crm_attribute", args...)
+	if err != nil {
+		return "", false, nodeAttributeQueryError(nodeID, name, output, err)
+	}
+
+	return strings.TrimSpace(string(output)), true, nil
+}
+
+// setNodeAttribute runs `pcs node attribute|utilization <node> <name>=<value>`.
+// pcs doesn't manage transient attributes, so `crm_attribute --lifetime reboot --update` is used for them.
+func (b *pcsBackend) setNodeAttribute(
+	ctx context.Context,
+	nodeID string,
+	attributeType NodeAttributeType,
+	name, value string,
+) error {
+	output, err := b.execNodeAttribute(ctx, nodeID, attributeType, name, value, "--update", value)
+	if err != nil {
+		return fmt.Errorf("error setting node attribute %s: %w, output: %s", name, err, string(output))
+	}
+
+	return nil
+}
+
+// deleteNodeAttribute runs `pcs node attribute|utilization <node> <name>=`, which removes the attribute.
+// Transient attributes are removed with `crm_attribute --lifetime reboot --delete`.
+func (b *pcsBackend) deleteNodeAttribute(
+	ctx context.Context,
+	nodeID string,
+	attributeType NodeAttributeType,
+	name string,
+) error {
+	output, err := b.execNodeAttribute(ctx, nodeID, attributeType, name, "", "--delete")
+	if err != nil {
+		return fmt.Errorf("error deleting node attribute %s: %w, output: %s", name, err, string(output))
+	}
+
+	return nil
+}
+
+func (b *pcsBackend) execNodeAttribute(
+	ctx context.Context,
+	nodeID string,
+	attributeType NodeAttributeType,
+	name, value string,
+	crmAttributeOperation ...string,
+) ([]byte, error) {
+	switch attributeType {
+	case TransientNodeAttribute:
+		args := append(
+			[]string{"--node", nodeID, "--name", name},
+			append(crmAttributeNodeArguments(attributeType), crmAttributeOperation...)...,
+		)
+		return b.executor.Exec(ctx, "crm_attribute", args...)
+	case UtilizationNodeAttribute:
+		return b.executor.Exec(ctx, pcsCommand, "node", "utilization", nodeID, name+"="+value)
+	default:
+		return b.executor.Exec(ctx, pcsCommand, "node", "attribute", nodeID, name+"="+value)
+	}
+}
+
+// queryAttribute runs a pacemaker attribute query command. The found return value is false
+// if the attribute is not set.
+func (b *pcsBackend) queryAttribute(ctx context.Context, command string, args ...string) (string, bool, error) {
+	output, err := b.executor.Exec(ctx, command, args...)
+	if err != nil {
+		if strings.Contains(string(output), propertyNotFoundMessage) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("%w, output: %s", err, string(output))
+	}
+
+	return strings.TrimSpace(string(output)), true, nil
+}
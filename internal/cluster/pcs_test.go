@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support/mocks"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+type PcsTestSuite struct {
+	suite.Suite
+	mockExecutor *mocks.MockCmdExecutor
+	pcsClient    cluster.Cluster
+}
+
+func TestPcs(t *testing.T) {
+	suite.Run(t, new(PcsTestSuite))
+}
+
+func (suite *PcsTestSuite) SetupTest() {
+	suite.mockExecutor = mocks.NewMockCmdExecutor(suite.T())
+	suite.pcsClient = cluster.NewClusterClientWithToolchain(cluster.PcsToolchain, suite.mockExecutor, slog.Default())
+}
+
+func (suite *PcsTestSuite) TestDetectToolchain() {
+	lookPath := func(installed ...string) func(string) (string, error) {
+		return func(file string) (string, error) {
+			for _, command := range installed {
+				if command == file {
+					return "/usr/sbin/" + file, nil
+				}
+			}
+			return "", exec.ErrNotFound
+		}
+	}
+
+	suite.Equal(cluster.CrmshToolchain, cluster.DetectToolchain(lookPath("crm", "pcs")))
+	suite.Equal(cluster.CrmshToolchain, cluster.DetectToolchain(lookPath("crm")))
+	suite.Equal(cluster.PcsToolchain, cluster.DetectToolchain(lookPath("pcs")))
+	suite.Equal(cluster.CrmshToolchain, cluster.DetectToolchain(lookPath()))
+}
+
+func (suite *PcsTestSuite) TestIsHostOnline() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "status").Return([]byte("Cluster name: hana_cluster"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "status").
+		Return([]byte("Error: error running crm_mon, is pacemaker running?"), errors.New("exit status 1")).Once()
+
+	suite.True(suite.pcsClient.IsHostOnline(ctx))
+	suite.False(suite.pcsClient.IsHostOnline(ctx))
+}
+
+func (suite *PcsTestSuite) TestStartStopCluster() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "cluster", "start").Return([]byte(""), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "cluster", "stop").
+		Return([]byte("Error: unable to stop all nodes"), errors.New("exit status 1")).Once()
+
+	suite.NoError(suite.pcsClient.StartCluster(ctx))
+	suite.EqualError(suite.pcsClient.StopCluster(ctx),
+		"failed to stop pcs cluster: exit status 1, output: Error: unable to stop all nodes")
+}
+
+func (suite *PcsTestSuite) TestStartStopClusterNodes() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "cluster", "start", "--all").Return([]byte(""), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "cluster", "stop", "vmhana01", "vmhana02").
+		Return([]byte(""), nil).Once()
+
+	suite.NoError(suite.pcsClient.StartClusterNodes(ctx))
+	suite.NoError(suite.pcsClient.StopClusterNodes(ctx, "vmhana01", "vmhana02"))
+}
+
+func (suite *PcsTestSuite) TestGetClusterStackStates() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "status", "nodes").
+		Return(helpers.ReadFixture("cluster/pcs_status_nodes.output"), nil).Once()
+
+	states, err := suite.pcsClient.GetClusterStackStates(ctx)
+	suite.NoError(err)
+	suite.Equal(map[string]bool{"vmhana01": true, "vmhana02": true, "vmhana03": false}, states)
+}
+
+func (suite *PcsTestSuite) TestGetClusterStackStatesLocalStackStopped() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "status", "nodes").
+		Return([]byte("Error: error running crm_mon, is pacemaker running?"), errors.New("exit status 1")).Once()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "cluster", "corosync").
+		Return(helpers.ReadFixture("cluster/pcs_cluster_corosync.output"), nil).Once()
+
+	states, err := suite.pcsClient.GetClusterStackStates(ctx)
+	suite.NoError(err)
+	suite.Equal(map[string]bool{"vmhana01": false, "vmhana02": false}, states)
+}
+
+func (suite *PcsTestSuite) TestGetClusterStackStatesError() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "status", "nodes").
+		Return([]byte("Error: error running crm_mon, is pacemaker running?"), errors.New("exit status 1")).Once()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "cluster", "corosync").
+		Return([]byte("Error: Unable to read /etc/corosync/corosync.conf"), errors.New("exit status 1")).Once()
+
+	states, err := suite.pcsClient.GetClusterStackStates(ctx)
+	suite.Nil(states)
+	suite.EqualError(err, "error getting corosync configuration: exit status 1, "+
+		"output: Error: Unable to read /etc/corosync/corosync.conf")
+}
+
+func (suite *PcsTestSuite) TestIsIdle() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crmadmin", "--dc_lookup", "--quiet").Return([]byte("vmhana01\n"), nil)
+	suite.mockExecutor.On("Exec", ctx, "crmadmin", "--status", "vmhana01", "--quiet").
+		Return([]byte("S_IDLE\n"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crmadmin", "--status", "vmhana01", "--quiet").
+		Return([]byte("S_TRANSITION_ENGINE\n"), nil).Once()
+
+	isIdle, err := suite.pcsClient.IsIdle(ctx)
+	suite.NoError(err)
+	suite.True(isIdle)
+
+	isIdle, err = suite.pcsClient.IsIdle(ctx)
+	suite.NoError(err)
+	suite.False(isIdle)
+}
+
+func (suite *PcsTestSuite) TestIsIdleError() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crmadmin", "--dc_lookup", "--quiet").
+		Return([]byte("error: Could not connect to controller"), errors.New("exit status 102")).Once()
+
+	isIdle, err := suite.pcsClient.IsIdle(ctx)
+	suite.False(isIdle)
+	suite.EqualError(err, "error looking for the DC node: exit status 102, output: error: Could not connect to controller")
+}
+
+func (suite *PcsTestSuite) TestResourceRefresh() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "resource", "refresh", "rsc_ip_PRD_HDB00", "node=vmhana01").
+		Return(helpers.ReadFixture("cluster/pcs_resource_refresh.output"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "resource", "refresh").
+		Return([]byte("Waiting for 1 reply from the controller"), nil).Once()
+
+	suite.NoError(suite.pcsClient.ResourceRefresh(ctx, "rsc_ip_PRD_HDB00", "vmhana01"))
+	suite.EqualError(suite.pcsClient.ResourceRefresh(ctx, "", ""),
+		"failed to refresh resource, unexpected output: Waiting for 1 reply from the controller")
+}
+
+func (suite *PcsTestSuite) TestAddLocationConstraint() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "constraint", "location", "add",
+		"cli-ban-rsc_ip_PRD_HDB00-on-vmhana01", "rsc_ip_PRD_HDB00", "vmhana01", "-INFINITY").
+		Return([]byte(""), nil).Once()
+
+	suite.NoError(suite.pcsClient.AddLocationConstraint(ctx, cluster.NewBanConstraint("rsc_ip_PRD_HDB00", "vmhana01")))
+
+	err := suite.pcsClient.AddLocationConstraint(ctx, cluster.LocationConstraint{
+		ID:       "loc_SAPHana_PRD_HDB00_vmhana01",
+		Resource: "msl_SAPHana_PRD_HDB00",
+		Node:     "vmhana01",
+		Score:    "100",
+		Role:     "Promoted",
+	})
+	suite.EqualError(err, "constraint loc_SAPHana_PRD_HDB00_vmhana01: roles are not supported with pcs")
+}
+
+func (suite *PcsTestSuite) TestDeleteConstraint() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "pcs", "constraint", "delete", "cli-prefer-rsc_ip_PRD_HDB00").
+		Return([]byte(""), nil).Once()
+
+	suite.NoError(suite.pcsClient.DeleteConstraint(ctx, "cli-prefer-rsc_ip_PRD_HDB00"))
+}
@@ -0,0 +1,367 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type PreflightCheckName string
+
+const (
+	QuorumCheck              PreflightCheckName = "quorum"
+	DCElectedCheck           PreflightCheckName = "dc_elected"
+	FailedActionsCheck       PreflightCheckName = "failed_actions"
+	PendingFencingCheck      PreflightCheckName = "pending_fencing"
+	StonithEnabledCheck      PreflightCheckName = "stonith_enabled"
+	LastRunningInstanceCheck PreflightCheckName = "last_running_instance"
+	QuorumLossCheck          PreflightCheckName = "quorum_loss"
+	HanaSRSyncCheck          PreflightCheckName = "hana_sr_sync"
+)
+
+// hanaSRAgents are the resource agents managing SAP HANA system replication
+var hanaSRAgents = []string{"ocf:suse:SAPHana", "ocf:suse:SAPHanaController"}
+
+// PreflightCheck is a safety check run against the cluster status before a disruptive operation.
+// The check returns an empty message if it passes, and the failure reason otherwise.
+type PreflightCheck struct {
+	Name  PreflightCheckName
+	check func(input *PreflightInput) (passed bool, message string)
+}
+
+// PreflightInput is the cluster information available to the pre-flight checks
+type PreflightInput struct {
+	Status *Status
+	// NodeID is the node affected by the operation. It is used by the checks
+	// that evaluate the impact of stopping the cluster services in a node.
+	NodeID string
+	// Quorum is the corosync quorum status. It is only set if the quorum_loss check is run.
+	Quorum *QuorumStatus
+	// HanaSR is the SAP HANA system replication state. It is only set if the hana_sr_sync check
+	// is run and the cluster manages SAP HANA system replication resources.
+	HanaSR *HanaSRAttributes
+}
+
+type PreflightOptions struct {
+	// Checks is the list of checks to run. All the available checks are run if it is empty,
+	// except hana_sr_sync, which has to be requested explicitly.
+	Checks []PreflightCheckName
+	// Overrides is the list of checks whose failure doesn't block the operation
+	Overrides []PreflightCheckName
+	// NodeID is the node affected by the operation. The local node is used if it is empty.
+	NodeID string
+}
+
+type PreflightCheckResult struct {
+	Name       PreflightCheckName `json:"name"`
+	Passed     bool               `json:"passed"`
+	Overridden bool               `json:"overridden,omitempty"`
+	Message    string             `json:"message,omitempty"`
+}
+
+type PreflightReport struct {
+	Results []PreflightCheckResult
+}
+
+// Passed returns true if all the checks passed or the failing ones are overridden
+func (r *PreflightReport) Passed() bool {
+	return len(r.FailedChecks()) == 0
+}
+
+// FailedChecks returns the failed checks that are not overridden
+func (r *PreflightReport) FailedChecks() []PreflightCheckResult {
+	failed := []PreflightCheckResult{}
+	for _, result := range r.Results {
+		if !result.Passed && !result.Overridden {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Error returns an error describing the failed checks, or nil if the report passed
+func (r *PreflightReport) Error() error {
+	failed := r.FailedChecks()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	reasons := make([]string, 0, len(failed))
+	for _, result := range failed {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", result.Name, result.Message))
+	}
+
+	return fmt.Errorf("cluster pre-flight checks failed: %s", strings.Join(reasons, "; "))
+}
+
+// PreflightChecks returns all the available pre-flight checks
+func PreflightChecks() []PreflightCheck {
+	return []PreflightCheck{
+		{Name: QuorumCheck, check: checkQuorum},
+		{Name: DCElectedCheck, check: checkDCElected},
+		{Name: FailedActionsCheck, check: checkFailedActions},
+		{Name: PendingFencingCheck, check: checkPendingFencing},
+		{Name: StonithEnabledCheck, check: checkStonithEnabled},
+		{Name: LastRunningInstanceCheck, check: checkLastRunningInstance},
+		{Name: QuorumLossCheck, check: checkQuorumLoss},
+		{Name: HanaSRSyncCheck, check: checkHanaSRSync},
+	}
+}
+
+// IsValidPreflightCheck returns true if the given name belongs to an available pre-flight check
+func IsValidPreflightCheck(name string) bool {
+	return slices.ContainsFunc(PreflightChecks(), func(check PreflightCheck) bool {
+		return string(check.Name) == name
+	})
+}
+
+// EvaluatePreflightChecks runs the given checks against the input and returns the report.
+// Failing checks included in the overrides list are flagged as overridden.
+func EvaluatePreflightChecks(
+	input *PreflightInput,
+	checks []PreflightCheck,
+	overrides []PreflightCheckName,
+) *PreflightReport {
+	report := &PreflightReport{
+		Results: make([]PreflightCheckResult, 0, len(checks)),
+	}
+
+	for _, check := range checks {
+		passed, message := check.check(input)
+		report.Results = append(report.Results, PreflightCheckResult{
+			Name:       check.Name,
+			Passed:     passed,
+			Overridden: !passed && slices.Contains(overrides, check.Name),
+			Message:    message,
+		})
+	}
+
+	return report
+}
+
+// RunPreflightChecks gets the current cluster status and runs the requested pre-flight checks
+func (c *Client) RunPreflightChecks(ctx context.Context, options PreflightOptions) (*PreflightReport, error) {
+	checks := []PreflightCheck{}
+	for _, check := range PreflightChecks() {
+		if slices.Contains(options.Checks, check.Name) ||
+			(len(options.Checks) == 0 && check.Name != HanaSRSyncCheck) {
+			checks = append(checks, check)
+		}
+	}
+
+	status, err := c.GetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &PreflightInput{Status: status, NodeID: options.NodeID}
+
+	needsNode := slices.ContainsFunc(checks, func(check PreflightCheck) bool {
+		return check.Name == LastRunningInstanceCheck || check.Name == QuorumLossCheck
+	})
+	if input.NodeID == "" && needsNode {
+		input.NodeID, err = c.LocalNodeName(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	needsQuorum := slices.ContainsFunc(checks, func(check PreflightCheck) bool {
+		return check.Name == QuorumLossCheck
+	})
+	if needsQuorum {
+		input.Quorum, err = c.GetQuorumStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	needsHanaSR := slices.ContainsFunc(checks, func(check PreflightCheck) bool {
+		return check.Name == HanaSRSyncCheck
+	})
+	if needsHanaSR && managesHanaSR(status) {
+		input.HanaSR, err = c.GetHanaSRAttributes(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report := EvaluatePreflightChecks(input, checks, options.Overrides)
+
+	for _, result := range report.Results {
+		c.logger.Info("Cluster pre-flight check",
+			"check", result.Name,
+			"passed", result.Passed,
+			"overridden", result.Overridden,
+			"message", result.Message,
+		)
+	}
+
+	return report, nil
+}
+
+func checkQuorum(input *PreflightInput) (bool, string) {
+	if !input.Status.DC.WithQuorum {
+		return false, "cluster partition doesn't have quorum"
+	}
+	return true, ""
+}
+
+func checkDCElected(input *PreflightInput) (bool, string) {
+	if !input.Status.DC.Present {
+		return false, "no designated coordinator is elected"
+	}
+	return true, ""
+}
+
+func checkFailedActions(input *PreflightInput) (bool, string) {
+	if len(input.Status.Failures) == 0 {
+		return true, ""
+	}
+
+	failures := make([]string, 0, len(input.Status.Failures))
+	for _, failure := range input.Status.Failures {
+		failures = append(failures, fmt.Sprintf("%s on %s (%s)",
+			failure.OperationKey, failure.Node, failure.ExitStatus))
+	}
+
+	return false, fmt.Sprintf("failed resource actions found: %s", strings.Join(failures, ", "))
+}
+
+func checkPendingFencing(input *PreflightInput) (bool, string) {
+	pending := input.Status.PendingFenceEvents()
+	if len(pending) == 0 {
+		return true, ""
+	}
+
+	events := make([]string, 0, len(pending))
+	for _, event := range pending {
+		events = append(events, fmt.Sprintf("%s of %s", event.Action, event.Target))
+	}
+
+	return false, fmt.Sprintf("pending fencing actions found: %s", strings.Join(events, ", "))
+}
+
+func checkStonithEnabled(input *PreflightInput) (bool, string) {
+	if !input.Status.StonithEnabled {
+		return false, "stonith is not enabled"
+	}
+	return true, ""
+}
+
+// checkLastRunningInstance verifies that stopping the cluster services in the given node
+// doesn't stop the last running instance of any managed resource.
+// Promotable resources are checked by their promoted instances, as stopping the node
+// running the last promoted instance leaves the resource without primary until a new
+// promotion happens, which in the case of SAP HANA means a takeover.
+// Primitive resources are only considered lost if there is no other node to run them.
+func checkLastRunningInstance(input *PreflightInput) (bool, string) {
+	status := input.Status
+	if status.MaintenanceMode {
+		return true, ""
+	}
+
+	otherNodesAvailable := slices.ContainsFunc(status.Nodes, func(node NodeStatus) bool {
+		return node.Name != input.NodeID && node.Online && !node.Standby && !node.Maintenance
+	})
+
+	// map[topLevelID]has an instance running outside of the node
+	runningElsewhere := make(map[string]bool)
+	// map[topLevelID]is cloned
+	cloned := make(map[string]bool)
+
+	for _, resource := range status.Resources {
+		if !resource.Active || !resource.Managed || resource.Maintenance {
+			continue
+		}
+
+		if resource.Promotable && !resource.IsPromoted() {
+			continue
+		}
+
+		id := resource.TopLevelID()
+		cloned[id] = resource.Cloned
+		elsewhere := slices.ContainsFunc(resource.Nodes, func(node string) bool {
+			return node != input.NodeID
+		})
+		runningElsewhere[id] = runningElsewhere[id] || elsewhere
+	}
+
+	affected := []string{}
+	for id, elsewhere := range runningElsewhere {
+		if elsewhere || (!cloned[id] && otherNodesAvailable) {
+			continue
+		}
+		affected = append(affected, id)
+	}
+
+	if len(affected) == 0 {
+		return true, ""
+	}
+
+	slices.Sort(affected)
+
+	return false, fmt.Sprintf("stopping node %s stops the last running instance of resources: %s",
+		input.NodeID, strings.Join(affected, ", "))
+}
+
+// checkQuorumLoss verifies that stopping the cluster services in the given node
+// doesn't make the cluster lose quorum, taking into account the votes of every
+// node and the quorum device
+func checkQuorumLoss(input *PreflightInput) (bool, string) {
+	quorum := input.Quorum
+	if quorum == nil || !quorum.Quorate {
+		// a partition without quorum is reported by the quorum check
+		return true, ""
+	}
+
+	if quorum.LosesQuorumWithout(input.NodeID) {
+		return false, fmt.Sprintf("stopping node %s makes the cluster lose quorum, %d votes required",
+			input.NodeID, quorum.Quorum)
+	}
+
+	return true, ""
+}
+
+// checkHanaSRSync verifies that the SAP HANA system replication has a primary site and
+// the secondary sites are in sync with it. Clusters without SAP HANA system replication pass the check.
+func checkHanaSRSync(input *PreflightInput) (bool, string) {
+	if input.HanaSR == nil {
+		return true, ""
+	}
+
+	primary, found := input.HanaSR.PrimarySite()
+	if !found {
+		return false, "SAP HANA system replication doesn't have a primary site"
+	}
+
+	if input.HanaSR.IsReplicationInSync() {
+		return true, ""
+	}
+
+	states := []string{}
+	for name, site := range input.HanaSR.Sites {
+		if name != primary.Name {
+			states = append(states, fmt.Sprintf("%s (%s)", name, site.SRHook))
+		}
+	}
+	slices.Sort(states)
+
+	if len(states) == 0 {
+		return false, fmt.Sprintf("SAP HANA system replication doesn't have secondary sites for primary %s",
+			primary.Name)
+	}
+
+	return false, fmt.Sprintf("SAP HANA system replication is not in sync with primary %s: %s",
+		primary.Name, strings.Join(states, ", "))
+}
+
+func managesHanaSR(status *Status) bool {
+	return slices.ContainsFunc(status.Resources, func(resource ResourceStatus) bool {
+		return slices.Contains(hanaSRAgents, resource.Agent)
+	})
+}
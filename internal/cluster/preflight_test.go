@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support/mocks"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+type PreflightTestSuite struct {
+	suite.Suite
+	mockExecutor *mocks.MockCmdExecutor
+}
+
+func TestPreflight(t *testing.T) {
+	suite.Run(t, new(PreflightTestSuite))
+}
+
+func (suite *PreflightTestSuite) SetupTest() {
+	suite.mockExecutor = mocks.NewMockCmdExecutor(suite.T())
+}
+
+func (suite *PreflightTestSuite) mockCrmMon(ctx context.Context, fixture string) {
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"crm_mon",
+		"--output-as=xml",
+		"--inactive",
+		"--failcounts",
+		"--fence-history=1",
+	).Return(helpers.ReadFixture(fixture), nil)
+}
+
+func (suite *PreflightTestSuite) mockQuorumtool(ctx context.Context, fixture string) {
+	suite.mockExecutor.On("Exec", ctx, "corosync-quorumtool", "-s").Return(helpers.ReadFixture(fixture), nil)
+}
+
+func (suite *PreflightTestSuite) TestPreflightChecksPassed() {
+	ctx := context.Background()
+	suite.mockCrmMon(ctx, "cluster/crm_mon_healthy.output")
+	suite.mockQuorumtool(ctx, "cluster/corosync_quorumtool_qdevice.output")
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	report, err := clusterClient.RunPreflightChecks(ctx, cluster.PreflightOptions{NodeID: "vmhana02"})
+	suite.NoError(err)
+	suite.True(report.Passed())
+	suite.NoError(report.Error())
+	suite.Equal([]cluster.PreflightCheckResult{
+		{Name: cluster.QuorumCheck, Passed: true},
+		{Name: cluster.DCElectedCheck, Passed: true},
+		{Name: cluster.FailedActionsCheck, Passed: true},
+		{Name: cluster.PendingFencingCheck, Passed: true},
+		{Name: cluster.StonithEnabledCheck, Passed: true},
+		{Name: cluster.LastRunningInstanceCheck, Passed: true},
+		{Name: cluster.QuorumLossCheck, Passed: true},
+	}, report.Results)
+}
+
+func (suite *PreflightTestSuite) TestPreflightChecksPromotedOnLocalNode() {
+	ctx := context.Background()
+	suite.mockCrmMon(ctx, "cluster/crm_mon_healthy.output")
+	suite.mockExecutor.On("Exec", ctx, "crm_node", "-n").Return([]byte("vmhana01"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	report, err := clusterClient.RunPreflightChecks(ctx, cluster.PreflightOptions{
+		Checks: []cluster.PreflightCheckName{cluster.LastRunningInstanceCheck},
+	})
+	suite.NoError(err)
+	suite.False(report.Passed())
+	suite.EqualError(
+		report.Error(),
+		"cluster pre-flight checks failed: last_running_instance: stopping node vmhana01 stops "+
+			"the last running instance of resources: msl_SAPHana_PRD_HDB00",
+	)
+}
+
+func (suite *PreflightTestSuite) TestPreflightChecksFailed() {
+	ctx := context.Background()
+	suite.mockCrmMon(ctx, "cluster/crm_mon_unhealthy.output")
+	suite.mockQuorumtool(ctx, "cluster/corosync_quorumtool_blocked.output")
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	report, err := clusterClient.RunPreflightChecks(ctx, cluster.PreflightOptions{
+		NodeID:    "vmhana01",
+		Overrides: []cluster.PreflightCheckName{cluster.StonithEnabledCheck, cluster.QuorumCheck},
+	})
+	suite.NoError(err)
+	suite.False(report.Passed())
+	suite.Equal([]cluster.PreflightCheckResult{
+		{
+			Name:       cluster.QuorumCheck,
+			Passed:     false,
+			Overridden: true,
+			Message:    "cluster partition doesn't have quorum",
+		},
+		{
+			Name:    cluster.DCElectedCheck,
+			Passed:  false,
+			Message: "no designated coordinator is elected",
+		},
+		{
+			Name:    cluster.FailedActionsCheck,
+			Passed:  false,
+			Message: "failed resource actions found: rsc_SAPHana_PRD_HDB00_monitor_60000 on vmhana01 (not running)",
+		},
+		{
+			Name:    cluster.PendingFencingCheck,
+			Passed:  false,
+			Message: "pending fencing actions found: reboot of vmhana02",
+		},
+		{
+			Name:       cluster.StonithEnabledCheck,
+			Passed:     false,
+			Overridden: true,
+			Message:    "stonith is not enabled",
+		},
+		{
+			Name:   cluster.LastRunningInstanceCheck,
+			Passed: false,
+			Message: "stopping node vmhana01 stops the last running instance of resources: " +
+				"cln_SAPHanaTopology_PRD_HDB00, msl_SAPHana_PRD_HDB00, rsc_ip_PRD_HDB00",
+		},
+		{
+			Name:   cluster.QuorumLossCheck,
+			Passed: true,
+		},
+	}, report.Results)
+	suite.Len(report.FailedChecks(), 4)
+}
+
+func (suite *PreflightTestSuite) TestPreflightChecksQuorumLoss() {
+	ctx := context.Background()
+	suite.mockCrmMon(ctx, "cluster/crm_mon_healthy.output")
+	suite.mockQuorumtool(ctx, "cluster/corosync_quorumtool_degraded.output")
+	suite.mockExecutor.On("Exec", ctx, "crm_node", "-n").Return([]byte("vmhana01"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	report, err := clusterClient.RunPreflightChecks(ctx, cluster.PreflightOptions{
+		Checks: []cluster.PreflightCheckName{cluster.QuorumLossCheck},
+	})
+	suite.NoError(err)
+	suite.False(report.Passed())
+	suite.EqualError(
+		report.Error(),
+		"cluster pre-flight checks failed: quorum_loss: stopping node vmhana01 makes "+
+			"the cluster lose quorum, 2 votes required",
+	)
+}
+
+func (suite *PreflightTestSuite) TestPreflightChecksQuorumLossOverridden() {
+	ctx := context.Background()
+	suite.mockCrmMon(ctx, "cluster/crm_mon_healthy.output")
+	suite.mockQuorumtool(ctx, "cluster/corosync_quorumtool_degraded.output")
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	report, err := clusterClient.RunPreflightChecks(ctx, cluster.PreflightOptions{
+		Checks:    []cluster.PreflightCheckName{cluster.QuorumLossCheck},
+		Overrides: []cluster.PreflightCheckName{cluster.QuorumLossCheck},
+		NodeID:    "vmhana02",
+	})
+	suite.NoError(err)
+	suite.True(report.Passed())
+	suite.Equal([]cluster.PreflightCheckResult{
+		{
+			Name:       cluster.QuorumLossCheck,
+			Passed:     false,
+			Overridden: true,
+			Message:    "stopping node vmhana02 makes the cluster lose quorum, 2 votes required",
+		},
+	}, report.Results)
+}
+
+func (suite *PreflightTestSuite) TestPreflightChecksHanaSRInSync() {
+	ctx := context.Background()
+	suite.mockCrmMon(ctx, "cluster/crm_mon_healthy.output")
+	suite.mockExecutor.On("Exec", ctx, "SAPHanaSR-showAttr", "--format=script").
+		Return(helpers.ReadFixture("cluster/saphanasr_showattr_classic.output"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	report, err := clusterClient.RunPreflightChecks(ctx, cluster.PreflightOptions{
+		Checks: []cluster.PreflightCheckName{cluster.HanaSRSyncCheck},
+	})
+	suite.NoError(err)
+	suite.True(report.Passed())
+	suite.Equal([]cluster.PreflightCheckResult{
+		{Name: cluster.HanaSRSyncCheck, Passed: true},
+	}, report.Results)
+}
+
+func (suite *PreflightTestSuite) TestPreflightChecksHanaSRNotInSync() {
+	ctx := context.Background()
+	suite.mockCrmMon(ctx, "cluster/crm_mon_healthy.output")
+	suite.mockExecutor.On("Exec", ctx, "SAPHanaSR-showAttr", "--format=script").
+		Return(helpers.ReadFixture("cluster/saphanasr_showattr_angi.output"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	report, err := clusterClient.RunPreflightChecks(ctx, cluster.PreflightOptions{
+		Checks: []cluster.PreflightCheckName{cluster.QuorumCheck, cluster.HanaSRSyncCheck},
+	})
+	suite.NoError(err)
+	suite.False(report.Passed())
+	suite.Equal([]cluster.PreflightCheckResult{
+		{Name: cluster.QuorumCheck, Passed: true},
+		{
+			Name:    cluster.HanaSRSyncCheck,
+			Passed:  false,
+			Message: "SAP HANA system replication is not in sync with primary S1: S2 (SFAIL)",
+		},
+	}, report.Results)
+}
+
+func (suite *PreflightTestSuite) TestPreflightChecksHanaSRError() {
+	ctx := context.Background()
+	suite.mockCrmMon(ctx, "cluster/crm_mon_healthy.output")
+	suite.mockExecutor.On("Exec", ctx, "SAPHanaSR-showAttr", "--format=script").
+		Return([]byte("command not found"), errors.New("exit status 127"))
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	_, err := clusterClient.RunPreflightChecks(ctx, cluster.PreflightOptions{
+		Checks: []cluster.PreflightCheckName{cluster.HanaSRSyncCheck},
+	})
+	suite.EqualError(err, "error running SAPHanaSR-showAttr: exit status 127, output: command not found")
+}
+
+func (suite *PreflightTestSuite) TestEvaluatePreflightChecksWithoutHanaSR() {
+	input := &cluster.PreflightInput{Status: &cluster.Status{}}
+
+	report := cluster.EvaluatePreflightChecks(input, cluster.PreflightChecks(), nil)
+	suite.Equal(cluster.PreflightCheckResult{Name: cluster.HanaSRSyncCheck, Passed: true}, report.Results[7])
+}
+
+func (suite *PreflightTestSuite) TestEvaluatePreflightChecksMaintenanceMode() {
+	input := &cluster.PreflightInput{
+		Status: &cluster.Status{
+			MaintenanceMode: true,
+			Resources: []cluster.ResourceStatus{
+				{ID: "rsc_ip", Active: true, Managed: true, Nodes: []string{"node1"}},
+			},
+		},
+		NodeID: "node1",
+	}
+
+	report := cluster.EvaluatePreflightChecks(input, cluster.PreflightChecks(), nil)
+	suite.Equal(cluster.PreflightCheckResult{Name: cluster.LastRunningInstanceCheck, Passed: true}, report.Results[5])
+}
+
+func (suite *PreflightTestSuite) TestIsValidPreflightCheck() {
+	suite.True(cluster.IsValidPreflightCheck("quorum"))
+	suite.True(cluster.IsValidPreflightCheck("last_running_instance"))
+	suite.True(cluster.IsValidPreflightCheck("quorum_loss"))
+	suite.True(cluster.IsValidPreflightCheck("hana_sr_sync"))
+	suite.False(cluster.IsValidPreflightCheck("unknown"))
+}
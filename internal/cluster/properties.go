@@ -0,0 +1,284 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PropertySet string
+
+const (
+	ClusterOptionsSet   PropertySet = "crm_config"
+	ResourceDefaultsSet PropertySet = "rsc_defaults"
+)
+
+type PropertyType int
+
+const (
+	BooleanProperty PropertyType = iota
+	IntegerProperty
+	// ScoreProperty is an integer that accepts INFINITY, +INFINITY and -INFINITY values
+	ScoreProperty
+	DurationProperty
+	EnumProperty
+)
+
+const propertyNotFoundMessage = "No such device or address"
+
+var (
+	integerPatternCompiled = regexp.MustCompile(`^[-+]?\d+$`)
+	scorePatternCompiled   = regexp.MustCompile(`^([-+]?\d+|[-+]?INFINITY)$`)
+	// pacemaker durations are integers with an optional time unit, seconds by default
+	durationPatternCompiled = regexp.MustCompile(`^(\d+)\s*(ms|msec|us|usec|s|sec|m|min|h|hr)?$`)
+	// ISO 8601 durations are accepted as well, e.g. PT5M
+	isoDurationPatternCompiled     = regexp.MustCompile(`^P(\d+[YMWD])*(T(\d+[HMS])+)?$`)
+	isoDurationPartPatternCompiled = regexp.MustCompile(`(\d+)([YMWDHS])`)
+	booleanValues                  = []string{"true", "false", "yes", "no", "on", "off", "y", "n", "1", "0"}
+	trueValues                     = []string{"true", "yes", "on", "y", "1"}
+	durationUnits                  = map[string]time.Duration{
+		"":     time.Second,
+		"ms":   time.Millisecond,
+		"msec": time.Millisecond,
+		"us":   time.Microsecond,
+		"usec": time.Microsecond,
+		"s":    time.Second,
+		"sec":  time.Second,
+		"m":    time.Minute,
+		"min":  time.Minute,
+		"h":    time.Hour,
+		"hr":   time.Hour,
+	}
+	isoDurationUnits = map[string]time.Duration{
+		"W": 7 * 24 * time.Hour,
+		"D": 24 * time.Hour,
+		"H": time.Hour,
+		"M": time.Minute,
+		"S": time.Second,
+	}
+)
+
+// PropertyDefinition describes a cluster property that can be changed and the accepted values
+type PropertyDefinition struct {
+	Set  PropertySet
+	Name string
+	Type PropertyType
+	// Values are the accepted values of enum properties
+	Values []string
+}
+
+// PropertyDefinitions returns the cluster options and resource defaults that can be changed.
+// maintenance-mode is not included, as it is handled by the maintenance specific commands.
+func PropertyDefinitions() []PropertyDefinition {
+	return []PropertyDefinition{
+		{Set: ClusterOptionsSet, Name: "stonith-enabled", Type: BooleanProperty},
+		{Set: ClusterOptionsSet, Name: "stonith-timeout", Type: DurationProperty},
+		{Set: ClusterOptionsSet, Name: "stonith-watchdog-timeout", Type: DurationProperty},
+		{Set: ClusterOptionsSet, Name: "stonith-action", Type: EnumProperty, Values: []string{"reboot", "off", "poweroff"}},
+		{Set: ClusterOptionsSet, Name: "priority-fencing-delay", Type: DurationProperty},
+		{Set: ClusterOptionsSet, Name: "concurrent-fencing", Type: BooleanProperty},
+		{Set: ClusterOptionsSet, Name: "fence-reaction", Type: EnumProperty, Values: []string{"stop", "panic"}},
+		{Set: ClusterOptionsSet, Name: "no-quorum-policy", Type: EnumProperty,
+			Values: []string{"stop", "freeze", "ignore", "demote", "suicide"}},
+		{Set: ClusterOptionsSet, Name: "symmetric-cluster", Type: BooleanProperty},
+		{Set: ClusterOptionsSet, Name: "start-failure-is-fatal", Type: BooleanProperty},
+		{Set: ClusterOptionsSet, Name: "cluster-recheck-interval", Type: DurationProperty},
+		{Set: ClusterOptionsSet, Name: "dc-deadtime", Type: DurationProperty},
+		{Set: ClusterOptionsSet, Name: "shutdown-escalation", Type: DurationProperty},
+		{Set: ClusterOptionsSet, Name: "batch-limit", Type: IntegerProperty},
+		{Set: ClusterOptionsSet, Name: "migration-limit", Type: IntegerProperty},
+		{Set: ClusterOptionsSet, Name: "node-action-limit", Type: IntegerProperty},
+		{Set: ClusterOptionsSet, Name: "placement-strategy", Type: EnumProperty,
+			Values: []string{"default", "utilization", "minimal", "balanced"}},
+		{Set: ResourceDefaultsSet, Name: "resource-stickiness", Type: ScoreProperty},
+		{Set: ResourceDefaultsSet, Name: "migration-threshold", Type: ScoreProperty},
+		{Set: ResourceDefaultsSet, Name: "failure-timeout", Type: DurationProperty},
+		{Set: ResourceDefaultsSet, Name: "priority", Type: IntegerProperty},
+		{Set: ResourceDefaultsSet, Name: "is-managed", Type: BooleanProperty},
+		{Set: ResourceDefaultsSet, Name: "multiple-active", Type: EnumProperty,
+			Values: []string{"block", "stop_only", "stop_start", "stop_unexpected"}},
+	}
+}
+
+// ValidateProperty checks that the property can be changed and that the value matches the property type
+func ValidateProperty(set PropertySet, name, value string) error {
+	definition, found := findPropertyDefinition(set, name)
+	if !found {
+		return fmt.Errorf("property %s is not supported in %s", name, set)
+	}
+
+	var valid bool
+	switch definition.Type {
+	case BooleanProperty:
+		valid = slices.Contains(booleanValues, strings.ToLower(value))
+	case IntegerProperty:
+		valid = integerPatternCompiled.MatchString(value)
+	case ScoreProperty:
+		valid = scorePatternCompiled.MatchString(value)
+	case DurationProperty:
+		valid = durationPatternCompiled.MatchString(value) ||
+			(value != "P" && isoDurationPatternCompiled.MatchString(value))
+	case EnumProperty:
+		valid = slices.Contains(definition.Values, value)
+	}
+
+	if !valid {
+		return fmt.Errorf("invalid value %s for property %s of type %s", value, name, definition.Type)
+	}
+
+	return nil
+}
+
+// EqualPropertyValues returns true if both values are the same for the property type,
+// e.g. the yes and true booleans, or the 60 and 1min durations.
+// Values that cannot be normalized are compared as they are.
+func EqualPropertyValues(set PropertySet, name, value, other string) bool {
+	definition, found := findPropertyDefinition(set, name)
+	if !found {
+		return value == other
+	}
+
+	return normalizePropertyValue(definition.Type, value) == normalizePropertyValue(definition.Type, other)
+}
+
+func findPropertyDefinition(set PropertySet, name string) (PropertyDefinition, bool) {
+	definitions := PropertyDefinitions()
+	index := slices.IndexFunc(definitions, func(definition PropertyDefinition) bool {
+		return definition.Set == set && definition.Name == name
+	})
+	if index == -1 {
+		return PropertyDefinition{}, false
+	}
+	return definitions[index], true
+}
+
+func normalizePropertyValue(propertyType PropertyType, value string) string {
+	value = strings.TrimSpace(value)
+
+	switch propertyType {
+	case BooleanProperty:
+		lowerValue := strings.ToLower(value)
+		if !slices.Contains(booleanValues, lowerValue) {
+			return value
+		}
+		return strconv.FormatBool(slices.Contains(trueValues, lowerValue))
+	case IntegerProperty, ScoreProperty:
+		if strings.HasSuffix(value, "INFINITY") {
+			return strings.TrimPrefix(value, "+")
+		}
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return value
+		}
+		return strconv.FormatInt(number, 10)
+	case DurationProperty:
+		duration, ok := parsePropertyDuration(value)
+		if !ok {
+			return value
+		}
+		return duration.String()
+	default:
+		return value
+	}
+}
+
+// parsePropertyDuration parses pacemaker and ISO 8601 durations. ISO 8601 durations with
+// years or months are not parsed, as their length depends on the calendar.
+func parsePropertyDuration(value string) (time.Duration, bool) {
+	if matches := durationPatternCompiled.FindStringSubmatch(value); matches != nil {
+		number, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return time.Duration(number) * durationUnits[matches[2]], true
+	}
+
+	if value == "P" || !isoDurationPatternCompiled.MatchString(value) {
+		return 0, false
+	}
+
+	datePart, timePart, _ := strings.Cut(strings.TrimPrefix(value, "P"), "T")
+	if strings.ContainsAny(datePart, "YM") {
+		return 0, false
+	}
+
+	var duration time.Duration
+	for _, part := range []string{datePart, timePart} {
+		for _, match := range isoDurationPartPatternCompiled.FindAllStringSubmatch(part, -1) {
+			number, err := strconv.ParseInt(match[1], 10, 64)
+			if err != nil {
+				return 0, false
+			}
+			duration += time.Duration(number) * isoDurationUnits[match[2]]
+		}
+	}
+
+	return duration, true
+}
+
+func (t PropertyType) String() string {
+	switch t {
+	case BooleanProperty:
+		return "boolean"
+	case IntegerProperty:
+		return "integer"
+	case ScoreProperty:
+		return "score"
+	case DurationProperty:
+		return "duration"
+	case EnumProperty:
+		return "enum"
+	default:
+		return "unknown"
+	}
+}
+
+// GetProperty returns the value of a cluster option or resource default using `crm_attribute --query`.
+// The found return value is false if the property is not set, so the default value applies.
+func (c *Client) GetProperty(ctx context.Context, set PropertySet, name string) (string, bool, error) {
+	output, err := c.executor.Exec(
+		ctx, "crm_attribute", "--type", string(set), "--name", name, "--query", "--quiet",
+	)
+	if err != nil {
+		if strings.Contains(string(output), propertyNotFoundMessage) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("error getting property %s: %w, output: %s", name, err, string(output))
+	}
+
+	return strings.TrimSpace(string(output)), true, nil
+}
+
+// SetProperty sets the value of a cluster option or resource default using `crm_attribute --update`
+func (c *Client) SetProperty(ctx context.Context, set PropertySet, name, value string) error {
+	output, err := c.executor.Exec(
+		ctx, "crm_attribute", "--type", string(set), "--name", name, "--update", value,
+	)
+	if err != nil {
+		return fmt.Errorf("error setting property %s: %w, output: %s", name, err, string(output))
+	}
+
+	c.logger.Info("Cluster property set", "set", set, "name", name, "value", value)
+	return nil
+}
+
+// DeleteProperty removes a cluster option or resource default using `crm_attribute --delete`,
+// so the default value applies
+func (c *Client) DeleteProperty(ctx context.Context, set PropertySet, name string) error {
+	output, err := c.executor.Exec(
+		ctx, "crm_attribute", "--type", string(set), "--name", name, "--delete",
+	)
+	if err != nil {
+		return fmt.Errorf("error deleting property %s: %w, output: %s", name, err, string(output))
+	}
+
+	c.logger.Info("Cluster property deleted", "set", set, "name", name)
+	return nil
+}
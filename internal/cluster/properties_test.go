@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support/mocks"
+)
+
+type PropertiesTestSuite struct {
+	suite.Suite
+	mockExecutor *mocks.MockCmdExecutor
+}
+
+func TestProperties(t *testing.T) {
+	suite.Run(t, new(PropertiesTestSuite))
+}
+
+func (suite *PropertiesTestSuite) SetupTest() {
+	suite.mockExecutor = mocks.NewMockCmdExecutor(suite.T())
+}
+
+func (suite *PropertiesTestSuite) TestValidateProperty() {
+	cases := []struct {
+		set   cluster.PropertySet
+		name  string
+		value string
+		err   string
+	}{
+		{set: cluster.ClusterOptionsSet, name: "stonith-enabled", value: "true"},
+		{set: cluster.ClusterOptionsSet, name: "concurrent-fencing", value: "No"},
+		{set: cluster.ClusterOptionsSet, name: "stonith-timeout", value: "150s"},
+		{set: cluster.ClusterOptionsSet, name: "stonith-timeout", value: "144"},
+		{set: cluster.ClusterOptionsSet, name: "priority-fencing-delay", value: "PT30S"},
+		{set: cluster.ClusterOptionsSet, name: "no-quorum-policy", value: "freeze"},
+		{set: cluster.ClusterOptionsSet, name: "batch-limit", value: "10"},
+		{set: cluster.ResourceDefaultsSet, name: "resource-stickiness", value: "1000"},
+		{set: cluster.ResourceDefaultsSet, name: "migration-threshold", value: "INFINITY"},
+		{
+			set: cluster.ClusterOptionsSet, name: "stonith-enabled", value: "enabled",
+			err: "invalid value enabled for property stonith-enabled of type boolean",
+		},
+		{
+			set: cluster.ClusterOptionsSet, name: "stonith-timeout", value: "2 weeks",
+			err: "invalid value 2 weeks for property stonith-timeout of type duration",
+		},
+		{
+			set: cluster.ClusterOptionsSet, name: "batch-limit", value: "INFINITY",
+			err: "invalid value INFINITY for property batch-limit of type integer",
+		},
+		{
+			set: cluster.ClusterOptionsSet, name: "no-quorum-policy", value: "panic",
+			err: "invalid value panic for property no-quorum-policy of type enum",
+		},
+		{
+			set: cluster.ClusterOptionsSet, name: "maintenance-mode", value: "true",
+			err: "property maintenance-mode is not supported in crm_config",
+		},
+		{
+			set: cluster.ResourceDefaultsSet, name: "stonith-timeout", value: "150s",
+			err: "property stonith-timeout is not supported in rsc_defaults",
+		},
+	}
+
+	for _, tc := range cases {
+		err := cluster.ValidateProperty(tc.set, tc.name, tc.value)
+		if tc.err == "" {
+			suite.NoError(err, tc.name)
+		} else {
+			suite.EqualError(err, tc.err)
+		}
+	}
+}
+
+func (suite *PropertiesTestSuite) TestEqualPropertyValues() {
+	cases := []struct {
+		set   cluster.PropertySet
+		name  string
+		value string
+		other string
+		equal bool
+	}{
+		{set: cluster.ClusterOptionsSet, name: "stonith-enabled", value: "yes", other: "true", equal: true},
+		{set: cluster.ClusterOptionsSet, name: "stonith-enabled", value: "on", other: "1", equal: true},
+		{set: cluster.ClusterOptionsSet, name: "stonith-enabled", value: "N", other: "false", equal: true},
+		{set: cluster.ClusterOptionsSet, name: "stonith-enabled", value: "no", other: "true", equal: false},
+		{set: cluster.ClusterOptionsSet, name: "stonith-timeout", value: "60", other: "60s", equal: true},
+		{set: cluster.ClusterOptionsSet, name: "stonith-timeout", value: "1min", other: "60", equal: true},
+		{set: cluster.ClusterOptionsSet, name: "stonith-timeout", value: "PT1M30S", other: "90s", equal: true},
+		{set: cluster.ClusterOptionsSet, name: "stonith-timeout", value: "P1DT1H", other: "25h", equal: true},
+		{set: cluster.ClusterOptionsSet, name: "stonith-timeout", value: "500ms", other: "1s", equal: false},
+		{set: cluster.ClusterOptionsSet, name: "stonith-timeout", value: "P1M", other: "P1M", equal: true},
+		{set: cluster.ClusterOptionsSet, name: "batch-limit", value: "+10", other: "10", equal: true},
+		{set: cluster.ResourceDefaultsSet, name: "migration-threshold", value: "+INFINITY", other: "INFINITY", equal: true},
+		{set: cluster.ResourceDefaultsSet, name: "migration-threshold", value: "-INFINITY", other: "INFINITY", equal: false},
+		{set: cluster.ClusterOptionsSet, name: "no-quorum-policy", value: "stop", other: "freeze", equal: false},
+		{set: cluster.ClusterOptionsSet, name: "unknown", value: "yes", other: "true", equal: false},
+	}
+
+	for _, tc := range cases {
+		suite.Equal(tc.equal, cluster.EqualPropertyValues(tc.set, tc.name, tc.value, tc.other),
+			"%s: %s and %s", tc.name, tc.value, tc.other)
+	}
+}
+
+func (suite *PropertiesTestSuite) TestGetProperty() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "crm_attribute", "--type", "crm_config", "--name", "stonith-timeout",
+		"--query", "--quiet").Return([]byte("144\n"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	value, found, err := clusterClient.GetProperty(ctx, cluster.ClusterOptionsSet, "stonith-timeout")
+	suite.NoError(err)
+	suite.True(found)
+	suite.Equal("144", value)
+}
+
+func (suite *PropertiesTestSuite) TestGetPropertyNotSet() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "crm_attribute", "--type", "rsc_defaults", "--name", "resource-stickiness",
+		"--query", "--quiet").
+		Return([]byte("crm_attribute: Error performing operation: No such device or address"),
+			errors.New("exit status 105"))
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	value, found, err := clusterClient.GetProperty(ctx, cluster.ResourceDefaultsSet, "resource-stickiness")
+	suite.NoError(err)
+	suite.False(found)
+	suite.Empty(value)
+}
+
+func (suite *PropertiesTestSuite) TestGetPropertyError() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "crm_attribute", "--type", "crm_config", "--name", "stonith-timeout",
+		"--query", "--quiet").
+		Return([]byte("Could not connect to the CIB"), errors.New("exit status 102"))
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	_, _, err := clusterClient.GetProperty(ctx, cluster.ClusterOptionsSet, "stonith-timeout")
+	suite.EqualError(err, "error getting property stonith-timeout: exit status 102, output: Could not connect to the CIB")
+}
+
+func (suite *PropertiesTestSuite) TestSetProperty() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "crm_attribute", "--type", "crm_config", "--name", "stonith-timeout",
+		"--update", "150s").Return([]byte(""), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	suite.NoError(clusterClient.SetProperty(ctx, cluster.ClusterOptionsSet, "stonith-timeout", "150s"))
+}
+
+func (suite *PropertiesTestSuite) TestDeletePropertyError() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "crm_attribute", "--type", "rsc_defaults", "--name", "resource-stickiness",
+		"--delete").Return([]byte("error"), errors.New("exit status 1"))
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	err := clusterClient.DeleteProperty(ctx, cluster.ResourceDefaultsSet, "resource-stickiness")
+	suite.EqualError(err, "error deleting property resource-stickiness: exit status 1, output: error")
+}
@@ -0,0 +1,283 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	hanaSRPrimaryRole   = "P"
+	hanaSRPrimaryHook   = "PRIM"
+	hanaSRSyncOkHook    = "SOK"
+	hanaSRPromotedState = "PROMOTED"
+)
+
+// HanaSRAttributes is the typed representation of the SAP HANA system replication
+// attributes stored in the cluster by the SAPHanaSR and SAPHanaSR-angi resource agents.
+// Attributes not mapped to a typed field are still available in the Attributes maps.
+type HanaSRAttributes struct {
+	SID       string
+	Topology  string
+	Global    map[string]string
+	Resources map[string]map[string]string
+	Sites     map[string]HanaSRSite
+	Hosts     map[string]HanaSRHost
+}
+
+type HanaSRSite struct {
+	Name string
+	// LastPrimaryTimestamp is the lpt attribute. It has a unix timestamp value
+	// for primary sites and a low static value (10, 20, 30) for secondary sites.
+	LastPrimaryTimestamp int64
+	LandscapeStatus      string
+	MasterNameServer     string
+	SRHook               string
+	SRPoll               string
+	SRRole               string
+	SRMode               string
+	OperationMode        string
+	Attributes           map[string]string
+}
+
+type HanaSRHost struct {
+	Name                 string
+	Site                 string
+	Roles                string
+	SyncState            string
+	CloneState           string
+	Score                string
+	NodeState            string
+	SRMode               string
+	OperationMode        string
+	RemoteHost           string
+	Version              string
+	VirtualHost          string
+	LastPrimaryTimestamp int64
+	Attributes           map[string]string
+}
+
+// PrimarySite returns the site currently acting as system replication primary.
+// The site role attribute is used when available, otherwise the promoted host site is used.
+func (a *HanaSRAttributes) PrimarySite() (HanaSRSite, bool) {
+	for _, site := range a.Sites {
+		if site.SRRole == hanaSRPrimaryRole {
+			return site, true
+		}
+	}
+
+	for _, host := range a.Hosts {
+		if !host.IsPrimary() {
+			continue
+		}
+		site, found := a.Sites[host.Site]
+		if !found {
+			return HanaSRSite{Name: host.Site}, true
+		}
+		return site, true
+	}
+
+	return HanaSRSite{}, false
+}
+
+// PrimaryHosts returns the names of the hosts belonging to the primary site, sorted by name
+func (a *HanaSRAttributes) PrimaryHosts() []string {
+	primary, found := a.PrimarySite()
+	if !found {
+		return []string{}
+	}
+
+	hosts := []string{}
+	for name, host := range a.Hosts {
+		if host.Site == primary.Name {
+			hosts = append(hosts, name)
+		}
+	}
+	slices.Sort(hosts)
+
+	return hosts
+}
+
+// IsReplicationInSync returns true if there is a primary site and all the other sites
+// report a SOK system replication state through the srHook attribute.
+func (a *HanaSRAttributes) IsReplicationInSync() bool {
+	primary, found := a.PrimarySite()
+	if !found || len(a.Sites) < 2 {
+		return false
+	}
+
+	for name, site := range a.Sites {
+		if name == primary.Name {
+			continue
+		}
+		if site.SRHook != hanaSRSyncOkHook {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsPrimary returns true if the host is running the primary HANA instance.
+// SAPHanaSR-angi doesn't include the P/S flag in the roles attribute, so the clone state is used as well.
+func (h *HanaSRHost) IsPrimary() bool {
+	if h.CloneState == hanaSRPromotedState {
+		return true
+	}
+
+	roles := strings.Split(h.Roles, ":")
+	if len(roles) > 1 && roles[1] == hanaSRPrimaryRole {
+		return true
+	}
+
+	return h.SyncState == hanaSRPrimaryHook
+}
+
+// GetHanaSRAttributes collects the SAP HANA system replication attributes using
+// `SAPHanaSR-showAttr --format=script`.
+// The command output format is shared by SAPHanaSR and SAPHanaSR-angi, being the main
+// difference the section names (Sites/Hosts in SAPHanaSR and Site/Host in SAPHanaSR-angi).
+func (c *Client) GetHanaSRAttributes(ctx context.Context) (*HanaSRAttributes, error) {
+	output, err := c.executor.Exec(ctx, "SAPHanaSR-showAttr", "--format=script")
+	if err != nil {
+		return nil, fmt.Errorf("error running SAPHanaSR-showAttr: %w, output: %s", err, string(output))
+	}
+
+	attributes, err := parseHanaSRAttributes(output)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing SAPHanaSR-showAttr output: %w", err)
+	}
+
+	return attributes, nil
+}
+
+// parseHanaSRAttributes parses the script formatted output, where each line has the
+// <section>/<name>/<attribute>="<value>" form.
+// Example:
+// Sites/NUREMBERG/srHook="PRIM"
+// Hosts/vmhana01/roles="4:P:master1:master:worker:master"
+func parseHanaSRAttributes(output []byte) (*HanaSRAttributes, error) {
+	rawSites := make(map[string]map[string]string)
+	rawHosts := make(map[string]map[string]string)
+	attributes := &HanaSRAttributes{
+		Global:    make(map[string]string),
+		Resources: make(map[string]map[string]string),
+		Sites:     make(map[string]HanaSRSite),
+		Hosts:     make(map[string]HanaSRHost),
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid line format: %s", line)
+		}
+		value = strings.Trim(value, `"`)
+
+		keyParts := strings.SplitN(key, "/", 3)
+		if len(keyParts) != 3 {
+			return nil, fmt.Errorf("invalid attribute key format: %s", key)
+		}
+		section, name, attribute := keyParts[0], keyParts[1], keyParts[2]
+
+		switch section {
+		case "Global":
+			attributes.Global[attribute] = value
+		case "Resource":
+			addRawAttribute(attributes.Resources, name, attribute, value)
+		case "Sites", "Site":
+			addRawAttribute(rawSites, name, attribute, value)
+		case "Hosts", "Host":
+			addRawAttribute(rawHosts, name, attribute, value)
+		default:
+			// unknown sections are ignored to be forward compatible with newer agent versions
+			continue
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	attributes.SID = attributes.Global["sid"]
+	attributes.Topology = attributes.Global["topology"]
+
+	for name, raw := range rawSites {
+		attributes.Sites[name] = newHanaSRSite(name, raw)
+	}
+
+	for name, raw := range rawHosts {
+		attributes.Hosts[name] = newHanaSRHost(name, raw)
+	}
+
+	return attributes, nil
+}
+
+func addRawAttribute(target map[string]map[string]string, name, attribute, value string) {
+	if _, found := target[name]; !found {
+		target[name] = make(map[string]string)
+	}
+	target[name][attribute] = value
+}
+
+func newHanaSRSite(name string, raw map[string]string) HanaSRSite {
+	return HanaSRSite{
+		Name:                 name,
+		LastPrimaryTimestamp: parseTimestamp(raw["lpt"]),
+		LandscapeStatus:      raw["lss"],
+		MasterNameServer:     raw["mns"],
+		SRHook:               raw["srHook"],
+		SRPoll:               raw["srPoll"],
+		SRRole:               raw["srr"],
+		SRMode:               raw["srMode"],
+		OperationMode:        raw["opMode"],
+		Attributes:           raw,
+	}
+}
+
+func newHanaSRHost(name string, raw map[string]string) HanaSRHost {
+	host := HanaSRHost{
+		Name:          name,
+		Site:          raw["site"],
+		Roles:         raw["roles"],
+		SyncState:     raw["sync_state"],
+		CloneState:    raw["clone_state"],
+		Score:         raw["score"],
+		NodeState:     raw["node_state"],
+		SRMode:        raw["srmode"],
+		OperationMode: raw["op_mode"],
+		RemoteHost:    raw["remoteHost"],
+		Version:       raw["version"],
+		VirtualHost:   raw["vhost"],
+		Attributes:    raw,
+	}
+
+	// SAPHanaSR stores the last primary timestamp per host in the lpa_<sid>_lpt attribute
+	for attribute, value := range raw {
+		if strings.HasPrefix(attribute, "lpa_") && strings.HasSuffix(attribute, "_lpt") {
+			host.LastPrimaryTimestamp = parseTimestamp(value)
+		}
+	}
+
+	return host
+}
+
+func parseTimestamp(value string) int64 {
+	timestamp, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return timestamp
+}
@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support/mocks"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+type HanaSRTestSuite struct {
+	suite.Suite
+	mockExecutor *mocks.MockCmdExecutor
+}
+
+func TestHanaSR(t *testing.T) {
+	suite.Run(t, new(HanaSRTestSuite))
+}
+
+func (suite *HanaSRTestSuite) SetupTest() {
+	suite.mockExecutor = mocks.NewMockCmdExecutor(suite.T())
+}
+
+func (suite *HanaSRTestSuite) TestGetHanaSRAttributesClassic() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "SAPHanaSR-showAttr", "--format=script").
+		Return(helpers.ReadFixture("cluster/saphanasr_showattr_classic.output"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	attributes, err := clusterClient.GetHanaSRAttributes(ctx)
+	suite.NoError(err)
+
+	suite.Equal("PRD", attributes.SID)
+	suite.Equal("ScaleUp", attributes.Topology)
+	suite.Equal("false", attributes.Global["maintenance"])
+	suite.Equal("false", attributes.Resources["msl_SAPHana_PRD_HDB00"]["maintenance"])
+	suite.Len(attributes.Sites, 2)
+	suite.Len(attributes.Hosts, 2)
+
+	nuremberg := attributes.Sites["NUREMBERG"]
+	suite.Equal("PRIM", nuremberg.SRHook)
+	suite.Equal("P", nuremberg.SRRole)
+	suite.Equal("vmhana01", nuremberg.MasterNameServer)
+	suite.Equal(int64(1739452956), nuremberg.LastPrimaryTimestamp)
+
+	vmhana02 := attributes.Hosts["vmhana02"]
+	suite.Equal("PRAGUE", vmhana02.Site)
+	suite.Equal("SOK", vmhana02.SyncState)
+	suite.Equal("4:S:master1:master:worker:master", vmhana02.Roles)
+	suite.Equal("DEMOTED", vmhana02.CloneState)
+	suite.Equal("sync", vmhana02.SRMode)
+	suite.Equal(int64(30), vmhana02.LastPrimaryTimestamp)
+	suite.False(vmhana02.IsPrimary())
+
+	primary, found := attributes.PrimarySite()
+	suite.True(found)
+	suite.Equal("NUREMBERG", primary.Name)
+	suite.Equal([]string{"vmhana01"}, attributes.PrimaryHosts())
+	suite.True(attributes.IsReplicationInSync())
+}
+
+func (suite *HanaSRTestSuite) TestGetHanaSRAttributesAngi() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "SAPHanaSR-showAttr", "--format=script").
+		Return(helpers.ReadFixture("cluster/saphanasr_showattr_angi.output"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	attributes, err := clusterClient.GetHanaSRAttributes(ctx)
+	suite.NoError(err)
+
+	suite.Equal("HA1", attributes.SID)
+	suite.Equal("true", attributes.Resources["mst_SAPHanaCon_HA1_HDB10"]["is-managed"])
+	suite.Len(attributes.Sites, 2)
+	suite.Len(attributes.Hosts, 2)
+
+	s2 := attributes.Sites["S2"]
+	suite.Equal("SFAIL", s2.SRHook)
+	suite.Equal("SFAIL", s2.SRPoll)
+	suite.Equal("logreplay", s2.OperationMode)
+	suite.Equal("sync", s2.SRMode)
+
+	node1 := attributes.Hosts["node1"]
+	suite.Equal("S1", node1.Site)
+	suite.Equal("-", node1.Attributes["srah"])
+	suite.True(node1.IsPrimary())
+
+	primary, found := attributes.PrimarySite()
+	suite.True(found)
+	suite.Equal("S1", primary.Name)
+	suite.False(attributes.IsReplicationInSync())
+}
+
+func (suite *HanaSRTestSuite) TestGetHanaSRAttributesPrimaryFromHosts() {
+	ctx := context.Background()
+
+	output := `Hosts/vmhana01/roles="4:P:master1:master:worker:master"
+Hosts/vmhana01/site="SITE1"
+Hosts/vmhana02/roles="4:S:master1:master:worker:master"
+Hosts/vmhana02/site="SITE2"`
+
+	suite.mockExecutor.On("Exec", ctx, "SAPHanaSR-showAttr", "--format=script").
+		Return([]byte(output), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	attributes, err := clusterClient.GetHanaSRAttributes(ctx)
+	suite.NoError(err)
+
+	primary, found := attributes.PrimarySite()
+	suite.True(found)
+	suite.Equal("SITE1", primary.Name)
+	suite.False(attributes.IsReplicationInSync())
+}
+
+func (suite *HanaSRTestSuite) TestGetHanaSRAttributesNoPrimary() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "SAPHanaSR-showAttr", "--format=script").
+		Return([]byte(`Global/global/sid="PRD"`), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	attributes, err := clusterClient.GetHanaSRAttributes(ctx)
+	suite.NoError(err)
+
+	_, found := attributes.PrimarySite()
+	suite.False(found)
+	suite.Empty(attributes.PrimaryHosts())
+}
+
+func (suite *HanaSRTestSuite) TestPrimaryHostsSorted() {
+	attributes := cluster.HanaSRAttributes{
+		Sites: map[string]cluster.HanaSRSite{
+			"NUREMBERG": {Name: "NUREMBERG", SRRole: "P"},
+			"PRAGUE":    {Name: "PRAGUE", SRRole: "S"},
+		},
+		Hosts: map[string]cluster.HanaSRHost{
+			"vmhana05": {Name: "vmhana05", Site: "NUREMBERG"},
+			"vmhana02": {Name: "vmhana02", Site: "PRAGUE"},
+			"vmhana03": {Name: "vmhana03", Site: "NUREMBERG"},
+			"vmhana01": {Name: "vmhana01", Site: "NUREMBERG"},
+			"vmhana04": {Name: "vmhana04", Site: "PRAGUE"},
+		},
+	}
+
+	for range 10 {
+		suite.Equal([]string{"vmhana01", "vmhana03", "vmhana05"}, attributes.PrimaryHosts())
+	}
+}
+
+func (suite *HanaSRTestSuite) TestGetHanaSRAttributesCommandError() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "SAPHanaSR-showAttr", "--format=script").
+		Return([]byte("command not found"), errors.New("exit status 127"))
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	_, err := clusterClient.GetHanaSRAttributes(ctx)
+	suite.EqualError(err, "error running SAPHanaSR-showAttr: exit status 127, output: command not found")
+}
+
+func (suite *HanaSRTestSuite) TestGetHanaSRAttributesInvalidOutput() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "SAPHanaSR-showAttr", "--format=script").
+		Return([]byte(`Sites/NUREMBERG="PRIM"`), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	_, err := clusterClient.GetHanaSRAttributes(ctx)
+	suite.EqualError(err, "error parsing SAPHanaSR-showAttr output: invalid attribute key format: Sites/NUREMBERG")
+}
@@ -0,0 +1,367 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	promotedRole       = "Promoted"
+	legacyPromotedRole = "Master"
+	pendingFenceStatus = "pending"
+	// scoreInfinity is the value used by pacemaker for INFINITY scores
+	scoreInfinity = 1000000
+)
+
+// Status is the cluster status as reported by `crm_mon --output-as=xml`.
+// Resources are flattened, so every resource instance running in the cluster
+// has its own entry, referencing the clone or group containing it.
+type Status struct {
+	DC              DCStatus
+	StonithEnabled  bool
+	MaintenanceMode bool
+	Nodes           []NodeStatus
+	Resources       []ResourceStatus
+	Failures        []FailedAction
+	FailCounts      []FailCount
+	FenceEvents     []FenceEvent
+}
+
+type DCStatus struct {
+	Present    bool
+	Name       string
+	WithQuorum bool
+}
+
+type NodeStatus struct {
+	Name        string
+	ID          string
+	Online      bool
+	Standby     bool
+	Maintenance bool
+	Pending     bool
+	Unclean     bool
+	Shutdown    bool
+	IsDC        bool
+}
+
+type ResourceStatus struct {
+	ID          string
+	Agent       string
+	Role        string
+	Active      bool
+	Failed      bool
+	Managed     bool
+	Maintenance bool
+	Nodes       []string
+	// Parent is the ID of the top level clone or group containing the resource.
+	// It is empty for primitive resources.
+	Parent     string
+	Cloned     bool
+	Promotable bool
+	// Pending is the action being executed in the resource, like Starting or Promoting.
+	// It is only reported if the record-pending operation option is enabled.
+	Pending string
+}
+
+type FailedAction struct {
+	OperationKey string
+	Node         string
+	ExitStatus   string
+	ExitReason   string
+	Task         string
+	LastChange   string
+	Interval     string
+}
+
+// FailCount is the fail count of a resource in a node.
+// Only the resources with a fail count are included. INFINITY values are set as 1000000.
+type FailCount struct {
+	Resource           string
+	Node               string
+	FailCount          int
+	MigrationThreshold int
+	LastFailure        string
+}
+
+type FenceEvent struct {
+	Action string
+	Target string
+	Origin string
+	Status string
+}
+
+// TopLevelID returns the ID of the resource as it is managed by the cluster,
+// the clone or group ID for resources contained in them and the resource ID otherwise.
+func (r *ResourceStatus) TopLevelID() string {
+	if r.Parent != "" {
+		return r.Parent
+	}
+	return r.ID
+}
+
+// IsPromoted returns true if the resource instance is running with the promoted role.
+// Pacemaker versions prior to 2.1 use the Master role name.
+func (r *ResourceStatus) IsPromoted() bool {
+	return r.Role == promotedRole || r.Role == legacyPromotedRole
+}
+
+// ResourceID returns the ID of the resource instance whose operation failed,
+// removing the task and interval suffix from the operation key
+func (f *FailedAction) ResourceID() string {
+	return strings.TrimSuffix(f.OperationKey, fmt.Sprintf("_%s_%s", f.Task, f.Interval))
+}
+
+// Node returns the status of the given node name
+func (s *Status) Node(name string) (NodeStatus, bool) {
+	for _, node := range s.Nodes {
+		if node.Name == name {
+			return node, true
+		}
+	}
+	return NodeStatus{}, false
+}
+
+// PendingFenceEvents returns the fencing actions that are not completed yet
+func (s *Status) PendingFenceEvents() []FenceEvent {
+	pending := []FenceEvent{}
+	for _, event := range s.FenceEvents {
+		if event.Status == pendingFenceStatus {
+			pending = append(pending, event)
+		}
+	}
+	return pending
+}
+
+type crmMonXML struct {
+	Summary struct {
+		CurrentDC struct {
+			Present    bool   `xml:"present,attr"`
+			Name       string `xml:"name,attr"`
+			WithQuorum bool   `xml:"with_quorum,attr"`
+		} `xml:"current_dc"`
+		ClusterOptions struct {
+			StonithEnabled  bool `xml:"stonith-enabled,attr"`
+			MaintenanceMode bool `xml:"maintenance-mode,attr"`
+		} `xml:"cluster_options"`
+	} `xml:"summary"`
+	Nodes []struct {
+		Name        string `xml:"name,attr"`
+		ID          string `xml:"id,attr"`
+		Online      bool   `xml:"online,attr"`
+		Standby     bool   `xml:"standby,attr"`
+		Maintenance bool   `xml:"maintenance,attr"`
+		Pending     bool   `xml:"pending,attr"`
+		Unclean     bool   `xml:"unclean,attr"`
+		Shutdown    bool   `xml:"shutdown,attr"`
+		IsDC        bool   `xml:"is_dc,attr"`
+	} `xml:"nodes>node"`
+	Resources crmMonResourcesXML `xml:"resources"`
+	Failures  []struct {
+		OperationKey string `xml:"op_key,attr"`
+		Node         string `xml:"node,attr"`
+		ExitStatus   string `xml:"exitstatus,attr"`
+		ExitReason   string `xml:"exitreason,attr"`
+		Task         string `xml:"task,attr"`
+		LastChange   string `xml:"last-rc-change,attr"`
+		Interval     string `xml:"interval,attr"`
+	} `xml:"failures>failure"`
+	NodeHistory []struct {
+		Name      string `xml:"name,attr"`
+		Resources []struct {
+			ID                 string `xml:"id,attr"`
+			FailCount          string `xml:"fail-count,attr"`
+			MigrationThreshold string `xml:"migration-threshold,attr"`
+			LastFailure        string `xml:"last-failure,attr"`
+		} `xml:"resource_history"`
+	} `xml:"node_history>node"`
+	FenceEvents []struct {
+		Action string `xml:"action,attr"`
+		Target string `xml:"target,attr"`
+		Origin string `xml:"origin,attr"`
+		Status string `xml:"status,attr"`
+	} `xml:"fence_history>fence_event"`
+}
+
+type crmMonResourcesXML struct {
+	Resources []crmMonResourceXML `xml:"resource"`
+	Groups    []struct {
+		ID        string              `xml:"id,attr"`
+		Resources []crmMonResourceXML `xml:"resource"`
+	} `xml:"group"`
+	Clones []struct {
+		ID         string `xml:"id,attr"`
+		MultiState bool   `xml:"multi_state,attr"`
+		crmMonResourcesXML
+	} `xml:"clone"`
+}
+
+type crmMonResourceXML struct {
+	ID          string `xml:"id,attr"`
+	Agent       string `xml:"resource_agent,attr"`
+	Role        string `xml:"role,attr"`
+	Active      bool   `xml:"active,attr"`
+	Failed      bool   `xml:"failed,attr"`
+	Managed     bool   `xml:"managed,attr"`
+	Maintenance bool   `xml:"maintenance,attr"`
+	Pending     string `xml:"pending,attr"`
+	Nodes       []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"node"`
+}
+
+// GetStatus returns the current cluster status using `crm_mon`.
+// Inactive resources, fail counts and failed or pending fencing actions are included.
+func (c *Client) GetStatus(ctx context.Context) (*Status, error) {
+	output, err := c.executor.Exec(
+		ctx, "crm_mon", "--output-as=xml", "--inactive", "--failcounts", "--fence-history=1",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error running crm_mon: %w, output: %s", err, string(output))
+	}
+
+	status, err := parseCrmMonOutput(output)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing crm_mon output: %w", err)
+	}
+
+	return status, nil
+}
+
+// LocalNodeName returns the name of the local node in the cluster using `crm_node -n`
+func (c *Client) LocalNodeName(ctx context.Context) (string, error) {
+	output, err := c.executor.Exec(ctx, "crm_node", "-n")
+	if err != nil {
+		return "", fmt.Errorf("error getting local node name: %w, output: %s", err, string(output))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+func parseCrmMonOutput(output []byte) (*Status, error) {
+	var crmMon crmMonXML
+	if err := xml.Unmarshal(output, &crmMon); err != nil {
+		return nil, err
+	}
+
+	status := &Status{
+		DC: DCStatus{
+			Present:    crmMon.Summary.CurrentDC.Present,
+			Name:       crmMon.Summary.CurrentDC.Name,
+			WithQuorum: crmMon.Summary.CurrentDC.WithQuorum,
+		},
+		StonithEnabled:  crmMon.Summary.ClusterOptions.StonithEnabled,
+		MaintenanceMode: crmMon.Summary.ClusterOptions.MaintenanceMode,
+		Nodes:           make([]NodeStatus, 0, len(crmMon.Nodes)),
+		Resources:       flattenResources(crmMon.Resources, "", false, false),
+		Failures:        make([]FailedAction, 0, len(crmMon.Failures)),
+		FailCounts:      []FailCount{},
+		FenceEvents:     make([]FenceEvent, 0, len(crmMon.FenceEvents)),
+	}
+
+	for _, node := range crmMon.Nodes {
+		status.Nodes = append(status.Nodes, NodeStatus(node))
+	}
+
+	for _, failure := range crmMon.Failures {
+		status.Failures = append(status.Failures, FailedAction(failure))
+	}
+
+	for _, node := range crmMon.NodeHistory {
+		for _, resource := range node.Resources {
+			if resource.FailCount == "" {
+				continue
+			}
+
+			failCount, err := parseScore(resource.FailCount)
+			if err != nil {
+				return nil, fmt.Errorf("invalid fail-count value for resource %s: %w", resource.ID, err)
+			}
+
+			// the migration threshold is reported by default, and it is 0 if it is disabled
+			migrationThreshold, _ := parseScore(resource.MigrationThreshold)
+
+			status.FailCounts = append(status.FailCounts, FailCount{
+				Resource:           resource.ID,
+				Node:               node.Name,
+				FailCount:          failCount,
+				MigrationThreshold: migrationThreshold,
+				LastFailure:        resource.LastFailure,
+			})
+		}
+	}
+
+	for _, event := range crmMon.FenceEvents {
+		status.FenceEvents = append(status.FenceEvents, FenceEvent(event))
+	}
+
+	return status, nil
+}
+
+func flattenResources(
+	resources crmMonResourcesXML,
+	parent string,
+	cloned bool,
+	promotable bool,
+) []ResourceStatus {
+	flattened := []ResourceStatus{}
+
+	for _, resource := range resources.Resources {
+		flattened = append(flattened, newResourceStatus(resource, parent, cloned, promotable))
+	}
+
+	for _, group := range resources.Groups {
+		groupParent := parent
+		if groupParent == "" {
+			groupParent = group.ID
+		}
+		for _, resource := range group.Resources {
+			flattened = append(flattened, newResourceStatus(resource, groupParent, cloned, promotable))
+		}
+	}
+
+	for _, clone := range resources.Clones {
+		flattened = append(flattened, flattenResources(clone.crmMonResourcesXML, clone.ID, true, clone.MultiState)...)
+	}
+
+	return flattened
+}
+
+func newResourceStatus(resource crmMonResourceXML, parent string, cloned bool, promotable bool) ResourceStatus {
+	nodes := make([]string, 0, len(resource.Nodes))
+	for _, node := range resource.Nodes {
+		nodes = append(nodes, node.Name)
+	}
+
+	return ResourceStatus{
+		ID:          resource.ID,
+		Agent:       resource.Agent,
+		Role:        resource.Role,
+		Active:      resource.Active,
+		Failed:      resource.Failed,
+		Managed:     resource.Managed,
+		Maintenance: resource.Maintenance,
+		Nodes:       nodes,
+		Parent:      parent,
+		Cloned:      cloned,
+		Promotable:  promotable,
+		Pending:     resource.Pending,
+	}
+}
+
+func parseScore(value string) (int, error) {
+	switch strings.TrimPrefix(value, "+") {
+	case "INFINITY":
+		return scoreInfinity, nil
+	case "-INFINITY":
+		return -scoreInfinity, nil
+	default:
+		return strconv.Atoi(value)
+	}
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support/mocks"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+type StatusTestSuite struct {
+	suite.Suite
+	mockExecutor *mocks.MockCmdExecutor
+}
+
+func TestStatus(t *testing.T) {
+	suite.Run(t, new(StatusTestSuite))
+}
+
+func (suite *StatusTestSuite) SetupTest() {
+	suite.mockExecutor = mocks.NewMockCmdExecutor(suite.T())
+}
+
+func (suite *StatusTestSuite) mockCrmMon(ctx context.Context, output []byte, err error) {
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"crm_mon",
+		"--output-as=xml",
+		"--inactive",
+		"--failcounts",
+		"--fence-history=1",
+	).Return(output, err)
+}
+
+func (suite *StatusTestSuite) TestGetStatus() {
+	ctx := context.Background()
+	suite.mockCrmMon(ctx, helpers.ReadFixture("cluster/crm_mon_healthy.output"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	status, err := clusterClient.GetStatus(ctx)
+	suite.NoError(err)
+
+	suite.Equal(cluster.DCStatus{Present: true, Name: "vmhana01", WithQuorum: true}, status.DC)
+	suite.True(status.StonithEnabled)
+	suite.False(status.MaintenanceMode)
+	suite.Empty(status.Failures)
+	suite.Empty(status.FailCounts)
+	suite.Empty(status.PendingFenceEvents())
+
+	suite.Len(status.Nodes, 2)
+	node, found := status.Node("vmhana02")
+	suite.True(found)
+	suite.Equal(cluster.NodeStatus{Name: "vmhana02", ID: "2", Online: true}, node)
+
+	suite.Len(status.Resources, 7)
+	suite.Equal(cluster.ResourceStatus{
+		ID:         "rsc_SAPHana_PRD_HDB00",
+		Agent:      "ocf:suse:SAPHana",
+		Role:       "Promoted",
+		Active:     true,
+		Managed:    true,
+		Nodes:      []string{"vmhana01"},
+		Parent:     "msl_SAPHana_PRD_HDB00",
+		Cloned:     true,
+		Promotable: true,
+	}, status.Resources[5])
+	suite.True(status.Resources[5].IsPromoted())
+	suite.Equal("g_nfs", status.Resources[2].TopLevelID())
+	suite.Equal("stonith-sbd", status.Resources[0].TopLevelID())
+}
+
+func (suite *StatusTestSuite) TestGetStatusWithFailures() {
+	ctx := context.Background()
+	suite.mockCrmMon(ctx, helpers.ReadFixture("cluster/crm_mon_unhealthy.output"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	status, err := clusterClient.GetStatus(ctx)
+	suite.NoError(err)
+
+	suite.Equal(cluster.DCStatus{}, status.DC)
+	suite.False(status.StonithEnabled)
+	suite.Equal([]cluster.FailedAction{
+		{
+			OperationKey: "rsc_SAPHana_PRD_HDB00_monitor_60000",
+			Node:         "vmhana01",
+			ExitStatus:   "not running",
+			Task:         "monitor",
+			LastChange:   "2025-02-13 14:01:10 +01:00",
+			Interval:     "60000",
+		},
+	}, status.Failures)
+	suite.Equal("rsc_SAPHana_PRD_HDB00", status.Failures[0].ResourceID())
+	suite.Equal([]cluster.FailCount{
+		{
+			Resource:           "rsc_SAPHana_PRD_HDB00",
+			Node:               "vmhana01",
+			FailCount:          2,
+			MigrationThreshold: 5000,
+			LastFailure:        "Thu Feb 13 14:01:10 2025",
+		},
+	}, status.FailCounts)
+	suite.Equal([]cluster.FenceEvent{
+		{Action: "reboot", Target: "vmhana02", Origin: "vmhana01", Status: "pending"},
+	}, status.PendingFenceEvents())
+}
+
+func (suite *StatusTestSuite) TestGetStatusCommandError() {
+	ctx := context.Background()
+	suite.mockCrmMon(ctx, []byte("crm_mon: Error: cluster is not available on this node"), errors.New("exit status 102"))
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	_, err := clusterClient.GetStatus(ctx)
+	suite.EqualError(err, "error running crm_mon: exit status 102, output: crm_mon: Error: cluster is not available on this node")
+}
+
+func (suite *StatusTestSuite) TestGetStatusInvalidOutput() {
+	ctx := context.Background()
+	suite.mockCrmMon(ctx, []byte("not xml"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	_, err := clusterClient.GetStatus(ctx)
+	suite.ErrorContains(err, "error parsing crm_mon output")
+}
+
+func (suite *StatusTestSuite) TestLocalNodeName() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm_node", "-n").Return([]byte("vmhana01\n"), nil)
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	name, err := clusterClient.LocalNodeName(ctx)
+	suite.NoError(err)
+	suite.Equal("vmhana01", name)
+}
+
+func (suite *StatusTestSuite) TestLocalNodeNameError() {
+	ctx := context.Background()
+	suite.mockExecutor.On("Exec", ctx, "crm_node", "-n").Return([]byte("error"), errors.New("exit status 1"))
+
+	clusterClient := cluster.NewClusterClient(suite.mockExecutor, slog.Default())
+
+	_, err := clusterClient.LocalNodeName(ctx)
+	suite.EqualError(err, "error getting local node name: exit status 1, output: error")
+}
@@ -13,6 +13,7 @@ import (
 // by the package "github.com/coreos/go-systemd/v22/dbus"
 type Connector interface {
 	GetUnitPropertyContext(ctx context.Context, unit string, propertyName string) (*dbus.Property, error)
+	GetServicePropertyContext(ctx context.Context, service string, propertyName string) (*dbus.Property, error)
 	EnableUnitFilesContext(ctx context.Context, files []string, runtime bool, force bool) (
 		bool,
 		[]dbus.EnableUnitFileChange,
@@ -22,9 +23,26 @@ type Connector interface {
 		[]dbus.DisableUnitFileChange,
 		error,
 	)
+	MaskUnitFilesContext(ctx context.Context, files []string, runtime bool, force bool) (
+		[]dbus.MaskUnitFileChange,
+		error,
+	)
+	UnmaskUnitFilesContext(ctx context.Context, files []string, runtime bool) (
+		[]dbus.UnmaskUnitFileChange,
+		error,
+	)
 	ReloadContext(ctx context.Context) error
+	StartUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error)
+	StopUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error)
+	RestartUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error)
+	ReloadUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error)
 	ListJobsContext(ctx context.Context) ([]dbus.JobStatus, error)
 	ListUnitsContext(ctx context.Context) ([]dbus.UnitStatus, error)
+	// Connected reports whether the underlying bus connections are still usable
+	Connected() bool
+	// SubscribeUnitEvents subscribes to the unit state change signals. The returned function
+	// cancels the subscription.
+	SubscribeUnitEvents(ctx context.Context) (<-chan UnitEvent, func(), error)
 	// NewWithContext establishes a connection to any available bus and authenticates.
 	// Callers should call Close() when done with the connection.
 	// see https://pkg.go.dev/github.com/coreos/go-systemd/v22@v22.5.0/dbus#NewWithContext
@@ -32,10 +50,9 @@ type Connector interface {
 }
 
 func NewConnector(ctx context.Context) (Connector, error) {
-	// the created connection does implement the DbusConnector interface, hence it can be returned as such
 	dbusConnection, err := dbus.NewWithContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return dbusConnection, nil
+	return newConnection(dbusConnection), nil
 }
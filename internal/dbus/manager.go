@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package dbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Manager shares a single D-Bus connection between its users.
+// The connection is established lazily and it is transparently re-established
+// when the health check reports that the bus is no longer reachable.
+// The connection outlives the context of the user establishing it, as it is established
+// with a background context. It is only closed by Close or when it is re-established.
+type Manager struct {
+	mutex       sync.Mutex
+	connection  Connector
+	constructor func(ctx context.Context) (Connector, error)
+}
+
+type ManagerOption func(*Manager)
+
+var (
+	defaultManager     *Manager
+	defaultManagerOnce sync.Once
+)
+
+// WithConnectorConstructor sets the function used to establish new connections, dbus.NewConnector by default
+func WithConnectorConstructor(constructor func(ctx context.Context) (Connector, error)) ManagerOption {
+	return func(m *Manager) {
+		m.constructor = constructor
+	}
+}
+
+func NewManager(options ...ManagerOption) *Manager {
+	manager := &Manager{
+		constructor: NewConnector,
+	}
+
+	for _, opt := range options {
+		opt(manager)
+	}
+
+	return manager
+}
+
+// DefaultManager returns the process wide connection manager
+func DefaultManager() *Manager {
+	defaultManagerOnce.Do(func() {
+		defaultManager = NewManager()
+	})
+	return defaultManager
+}
+
+// Connection returns the shared connection, reconnecting if the current one is not healthy.
+// Closing the returned connector is a no-op, the connection is owned by the manager.
+func (m *Manager) Connection(ctx context.Context) (Connector, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.connection != nil && m.connection.Connected() {
+		return &sharedConnector{Connector: m.connection}, nil
+	}
+
+	if m.connection != nil {
+		m.connection.Close()
+		m.connection = nil
+	}
+
+	connection, err := m.connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to D-Bus: %w", err)
+	}
+	m.connection = connection
+
+	return &sharedConnector{Connector: connection}, nil
+}
+
+// connect establishes a new connection bound to the manager lifetime. The given context
+// only bounds the time waiting for the connection to be established.
+func (m *Manager) connect(ctx context.Context) (Connector, error) {
+	type connectResult struct {
+		connection Connector
+		err        error
+	}
+
+	results := make(chan connectResult, 1)
+	go func() {
+		connection, err := m.constructor(context.Background())
+		results <- connectResult{connection: connection, err: err}
+	}()
+
+	select {
+	case result := <-results:
+		return result.connection, result.err
+	case <-ctx.Done():
+		// the connection is not used by anyone if it is established after the context is done
+		go func() {
+			if result := <-results; result.connection != nil {
+				result.connection.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// Healthy reports whether the manager holds a usable connection
+func (m *Manager) Healthy() bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.connection != nil && m.connection.Connected()
+}
+
+// Close closes the shared connection. A later call to Connection establishes a new one.
+func (m *Manager) Close() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.connection == nil {
+		return
+	}
+	m.connection.Close()
+	m.connection = nil
+}
+
+// sharedConnector prevents the users of the shared connection from closing it
+type sharedConnector struct {
+	Connector
+}
+
+func (s *sharedConnector) Close() {}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package dbus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/dbus"
+	"github.com/trento-project/workbench/internal/dbus/mocks"
+)
+
+type ManagerTestSuite struct {
+	suite.Suite
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, new(ManagerTestSuite))
+}
+
+func countingConstructor(connectors ...dbus.Connector) (func(context.Context) (dbus.Connector, error), *int) {
+	calls := 0
+	return func(_ context.Context) (dbus.Connector, error) {
+		connector := connectors[calls]
+		calls++
+		return connector, nil
+	}, &calls
+}
+
+func (suite *ManagerTestSuite) TestConnectionIsReused() {
+	ctx := context.Background()
+	connector := mocks.NewMockConnector(suite.T())
+	constructor, calls := countingConstructor(connector)
+
+	connector.On("Connected").Return(true).Times(2)
+
+	manager := dbus.NewManager(dbus.WithConnectorConstructor(constructor))
+
+	first, err := manager.Connection(ctx)
+	suite.NoError(err)
+	second, err := manager.Connection(ctx)
+	suite.NoError(err)
+
+	suite.Equal(1, *calls)
+	suite.NotNil(first)
+	suite.NotNil(second)
+	suite.True(manager.Healthy())
+}
+
+func (suite *ManagerTestSuite) TestConnectionCloseIsNoOp() {
+	ctx := context.Background()
+	connector := mocks.NewMockConnector(suite.T())
+	constructor, _ := countingConstructor(connector)
+
+	manager := dbus.NewManager(dbus.WithConnectorConstructor(constructor))
+
+	connection, err := manager.Connection(ctx)
+	suite.NoError(err)
+
+	connection.Close()
+	connector.AssertNotCalled(suite.T(), "Close")
+}
+
+func (suite *ManagerTestSuite) TestReconnectWhenUnhealthy() {
+	ctx := context.Background()
+	staleConnector := mocks.NewMockConnector(suite.T())
+	freshConnector := mocks.NewMockConnector(suite.T())
+	constructor, calls := countingConstructor(staleConnector, freshConnector)
+
+	staleConnector.On("Connected").Return(false).Once()
+	staleConnector.On("Close").Return().Once()
+	freshConnector.On("Connected").Return(true).Once()
+
+	manager := dbus.NewManager(dbus.WithConnectorConstructor(constructor))
+
+	_, err := manager.Connection(ctx)
+	suite.NoError(err)
+	_, err = manager.Connection(ctx)
+	suite.NoError(err)
+
+	suite.Equal(2, *calls)
+	suite.True(manager.Healthy())
+}
+
+func (suite *ManagerTestSuite) TestConnectionFailure() {
+	ctx := context.Background()
+	manager := dbus.NewManager(dbus.WithConnectorConstructor(func(_ context.Context) (dbus.Connector, error) {
+		return nil, errors.New("no bus available")
+	}))
+
+	connection, err := manager.Connection(ctx)
+
+	suite.Nil(connection)
+	suite.EqualError(err, "failed to connect to D-Bus: no bus available")
+	suite.False(manager.Healthy())
+}
+
+func (suite *ManagerTestSuite) TestClose() {
+	ctx := context.Background()
+	connector := mocks.NewMockConnector(suite.T())
+	constructor, _ := countingConstructor(connector)
+
+	connector.On("Close").Return().Once()
+
+	manager := dbus.NewManager(dbus.WithConnectorConstructor(constructor))
+
+	_, err := manager.Connection(ctx)
+	suite.NoError(err)
+
+	manager.Close()
+	suite.False(manager.Healthy())
+}
+
+func (suite *ManagerTestSuite) TestConnectionOutlivesCallerContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	connector := mocks.NewMockConnector(suite.T())
+
+	var constructorCtx context.Context
+	manager := dbus.NewManager(dbus.WithConnectorConstructor(func(ctx context.Context) (dbus.Connector, error) {
+		constructorCtx = ctx
+		return connector, nil
+	}))
+
+	_, err := manager.Connection(ctx)
+	suite.NoError(err)
+
+	cancel()
+	suite.NoError(constructorCtx.Err())
+}
+
+func (suite *ManagerTestSuite) TestConnectionContextDone() {
+	ctx, cancel := context.WithCancel(context.Background())
+	connector := mocks.NewMockConnector(suite.T())
+	established := make(chan struct{})
+	closed := make(chan struct{})
+
+	connector.On("Close").Run(func(_ mock.Arguments) { close(closed) }).Return().Once()
+
+	manager := dbus.NewManager(dbus.WithConnectorConstructor(func(_ context.Context) (dbus.Connector, error) {
+		<-established
+		return connector, nil
+	}))
+
+	cancel()
+	connection, err := manager.Connection(ctx)
+
+	suite.Nil(connection)
+	suite.ErrorIs(err, context.Canceled)
+	suite.False(manager.Healthy())
+
+	// the connection established after giving up is closed
+	close(established)
+	<-closed
+}
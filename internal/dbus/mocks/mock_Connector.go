@@ -9,6 +9,7 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	dbus "github.com/trento-project/workbench/internal/dbus"
 
 	v22dbus "github.com/coreos/go-systemd/v22/dbus"
 )
@@ -58,6 +59,51 @@ func (_c *MockConnector_Close_Call) RunAndReturn(run func()) *MockConnector_Clos
 	return _c
 }
 
+// Connected provides a mock function with no fields
+func (_m *MockConnector) Connected() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Connected")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockConnector_Connected_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Connected'
+type MockConnector_Connected_Call struct {
+	*mock.Call
+}
+
+// Connected is a helper method to define mock.On call
+func (_e *MockConnector_Expecter) Connected() *MockConnector_Connected_Call {
+	return &MockConnector_Connected_Call{Call: _e.mock.On("Connected")}
+}
+
+func (_c *MockConnector_Connected_Call) Run(run func()) *MockConnector_Connected_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConnector_Connected_Call) Return(_a0 bool) *MockConnector_Connected_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockConnector_Connected_Call) RunAndReturn(run func() bool) *MockConnector_Connected_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DisableUnitFilesContext provides a mock function with given fields: ctx, files, runtime
 func (_m *MockConnector) DisableUnitFilesContext(ctx context.Context, files []string, runtime bool) ([]v22dbus.DisableUnitFileChange, error) {
 	ret := _m.Called(ctx, files, runtime)
@@ -186,6 +232,66 @@ func (_c *MockConnector_EnableUnitFilesContext_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// GetServicePropertyContext provides a mock function with given fields: ctx, service, propertyName
+func (_m *MockConnector) GetServicePropertyContext(ctx context.Context, service string, propertyName string) (*v22dbus.Property, error) {
+	ret := _m.Called(ctx, service, propertyName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServicePropertyContext")
+	}
+
+	var r0 *v22dbus.Property
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v22dbus.Property, error)); ok {
+		return rf(ctx, service, propertyName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v22dbus.Property); ok {
+		r0 = rf(ctx, service, propertyName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v22dbus.Property)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, service, propertyName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConnector_GetServicePropertyContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServicePropertyContext'
+type MockConnector_GetServicePropertyContext_Call struct {
+	*mock.Call
+}
+
+// GetServicePropertyContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+//   - propertyName string
+func (_e *MockConnector_Expecter) GetServicePropertyContext(ctx interface{}, service interface{}, propertyName interface{}) *MockConnector_GetServicePropertyContext_Call {
+	return &MockConnector_GetServicePropertyContext_Call{Call: _e.mock.On("GetServicePropertyContext", ctx, service, propertyName)}
+}
+
+func (_c *MockConnector_GetServicePropertyContext_Call) Run(run func(ctx context.Context, service string, propertyName string)) *MockConnector_GetServicePropertyContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockConnector_GetServicePropertyContext_Call) Return(_a0 *v22dbus.Property, _a1 error) *MockConnector_GetServicePropertyContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConnector_GetServicePropertyContext_Call) RunAndReturn(run func(context.Context, string, string) (*v22dbus.Property, error)) *MockConnector_GetServicePropertyContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUnitPropertyContext provides a mock function with given fields: ctx, unit, propertyName
 func (_m *MockConnector) GetUnitPropertyContext(ctx context.Context, unit string, propertyName string) (*v22dbus.Property, error) {
 	ret := _m.Called(ctx, unit, propertyName)
@@ -362,6 +468,67 @@ func (_c *MockConnector_ListUnitsContext_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// MaskUnitFilesContext provides a mock function with given fields: ctx, files, runtime, force
+func (_m *MockConnector) MaskUnitFilesContext(ctx context.Context, files []string, runtime bool, force bool) ([]v22dbus.MaskUnitFileChange, error) {
+	ret := _m.Called(ctx, files, runtime, force)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MaskUnitFilesContext")
+	}
+
+	var r0 []v22dbus.MaskUnitFileChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, bool, bool) ([]v22dbus.MaskUnitFileChange, error)); ok {
+		return rf(ctx, files, runtime, force)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, bool, bool) []v22dbus.MaskUnitFileChange); ok {
+		r0 = rf(ctx, files, runtime, force)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v22dbus.MaskUnitFileChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, bool, bool) error); ok {
+		r1 = rf(ctx, files, runtime, force)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConnector_MaskUnitFilesContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MaskUnitFilesContext'
+type MockConnector_MaskUnitFilesContext_Call struct {
+	*mock.Call
+}
+
+// MaskUnitFilesContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - files []string
+//   - runtime bool
+//   - force bool
+func (_e *MockConnector_Expecter) MaskUnitFilesContext(ctx interface{}, files interface{}, runtime interface{}, force interface{}) *MockConnector_MaskUnitFilesContext_Call {
+	return &MockConnector_MaskUnitFilesContext_Call{Call: _e.mock.On("MaskUnitFilesContext", ctx, files, runtime, force)}
+}
+
+func (_c *MockConnector_MaskUnitFilesContext_Call) Run(run func(ctx context.Context, files []string, runtime bool, force bool)) *MockConnector_MaskUnitFilesContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(bool), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockConnector_MaskUnitFilesContext_Call) Return(_a0 []v22dbus.MaskUnitFileChange, _a1 error) *MockConnector_MaskUnitFilesContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConnector_MaskUnitFilesContext_Call) RunAndReturn(run func(context.Context, []string, bool, bool) ([]v22dbus.MaskUnitFileChange, error)) *MockConnector_MaskUnitFilesContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReloadContext provides a mock function with given fields: ctx
 func (_m *MockConnector) ReloadContext(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -408,6 +575,369 @@ func (_c *MockConnector_ReloadContext_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// ReloadUnitContext provides a mock function with given fields: ctx, name, mode, ch
+func (_m *MockConnector) ReloadUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error) {
+	ret := _m.Called(ctx, name, mode, ch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReloadUnitContext")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, chan<- string) (int, error)); ok {
+		return rf(ctx, name, mode, ch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, chan<- string) int); ok {
+		r0 = rf(ctx, name, mode, ch)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, chan<- string) error); ok {
+		r1 = rf(ctx, name, mode, ch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConnector_ReloadUnitContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReloadUnitContext'
+type MockConnector_ReloadUnitContext_Call struct {
+	*mock.Call
+}
+
+// ReloadUnitContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - mode string
+//   - ch chan<- string
+func (_e *MockConnector_Expecter) ReloadUnitContext(ctx interface{}, name interface{}, mode interface{}, ch interface{}) *MockConnector_ReloadUnitContext_Call {
+	return &MockConnector_ReloadUnitContext_Call{Call: _e.mock.On("ReloadUnitContext", ctx, name, mode, ch)}
+}
+
+func (_c *MockConnector_ReloadUnitContext_Call) Run(run func(ctx context.Context, name string, mode string, ch chan<- string)) *MockConnector_ReloadUnitContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(chan<- string))
+	})
+	return _c
+}
+
+func (_c *MockConnector_ReloadUnitContext_Call) Return(_a0 int, _a1 error) *MockConnector_ReloadUnitContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConnector_ReloadUnitContext_Call) RunAndReturn(run func(context.Context, string, string, chan<- string) (int, error)) *MockConnector_ReloadUnitContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestartUnitContext provides a mock function with given fields: ctx, name, mode, ch
+func (_m *MockConnector) RestartUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error) {
+	ret := _m.Called(ctx, name, mode, ch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestartUnitContext")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, chan<- string) (int, error)); ok {
+		return rf(ctx, name, mode, ch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, chan<- string) int); ok {
+		r0 = rf(ctx, name, mode, ch)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, chan<- string) error); ok {
+		r1 = rf(ctx, name, mode, ch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConnector_RestartUnitContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestartUnitContext'
+type MockConnector_RestartUnitContext_Call struct {
+	*mock.Call
+}
+
+// RestartUnitContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - mode string
+//   - ch chan<- string
+func (_e *MockConnector_Expecter) RestartUnitContext(ctx interface{}, name interface{}, mode interface{}, ch interface{}) *MockConnector_RestartUnitContext_Call {
+	return &MockConnector_RestartUnitContext_Call{Call: _e.mock.On("RestartUnitContext", ctx, name, mode, ch)}
+}
+
+func (_c *MockConnector_RestartUnitContext_Call) Run(run func(ctx context.Context, name string, mode string, ch chan<- string)) *MockConnector_RestartUnitContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(chan<- string))
+	})
+	return _c
+}
+
+func (_c *MockConnector_RestartUnitContext_Call) Return(_a0 int, _a1 error) *MockConnector_RestartUnitContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConnector_RestartUnitContext_Call) RunAndReturn(run func(context.Context, string, string, chan<- string) (int, error)) *MockConnector_RestartUnitContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartUnitContext provides a mock function with given fields: ctx, name, mode, ch
+func (_m *MockConnector) StartUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error) {
+	ret := _m.Called(ctx, name, mode, ch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartUnitContext")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, chan<- string) (int, error)); ok {
+		return rf(ctx, name, mode, ch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, chan<- string) int); ok {
+		r0 = rf(ctx, name, mode, ch)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, chan<- string) error); ok {
+		r1 = rf(ctx, name, mode, ch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConnector_StartUnitContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartUnitContext'
+type MockConnector_StartUnitContext_Call struct {
+	*mock.Call
+}
+
+// StartUnitContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - mode string
+//   - ch chan<- string
+func (_e *MockConnector_Expecter) StartUnitContext(ctx interface{}, name interface{}, mode interface{}, ch interface{}) *MockConnector_StartUnitContext_Call {
+	return &MockConnector_StartUnitContext_Call{Call: _e.mock.On("StartUnitContext", ctx, name, mode, ch)}
+}
+
+func (_c *MockConnector_StartUnitContext_Call) Run(run func(ctx context.Context, name string, mode string, ch chan<- string)) *MockConnector_StartUnitContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(chan<- string))
+	})
+	return _c
+}
+
+func (_c *MockConnector_StartUnitContext_Call) Return(_a0 int, _a1 error) *MockConnector_StartUnitContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConnector_StartUnitContext_Call) RunAndReturn(run func(context.Context, string, string, chan<- string) (int, error)) *MockConnector_StartUnitContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StopUnitContext provides a mock function with given fields: ctx, name, mode, ch
+func (_m *MockConnector) StopUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error) {
+	ret := _m.Called(ctx, name, mode, ch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopUnitContext")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, chan<- string) (int, error)); ok {
+		return rf(ctx, name, mode, ch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, chan<- string) int); ok {
+		r0 = rf(ctx, name, mode, ch)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, chan<- string) error); ok {
+		r1 = rf(ctx, name, mode, ch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConnector_StopUnitContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopUnitContext'
+type MockConnector_StopUnitContext_Call struct {
+	*mock.Call
+}
+
+// StopUnitContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - mode string
+//   - ch chan<- string
+func (_e *MockConnector_Expecter) StopUnitContext(ctx interface{}, name interface{}, mode interface{}, ch interface{}) *MockConnector_StopUnitContext_Call {
+	return &MockConnector_StopUnitContext_Call{Call: _e.mock.On("StopUnitContext", ctx, name, mode, ch)}
+}
+
+func (_c *MockConnector_StopUnitContext_Call) Run(run func(ctx context.Context, name string, mode string, ch chan<- string)) *MockConnector_StopUnitContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(chan<- string))
+	})
+	return _c
+}
+
+func (_c *MockConnector_StopUnitContext_Call) Return(_a0 int, _a1 error) *MockConnector_StopUnitContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConnector_StopUnitContext_Call) RunAndReturn(run func(context.Context, string, string, chan<- string) (int, error)) *MockConnector_StopUnitContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubscribeUnitEvents provides a mock function with given fields: ctx
+func (_m *MockConnector) SubscribeUnitEvents(ctx context.Context) (<-chan dbus.UnitEvent, func(), error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeUnitEvents")
+	}
+
+	var r0 <-chan dbus.UnitEvent
+	var r1 func()
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (<-chan dbus.UnitEvent, func(), error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan dbus.UnitEvent); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan dbus.UnitEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) func()); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockConnector_SubscribeUnitEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeUnitEvents'
+type MockConnector_SubscribeUnitEvents_Call struct {
+	*mock.Call
+}
+
+// SubscribeUnitEvents is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockConnector_Expecter) SubscribeUnitEvents(ctx interface{}) *MockConnector_SubscribeUnitEvents_Call {
+	return &MockConnector_SubscribeUnitEvents_Call{Call: _e.mock.On("SubscribeUnitEvents", ctx)}
+}
+
+func (_c *MockConnector_SubscribeUnitEvents_Call) Run(run func(ctx context.Context)) *MockConnector_SubscribeUnitEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockConnector_SubscribeUnitEvents_Call) Return(_a0 <-chan dbus.UnitEvent, _a1 func(), _a2 error) *MockConnector_SubscribeUnitEvents_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockConnector_SubscribeUnitEvents_Call) RunAndReturn(run func(context.Context) (<-chan dbus.UnitEvent, func(), error)) *MockConnector_SubscribeUnitEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnmaskUnitFilesContext provides a mock function with given fields: ctx, files, runtime
+func (_m *MockConnector) UnmaskUnitFilesContext(ctx context.Context, files []string, runtime bool) ([]v22dbus.UnmaskUnitFileChange, error) {
+	ret := _m.Called(ctx, files, runtime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnmaskUnitFilesContext")
+	}
+
+	var r0 []v22dbus.UnmaskUnitFileChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, bool) ([]v22dbus.UnmaskUnitFileChange, error)); ok {
+		return rf(ctx, files, runtime)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, bool) []v22dbus.UnmaskUnitFileChange); ok {
+		r0 = rf(ctx, files, runtime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v22dbus.UnmaskUnitFileChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, bool) error); ok {
+		r1 = rf(ctx, files, runtime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConnector_UnmaskUnitFilesContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnmaskUnitFilesContext'
+type MockConnector_UnmaskUnitFilesContext_Call struct {
+	*mock.Call
+}
+
+// UnmaskUnitFilesContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - files []string
+//   - runtime bool
+func (_e *MockConnector_Expecter) UnmaskUnitFilesContext(ctx interface{}, files interface{}, runtime interface{}) *MockConnector_UnmaskUnitFilesContext_Call {
+	return &MockConnector_UnmaskUnitFilesContext_Call{Call: _e.mock.On("UnmaskUnitFilesContext", ctx, files, runtime)}
+}
+
+func (_c *MockConnector_UnmaskUnitFilesContext_Call) Run(run func(ctx context.Context, files []string, runtime bool)) *MockConnector_UnmaskUnitFilesContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockConnector_UnmaskUnitFilesContext_Call) Return(_a0 []v22dbus.UnmaskUnitFileChange, _a1 error) *MockConnector_UnmaskUnitFilesContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConnector_UnmaskUnitFilesContext_Call) RunAndReturn(run func(context.Context, []string, bool) ([]v22dbus.UnmaskUnitFileChange, error)) *MockConnector_UnmaskUnitFilesContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockConnector creates a new instance of MockConnector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockConnector(t interface {
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package dbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	godbus "github.com/godbus/dbus/v5"
+)
+
+const (
+	signalBuffer   = 256
+	listenerBuffer = 64
+)
+
+// UnitEvent notifies a state change of a unit.
+// Properties holds the changed properties when the event comes from a PropertiesChanged signal.
+// It is empty when the event comes from a JobRemoved or UnitNew signal, and the state of the unit
+// must be read again.
+type UnitEvent struct {
+	Unit       string
+	Properties map[string]godbus.Variant
+}
+
+// connection is the go-systemd connection, extended with the fan-out of the unit signals,
+// as the go-systemd connection supports a single subscriber
+type connection struct {
+	*dbus.Conn
+	mutex      sync.Mutex
+	subscribed bool
+	listeners  map[chan UnitEvent]struct{}
+	done       chan struct{}
+}
+
+func newConnection(conn *dbus.Conn) *connection {
+	return &connection{
+		Conn:      conn,
+		listeners: make(map[chan UnitEvent]struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// SubscribeUnitEvents subscribes the connection to the systemd signals the first time it is called,
+// and registers a new listener. Events are dropped if the listener does not keep up with them.
+func (c *connection) SubscribeUnitEvents(_ context.Context) (<-chan UnitEvent, func(), error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.subscribed {
+		if err := c.Subscribe(); err != nil {
+			return nil, nil, fmt.Errorf("failed to subscribe to systemd signals: %w", err)
+		}
+
+		propertiesChannel := make(chan *dbus.PropertiesUpdate, signalBuffer)
+		subStateChannel := make(chan *dbus.SubStateUpdate, signalBuffer)
+		errorChannel := make(chan error, signalBuffer)
+		c.SetPropertiesSubscriber(propertiesChannel, errorChannel)
+		c.SetSubStateSubscriber(subStateChannel, errorChannel)
+
+		go c.dispatch(propertiesChannel, subStateChannel, errorChannel)
+		c.subscribed = true
+	}
+
+	listener := make(chan UnitEvent, listenerBuffer)
+	c.listeners[listener] = struct{}{}
+
+	unsubscribe := sync.OnceFunc(func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		delete(c.listeners, listener)
+	})
+
+	return listener, unsubscribe, nil
+}
+
+func (c *connection) Close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	select {
+	case <-c.done:
+	default:
+		close(c.done)
+	}
+	c.Conn.Close()
+}
+
+// dispatch forwards the PropertiesChanged updates, and the JobRemoved and UnitNew notifications
+// reported as sub state updates, to the listeners
+func (c *connection) dispatch(
+	propertiesChannel <-chan *dbus.PropertiesUpdate,
+	subStateChannel <-chan *dbus.SubStateUpdate,
+	errorChannel <-chan error,
+) {
+	for {
+		select {
+		case <-c.done:
+			return
+		case update := <-propertiesChannel:
+			c.broadcast(UnitEvent{Unit: update.UnitName, Properties: update.Changed})
+		case update := <-subStateChannel:
+			c.broadcast(UnitEvent{Unit: update.UnitName})
+		case <-errorChannel:
+			// the update channels are full and some signals were lost,
+			// the listeners read the unit state again on the next event
+		}
+	}
+}
+
+func (c *connection) broadcast(event UnitEvent) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for listener := range c.listeners {
+		select {
+		case listener <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package logind
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	godbus "github.com/godbus/dbus/v5"
+)
+
+const (
+	ShutdownTypeReboot = "reboot"
+
+	logindDestination      = "org.freedesktop.login1"
+	logindObjectPath       = "/org/freedesktop/login1"
+	logindManagerInterface = "org.freedesktop.login1.Manager"
+	propertiesGetMethod    = "org.freedesktop.DBus.Properties.Get"
+)
+
+// ScheduledShutdown is the shutdown scheduled in systemd-logind, as reported by the
+// ScheduledShutdown property. The type is empty if there is no scheduled shutdown.
+type ScheduledShutdown struct {
+	Type string
+	Time time.Time
+}
+
+func (s ScheduledShutdown) IsScheduled() bool {
+	return s.Type != ""
+}
+
+// WallMessage is the message sent to the logged in users when shutting down,
+// as reported by the WallMessage and EnableWallMessages properties
+type WallMessage struct {
+	Message string
+	Enabled bool
+}
+
+// BusObject is the part of the godbus object used to call the systemd-logind manager
+type BusObject interface {
+	CallWithContext(ctx context.Context, method string, flags godbus.Flags, args ...any) *godbus.Call
+}
+
+type Logind interface {
+	ScheduleShutdown(ctx context.Context, shutdownType string, at time.Time) error
+	CancelScheduledShutdown(ctx context.Context) (bool, error)
+	GetScheduledShutdown(ctx context.Context) (ScheduledShutdown, error)
+	GetWallMessage(ctx context.Context) (WallMessage, error)
+	SetWallMessage(ctx context.Context, message WallMessage) error
+	Reboot(ctx context.Context) error
+	Close()
+}
+
+type Client struct {
+	connection *godbus.Conn
+	object     BusObject
+	logger     *slog.Logger
+}
+
+type ClientOption func(*Client)
+
+func WithCustomBusObject(object BusObject) ClientOption {
+	return func(c *Client) {
+		c.object = object
+	}
+}
+
+// NewLogind connects to the systemd-logind manager in the system bus
+func NewLogind(logger *slog.Logger, options ...ClientOption) (Logind, error) {
+	client := &Client{
+		logger: logger,
+	}
+
+	for _, opt := range options {
+		opt(client)
+	}
+
+	if client.object != nil {
+		return client, nil
+	}
+
+	connection, err := godbus.ConnectSystemBus()
+	if err != nil {
+		logger.Error("failed to connect to the system bus", "error", err)
+		return nil, fmt.Errorf("failed to connect to the system bus: %w", err)
+	}
+	client.connection = connection
+	client.object = connection.Object(logindDestination, logindObjectPath)
+
+	return client, nil
+}
+
+// ScheduleShutdown schedules a shutdown of the given type, e.g. reboot, at the given time.
+// A previously scheduled shutdown is replaced.
+func (c *Client) ScheduleShutdown(ctx context.Context, shutdownType string, at time.Time) error {
+	err := c.call(ctx, "ScheduleShutdown", shutdownType, uint64(at.UnixMicro())).Store()
+	if err != nil {
+		c.logger.Error("failed to schedule shutdown", "type", shutdownType, "time", at, "error", err)
+		return fmt.Errorf("failed to schedule %s at %s: %w", shutdownType, at.Format(time.RFC3339), err)
+	}
+	return nil
+}
+
+// CancelScheduledShutdown cancels the scheduled shutdown. It returns false if there was nothing to cancel.
+func (c *Client) CancelScheduledShutdown(ctx context.Context) (bool, error) {
+	var cancelled bool
+	if err := c.call(ctx, "CancelScheduledShutdown").Store(&cancelled); err != nil {
+		c.logger.Error("failed to cancel scheduled shutdown", "error", err)
+		return false, fmt.Errorf("failed to cancel scheduled shutdown: %w", err)
+	}
+	return cancelled, nil
+}
+
+func (c *Client) GetScheduledShutdown(ctx context.Context) (ScheduledShutdown, error) {
+	property, err := c.getProperty(ctx, "ScheduledShutdown")
+	if err != nil {
+		c.logger.Error("failed to get scheduled shutdown", "error", err)
+		return ScheduledShutdown{}, fmt.Errorf("failed to get scheduled shutdown: %w", err)
+	}
+
+	value, ok := property.Value().([]any)
+	if !ok || len(value) != 2 {
+		return ScheduledShutdown{}, fmt.Errorf("unexpected scheduled shutdown value: %v", property.Value())
+	}
+
+	shutdownType, typeOk := value[0].(string)
+	usec, usecOk := value[1].(uint64)
+	if !typeOk || !usecOk {
+		return ScheduledShutdown{}, fmt.Errorf("unexpected scheduled shutdown value: %v", property.Value())
+	}
+
+	if shutdownType == "" || usec == 0 {
+		return ScheduledShutdown{}, nil
+	}
+
+	return ScheduledShutdown{
+		Type: shutdownType,
+		Time: time.UnixMicro(int64(usec)).UTC(),
+	}, nil
+}
+
+func (c *Client) GetWallMessage(ctx context.Context) (WallMessage, error) {
+	message, err := c.getProperty(ctx, "WallMessage")
+	if err != nil {
+		c.logger.Error("failed to get wall message", "error", err)
+		return WallMessage{}, fmt.Errorf("failed to get wall message: %w", err)
+	}
+
+	enabled, err := c.getProperty(ctx, "EnableWallMessages")
+	if err != nil {
+		c.logger.Error("failed to get wall message", "error", err)
+		return WallMessage{}, fmt.Errorf("failed to get wall message: %w", err)
+	}
+
+	messageValue, messageOk := message.Value().(string)
+	enabledValue, enabledOk := enabled.Value().(bool)
+	if !messageOk || !enabledOk {
+		return WallMessage{}, fmt.Errorf("unexpected wall message value: %v, %v", message.Value(), enabled.Value())
+	}
+
+	return WallMessage{Message: messageValue, Enabled: enabledValue}, nil
+}
+
+// SetWallMessage sets the message sent to the logged in users when shutting down
+func (c *Client) SetWallMessage(ctx context.Context, message WallMessage) error {
+	if err := c.call(ctx, "SetWallMessage", message.Message, message.Enabled).Store(); err != nil {
+		c.logger.Error("failed to set wall message", "error", err)
+		return fmt.Errorf("failed to set wall message: %w", err)
+	}
+	return nil
+}
+
+// Reboot reboots the host immediately, without asking for authentication interactively
+func (c *Client) Reboot(ctx context.Context) error {
+	if err := c.call(ctx, "Reboot", false).Store(); err != nil {
+		c.logger.Error("failed to reboot", "error", err)
+		return fmt.Errorf("failed to reboot: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) Close() {
+	if c.connection != nil {
+		c.connection.Close()
+	}
+}
+
+func (c *Client) getProperty(ctx context.Context, name string) (godbus.Variant, error) {
+	var property godbus.Variant
+	err := c.object.CallWithContext(ctx, propertiesGetMethod, 0, logindManagerInterface, name).Store(&property)
+	return property, err
+}
+
+func (c *Client) call(ctx context.Context, method string, args ...any) *godbus.Call {
+	return c.object.CallWithContext(ctx, logindManagerInterface+"."+method, 0, args...)
+}
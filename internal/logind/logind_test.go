@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package logind_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	godbus "github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/logind"
+	"github.com/trento-project/workbench/internal/logind/mocks"
+	"github.com/trento-project/workbench/internal/support"
+)
+
+type LogindTestSuite struct {
+	suite.Suite
+	busObject *mocks.MockBusObject
+	logger    *slog.Logger
+}
+
+func TestLogind(t *testing.T) {
+	suite.Run(t, new(LogindTestSuite))
+}
+
+func (suite *LogindTestSuite) SetupTest() {
+	suite.busObject = mocks.NewMockBusObject(suite.T())
+	suite.logger = support.NewDefaultLogger(slog.LevelInfo).With("test", "logind_test_suite")
+}
+
+func (suite *LogindTestSuite) newLogind() logind.Logind {
+	client, err := logind.NewLogind(suite.logger, logind.WithCustomBusObject(suite.busObject))
+	suite.Require().NoError(err)
+	return client
+}
+
+func (suite *LogindTestSuite) TestScheduleShutdown() {
+	ctx := context.Background()
+	at := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+
+	suite.busObject.On(
+		"CallWithContext", ctx, "org.freedesktop.login1.Manager.ScheduleShutdown", godbus.Flags(0),
+		"reboot", uint64(at.UnixMicro()),
+	).Return(&godbus.Call{}).Once()
+
+	err := suite.newLogind().ScheduleShutdown(ctx, logind.ShutdownTypeReboot, at)
+
+	suite.NoError(err)
+}
+
+func (suite *LogindTestSuite) TestScheduleShutdownFailure() {
+	ctx := context.Background()
+	at := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+
+	suite.busObject.On(
+		"CallWithContext", ctx, "org.freedesktop.login1.Manager.ScheduleShutdown", godbus.Flags(0),
+		"reboot", uint64(at.UnixMicro()),
+	).Return(&godbus.Call{Err: errors.New("access denied")}).Once()
+
+	err := suite.newLogind().ScheduleShutdown(ctx, logind.ShutdownTypeReboot, at)
+
+	suite.EqualError(err, "failed to schedule reboot at 2026-03-01T22:00:00Z: access denied")
+}
+
+func (suite *LogindTestSuite) TestCancelScheduledShutdown() {
+	ctx := context.Background()
+
+	suite.busObject.On(
+		"CallWithContext", ctx, "org.freedesktop.login1.Manager.CancelScheduledShutdown", godbus.Flags(0),
+	).Return(&godbus.Call{Body: []any{true}}).Once()
+
+	cancelled, err := suite.newLogind().CancelScheduledShutdown(ctx)
+
+	suite.NoError(err)
+	suite.True(cancelled)
+}
+
+func (suite *LogindTestSuite) TestGetScheduledShutdown() {
+	ctx := context.Background()
+	at := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+
+	suite.busObject.On(
+		"CallWithContext", ctx, "org.freedesktop.DBus.Properties.Get", godbus.Flags(0),
+		"org.freedesktop.login1.Manager", "ScheduledShutdown",
+	).Return(&godbus.Call{
+		Body: []any{godbus.MakeVariant([]any{"reboot", uint64(at.UnixMicro())})},
+	}).Once()
+
+	shutdown, err := suite.newLogind().GetScheduledShutdown(ctx)
+
+	suite.NoError(err)
+	suite.True(shutdown.IsScheduled())
+	suite.Equal(logind.ScheduledShutdown{Type: "reboot", Time: at}, shutdown)
+}
+
+func (suite *LogindTestSuite) TestGetScheduledShutdownNone() {
+	ctx := context.Background()
+
+	suite.busObject.On(
+		"CallWithContext", ctx, "org.freedesktop.DBus.Properties.Get", godbus.Flags(0),
+		"org.freedesktop.login1.Manager", "ScheduledShutdown",
+	).Return(&godbus.Call{
+		Body: []any{godbus.MakeVariant([]any{"", uint64(0)})},
+	}).Once()
+
+	shutdown, err := suite.newLogind().GetScheduledShutdown(ctx)
+
+	suite.NoError(err)
+	suite.False(shutdown.IsScheduled())
+}
+
+func (suite *LogindTestSuite) TestGetScheduledShutdownUnexpectedValue() {
+	ctx := context.Background()
+
+	suite.busObject.On(
+		"CallWithContext", ctx, "org.freedesktop.DBus.Properties.Get", godbus.Flags(0),
+		"org.freedesktop.login1.Manager", "ScheduledShutdown",
+	).Return(&godbus.Call{
+		Body: []any{godbus.MakeVariant("reboot")},
+	}).Once()
+
+	_, err := suite.newLogind().GetScheduledShutdown(ctx)
+
+	suite.EqualError(err, "unexpected scheduled shutdown value: reboot")
+}
+
+func (suite *LogindTestSuite) TestSetWallMessageAndReboot() {
+	ctx := context.Background()
+
+	suite.busObject.On(
+		"CallWithContext", ctx, "org.freedesktop.login1.Manager.SetWallMessage", godbus.Flags(0),
+		"Kernel update", true,
+	).Return(&godbus.Call{}).Once()
+
+	suite.busObject.On(
+		"CallWithContext", ctx, "org.freedesktop.login1.Manager.Reboot", godbus.Flags(0), false,
+	).Return(&godbus.Call{Err: errors.New("interactive authentication required")}).Once()
+
+	client := suite.newLogind()
+
+	suite.NoError(client.SetWallMessage(ctx, logind.WallMessage{Message: "Kernel update", Enabled: true}))
+	suite.EqualError(client.Reboot(ctx), "failed to reboot: interactive authentication required")
+}
+
+func (suite *LogindTestSuite) TestGetWallMessage() {
+	ctx := context.Background()
+
+	suite.busObject.On(
+		"CallWithContext", ctx, "org.freedesktop.DBus.Properties.Get", godbus.Flags(0),
+		"org.freedesktop.login1.Manager", "WallMessage",
+	).Return(&godbus.Call{Body: []any{godbus.MakeVariant("Maintenance window")}}).Once()
+	suite.busObject.On(
+		"CallWithContext", ctx, "org.freedesktop.DBus.Properties.Get", godbus.Flags(0),
+		"org.freedesktop.login1.Manager", "EnableWallMessages",
+	).Return(&godbus.Call{Body: []any{godbus.MakeVariant(false)}}).Once()
+
+	message, err := suite.newLogind().GetWallMessage(ctx)
+
+	suite.NoError(err)
+	suite.Equal(logind.WallMessage{Message: "Maintenance window", Enabled: false}, message)
+}
+
+func (suite *LogindTestSuite) TestGetWallMessageFailure() {
+	ctx := context.Background()
+
+	suite.busObject.On(
+		"CallWithContext", ctx, "org.freedesktop.DBus.Properties.Get", godbus.Flags(0),
+		"org.freedesktop.login1.Manager", "WallMessage",
+	).Return(&godbus.Call{Err: errors.New("access denied")}).Once()
+
+	_, err := suite.newLogind().GetWallMessage(ctx)
+
+	suite.EqualError(err, "failed to get wall message: access denied")
+}
@@ -9,6 +9,7 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	saptune "github.com/trento-project/workbench/internal/saptune"
 )
 
 // MockSaptune is an autogenerated mock type for the Saptune type
@@ -24,6 +25,53 @@ func (_m *MockSaptune) EXPECT() *MockSaptune_Expecter {
 	return &MockSaptune_Expecter{mock: &_m.Mock}
 }
 
+// ApplyNote provides a mock function with given fields: ctx, noteID
+func (_m *MockSaptune) ApplyNote(ctx context.Context, noteID string) error {
+	ret := _m.Called(ctx, noteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyNote")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, noteID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSaptune_ApplyNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyNote'
+type MockSaptune_ApplyNote_Call struct {
+	*mock.Call
+}
+
+// ApplyNote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - noteID string
+func (_e *MockSaptune_Expecter) ApplyNote(ctx interface{}, noteID interface{}) *MockSaptune_ApplyNote_Call {
+	return &MockSaptune_ApplyNote_Call{Call: _e.mock.On("ApplyNote", ctx, noteID)}
+}
+
+func (_c *MockSaptune_ApplyNote_Call) Run(run func(ctx context.Context, noteID string)) *MockSaptune_ApplyNote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_ApplyNote_Call) Return(_a0 error) *MockSaptune_ApplyNote_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSaptune_ApplyNote_Call) RunAndReturn(run func(context.Context, string) error) *MockSaptune_ApplyNote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ApplySolution provides a mock function with given fields: ctx, solution
 func (_m *MockSaptune) ApplySolution(ctx context.Context, solution string) error {
 	ret := _m.Called(ctx, solution)
@@ -118,6 +166,52 @@ func (_c *MockSaptune_ChangeSolution_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// CheckServiceStatus provides a mock function with given fields: ctx
+func (_m *MockSaptune) CheckServiceStatus(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckServiceStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSaptune_CheckServiceStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckServiceStatus'
+type MockSaptune_CheckServiceStatus_Call struct {
+	*mock.Call
+}
+
+// CheckServiceStatus is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSaptune_Expecter) CheckServiceStatus(ctx interface{}) *MockSaptune_CheckServiceStatus_Call {
+	return &MockSaptune_CheckServiceStatus_Call{Call: _e.mock.On("CheckServiceStatus", ctx)}
+}
+
+func (_c *MockSaptune_CheckServiceStatus_Call) Run(run func(ctx context.Context)) *MockSaptune_CheckServiceStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_CheckServiceStatus_Call) Return(_a0 error) *MockSaptune_CheckServiceStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSaptune_CheckServiceStatus_Call) RunAndReturn(run func(context.Context) error) *MockSaptune_CheckServiceStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CheckVersionSupport provides a mock function with given fields: ctx
 func (_m *MockSaptune) CheckVersionSupport(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -164,6 +258,167 @@ func (_c *MockSaptune_CheckVersionSupport_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// DeleteNoteOverride provides a mock function with given fields: ctx, noteID
+func (_m *MockSaptune) DeleteNoteOverride(ctx context.Context, noteID string) error {
+	ret := _m.Called(ctx, noteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNoteOverride")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, noteID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSaptune_DeleteNoteOverride_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNoteOverride'
+type MockSaptune_DeleteNoteOverride_Call struct {
+	*mock.Call
+}
+
+// DeleteNoteOverride is a helper method to define mock.On call
+//   - ctx context.Context
+//   - noteID string
+func (_e *MockSaptune_Expecter) DeleteNoteOverride(ctx interface{}, noteID interface{}) *MockSaptune_DeleteNoteOverride_Call {
+	return &MockSaptune_DeleteNoteOverride_Call{Call: _e.mock.On("DeleteNoteOverride", ctx, noteID)}
+}
+
+func (_c *MockSaptune_DeleteNoteOverride_Call) Run(run func(ctx context.Context, noteID string)) *MockSaptune_DeleteNoteOverride_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_DeleteNoteOverride_Call) Return(_a0 error) *MockSaptune_DeleteNoteOverride_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSaptune_DeleteNoteOverride_Call) RunAndReturn(run func(context.Context, string) error) *MockSaptune_DeleteNoteOverride_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveTunedProfile provides a mock function with given fields: ctx
+func (_m *MockSaptune) GetActiveTunedProfile(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveTunedProfile")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSaptune_GetActiveTunedProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveTunedProfile'
+type MockSaptune_GetActiveTunedProfile_Call struct {
+	*mock.Call
+}
+
+// GetActiveTunedProfile is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSaptune_Expecter) GetActiveTunedProfile(ctx interface{}) *MockSaptune_GetActiveTunedProfile_Call {
+	return &MockSaptune_GetActiveTunedProfile_Call{Call: _e.mock.On("GetActiveTunedProfile", ctx)}
+}
+
+func (_c *MockSaptune_GetActiveTunedProfile_Call) Run(run func(ctx context.Context)) *MockSaptune_GetActiveTunedProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_GetActiveTunedProfile_Call) Return(_a0 string, _a1 error) *MockSaptune_GetActiveTunedProfile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSaptune_GetActiveTunedProfile_Call) RunAndReturn(run func(context.Context) (string, error)) *MockSaptune_GetActiveTunedProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAppliedNotes provides a mock function with given fields: ctx
+func (_m *MockSaptune) GetAppliedNotes(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppliedNotes")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSaptune_GetAppliedNotes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppliedNotes'
+type MockSaptune_GetAppliedNotes_Call struct {
+	*mock.Call
+}
+
+// GetAppliedNotes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSaptune_Expecter) GetAppliedNotes(ctx interface{}) *MockSaptune_GetAppliedNotes_Call {
+	return &MockSaptune_GetAppliedNotes_Call{Call: _e.mock.On("GetAppliedNotes", ctx)}
+}
+
+func (_c *MockSaptune_GetAppliedNotes_Call) Run(run func(ctx context.Context)) *MockSaptune_GetAppliedNotes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_GetAppliedNotes_Call) Return(_a0 []string, _a1 error) *MockSaptune_GetAppliedNotes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSaptune_GetAppliedNotes_Call) RunAndReturn(run func(context.Context) ([]string, error)) *MockSaptune_GetAppliedNotes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAppliedSolution provides a mock function with given fields: ctx
 func (_m *MockSaptune) GetAppliedSolution(ctx context.Context) (string, error) {
 	ret := _m.Called(ctx)
@@ -220,49 +475,966 @@ func (_c *MockSaptune_GetAppliedSolution_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
-// RevertSolution provides a mock function with given fields: ctx, solution
-func (_m *MockSaptune) RevertSolution(ctx context.Context, solution string) error {
-	ret := _m.Called(ctx, solution)
+// GetNoteDefinition provides a mock function with given fields: ctx, noteID
+func (_m *MockSaptune) GetNoteDefinition(ctx context.Context, noteID string) ([]saptune.NoteSection, error) {
+	ret := _m.Called(ctx, noteID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RevertSolution")
+		panic("no return value specified for GetNoteDefinition")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, solution)
+	var r0 []saptune.NoteSection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]saptune.NoteSection, error)); ok {
+		return rf(ctx, noteID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []saptune.NoteSection); ok {
+		r0 = rf(ctx, noteID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]saptune.NoteSection)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, noteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockSaptune_RevertSolution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevertSolution'
-type MockSaptune_RevertSolution_Call struct {
+// MockSaptune_GetNoteDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNoteDefinition'
+type MockSaptune_GetNoteDefinition_Call struct {
 	*mock.Call
 }
 
-// RevertSolution is a helper method to define mock.On call
+// GetNoteDefinition is a helper method to define mock.On call
 //   - ctx context.Context
-//   - solution string
-func (_e *MockSaptune_Expecter) RevertSolution(ctx interface{}, solution interface{}) *MockSaptune_RevertSolution_Call {
-	return &MockSaptune_RevertSolution_Call{Call: _e.mock.On("RevertSolution", ctx, solution)}
+//   - noteID string
+func (_e *MockSaptune_Expecter) GetNoteDefinition(ctx interface{}, noteID interface{}) *MockSaptune_GetNoteDefinition_Call {
+	return &MockSaptune_GetNoteDefinition_Call{Call: _e.mock.On("GetNoteDefinition", ctx, noteID)}
 }
 
-func (_c *MockSaptune_RevertSolution_Call) Run(run func(ctx context.Context, solution string)) *MockSaptune_RevertSolution_Call {
+func (_c *MockSaptune_GetNoteDefinition_Call) Run(run func(ctx context.Context, noteID string)) *MockSaptune_GetNoteDefinition_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockSaptune_RevertSolution_Call) Return(_a0 error) *MockSaptune_RevertSolution_Call {
-	_c.Call.Return(_a0)
+func (_c *MockSaptune_GetNoteDefinition_Call) Return(_a0 []saptune.NoteSection, _a1 error) *MockSaptune_GetNoteDefinition_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSaptune_RevertSolution_Call) RunAndReturn(run func(context.Context, string) error) *MockSaptune_RevertSolution_Call {
+func (_c *MockSaptune_GetNoteDefinition_Call) RunAndReturn(run func(context.Context, string) ([]saptune.NoteSection, error)) *MockSaptune_GetNoteDefinition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNoteOverride provides a mock function with given fields: ctx, noteID
+func (_m *MockSaptune) GetNoteOverride(ctx context.Context, noteID string) ([]byte, bool, error) {
+	ret := _m.Called(ctx, noteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNoteOverride")
+	}
+
+	var r0 []byte
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]byte, bool, error)); ok {
+		return rf(ctx, noteID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = rf(ctx, noteID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, noteID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, noteID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSaptune_GetNoteOverride_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNoteOverride'
+type MockSaptune_GetNoteOverride_Call struct {
+	*mock.Call
+}
+
+// GetNoteOverride is a helper method to define mock.On call
+//   - ctx context.Context
+//   - noteID string
+func (_e *MockSaptune_Expecter) GetNoteOverride(ctx interface{}, noteID interface{}) *MockSaptune_GetNoteOverride_Call {
+	return &MockSaptune_GetNoteOverride_Call{Call: _e.mock.On("GetNoteOverride", ctx, noteID)}
+}
+
+func (_c *MockSaptune_GetNoteOverride_Call) Run(run func(ctx context.Context, noteID string)) *MockSaptune_GetNoteOverride_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_GetNoteOverride_Call) Return(_a0 []byte, _a1 bool, _a2 error) *MockSaptune_GetNoteOverride_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSaptune_GetNoteOverride_Call) RunAndReturn(run func(context.Context, string) ([]byte, bool, error)) *MockSaptune_GetNoteOverride_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStagingDiff provides a mock function with given fields: ctx, id
+func (_m *MockSaptune) GetStagingDiff(ctx context.Context, id string) (string, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStagingDiff")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSaptune_GetStagingDiff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStagingDiff'
+type MockSaptune_GetStagingDiff_Call struct {
+	*mock.Call
+}
+
+// GetStagingDiff is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockSaptune_Expecter) GetStagingDiff(ctx interface{}, id interface{}) *MockSaptune_GetStagingDiff_Call {
+	return &MockSaptune_GetStagingDiff_Call{Call: _e.mock.On("GetStagingDiff", ctx, id)}
+}
+
+func (_c *MockSaptune_GetStagingDiff_Call) Run(run func(ctx context.Context, id string)) *MockSaptune_GetStagingDiff_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_GetStagingDiff_Call) Return(_a0 string, _a1 error) *MockSaptune_GetStagingDiff_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSaptune_GetStagingDiff_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockSaptune_GetStagingDiff_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStatus provides a mock function with given fields: ctx
+func (_m *MockSaptune) GetStatus(ctx context.Context) (*saptune.Status, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatus")
+	}
+
+	var r0 *saptune.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*saptune.Status, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *saptune.Status); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*saptune.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSaptune_GetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatus'
+type MockSaptune_GetStatus_Call struct {
+	*mock.Call
+}
+
+// GetStatus is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSaptune_Expecter) GetStatus(ctx interface{}) *MockSaptune_GetStatus_Call {
+	return &MockSaptune_GetStatus_Call{Call: _e.mock.On("GetStatus", ctx)}
+}
+
+func (_c *MockSaptune_GetStatus_Call) Run(run func(ctx context.Context)) *MockSaptune_GetStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_GetStatus_Call) Return(_a0 *saptune.Status, _a1 error) *MockSaptune_GetStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSaptune_GetStatus_Call) RunAndReturn(run func(context.Context) (*saptune.Status, error)) *MockSaptune_GetStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTuningProfile provides a mock function with given fields: ctx
+func (_m *MockSaptune) GetTuningProfile(ctx context.Context) (*saptune.TuningProfile, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTuningProfile")
+	}
+
+	var r0 *saptune.TuningProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*saptune.TuningProfile, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *saptune.TuningProfile); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*saptune.TuningProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSaptune_GetTuningProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTuningProfile'
+type MockSaptune_GetTuningProfile_Call struct {
+	*mock.Call
+}
+
+// GetTuningProfile is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSaptune_Expecter) GetTuningProfile(ctx interface{}) *MockSaptune_GetTuningProfile_Call {
+	return &MockSaptune_GetTuningProfile_Call{Call: _e.mock.On("GetTuningProfile", ctx)}
+}
+
+func (_c *MockSaptune_GetTuningProfile_Call) Run(run func(ctx context.Context)) *MockSaptune_GetTuningProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_GetTuningProfile_Call) Return(_a0 *saptune.TuningProfile, _a1 error) *MockSaptune_GetTuningProfile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSaptune_GetTuningProfile_Call) RunAndReturn(run func(context.Context) (*saptune.TuningProfile, error)) *MockSaptune_GetTuningProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkingDefinition provides a mock function with given fields: ctx, item
+func (_m *MockSaptune) GetWorkingDefinition(ctx context.Context, item saptune.StagedItem) ([]byte, bool, error) {
+	ret := _m.Called(ctx, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkingDefinition")
+	}
+
+	var r0 []byte
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, saptune.StagedItem) ([]byte, bool, error)); ok {
+		return rf(ctx, item)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, saptune.StagedItem) []byte); ok {
+		r0 = rf(ctx, item)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, saptune.StagedItem) bool); ok {
+		r1 = rf(ctx, item)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, saptune.StagedItem) error); ok {
+		r2 = rf(ctx, item)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSaptune_GetWorkingDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkingDefinition'
+type MockSaptune_GetWorkingDefinition_Call struct {
+	*mock.Call
+}
+
+// GetWorkingDefinition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item saptune.StagedItem
+func (_e *MockSaptune_Expecter) GetWorkingDefinition(ctx interface{}, item interface{}) *MockSaptune_GetWorkingDefinition_Call {
+	return &MockSaptune_GetWorkingDefinition_Call{Call: _e.mock.On("GetWorkingDefinition", ctx, item)}
+}
+
+func (_c *MockSaptune_GetWorkingDefinition_Call) Run(run func(ctx context.Context, item saptune.StagedItem)) *MockSaptune_GetWorkingDefinition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(saptune.StagedItem))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_GetWorkingDefinition_Call) Return(_a0 []byte, _a1 bool, _a2 error) *MockSaptune_GetWorkingDefinition_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSaptune_GetWorkingDefinition_Call) RunAndReturn(run func(context.Context, saptune.StagedItem) ([]byte, bool, error)) *MockSaptune_GetWorkingDefinition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNotes provides a mock function with given fields: ctx
+func (_m *MockSaptune) ListNotes(ctx context.Context) ([]saptune.Note, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNotes")
+	}
+
+	var r0 []saptune.Note
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]saptune.Note, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []saptune.Note); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]saptune.Note)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSaptune_ListNotes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNotes'
+type MockSaptune_ListNotes_Call struct {
+	*mock.Call
+}
+
+// ListNotes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSaptune_Expecter) ListNotes(ctx interface{}) *MockSaptune_ListNotes_Call {
+	return &MockSaptune_ListNotes_Call{Call: _e.mock.On("ListNotes", ctx)}
+}
+
+func (_c *MockSaptune_ListNotes_Call) Run(run func(ctx context.Context)) *MockSaptune_ListNotes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_ListNotes_Call) Return(_a0 []saptune.Note, _a1 error) *MockSaptune_ListNotes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSaptune_ListNotes_Call) RunAndReturn(run func(context.Context) ([]saptune.Note, error)) *MockSaptune_ListNotes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSolutions provides a mock function with given fields: ctx
+func (_m *MockSaptune) ListSolutions(ctx context.Context) ([]saptune.Solution, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSolutions")
+	}
+
+	var r0 []saptune.Solution
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]saptune.Solution, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []saptune.Solution); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]saptune.Solution)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSaptune_ListSolutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSolutions'
+type MockSaptune_ListSolutions_Call struct {
+	*mock.Call
+}
+
+// ListSolutions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSaptune_Expecter) ListSolutions(ctx interface{}) *MockSaptune_ListSolutions_Call {
+	return &MockSaptune_ListSolutions_Call{Call: _e.mock.On("ListSolutions", ctx)}
+}
+
+func (_c *MockSaptune_ListSolutions_Call) Run(run func(ctx context.Context)) *MockSaptune_ListSolutions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_ListSolutions_Call) Return(_a0 []saptune.Solution, _a1 error) *MockSaptune_ListSolutions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSaptune_ListSolutions_Call) RunAndReturn(run func(context.Context) ([]saptune.Solution, error)) *MockSaptune_ListSolutions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStaging provides a mock function with given fields: ctx
+func (_m *MockSaptune) ListStaging(ctx context.Context) ([]saptune.StagedItem, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStaging")
+	}
+
+	var r0 []saptune.StagedItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]saptune.StagedItem, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []saptune.StagedItem); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]saptune.StagedItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSaptune_ListStaging_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStaging'
+type MockSaptune_ListStaging_Call struct {
+	*mock.Call
+}
+
+// ListStaging is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSaptune_Expecter) ListStaging(ctx interface{}) *MockSaptune_ListStaging_Call {
+	return &MockSaptune_ListStaging_Call{Call: _e.mock.On("ListStaging", ctx)}
+}
+
+func (_c *MockSaptune_ListStaging_Call) Run(run func(ctx context.Context)) *MockSaptune_ListStaging_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_ListStaging_Call) Return(_a0 []saptune.StagedItem, _a1 error) *MockSaptune_ListStaging_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSaptune_ListStaging_Call) RunAndReturn(run func(context.Context) ([]saptune.StagedItem, error)) *MockSaptune_ListStaging_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseStaging provides a mock function with given fields: ctx, ids
+func (_m *MockSaptune) ReleaseStaging(ctx context.Context, ids ...string) error {
+	_va := make([]interface{}, len(ids))
+	for _i := range ids {
+		_va[_i] = ids[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseStaging")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = rf(ctx, ids...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSaptune_ReleaseStaging_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseStaging'
+type MockSaptune_ReleaseStaging_Call struct {
+	*mock.Call
+}
+
+// ReleaseStaging is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids ...string
+func (_e *MockSaptune_Expecter) ReleaseStaging(ctx interface{}, ids ...interface{}) *MockSaptune_ReleaseStaging_Call {
+	return &MockSaptune_ReleaseStaging_Call{Call: _e.mock.On("ReleaseStaging",
+		append([]interface{}{ctx}, ids...)...)}
+}
+
+func (_c *MockSaptune_ReleaseStaging_Call) Run(run func(ctx context.Context, ids ...string)) *MockSaptune_ReleaseStaging_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSaptune_ReleaseStaging_Call) Return(_a0 error) *MockSaptune_ReleaseStaging_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSaptune_ReleaseStaging_Call) RunAndReturn(run func(context.Context, ...string) error) *MockSaptune_ReleaseStaging_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevertNote provides a mock function with given fields: ctx, noteID
+func (_m *MockSaptune) RevertNote(ctx context.Context, noteID string) error {
+	ret := _m.Called(ctx, noteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertNote")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, noteID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSaptune_RevertNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevertNote'
+type MockSaptune_RevertNote_Call struct {
+	*mock.Call
+}
+
+// RevertNote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - noteID string
+func (_e *MockSaptune_Expecter) RevertNote(ctx interface{}, noteID interface{}) *MockSaptune_RevertNote_Call {
+	return &MockSaptune_RevertNote_Call{Call: _e.mock.On("RevertNote", ctx, noteID)}
+}
+
+func (_c *MockSaptune_RevertNote_Call) Run(run func(ctx context.Context, noteID string)) *MockSaptune_RevertNote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_RevertNote_Call) Return(_a0 error) *MockSaptune_RevertNote_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSaptune_RevertNote_Call) RunAndReturn(run func(context.Context, string) error) *MockSaptune_RevertNote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevertSolution provides a mock function with given fields: ctx, solution
+func (_m *MockSaptune) RevertSolution(ctx context.Context, solution string) error {
+	ret := _m.Called(ctx, solution)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertSolution")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, solution)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSaptune_RevertSolution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevertSolution'
+type MockSaptune_RevertSolution_Call struct {
+	*mock.Call
+}
+
+// RevertSolution is a helper method to define mock.On call
+//   - ctx context.Context
+//   - solution string
+func (_e *MockSaptune_Expecter) RevertSolution(ctx interface{}, solution interface{}) *MockSaptune_RevertSolution_Call {
+	return &MockSaptune_RevertSolution_Call{Call: _e.mock.On("RevertSolution", ctx, solution)}
+}
+
+func (_c *MockSaptune_RevertSolution_Call) Run(run func(ctx context.Context, solution string)) *MockSaptune_RevertSolution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_RevertSolution_Call) Return(_a0 error) *MockSaptune_RevertSolution_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSaptune_RevertSolution_Call) RunAndReturn(run func(context.Context, string) error) *MockSaptune_RevertSolution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevertStagingRelease provides a mock function with given fields: ctx, snapshot
+func (_m *MockSaptune) RevertStagingRelease(ctx context.Context, snapshot *saptune.StagingSnapshot) error {
+	ret := _m.Called(ctx, snapshot)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertStagingRelease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *saptune.StagingSnapshot) error); ok {
+		r0 = rf(ctx, snapshot)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSaptune_RevertStagingRelease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevertStagingRelease'
+type MockSaptune_RevertStagingRelease_Call struct {
+	*mock.Call
+}
+
+// RevertStagingRelease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - snapshot *saptune.StagingSnapshot
+func (_e *MockSaptune_Expecter) RevertStagingRelease(ctx interface{}, snapshot interface{}) *MockSaptune_RevertStagingRelease_Call {
+	return &MockSaptune_RevertStagingRelease_Call{Call: _e.mock.On("RevertStagingRelease", ctx, snapshot)}
+}
+
+func (_c *MockSaptune_RevertStagingRelease_Call) Run(run func(ctx context.Context, snapshot *saptune.StagingSnapshot)) *MockSaptune_RevertStagingRelease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*saptune.StagingSnapshot))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_RevertStagingRelease_Call) Return(_a0 error) *MockSaptune_RevertStagingRelease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSaptune_RevertStagingRelease_Call) RunAndReturn(run func(context.Context, *saptune.StagingSnapshot) error) *MockSaptune_RevertStagingRelease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SnapshotStaging provides a mock function with given fields: ctx, item
+func (_m *MockSaptune) SnapshotStaging(ctx context.Context, item saptune.StagedItem) (*saptune.StagingSnapshot, error) {
+	ret := _m.Called(ctx, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SnapshotStaging")
+	}
+
+	var r0 *saptune.StagingSnapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, saptune.StagedItem) (*saptune.StagingSnapshot, error)); ok {
+		return rf(ctx, item)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, saptune.StagedItem) *saptune.StagingSnapshot); ok {
+		r0 = rf(ctx, item)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*saptune.StagingSnapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, saptune.StagedItem) error); ok {
+		r1 = rf(ctx, item)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSaptune_SnapshotStaging_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SnapshotStaging'
+type MockSaptune_SnapshotStaging_Call struct {
+	*mock.Call
+}
+
+// SnapshotStaging is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item saptune.StagedItem
+func (_e *MockSaptune_Expecter) SnapshotStaging(ctx interface{}, item interface{}) *MockSaptune_SnapshotStaging_Call {
+	return &MockSaptune_SnapshotStaging_Call{Call: _e.mock.On("SnapshotStaging", ctx, item)}
+}
+
+func (_c *MockSaptune_SnapshotStaging_Call) Run(run func(ctx context.Context, item saptune.StagedItem)) *MockSaptune_SnapshotStaging_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(saptune.StagedItem))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_SnapshotStaging_Call) Return(_a0 *saptune.StagingSnapshot, _a1 error) *MockSaptune_SnapshotStaging_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSaptune_SnapshotStaging_Call) RunAndReturn(run func(context.Context, saptune.StagedItem) (*saptune.StagingSnapshot, error)) *MockSaptune_SnapshotStaging_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function with given fields: ctx
+func (_m *MockSaptune) Verify(ctx context.Context) (*saptune.VerifyResult, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 *saptune.VerifyResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*saptune.VerifyResult, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *saptune.VerifyResult); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*saptune.VerifyResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSaptune_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockSaptune_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSaptune_Expecter) Verify(ctx interface{}) *MockSaptune_Verify_Call {
+	return &MockSaptune_Verify_Call{Call: _e.mock.On("Verify", ctx)}
+}
+
+func (_c *MockSaptune_Verify_Call) Run(run func(ctx context.Context)) *MockSaptune_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_Verify_Call) Return(_a0 *saptune.VerifyResult, _a1 error) *MockSaptune_Verify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSaptune_Verify_Call) RunAndReturn(run func(context.Context) (*saptune.VerifyResult, error)) *MockSaptune_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyNote provides a mock function with given fields: ctx, noteID
+func (_m *MockSaptune) VerifyNote(ctx context.Context, noteID string) (*saptune.VerifyResult, error) {
+	ret := _m.Called(ctx, noteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyNote")
+	}
+
+	var r0 *saptune.VerifyResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*saptune.VerifyResult, error)); ok {
+		return rf(ctx, noteID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *saptune.VerifyResult); ok {
+		r0 = rf(ctx, noteID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*saptune.VerifyResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, noteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSaptune_VerifyNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyNote'
+type MockSaptune_VerifyNote_Call struct {
+	*mock.Call
+}
+
+// VerifyNote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - noteID string
+func (_e *MockSaptune_Expecter) VerifyNote(ctx interface{}, noteID interface{}) *MockSaptune_VerifyNote_Call {
+	return &MockSaptune_VerifyNote_Call{Call: _e.mock.On("VerifyNote", ctx, noteID)}
+}
+
+func (_c *MockSaptune_VerifyNote_Call) Run(run func(ctx context.Context, noteID string)) *MockSaptune_VerifyNote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_VerifyNote_Call) Return(_a0 *saptune.VerifyResult, _a1 error) *MockSaptune_VerifyNote_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSaptune_VerifyNote_Call) RunAndReturn(run func(context.Context, string) (*saptune.VerifyResult, error)) *MockSaptune_VerifyNote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WriteNoteOverride provides a mock function with given fields: ctx, noteID, content
+func (_m *MockSaptune) WriteNoteOverride(ctx context.Context, noteID string, content []byte) error {
+	ret := _m.Called(ctx, noteID, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteNoteOverride")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = rf(ctx, noteID, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSaptune_WriteNoteOverride_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteNoteOverride'
+type MockSaptune_WriteNoteOverride_Call struct {
+	*mock.Call
+}
+
+// WriteNoteOverride is a helper method to define mock.On call
+//   - ctx context.Context
+//   - noteID string
+//   - content []byte
+func (_e *MockSaptune_Expecter) WriteNoteOverride(ctx interface{}, noteID interface{}, content interface{}) *MockSaptune_WriteNoteOverride_Call {
+	return &MockSaptune_WriteNoteOverride_Call{Call: _e.mock.On("WriteNoteOverride", ctx, noteID, content)}
+}
+
+func (_c *MockSaptune_WriteNoteOverride_Call) Run(run func(ctx context.Context, noteID string, content []byte)) *MockSaptune_WriteNoteOverride_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockSaptune_WriteNoteOverride_Call) Return(_a0 error) *MockSaptune_WriteNoteOverride_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSaptune_WriteNoteOverride_Call) RunAndReturn(run func(context.Context, string, []byte) error) *MockSaptune_WriteNoteOverride_Call {
 	_c.Call.Return(run)
 	return _c
 }
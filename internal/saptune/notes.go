@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package saptune
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Note is a saptune note available in the system
+type Note struct {
+	ID                string
+	Description       string
+	Version           string
+	ReleaseDate       string
+	Enabled           bool
+	EnabledManually   bool
+	EnabledBySolution bool
+	RevertedManually  bool
+	Custom            bool
+	OverrideExists    bool
+}
+
+func (saptune *saptuneClient) ListNotes(ctx context.Context) ([]Note, error) {
+	result, err := saptune.execJSON(ctx, false, "note", "list")
+	if err != nil {
+		return nil, err
+	}
+
+	enabledNotes := stringArray(result.Get("Notes enabled"))
+
+	notes := []Note{}
+	for _, note := range result.Get("Notes available").Array() {
+		noteID := note.Get("Note ID").String()
+		notes = append(notes, Note{
+			ID:                noteID,
+			Description:       note.Get("Note description").String(),
+			Version:           note.Get("Note version").String(),
+			ReleaseDate:       note.Get("Note release date").String(),
+			Enabled:           slices.Contains(enabledNotes, noteID),
+			EnabledManually:   note.Get("Note enabled manually").Bool(),
+			EnabledBySolution: note.Get("Note enabled by Solution").Bool(),
+			RevertedManually:  note.Get("Note reverted manually").Bool(),
+			Custom:            note.Get("custom Note").Bool(),
+			OverrideExists:    note.Get("Note override exists").Bool(),
+		})
+	}
+
+	return notes, nil
+}
+
+func (saptune *saptuneClient) GetAppliedNotes(ctx context.Context) ([]string, error) {
+	result, err := saptune.execJSON(ctx, false, "note", "applied")
+	if err != nil {
+		return nil, err
+	}
+
+	return stringArray(result.Get("Notes applied")), nil
+}
+
+func (saptune *saptuneClient) ApplyNote(ctx context.Context, noteID string) error {
+	applyOutput, err := saptune.executor.Exec(ctx, "saptune", "note", "apply", noteID)
+	if err != nil {
+		saptune.logger.Error("could not perform saptune note apply",
+			"note", noteID,
+			"error_output", applyOutput)
+
+		return fmt.Errorf("could not perform saptune note apply %s, error: %w",
+			noteID,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (saptune *saptuneClient) RevertNote(ctx context.Context, noteID string) error {
+	revertOutput, err := saptune.executor.Exec(ctx, "saptune", "note", "revert", noteID)
+	if err != nil {
+		saptune.logger.Error("could not perform saptune note revert", "note", noteID, "error_output", revertOutput)
+
+		return fmt.Errorf("could not perform saptune note revert %s, error: %w",
+			noteID,
+			err,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package saptune_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/trento-project/workbench/internal/saptune"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+func (suite *SaptuneClientTestSuite) TestListNotes() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"--format",
+		"json",
+		"note",
+		"list",
+	).Return(helpers.ReadFixture("saptune/note_list.json"), nil)
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	notes, err := saptuneClient.ListNotes(ctx)
+
+	suite.NoError(err)
+	suite.Equal([]saptune.Note{
+		{
+			ID:          "1410736",
+			Description: "TCP/IP: setting keepalive interval",
+			Version:     "6",
+			ReleaseDate: "13.01.2020",
+		},
+		{
+			ID:                "1656250",
+			Description:       "SAP on AWS: Support prerequisites - only Linux Operating System IO Recommendations",
+			Version:           "46",
+			ReleaseDate:       "11.05.2022",
+			Enabled:           true,
+			EnabledBySolution: true,
+		},
+		{
+			ID:                "941735",
+			Description:       "SAP memory management system for 64-bit Linux systems",
+			Version:           "11",
+			ReleaseDate:       "14.10.2022",
+			Enabled:           true,
+			EnabledBySolution: true,
+			OverrideExists:    true,
+		},
+		{
+			ID:              "1868829",
+			Description:     "Startup Issues with SLES 11 SP2 and SP3",
+			Version:         "2",
+			ReleaseDate:     "10.06.2015",
+			Enabled:         true,
+			EnabledManually: true,
+		},
+	}, notes)
+}
+
+func (suite *SaptuneClientTestSuite) TestGetAppliedNotes() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"--format",
+		"json",
+		"note",
+		"applied",
+	).Return(helpers.ReadFixture("saptune/note_applied.json"), nil)
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	notes, err := saptuneClient.GetAppliedNotes(ctx)
+
+	suite.NoError(err)
+	suite.Equal([]string{"941735", "1656250", "1868829"}, notes)
+}
+
+func (suite *SaptuneClientTestSuite) TestGetAppliedNotesInvalidOutput() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"--format",
+		"json",
+		"note",
+		"applied",
+	).Return([]byte("941735 1656250"), nil)
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	notes, err := saptuneClient.GetAppliedNotes(ctx)
+
+	suite.EqualError(err, "could not parse saptune note applied output: invalid json")
+	suite.Nil(notes)
+}
+
+func (suite *SaptuneClientTestSuite) TestApplyNote() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"note",
+		"apply",
+		"1868829",
+	).Return(helpers.ReadFixture("saptune/apply_note_success.output"), nil)
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"note",
+		"apply",
+		"123",
+	).Return(helpers.ReadFixture("saptune/apply_note_unknown.output"), errors.New("exit status 1"))
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+
+	suite.NoError(saptuneClient.ApplyNote(ctx, "1868829"))
+	suite.EqualError(
+		saptuneClient.ApplyNote(ctx, "123"),
+		"could not perform saptune note apply 123, error: exit status 1",
+	)
+}
+
+func (suite *SaptuneClientTestSuite) TestRevertNote() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"note",
+		"revert",
+		"1868829",
+	).Return(helpers.ReadFixture("saptune/revert_note_success.output"), nil).Once()
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"note",
+		"revert",
+		"1868829",
+	).Return(nil, errors.New("error calling saptune")).Once()
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+
+	suite.NoError(saptuneClient.RevertNote(ctx, "1868829"))
+	suite.EqualError(
+		saptuneClient.RevertNote(ctx, "1868829"),
+		"could not perform saptune note revert 1868829, error: error calling saptune",
+	)
+}
@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package saptune
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const DefaultOverrideDirectory = "/etc/saptune/override"
+
+// nonTunableSections are note definition sections that don't contain tuning parameters
+var nonTunableSections = []string{"version", "reminder"}
+
+// noteIDPatternCompiled matches the note IDs that can be used as file names in the override directory
+var noteIDPatternCompiled = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// NoteSection is a section of a note definition or override file, e.g. [sysctl]
+type NoteSection struct {
+	Name       string
+	Parameters []NoteParameter
+}
+
+type NoteParameter struct {
+	Name  string
+	Value string
+}
+
+// GetNoteDefinition returns the tuning sections of a note, as shown by saptune note show.
+// The version and reminder sections are not included.
+func (saptune *saptuneClient) GetNoteDefinition(ctx context.Context, noteID string) ([]NoteSection, error) {
+	showOutput, err := saptune.executor.Exec(ctx, "saptune", "note", "show", noteID)
+	if err != nil {
+		saptune.logger.Error("could not perform saptune note show", "note", noteID, "error_output", showOutput)
+
+		return nil, fmt.Errorf("could not perform saptune note show %s, error: %w",
+			noteID,
+			err,
+		)
+	}
+
+	sections := []NoteSection{}
+	for _, section := range ParseNoteFile(showOutput) {
+		if !slices.Contains(nonTunableSections, section.Name) {
+			sections = append(sections, section)
+		}
+	}
+
+	return sections, nil
+}
+
+// GetNoteOverride returns the content of the note override file.
+// The found return value is false if the note doesn't have an override file.
+func (saptune *saptuneClient) GetNoteOverride(_ context.Context, noteID string) ([]byte, bool, error) {
+	path, err := saptune.overridePath(noteID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("could not read saptune override file %s: %w", path, err)
+	}
+
+	return content, true, nil
+}
+
+func (saptune *saptuneClient) WriteNoteOverride(_ context.Context, noteID string, content []byte) error {
+	path, err := saptune.overridePath(noteID)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(saptune.paths.OverrideDirectory, 0o755); err != nil {
+		return fmt.Errorf("could not create saptune override directory %s: %w", saptune.paths.OverrideDirectory, err)
+	}
+
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("could not write saptune override file %s: %w", path, err)
+	}
+
+	saptune.logger.Info("saptune override file written", "note", noteID, "path", path)
+	return nil
+}
+
+// DeleteNoteOverride removes the note override file. Nothing is done if the file doesn't exist.
+func (saptune *saptuneClient) DeleteNoteOverride(_ context.Context, noteID string) error {
+	path, err := saptune.overridePath(noteID)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not remove saptune override file %s: %w", path, err)
+	}
+
+	saptune.logger.Info("saptune override file removed", "note", noteID, "path", path)
+	return nil
+}
+
+func (saptune *saptuneClient) overridePath(noteID string) (string, error) {
+	if err := ValidateNoteID(noteID); err != nil {
+		return "", err
+	}
+	return filepath.Join(saptune.paths.OverrideDirectory, noteID), nil
+}
+
+// ValidateNoteID checks that the note ID can be used as a file name in the saptune directories
+func ValidateNoteID(noteID string) error {
+	if !noteIDPatternCompiled.MatchString(noteID) {
+		return fmt.Errorf("invalid note ID %q, only letters, numbers, dots, dashes and underscores are allowed", noteID)
+	}
+	return nil
+}
+
+// ParseNoteFile parses the ini like format of the note definition and override files.
+// Comments and lines outside of a section are ignored.
+func ParseNoteFile(content []byte) []NoteSection {
+	sections := []NoteSection{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			sections = append(sections, NoteSection{
+				Name:       strings.TrimSuffix(strings.TrimPrefix(line, "["), "]"),
+				Parameters: []NoteParameter{},
+			})
+		case len(sections) > 0:
+			name, value, _ := strings.Cut(line, "=")
+			current := &sections[len(sections)-1]
+			current.Parameters = append(current.Parameters, NoteParameter{
+				Name:  strings.TrimSpace(name),
+				Value: strings.TrimSpace(value),
+			})
+		}
+	}
+
+	return sections
+}
+
+// FormatNoteFile builds the content of an override file. Sections without parameters are skipped.
+// Section names, parameter names and values with line breaks are rejected, as they would add new entries.
+func FormatNoteFile(sections []NoteSection) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	for _, section := range sections {
+		if len(section.Parameters) == 0 {
+			continue
+		}
+
+		if containsLineBreak(section.Name) {
+			return nil, fmt.Errorf("invalid section name %q, line breaks are not allowed", section.Name)
+		}
+
+		if buffer.Len() > 0 {
+			buffer.WriteString("\n")
+		}
+		fmt.Fprintf(&buffer, "[%s]\n", section.Name)
+		for _, parameter := range section.Parameters {
+			if containsLineBreak(parameter.Name) || containsLineBreak(parameter.Value) {
+				return nil, fmt.Errorf("invalid parameter %q, line breaks are not allowed", parameter.Name)
+			}
+			fmt.Fprintf(&buffer, "%s=%s\n", parameter.Name, parameter.Value)
+		}
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func containsLineBreak(value string) bool {
+	return strings.ContainsAny(value, "\r\n")
+}
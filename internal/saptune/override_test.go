@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package saptune_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/trento-project/workbench/internal/saptune"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+func (suite *SaptuneClientTestSuite) TestGetNoteDefinition() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"note",
+		"show",
+		"941735",
+	).Return(helpers.ReadFixture("saptune/note_show_941735.output"), nil)
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	sections, err := saptuneClient.GetNoteDefinition(ctx, "941735")
+
+	suite.NoError(err)
+	suite.Equal([]saptune.NoteSection{
+		{
+			Name: "mem",
+			Parameters: []saptune.NoteParameter{
+				{Name: "ShmFileSystemSizeMB", Value: "0"},
+				{Name: "VSZ_TMPFS_PERCENT", Value: "75"},
+			},
+		},
+		{
+			Name: "sysctl",
+			Parameters: []saptune.NoteParameter{
+				{Name: "kernel.shmall", Value: "1152921504606846720"},
+				{Name: "kernel.shmmax", Value: "18446744073709551615"},
+			},
+		},
+	}, sections)
+}
+
+func (suite *SaptuneClientTestSuite) TestGetNoteDefinitionFailure() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"note",
+		"show",
+		"123",
+	).Return([]byte(`ERROR: the Note ID "123" is not recognised by saptune.`), errors.New("exit status 1"))
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	sections, err := saptuneClient.GetNoteDefinition(ctx, "123")
+
+	suite.EqualError(err, "could not perform saptune note show 123, error: exit status 1")
+	suite.Nil(sections)
+}
+
+func (suite *SaptuneClientTestSuite) TestNoteOverrideLifecycle() {
+	ctx := context.Background()
+	overrideDirectory := filepath.Join(suite.T().TempDir(), "override")
+
+	saptuneClient := saptune.NewSaptuneClientWithPaths(
+		suite.mockExecutor,
+		saptune.Paths{OverrideDirectory: overrideDirectory},
+		suite.logger,
+	)
+
+	content, found, err := saptuneClient.GetNoteOverride(ctx, "941735")
+	suite.NoError(err)
+	suite.False(found)
+	suite.Nil(content)
+
+	suite.NoError(saptuneClient.WriteNoteOverride(ctx, "941735", []byte("[sysctl]\nkernel.shmmax=68719476736\n")))
+
+	written, err := os.ReadFile(filepath.Join(overrideDirectory, "941735"))
+	suite.NoError(err)
+	suite.Equal("[sysctl]\nkernel.shmmax=68719476736\n", string(written))
+
+	content, found, err = saptuneClient.GetNoteOverride(ctx, "941735")
+	suite.NoError(err)
+	suite.True(found)
+	suite.Equal(written, content)
+
+	suite.NoError(saptuneClient.DeleteNoteOverride(ctx, "941735"))
+	suite.NoError(saptuneClient.DeleteNoteOverride(ctx, "941735"))
+	suite.NoFileExists(filepath.Join(overrideDirectory, "941735"))
+}
+
+func (suite *SaptuneClientTestSuite) TestParseAndFormatNoteFile() {
+	sections := saptune.ParseNoteFile([]byte(
+		"# override for 941735\n[mem]\n\n[sysctl]\nkernel.shmmax = 68719476736\nvm.swappiness=10\n",
+	))
+
+	suite.Equal([]saptune.NoteSection{
+		{Name: "mem", Parameters: []saptune.NoteParameter{}},
+		{
+			Name: "sysctl",
+			Parameters: []saptune.NoteParameter{
+				{Name: "kernel.shmmax", Value: "68719476736"},
+				{Name: "vm.swappiness", Value: "10"},
+			},
+		},
+	}, sections)
+
+	content, err := saptune.FormatNoteFile(append(sections, saptune.NoteSection{
+		Name:       "block",
+		Parameters: []saptune.NoteParameter{{Name: "IO_SCHEDULER", Value: "none"}},
+	}))
+	suite.NoError(err)
+	suite.Equal(
+		"[sysctl]\nkernel.shmmax=68719476736\nvm.swappiness=10\n\n[block]\nIO_SCHEDULER=none\n",
+		string(content),
+	)
+}
+
+func (suite *SaptuneClientTestSuite) TestFormatNoteFileLineBreaks() {
+	for _, value := range []string{"10\n[block]\nIO_SCHEDULER=none", "10\rIO_SCHEDULER=none"} {
+		content, err := saptune.FormatNoteFile([]saptune.NoteSection{
+			{Name: "sysctl", Parameters: []saptune.NoteParameter{{Name: "vm.swappiness", Value: value}}},
+		})
+		suite.Nil(content)
+		suite.EqualError(err, `invalid parameter "vm.swappiness", line breaks are not allowed`)
+	}
+
+	_, err := saptune.FormatNoteFile([]saptune.NoteSection{
+		{Name: "sysctl]\n[block", Parameters: []saptune.NoteParameter{{Name: "IO_SCHEDULER", Value: "none"}}},
+	})
+	suite.EqualError(err, `invalid section name "sysctl]\n[block", line breaks are not allowed`)
+}
+
+func (suite *SaptuneClientTestSuite) TestNoteOverrideInvalidNoteID() {
+	ctx := context.Background()
+	overrideDirectory := filepath.Join(suite.T().TempDir(), "override")
+
+	saptuneClient := saptune.NewSaptuneClientWithPaths(
+		suite.mockExecutor,
+		saptune.Paths{OverrideDirectory: overrideDirectory},
+		suite.logger,
+	)
+
+	for _, noteID := range []string{"../941735", "/etc/passwd", ".hidden", ""} {
+		expectedError := fmt.Sprintf(
+			"invalid note ID %q, only letters, numbers, dots, dashes and underscores are allowed", noteID,
+		)
+
+		_, _, err := saptuneClient.GetNoteOverride(ctx, noteID)
+		suite.EqualError(err, expectedError)
+		suite.EqualError(saptuneClient.WriteNoteOverride(ctx, noteID, []byte("[sysctl]\n")), expectedError)
+		suite.EqualError(saptuneClient.DeleteNoteOverride(ctx, noteID), expectedError)
+	}
+
+	suite.NoDirExists(overrideDirectory)
+}
@@ -20,22 +20,76 @@ const minimalSaptuneVersion = "v3.1.0"
 type Saptune interface {
 	CheckVersionSupport(ctx context.Context) error
 	GetAppliedSolution(ctx context.Context) (string, error)
+	ListSolutions(ctx context.Context) ([]Solution, error)
 	ApplySolution(ctx context.Context, solution string) error
 	ChangeSolution(ctx context.Context, solution string) error
 	RevertSolution(ctx context.Context, solution string) error
+	ListNotes(ctx context.Context) ([]Note, error)
+	GetAppliedNotes(ctx context.Context) ([]string, error)
+	ApplyNote(ctx context.Context, noteID string) error
+	RevertNote(ctx context.Context, noteID string) error
+	Verify(ctx context.Context) (*VerifyResult, error)
+	VerifyNote(ctx context.Context, noteID string) (*VerifyResult, error)
+	GetStatus(ctx context.Context) (*Status, error)
+	GetTuningProfile(ctx context.Context) (*TuningProfile, error)
+	CheckServiceStatus(ctx context.Context) error
+	GetActiveTunedProfile(ctx context.Context) (string, error)
+	GetNoteDefinition(ctx context.Context, noteID string) ([]NoteSection, error)
+	GetNoteOverride(ctx context.Context, noteID string) ([]byte, bool, error)
+	WriteNoteOverride(ctx context.Context, noteID string, content []byte) error
+	DeleteNoteOverride(ctx context.Context, noteID string) error
+	ListStaging(ctx context.Context) ([]StagedItem, error)
+	GetStagingDiff(ctx context.Context, id string) (string, error)
+	ReleaseStaging(ctx context.Context, ids ...string) error
+	SnapshotStaging(ctx context.Context, item StagedItem) (*StagingSnapshot, error)
+	RevertStagingRelease(ctx context.Context, snapshot *StagingSnapshot) error
+	GetWorkingDefinition(ctx context.Context, item StagedItem) ([]byte, bool, error)
+}
+
+// Solution is a saptune solution available in the system
+type Solution struct {
+	ID             string
+	Notes          []string
+	Enabled        bool
+	Custom         bool
+	Deprecated     bool
+	OverrideExists bool
+}
+
+// Paths are the saptune directories managed by the client
+type Paths struct {
+	// OverrideDirectory contains the note override files
+	OverrideDirectory string
+	// DataDirectory contains the working and staging areas of note and solution definitions
+	DataDirectory string
+}
+
+var DefaultPaths = Paths{
+	OverrideDirectory: DefaultOverrideDirectory,
+	DataDirectory:     DefaultDataDirectory,
 }
 
 type saptuneClient struct {
 	executor support.CmdExecutor
+	paths    Paths
 	logger   *slog.Logger
 }
 
 func NewSaptuneClient(
 	executor support.CmdExecutor,
 	logger *slog.Logger,
+) Saptune {
+	return NewSaptuneClientWithPaths(executor, DefaultPaths, logger)
+}
+
+func NewSaptuneClientWithPaths(
+	executor support.CmdExecutor,
+	paths Paths,
+	logger *slog.Logger,
 ) Saptune {
 	return &saptuneClient{
 		executor: executor,
+		paths:    paths,
 		logger:   logger,
 	}
 }
@@ -72,6 +126,27 @@ func (saptune *saptuneClient) GetAppliedSolution(ctx context.Context) (string, e
 	return gjson.GetBytes(solutionAppliedOutput, "result.Solution applied.0.Solution ID").String(), nil
 }
 
+func (saptune *saptuneClient) ListSolutions(ctx context.Context) ([]Solution, error) {
+	result, err := saptune.execJSON(ctx, false, "solution", "list")
+	if err != nil {
+		return nil, err
+	}
+
+	solutions := []Solution{}
+	for _, solution := range result.Get("Solutions available").Array() {
+		solutions = append(solutions, Solution{
+			ID:             solution.Get("Solution ID").String(),
+			Notes:          stringArray(solution.Get("Note list")),
+			Enabled:        solution.Get("Solution enabled").Bool(),
+			Custom:         solution.Get("custom Solution").Bool(),
+			Deprecated:     solution.Get("Solution deprecated").Bool(),
+			OverrideExists: solution.Get("Solution override exists").Bool(),
+		})
+	}
+
+	return solutions, nil
+}
+
 func (saptune *saptuneClient) ApplySolution(ctx context.Context, solution string) error {
 	applyOutput, err := saptune.executor.Exec(ctx, "saptune", "solution", "apply", solution)
 	if err != nil {
@@ -118,6 +193,41 @@ func (saptune *saptuneClient) RevertSolution(ctx context.Context, solution strin
 	return nil
 }
 
+// execJSON runs a saptune command with json output and returns its result field.
+// Some commands, like verify or status, exit with a non zero code when the system is not compliant,
+// the output is still parsed in that case if allowExitCode is true.
+func (saptune *saptuneClient) execJSON(
+	ctx context.Context,
+	allowExitCode bool,
+	args ...string,
+) (gjson.Result, error) {
+	command := strings.Join(args, " ")
+	output, err := saptune.executor.Exec(ctx, "saptune", append([]string{"--format", "json"}, args...)...)
+	if err != nil && (!allowExitCode || !gjson.ValidBytes(output)) {
+		saptune.logger.Error("could not call saptune", "command", command, "error_output", output)
+		return gjson.Result{}, fmt.Errorf("could not call saptune %s: %w", command, err)
+	}
+
+	if !gjson.ValidBytes(output) {
+		return gjson.Result{}, fmt.Errorf("could not parse saptune %s output: invalid json", command)
+	}
+
+	result := gjson.GetBytes(output, "result")
+	if !result.IsObject() {
+		return gjson.Result{}, fmt.Errorf("could not parse saptune %s output: result not found", command)
+	}
+
+	return result, nil
+}
+
+func stringArray(value gjson.Result) []string {
+	values := []string{}
+	for _, item := range value.Array() {
+		values = append(values, item.String())
+	}
+	return values
+}
+
 func isSaptuneVersionSupported(version string) bool {
 	compareOutput := semver.Compare(minimalSaptuneVersion, "v"+strings.TrimSpace(version))
 
@@ -364,3 +364,56 @@ func (suite *SaptuneClientTestSuite) TestChangeSolutionSucceess() {
 		suite.NoError(err)
 	}
 }
+
+func (suite *SaptuneClientTestSuite) TestListSolutions() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"--format",
+		"json",
+		"solution",
+		"list",
+	).Return(helpers.ReadFixture("saptune/solution_list.json"), nil)
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	solutions, err := saptuneClient.ListSolutions(ctx)
+
+	suite.NoError(err)
+	suite.Len(solutions, 3)
+	suite.Equal(saptune.Solution{
+		ID:      "HANA",
+		Notes:   []string{"941735", "1771258", "1980196", "2578899", "2684254", "2382421", "2534844", "2993054", "1656250"},
+		Enabled: true,
+	}, solutions[1])
+	suite.False(solutions[0].Enabled)
+	suite.True(solutions[2].OverrideExists)
+}
+
+func (suite *SaptuneClientTestSuite) TestListSolutionsFailure() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"--format",
+		"json",
+		"solution",
+		"list",
+	).Return([]byte("saptune: command not found"), errors.New("exit status 127"))
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	solutions, err := saptuneClient.ListSolutions(ctx)
+
+	suite.EqualError(err, "could not call saptune solution list: exit status 127")
+	suite.Nil(solutions)
+}
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package saptune
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	DefaultDataDirectory = "/var/lib/saptune"
+	solutionFileSuffix   = ".sol"
+)
+
+type StagedItemType string
+
+const (
+	StagedNote     StagedItemType = "note"
+	StagedSolution StagedItemType = "solution"
+)
+
+// StagedItem is a note or solution definition waiting in the staging area to be released.
+// Notes is only set for solutions.
+type StagedItem struct {
+	ID          string
+	Type        StagedItemType
+	Description string
+	Version     string
+	ReleaseDate string
+	Notes       []string
+}
+
+// StagingSnapshot is the content of the working and staged definitions of a staged item
+// before it is released. Working is nil if the item doesn't exist in the working area.
+type StagingSnapshot struct {
+	Item    StagedItem
+	Working []byte
+	Staged  []byte
+}
+
+func (saptune *saptuneClient) ListStaging(ctx context.Context) ([]StagedItem, error) {
+	result, err := saptune.execJSON(ctx, false, "staging", "list")
+	if err != nil {
+		return nil, err
+	}
+
+	items := []StagedItem{}
+	for _, note := range result.Get("Notes staged").Array() {
+		items = append(items, StagedItem{
+			ID:          note.Get("Note ID").String(),
+			Type:        StagedNote,
+			Description: note.Get("Note description").String(),
+			Version:     note.Get("Note version").String(),
+			ReleaseDate: note.Get("Note release date").String(),
+		})
+	}
+
+	for _, solution := range result.Get("Solutions staged").Array() {
+		items = append(items, StagedItem{
+			ID:    solution.Get("Solution ID").String(),
+			Type:  StagedSolution,
+			Notes: stringArray(solution.Get("Note list")),
+		})
+	}
+
+	return items, nil
+}
+
+// GetStagingDiff returns the differences between the working and the staged definition of an item
+func (saptune *saptuneClient) GetStagingDiff(ctx context.Context, id string) (string, error) {
+	diffOutput, err := saptune.executor.Exec(ctx, "saptune", "staging", "diff", id)
+	if err != nil {
+		saptune.logger.Error("could not perform saptune staging diff", "id", id, "error_output", diffOutput)
+
+		return "", fmt.Errorf("could not perform saptune staging diff %s, error: %w",
+			id,
+			err,
+		)
+	}
+
+	return strings.TrimSpace(string(diffOutput)), nil
+}
+
+func (saptune *saptuneClient) ReleaseStaging(ctx context.Context, ids ...string) error {
+	releaseOutput, err := saptune.executor.Exec(
+		ctx, "saptune", append([]string{"staging", "release", "--force"}, ids...)...,
+	)
+	if err != nil {
+		saptune.logger.Error("could not perform saptune staging release",
+			"ids", ids,
+			"error_output", releaseOutput)
+
+		return fmt.Errorf("could not perform saptune staging release %s, error: %w",
+			strings.Join(ids, " "),
+			err,
+		)
+	}
+
+	return nil
+}
+
+// SnapshotStaging reads the working and staged definitions of an item, so the release can be reverted
+func (saptune *saptuneClient) SnapshotStaging(ctx context.Context, item StagedItem) (*StagingSnapshot, error) {
+	working, found, err := saptune.GetWorkingDefinition(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		working = nil
+	}
+
+	stagedPath := saptune.stagedDefinitionPath(item)
+	staged, err := os.ReadFile(stagedPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read staged definition %s: %w", stagedPath, err)
+	}
+
+	return &StagingSnapshot{Item: item, Working: working, Staged: staged}, nil
+}
+
+// RevertStagingRelease restores the working definition of a released item and puts it back
+// in the staging area. saptune doesn't provide a command to revert a release.
+func (saptune *saptuneClient) RevertStagingRelease(_ context.Context, snapshot *StagingSnapshot) error {
+	workingPath := saptune.workingDefinitionPath(snapshot.Item)
+	if snapshot.Working == nil {
+		if err := os.Remove(workingPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("could not remove working definition %s: %w", workingPath, err)
+		}
+	} else if err := writeDefinition(workingPath, snapshot.Working); err != nil {
+		return err
+	}
+
+	if err := writeDefinition(saptune.stagedDefinitionPath(snapshot.Item), snapshot.Staged); err != nil {
+		return err
+	}
+
+	saptune.logger.Info("saptune staging release reverted", "id", snapshot.Item.ID, "type", snapshot.Item.Type)
+	return nil
+}
+
+// GetWorkingDefinition returns the active definition of a note or solution.
+// The found return value is false if the item doesn't exist in the working area.
+func (saptune *saptuneClient) GetWorkingDefinition(_ context.Context, item StagedItem) ([]byte, bool, error) {
+	path := saptune.workingDefinitionPath(item)
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("could not read working definition %s: %w", path, err)
+	}
+
+	return content, true, nil
+}
+
+func (saptune *saptuneClient) workingDefinitionPath(item StagedItem) string {
+	if item.Type == StagedSolution {
+		return filepath.Join(saptune.paths.DataDirectory, "working", "sols", item.ID+solutionFileSuffix)
+	}
+	return filepath.Join(saptune.paths.DataDirectory, "working", "notes", item.ID)
+}
+
+func (saptune *saptuneClient) stagedDefinitionPath(item StagedItem) string {
+	fileName := item.ID
+	if item.Type == StagedSolution {
+		fileName += solutionFileSuffix
+	}
+	return filepath.Join(saptune.paths.DataDirectory, "staging", "latest", fileName)
+}
+
+func writeDefinition(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("could not create directory %s: %w", filepath.Dir(path), err)
+	}
+
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("could not write definition %s: %w", path, err)
+	}
+
+	return nil
+}
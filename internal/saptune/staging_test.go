@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package saptune_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/trento-project/workbench/internal/saptune"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+func (suite *SaptuneClientTestSuite) TestListStaging() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"--format",
+		"json",
+		"staging",
+		"list",
+	).Return(helpers.ReadFixture("saptune/staging_list.json"), nil)
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	items, err := saptuneClient.ListStaging(ctx)
+
+	suite.NoError(err)
+	suite.Equal([]saptune.StagedItem{
+		{
+			ID:          "1656250",
+			Type:        saptune.StagedNote,
+			Description: "SAP on AWS: Support prerequisites - only Linux Operating System IO Recommendations",
+			Version:     "47",
+			ReleaseDate: "02.04.2025",
+		},
+		{
+			ID:   "HANA",
+			Type: saptune.StagedSolution,
+			Notes: []string{
+				"941735", "1771258", "1980196", "2578899", "2684254", "2382421", "2534844", "2993054", "1656250", "3565382",
+			},
+		},
+	}, items)
+}
+
+func (suite *SaptuneClientTestSuite) TestGetStagingDiff() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"staging",
+		"diff",
+		"1656250",
+	).Return(helpers.ReadFixture("saptune/staging_diff_1656250.output"), nil)
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	diff, err := saptuneClient.GetStagingDiff(ctx, "1656250")
+
+	suite.NoError(err)
+	suite.Contains(diff, "1656250  NRREQ_xvd        1024                2048")
+}
+
+func (suite *SaptuneClientTestSuite) TestReleaseStaging() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"staging",
+		"release",
+		"--force",
+		"1656250",
+		"HANA",
+	).Return([]byte(""), nil).Once()
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"staging",
+		"release",
+		"--force",
+		"123",
+	).Return([]byte("ERROR: '123' not found in staging area"), errors.New("exit status 1")).Once()
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+
+	suite.NoError(saptuneClient.ReleaseStaging(ctx, "1656250", "HANA"))
+	suite.EqualError(
+		saptuneClient.ReleaseStaging(ctx, "123"),
+		"could not perform saptune staging release 123, error: exit status 1",
+	)
+}
+
+func (suite *SaptuneClientTestSuite) TestSnapshotAndRevertStagingRelease() {
+	ctx := context.Background()
+	dataDirectory := suite.T().TempDir()
+
+	workingNote := filepath.Join(dataDirectory, "working", "notes", "1656250")
+	stagedNote := filepath.Join(dataDirectory, "staging", "latest", "1656250")
+	workingSolution := filepath.Join(dataDirectory, "working", "sols", "HANA.sol")
+	stagedSolution := filepath.Join(dataDirectory, "staging", "latest", "HANA.sol")
+
+	for path, content := range map[string]string{
+		workingNote:    "VERSION=46\n",
+		stagedNote:     "VERSION=47\n",
+		stagedSolution: "[ArchX86]\n941735 1656250\n",
+	} {
+		suite.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+		suite.Require().NoError(os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	saptuneClient := saptune.NewSaptuneClientWithPaths(
+		suite.mockExecutor,
+		saptune.Paths{DataDirectory: dataDirectory},
+		suite.logger,
+	)
+
+	note := saptune.StagedItem{ID: "1656250", Type: saptune.StagedNote}
+	solution := saptune.StagedItem{ID: "HANA", Type: saptune.StagedSolution}
+
+	noteSnapshot, err := saptuneClient.SnapshotStaging(ctx, note)
+	suite.NoError(err)
+	suite.Equal(&saptune.StagingSnapshot{
+		Item:    note,
+		Working: []byte("VERSION=46\n"),
+		Staged:  []byte("VERSION=47\n"),
+	}, noteSnapshot)
+
+	solutionSnapshot, err := saptuneClient.SnapshotStaging(ctx, solution)
+	suite.NoError(err)
+	suite.Nil(solutionSnapshot.Working)
+
+	// simulate the release done by saptune
+	suite.Require().NoError(os.MkdirAll(filepath.Dir(workingSolution), 0o755))
+	suite.Require().NoError(os.Rename(stagedNote, workingNote))
+	suite.Require().NoError(os.Rename(stagedSolution, workingSolution))
+
+	content, found, err := saptuneClient.GetWorkingDefinition(ctx, note)
+	suite.NoError(err)
+	suite.True(found)
+	suite.Equal("VERSION=47\n", string(content))
+
+	suite.NoError(saptuneClient.RevertStagingRelease(ctx, noteSnapshot))
+	suite.NoError(saptuneClient.RevertStagingRelease(ctx, solutionSnapshot))
+
+	content, err = os.ReadFile(workingNote)
+	suite.NoError(err)
+	suite.Equal("VERSION=46\n", string(content))
+	content, err = os.ReadFile(stagedNote)
+	suite.NoError(err)
+	suite.Equal("VERSION=47\n", string(content))
+	suite.NoFileExists(workingSolution)
+	suite.FileExists(stagedSolution)
+}
+
+func (suite *SaptuneClientTestSuite) TestSnapshotStagingNotStaged() {
+	saptuneClient := saptune.NewSaptuneClientWithPaths(
+		suite.mockExecutor,
+		saptune.Paths{DataDirectory: suite.T().TempDir()},
+		suite.logger,
+	)
+
+	_, err := saptuneClient.SnapshotStaging(
+		context.Background(),
+		saptune.StagedItem{ID: "1656250", Type: saptune.StagedNote},
+	)
+	suite.ErrorContains(err, "could not read staged definition")
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package saptune
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// tuned-adm active output line, e.g. Current active profile: throughput-performance
+var tunedActiveProfilePatternCompiled = regexp.MustCompile(`(?m)^Current active profile:\s*(\S+)`)
+
+// ServiceState is the state of a tuning related systemd service as reported by saptune status.
+// Available is false if the service is not installed.
+type ServiceState struct {
+	Available bool
+	Enabled   bool
+	Active    bool
+}
+
+// StagingStatus is the state of the saptune staging area
+type StagingStatus struct {
+	Enabled   bool
+	Notes     []string
+	Solutions []string
+}
+
+// Status is the result of saptune status
+type Status struct {
+	// Services contains the state of the saptune, sapconf and tuned services
+	Services          map[string]ServiceState
+	SystemdState      string
+	TuningState       string
+	PackageVersion    string
+	ConfiguredVersion string
+	Staging           StagingStatus
+	// OrphanedOverrides are override files without a matching note
+	OrphanedOverrides []string
+}
+
+// TuningProfile is the tuning configured in saptune. It is the configuration applied
+// by saptune.service on boot.
+type TuningProfile struct {
+	Solutions []string
+	// SolutionNotes are the notes enabled by the configured solutions
+	SolutionNotes []string
+	// AdditionalNotes are the notes enabled on top of the solutions
+	AdditionalNotes []string
+	// NoteApplyOrder are all the enabled notes, in the order they are applied
+	NoteApplyOrder []string
+}
+
+func (saptune *saptuneClient) GetStatus(ctx context.Context) (*Status, error) {
+	result, err := saptune.execJSON(ctx, true, "status")
+	if err != nil {
+		return nil, err
+	}
+
+	services := make(map[string]ServiceState)
+	for name, states := range result.Get("services").Map() {
+		serviceStates := stringArray(states)
+		services[name] = ServiceState{
+			Available: len(serviceStates) > 0,
+			Enabled:   slices.Contains(serviceStates, "enabled"),
+			Active:    slices.Contains(serviceStates, "active"),
+		}
+	}
+
+	return &Status{
+		Services:          services,
+		SystemdState:      result.Get("systemd system state").String(),
+		TuningState:       result.Get("tuning state").String(),
+		PackageVersion:    result.Get("package version").String(),
+		ConfiguredVersion: result.Get("configured version").String(),
+		Staging: StagingStatus{
+			Enabled:   result.Get("staging.staging enabled").Bool(),
+			Notes:     stringArray(result.Get("staging.Notes staged")),
+			Solutions: stringArray(result.Get("staging.Solutions staged")),
+		},
+		OrphanedOverrides: stringArray(result.Get("orphaned Overrides")),
+	}, nil
+}
+
+func (saptune *saptuneClient) GetTuningProfile(ctx context.Context) (*TuningProfile, error) {
+	result, err := saptune.execJSON(ctx, true, "status")
+	if err != nil {
+		return nil, err
+	}
+
+	solutionNotes := []string{}
+	for _, solution := range result.Get("Notes enabled by Solution").Array() {
+		for _, note := range stringArray(solution.Get("Note list")) {
+			if !slices.Contains(solutionNotes, note) {
+				solutionNotes = append(solutionNotes, note)
+			}
+		}
+	}
+
+	return &TuningProfile{
+		Solutions:       stringArray(result.Get("Solution enabled")),
+		SolutionNotes:   solutionNotes,
+		AdditionalNotes: stringArray(result.Get("Notes enabled additionally")),
+		NoteApplyOrder:  stringArray(result.Get("Notes enabled")),
+	}, nil
+}
+
+// CheckServiceStatus runs saptune service status, which fails if saptune.service is not
+// enabled and active or if a conflicting tuning service is running
+func (saptune *saptuneClient) CheckServiceStatus(ctx context.Context) error {
+	statusOutput, err := saptune.executor.Exec(ctx, "saptune", "service", "status")
+	if err != nil {
+		saptune.logger.Error("saptune service status failed", "error_output", statusOutput)
+
+		return fmt.Errorf("saptune service status failed: %w, output: %s",
+			err,
+			strings.TrimSpace(string(statusOutput)),
+		)
+	}
+
+	return nil
+}
+
+// GetActiveTunedProfile returns the active tuned profile.
+// An empty profile is returned if tuned is not installed or not running.
+func (saptune *saptuneClient) GetActiveTunedProfile(ctx context.Context) (string, error) {
+	activeOutput, err := saptune.executor.Exec(ctx, "tuned-adm", "active")
+
+	match := tunedActiveProfilePatternCompiled.FindSubmatch(activeOutput)
+	if match == nil {
+		saptune.logger.Debug("no active tuned profile found", "output", string(activeOutput), "error", err)
+		return "", nil
+	}
+
+	return string(match[1]), nil
+}
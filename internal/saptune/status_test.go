@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package saptune_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/trento-project/workbench/internal/saptune"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+func (suite *SaptuneClientTestSuite) TestGetStatus() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"--format",
+		"json",
+		"status",
+	).Return(helpers.ReadFixture("saptune/status.json"), errors.New("exit status 1"))
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	status, err := saptuneClient.GetStatus(ctx)
+
+	suite.NoError(err)
+	suite.Equal(&saptune.Status{
+		Services: map[string]saptune.ServiceState{
+			"saptune": {Available: true, Enabled: true, Active: true},
+			"sapconf": {},
+			"tuned":   {Available: true},
+		},
+		SystemdState:      "running",
+		TuningState:       "not compliant",
+		PackageVersion:    "3.1.4",
+		ConfiguredVersion: "3",
+		Staging: saptune.StagingStatus{
+			Enabled:   true,
+			Notes:     []string{"1656250"},
+			Solutions: []string{},
+		},
+		OrphanedOverrides: []string{"2382421"},
+	}, status)
+}
+
+func (suite *SaptuneClientTestSuite) TestGetTuningProfile() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"--format",
+		"json",
+		"status",
+	).Return(helpers.ReadFixture("saptune/status.json"), errors.New("exit status 1"))
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	profile, err := saptuneClient.GetTuningProfile(ctx)
+
+	suite.NoError(err)
+	suite.Equal(&saptune.TuningProfile{
+		Solutions:       []string{"HANA"},
+		SolutionNotes:   []string{"941735", "1656250"},
+		AdditionalNotes: []string{"1868829"},
+		NoteApplyOrder:  []string{"941735", "1656250", "1868829"},
+	}, profile)
+}
+
+func (suite *SaptuneClientTestSuite) TestGetStatusFailure() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"--format",
+		"json",
+		"status",
+	).Return([]byte(`{"result": []}`), nil)
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	status, err := saptuneClient.GetStatus(ctx)
+
+	suite.EqualError(err, "could not parse saptune status output: result not found")
+	suite.Nil(status)
+}
+
+func (suite *SaptuneClientTestSuite) TestCheckServiceStatus() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"service",
+		"status",
+	).Return([]byte("saptune.service:          enabled/active\n"), nil).Once()
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"service",
+		"status",
+	).Return([]byte("saptune.service:          disabled/inactive\n"), errors.New("exit status 1")).Once()
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+
+	suite.NoError(saptuneClient.CheckServiceStatus(ctx))
+	suite.EqualError(
+		saptuneClient.CheckServiceStatus(ctx),
+		"saptune service status failed: exit status 1, output: saptune.service:          disabled/inactive",
+	)
+}
+
+func (suite *SaptuneClientTestSuite) TestGetActiveTunedProfile() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"tuned-adm",
+		"active",
+	).Return([]byte("Current active profile: throughput-performance\n"), nil).Once()
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"tuned-adm",
+		"active",
+	).Return([]byte("No current active profile.\n"), errors.New("exit status 1")).Once()
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+
+	profile, err := saptuneClient.GetActiveTunedProfile(ctx)
+	suite.NoError(err)
+	suite.Equal("throughput-performance", profile)
+
+	profile, err = saptuneClient.GetActiveTunedProfile(ctx)
+	suite.NoError(err)
+	suite.Empty(profile)
+}
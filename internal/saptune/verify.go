@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package saptune
+
+import (
+	"context"
+
+	"github.com/tidwall/gjson"
+)
+
+// ComplianceRecord is the verification result of a single parameter of a note
+type ComplianceRecord struct {
+	NoteID        string
+	NoteVersion   string
+	Parameter     string
+	ExpectedValue string
+	// OverrideValue is the value set in the note override file, if any
+	OverrideValue string
+	ActualValue   string
+	Compliant     bool
+	// Amendments are the footnotes given by saptune, e.g. when a setting is not supported
+	Amendments []string
+}
+
+// VerifyResult is the result of saptune note verify
+type VerifyResult struct {
+	Compliant bool
+	Records   []ComplianceRecord
+}
+
+// NonCompliantNotes returns the notes with at least one non compliant parameter, in order of appearance
+func (v *VerifyResult) NonCompliantNotes() []string {
+	notes := []string{}
+	seen := make(map[string]bool)
+	for _, record := range v.Records {
+		if record.Compliant || seen[record.NoteID] {
+			continue
+		}
+		seen[record.NoteID] = true
+		notes = append(notes, record.NoteID)
+	}
+	return notes
+}
+
+// Verify checks the compliance of all the enabled notes
+func (saptune *saptuneClient) Verify(ctx context.Context) (*VerifyResult, error) {
+	result, err := saptune.execJSON(ctx, true, "note", "verify")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseVerifyResult(result), nil
+}
+
+// VerifyNote checks the compliance of a single note
+func (saptune *saptuneClient) VerifyNote(ctx context.Context, noteID string) (*VerifyResult, error) {
+	result, err := saptune.execJSON(ctx, true, "note", "verify", noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseVerifyResult(result), nil
+}
+
+func parseVerifyResult(result gjson.Result) *VerifyResult {
+	records := []ComplianceRecord{}
+	for _, verification := range result.Get("verifications").Array() {
+		amendments := []string{}
+		for _, amendment := range verification.Get("amendments").Array() {
+			amendments = append(amendments, amendment.Get("amendment").String())
+		}
+
+		records = append(records, ComplianceRecord{
+			NoteID:        verification.Get("Note ID").String(),
+			NoteVersion:   verification.Get("Note version").String(),
+			Parameter:     verification.Get("parameter").String(),
+			ExpectedValue: verification.Get("expected value").String(),
+			OverrideValue: verification.Get("override value").String(),
+			ActualValue:   verification.Get("actual value").String(),
+			Compliant:     verification.Get("compliant").Bool(),
+			Amendments:    amendments,
+		})
+	}
+
+	return &VerifyResult{
+		Compliant: result.Get("system compliance").Bool(),
+		Records:   records,
+	}
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package saptune_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/trento-project/workbench/internal/saptune"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+func (suite *SaptuneClientTestSuite) TestVerifyNotCompliant() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"--format",
+		"json",
+		"note",
+		"verify",
+	).Return(helpers.ReadFixture("saptune/verify_not_compliant.json"), errors.New("exit status 1"))
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	result, err := saptuneClient.Verify(ctx)
+
+	suite.NoError(err)
+	suite.Equal(&saptune.VerifyResult{
+		Compliant: false,
+		Records: []saptune.ComplianceRecord{
+			{
+				NoteID:        "941735",
+				NoteVersion:   "11",
+				Parameter:     "ShmFileSystemSizeMB",
+				ExpectedValue: "25605",
+				ActualValue:   "25605",
+				Compliant:     true,
+				Amendments:    []string{},
+			},
+			{
+				NoteID:        "941735",
+				NoteVersion:   "11",
+				Parameter:     "kernel.shmmax",
+				ExpectedValue: "18446744073709551615",
+				ActualValue:   "68719476736",
+				Compliant:     false,
+				Amendments:    []string{},
+			},
+			{
+				NoteID:        "1656250",
+				NoteVersion:   "46",
+				Parameter:     "IO_SCHEDULER_vda",
+				ExpectedValue: "none",
+				OverrideValue: "none",
+				ActualValue:   "none",
+				Compliant:     true,
+				Amendments:    []string{"[4] setting is not available on the system"},
+			},
+		},
+	}, result)
+	suite.Equal([]string{"941735"}, result.NonCompliantNotes())
+}
+
+func (suite *SaptuneClientTestSuite) TestVerifyNoteCompliant() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"--format",
+		"json",
+		"note",
+		"verify",
+		"1868829",
+	).Return(helpers.ReadFixture("saptune/verify_note_compliant.json"), nil)
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	result, err := saptuneClient.VerifyNote(ctx, "1868829")
+
+	suite.NoError(err)
+	suite.True(result.Compliant)
+	suite.Len(result.Records, 1)
+	suite.Equal("grub:intel_idle.max_cstate", result.Records[0].Parameter)
+	suite.Empty(result.NonCompliantNotes())
+}
+
+func (suite *SaptuneClientTestSuite) TestVerifyFailure() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On(
+		"Exec",
+		ctx,
+		"saptune",
+		"--format",
+		"json",
+		"note",
+		"verify",
+	).Return([]byte("ERROR: saptune is locked"), errors.New("exit status 1"))
+
+	saptuneClient := saptune.NewSaptuneClient(
+		suite.mockExecutor,
+		suite.logger,
+	)
+	result, err := saptuneClient.Verify(ctx)
+
+	suite.EqualError(err, "could not call saptune note verify: exit status 1")
+	suite.Nil(result)
+}
@@ -0,0 +1,316 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sbd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/trento-project/workbench/internal/support"
+)
+
+const (
+	DefaultConfigPath      = "/etc/sysconfig/sbd"
+	defaultWatchdogDevice  = "/dev/watchdog"
+	watchdogBusyIdentifier = "Busy:"
+)
+
+type Message string
+
+const (
+	ClearMessage Message = "clear"
+	TestMessage  Message = "test"
+)
+
+var (
+	// sbd query-watchdog device line, e.g. [1] /dev/watchdog
+	watchdogDevicePatternCompiled = regexp.MustCompile(`^\[\d+\]\s+(\S+)$`)
+	// sbd dump header line, e.g. Timeout (msgwait)  : 10
+	dumpLinePatternCompiled = regexp.MustCompile(`^([^:]+?)\s*:\s*(.*)$`)
+)
+
+type SBD interface {
+	GetConfiguration(ctx context.Context) (*Configuration, error)
+	DumpHeader(ctx context.Context, device string) (*DeviceHeader, error)
+	ListSlots(ctx context.Context, device string) ([]Slot, error)
+	QueryWatchdogs(ctx context.Context) ([]WatchdogDevice, error)
+	SendMessage(ctx context.Context, device, node string, message Message) error
+}
+
+// Configuration is the SBD daemon configuration set in the sysconfig file.
+// Devices is empty for diskless SBD, where only the watchdog is used for fencing.
+type Configuration struct {
+	Devices         []string `json:"devices"`
+	WatchdogDevice  string   `json:"watchdog_device"`
+	WatchdogTimeout string   `json:"watchdog_timeout,omitempty"`
+	DelayStart      string   `json:"delay_start,omitempty"`
+	StartMode       string   `json:"start_mode,omitempty"`
+}
+
+// DeviceHeader is the SBD device metadata as reported by `sbd -d <device> dump`.
+// Timeouts are expressed in seconds.
+type DeviceHeader struct {
+	Device          string `json:"device"`
+	Version         string `json:"version"`
+	UUID            string `json:"uuid"`
+	Slots           int    `json:"slots"`
+	SectorSize      int    `json:"sector_size"`
+	WatchdogTimeout int    `json:"watchdog_timeout"`
+	AllocateTimeout int    `json:"allocate_timeout"`
+	LoopTimeout     int    `json:"loop_timeout"`
+	MsgwaitTimeout  int    `json:"msgwait_timeout"`
+}
+
+// Slot is a node slot of an SBD device as reported by `sbd -d <device> list`.
+// Message is clear unless a message is pending to be read by the node.
+type Slot struct {
+	ID      int     `json:"id"`
+	Node    string  `json:"node"`
+	Message Message `json:"message"`
+	Sender  string  `json:"sender,omitempty"`
+}
+
+// WatchdogDevice is a watchdog device discovered by `sbd query-watchdog`.
+// Busy devices are already opened by another process, usually the sbd daemon.
+type WatchdogDevice struct {
+	Path     string `json:"path"`
+	Identity string `json:"identity"`
+	Driver   string `json:"driver"`
+	Busy     bool   `json:"busy"`
+}
+
+type sbdClient struct {
+	executor   support.CmdExecutor
+	configPath string
+	logger     *slog.Logger
+}
+
+func NewDefaultSBDClient() SBD {
+	return NewSBDClient(
+		support.CliExecutor{},
+		DefaultConfigPath,
+		slog.Default(),
+	)
+}
+
+func NewSBDClient(
+	executor support.CmdExecutor,
+	configPath string,
+	logger *slog.Logger,
+) SBD {
+	return &sbdClient{
+		executor:   executor,
+		configPath: configPath,
+		logger:     logger,
+	}
+}
+
+// GetConfiguration reads the SBD sysconfig file.
+// The watchdog device defaults to /dev/watchdog if it is not configured.
+func (s *sbdClient) GetConfiguration(_ context.Context) (*Configuration, error) {
+	content, err := os.ReadFile(s.configPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read SBD configuration file %s: %w", s.configPath, err)
+	}
+
+	values := parseSysconfig(content)
+
+	config := &Configuration{
+		Devices:         []string{},
+		WatchdogDevice:  defaultWatchdogDevice,
+		WatchdogTimeout: values["SBD_WATCHDOG_TIMEOUT"],
+		DelayStart:      values["SBD_DELAY_START"],
+		StartMode:       values["SBD_STARTMODE"],
+	}
+
+	for device := range strings.SplitSeq(values["SBD_DEVICE"], ";") {
+		if device = strings.TrimSpace(device); device != "" {
+			config.Devices = append(config.Devices, device)
+		}
+	}
+
+	if watchdogDevice := values["SBD_WATCHDOG_DEV"]; watchdogDevice != "" {
+		config.WatchdogDevice = watchdogDevice
+	}
+
+	return config, nil
+}
+
+// DumpHeader returns the SBD device metadata using `sbd -d <device> dump`
+func (s *sbdClient) DumpHeader(ctx context.Context, device string) (*DeviceHeader, error) {
+	output, err := s.executor.Exec(ctx, "sbd", "-d", device, "dump")
+	if err != nil {
+		return nil, fmt.Errorf("could not dump SBD device %s header: %w, output: %s", device, err, string(output))
+	}
+
+	header, err := parseDumpOutput(device, output)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse SBD device %s header: %w", device, err)
+	}
+
+	return header, nil
+}
+
+// ListSlots returns the node slots allocated in the SBD device using `sbd -d <device> list`
+func (s *sbdClient) ListSlots(ctx context.Context, device string) ([]Slot, error) {
+	output, err := s.executor.Exec(ctx, "sbd", "-d", device, "list")
+	if err != nil {
+		return nil, fmt.Errorf("could not list SBD device %s slots: %w, output: %s", device, err, string(output))
+	}
+
+	slots, err := parseListOutput(output)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse SBD device %s slots: %w", device, err)
+	}
+
+	return slots, nil
+}
+
+// QueryWatchdogs returns the watchdog devices available in the host using `sbd query-watchdog`
+func (s *sbdClient) QueryWatchdogs(ctx context.Context) ([]WatchdogDevice, error) {
+	output, err := s.executor.Exec(ctx, "sbd", "query-watchdog")
+	if err != nil {
+		return nil, fmt.Errorf("could not query watchdog devices: %w, output: %s", err, string(output))
+	}
+
+	return parseQueryWatchdogOutput(output), nil
+}
+
+// SendMessage writes a message in the node slot of the SBD device using `sbd -d <device> message <node> <message>`.
+// The node clears the slot once the message is read.
+func (s *sbdClient) SendMessage(ctx context.Context, device, node string, message Message) error {
+	s.logger.Info("Sending SBD message", "device", device, "node", node, "message", message)
+	output, err := s.executor.Exec(ctx, "sbd", "-d", device, "message", node, string(message))
+	if err != nil {
+		return fmt.Errorf(
+			"could not send SBD message %s to node %s using device %s: %w, output: %s",
+			message, node, device, err, string(output),
+		)
+	}
+
+	s.logger.Info("SBD message sent", "device", device, "node", node, "message", message)
+	return nil
+}
+
+func parseSysconfig(content []byte) map[string]string {
+	values := make(map[string]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		values[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+
+	return values
+}
+
+func parseDumpOutput(device string, output []byte) (*DeviceHeader, error) {
+	header := &DeviceHeader{Device: device}
+	fields := map[string]*int{
+		"Number of slots":    &header.Slots,
+		"Sector size":        &header.SectorSize,
+		"Timeout (watchdog)": &header.WatchdogTimeout,
+		"Timeout (allocate)": &header.AllocateTimeout,
+		"Timeout (loop)":     &header.LoopTimeout,
+		"Timeout (msgwait)":  &header.MsgwaitTimeout,
+	}
+
+	for line := range strings.Lines(string(output)) {
+		matches := dumpLinePatternCompiled.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+		key, value := matches[1], matches[2]
+
+		switch key {
+		case "Header version":
+			header.Version = value
+		case "UUID":
+			header.UUID = value
+		default:
+			field, ok := fields[key]
+			if !ok {
+				continue
+			}
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value %s", key, value)
+			}
+			*field = number
+		}
+	}
+
+	if header.UUID == "" {
+		return nil, fmt.Errorf("header not found in output: %s", string(output))
+	}
+
+	return header, nil
+}
+
+func parseListOutput(output []byte) ([]Slot, error) {
+	slots := []Slot{}
+
+	for line := range strings.Lines(string(output)) {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+
+		id, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid slot line: %s", strings.TrimSpace(line))
+		}
+
+		slot := Slot{ID: id, Node: fields[1], Message: Message(fields[2])}
+		if len(fields) > 3 {
+			slot.Sender = fields[3]
+		}
+		slots = append(slots, slot)
+	}
+
+	return slots, nil
+}
+
+func parseQueryWatchdogOutput(output []byte) []WatchdogDevice {
+	devices := []WatchdogDevice{}
+	var current *WatchdogDevice
+
+	for line := range strings.Lines(string(output)) {
+		line = strings.TrimSpace(line)
+
+		if matches := watchdogDevicePatternCompiled.FindStringSubmatch(line); matches != nil {
+			devices = append(devices, WatchdogDevice{Path: matches[1]})
+			current = &devices[len(devices)-1]
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		if identity, found := strings.CutPrefix(line, "Identity:"); found {
+			current.Identity = strings.TrimSpace(identity)
+			current.Busy = strings.HasPrefix(current.Identity, watchdogBusyIdentifier)
+		} else if driver, found := strings.CutPrefix(line, "Driver:"); found {
+			current.Driver = strings.TrimSpace(driver)
+		}
+	}
+
+	return devices
+}
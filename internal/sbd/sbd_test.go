@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sbd_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/sbd"
+	"github.com/trento-project/workbench/internal/support"
+	"github.com/trento-project/workbench/internal/support/mocks"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+const sbdDevice = "/dev/disk/by-id/scsi-SLIO-ORG_IBLOCK_1b2c3d4e"
+
+type SBDClientTestSuite struct {
+	suite.Suite
+	mockExecutor *mocks.MockCmdExecutor
+	logger       *slog.Logger
+}
+
+func TestSBDClient(t *testing.T) {
+	suite.Run(t, new(SBDClientTestSuite))
+}
+
+func (suite *SBDClientTestSuite) SetupTest() {
+	suite.mockExecutor = mocks.NewMockCmdExecutor(suite.T())
+	suite.logger = support.NewDefaultLogger(slog.LevelInfo)
+}
+
+func (suite *SBDClientTestSuite) buildClient(configPath string) sbd.SBD {
+	return sbd.NewSBDClient(suite.mockExecutor, configPath, suite.logger)
+}
+
+func (suite *SBDClientTestSuite) TestGetConfiguration() {
+	config, err := suite.buildClient(helpers.GetFixturePath("sbd/sysconfig_sbd")).GetConfiguration(context.Background())
+
+	suite.NoError(err)
+	suite.Equal(&sbd.Configuration{
+		Devices: []string{
+			"/dev/disk/by-id/scsi-SLIO-ORG_IBLOCK_1b2c3d4e",
+			"/dev/disk/by-id/scsi-SLIO-ORG_IBLOCK_5f6a7b8c",
+		},
+		WatchdogDevice:  "/dev/watchdog",
+		WatchdogTimeout: "5",
+		DelayStart:      "no",
+		StartMode:       "always",
+	}, config)
+}
+
+func (suite *SBDClientTestSuite) TestGetConfigurationNotFound() {
+	config, err := suite.buildClient("/non/existent/sbd").GetConfiguration(context.Background())
+
+	suite.Nil(config)
+	suite.ErrorContains(err, "could not read SBD configuration file /non/existent/sbd")
+}
+
+func (suite *SBDClientTestSuite) TestDumpHeader() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "sbd", "-d", sbdDevice, "dump").
+		Return(helpers.ReadFixture("sbd/sbd_dump.output"), nil)
+
+	header, err := suite.buildClient(sbd.DefaultConfigPath).DumpHeader(ctx, sbdDevice)
+
+	suite.NoError(err)
+	suite.Equal(&sbd.DeviceHeader{
+		Device:          sbdDevice,
+		Version:         "2.1",
+		UUID:            "0de4f60f-7a7e-4c59-8d3b-2b6a4c7f0a5e",
+		Slots:           255,
+		SectorSize:      512,
+		WatchdogTimeout: 15,
+		AllocateTimeout: 2,
+		LoopTimeout:     1,
+		MsgwaitTimeout:  30,
+	}, header)
+}
+
+func (suite *SBDClientTestSuite) TestDumpHeaderFailure() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "sbd", "-d", sbdDevice, "dump").
+		Return([]byte("== disk /dev/sdx unreadable!"), errors.New("exit status 1"))
+
+	header, err := suite.buildClient(sbd.DefaultConfigPath).DumpHeader(ctx, sbdDevice)
+
+	suite.Nil(header)
+	suite.EqualError(err, "could not dump SBD device "+sbdDevice+
+		" header: exit status 1, output: == disk /dev/sdx unreadable!")
+}
+
+func (suite *SBDClientTestSuite) TestDumpHeaderInvalidOutput() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "sbd", "-d", sbdDevice, "dump").
+		Return([]byte("unexpected output"), nil)
+
+	header, err := suite.buildClient(sbd.DefaultConfigPath).DumpHeader(ctx, sbdDevice)
+
+	suite.Nil(header)
+	suite.EqualError(err, "could not parse SBD device "+sbdDevice+
+		" header: header not found in output: unexpected output")
+}
+
+func (suite *SBDClientTestSuite) TestListSlots() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "sbd", "-d", sbdDevice, "list").
+		Return(helpers.ReadFixture("sbd/sbd_list_pending_message.output"), nil)
+
+	slots, err := suite.buildClient(sbd.DefaultConfigPath).ListSlots(ctx, sbdDevice)
+
+	suite.NoError(err)
+	suite.Equal([]sbd.Slot{
+		{ID: 0, Node: "vmhana01", Message: sbd.ClearMessage},
+		{ID: 1, Node: "vmhana02", Message: sbd.TestMessage, Sender: "vmhana01"},
+	}, slots)
+}
+
+func (suite *SBDClientTestSuite) TestListSlotsFailure() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "sbd", "-d", sbdDevice, "list").
+		Return([]byte("error"), errors.New("exit status 1"))
+
+	slots, err := suite.buildClient(sbd.DefaultConfigPath).ListSlots(ctx, sbdDevice)
+
+	suite.Nil(slots)
+	suite.EqualError(err, "could not list SBD device "+sbdDevice+" slots: exit status 1, output: error")
+}
+
+func (suite *SBDClientTestSuite) TestQueryWatchdogs() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "sbd", "query-watchdog").
+		Return(helpers.ReadFixture("sbd/sbd_query_watchdog.output"), nil)
+
+	devices, err := suite.buildClient(sbd.DefaultConfigPath).QueryWatchdogs(ctx)
+
+	suite.NoError(err)
+	suite.Equal([]sbd.WatchdogDevice{
+		{Path: "/dev/watchdog", Identity: "Busy: PID 2145 (sbd)", Driver: "<unknown>", Busy: true},
+		{Path: "/dev/watchdog0", Identity: "Busy: PID 2145 (sbd)", Driver: "<unknown>", Busy: true},
+	}, devices)
+}
+
+func (suite *SBDClientTestSuite) TestSendMessage() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "sbd", "-d", sbdDevice, "message", "vmhana02", "test").
+		Return([]byte(""), nil)
+
+	err := suite.buildClient(sbd.DefaultConfigPath).SendMessage(ctx, sbdDevice, "vmhana02", sbd.TestMessage)
+
+	suite.NoError(err)
+}
+
+func (suite *SBDClientTestSuite) TestSendMessageFailure() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "sbd", "-d", sbdDevice, "message", "vmhana03", "test").
+		Return([]byte("slot for vmhana03 not found"), errors.New("exit status 1"))
+
+	err := suite.buildClient(sbd.DefaultConfigPath).SendMessage(ctx, sbdDevice, "vmhana03", sbd.TestMessage)
+
+	suite.EqualError(err, "could not send SBD message test to node vmhana03 using device "+sbdDevice+
+		": exit status 1, output: slot for vmhana03 not found")
+}
@@ -0,0 +1,329 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package systemd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultUnitDirectory = "/etc/systemd/system"
+	dropInExtension      = ".conf"
+	serviceSection       = "Service"
+	infinityValue        = "infinity"
+)
+
+var (
+	unitNamePatternCompiled     = regexp.MustCompile(`^[A-Za-z0-9:_.\\@-]+\.(service|socket|timer|mount|target)$`)
+	dropInNamePatternCompiled   = regexp.MustCompile(`^[A-Za-z0-9_.@-]+\.conf$`)
+	timespanPartPatternCompiled = regexp.MustCompile(`^(\d+)(?:\.(\d+))?\s*([a-z]*)\s*`)
+)
+
+// timespanUnits are the time span units accepted by systemd, in microseconds
+var timespanUnits = map[string]uint64{
+	"":        1000000,
+	"us":      1,
+	"usec":    1,
+	"ms":      1000,
+	"msec":    1000,
+	"s":       1000000,
+	"sec":     1000000,
+	"second":  1000000,
+	"seconds": 1000000,
+	"m":       60 * 1000000,
+	"min":     60 * 1000000,
+	"minute":  60 * 1000000,
+	"minutes": 60 * 1000000,
+	"h":       3600 * 1000000,
+	"hr":      3600 * 1000000,
+	"hour":    3600 * 1000000,
+	"hours":   3600 * 1000000,
+	"d":       86400 * 1000000,
+	"day":     86400 * 1000000,
+	"days":    86400 * 1000000,
+}
+
+// limitUnits are the binary suffixes accepted by the resource limits
+var limitUnits = map[string]uint64{
+	"K": 1 << 10,
+	"M": 1 << 20,
+	"G": 1 << 30,
+	"T": 1 << 40,
+}
+
+// DropInSection is a section of a drop-in file, e.g. [Service]
+type DropInSection struct {
+	Name       string
+	Directives []DropInDirective
+}
+
+type DropInDirective struct {
+	Name  string
+	Value string
+}
+
+type directiveProperty struct {
+	// property is the D-Bus property of the service exposing the directive
+	property string
+	// parse converts the configured value to the value of the property
+	parse func(value string) (any, error)
+}
+
+// verifiableDirectives are the [Service] directives that can be checked through
+// the service properties once the drop-in is loaded. They are the only directives
+// accepted in the drop-ins, as others like ExecStart or User run commands with any privileges.
+var verifiableDirectives = map[string]directiveProperty{
+	"TimeoutStartSec": {property: "TimeoutStartUSec", parse: parseTimespan},
+	"TimeoutStopSec":  {property: "TimeoutStopUSec", parse: parseTimespan},
+	"RestartSec":      {property: "RestartUSec", parse: parseTimespan},
+	"LimitNOFILE":     {property: "LimitNOFILE", parse: parseLimit},
+	"LimitNPROC":      {property: "LimitNPROC", parse: parseLimit},
+	"LimitMEMLOCK":    {property: "LimitMEMLOCK", parse: parseLimit},
+	"LimitCORE":       {property: "LimitCORE", parse: parseLimit},
+	"Restart":         {property: "Restart", parse: parseString},
+	"KillMode":        {property: "KillMode", parse: parseString},
+}
+
+// ValidateDropInUnit checks that the unit name can have drop-ins managed by the operators
+func ValidateDropInUnit(unit string) error {
+	if !unitNamePatternCompiled.MatchString(unit) {
+		return fmt.Errorf("invalid unit name %s", unit)
+	}
+	return nil
+}
+
+// ValidateDropInName checks the drop-in file name, e.g. 50-timeout.conf
+func ValidateDropInName(name string) error {
+	if !dropInNamePatternCompiled.MatchString(name) {
+		return fmt.Errorf("invalid drop-in name %s", name)
+	}
+	return nil
+}
+
+// ValidateDropInDirective checks that the directive is one of the verifiable [Service] directives
+// and that its value is valid
+func ValidateDropInDirective(section string, directive DropInDirective) error {
+	if section != serviceSection {
+		return fmt.Errorf("invalid drop-in section %s, only the %s section is supported", section, serviceSection)
+	}
+
+	property, found := verifiableDirectives[directive.Name]
+	if !found {
+		return fmt.Errorf("directive %s is not allowed, allowed directives: %s",
+			directive.Name, strings.Join(slices.Sorted(maps.Keys(verifiableDirectives)), ", "))
+	}
+
+	if strings.ContainsAny(directive.Value, "\r\n") {
+		return fmt.Errorf("invalid value for directive %s, it cannot contain line breaks", directive.Name)
+	}
+
+	if _, err := property.parse(directive.Value); err != nil {
+		return fmt.Errorf("invalid value %s for directive %s: %w", directive.Value, directive.Name, err)
+	}
+
+	return nil
+}
+
+// FormatDropIn builds the content of a drop-in file. Sections without directives are skipped.
+func FormatDropIn(sections []DropInSection) []byte {
+	var buffer bytes.Buffer
+
+	for _, section := range sections {
+		if len(section.Directives) == 0 {
+			continue
+		}
+
+		if buffer.Len() > 0 {
+			buffer.WriteString("\n")
+		}
+		fmt.Fprintf(&buffer, "[%s]\n", section.Name)
+		for _, directive := range section.Directives {
+			fmt.Fprintf(&buffer, "%s=%s\n", directive.Name, directive.Value)
+		}
+	}
+
+	return buffer.Bytes()
+}
+
+// DropInPath returns the path of the drop-in file, e.g. /etc/systemd/system/sapinit.service.d/50-timeout.conf
+func (s *Connector) DropInPath(unit, name string) string {
+	return filepath.Join(s.unitDirectory, unit+".d", name)
+}
+
+// GetDropIn returns the content of the drop-in file.
+// The found return value is false if the drop-in doesn't exist.
+func (s *Connector) GetDropIn(_ context.Context, unit, name string) ([]byte, bool, error) {
+	path := s.DropInPath(unit, name)
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("could not read drop-in file %s: %w", path, err)
+	}
+
+	return content, true, nil
+}
+
+// WriteDropIn writes the drop-in file. The configuration must be reloaded to apply it.
+func (s *Connector) WriteDropIn(_ context.Context, unit, name string, content []byte) error {
+	path := s.DropInPath(unit, name)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("could not create drop-in directory %s: %w", filepath.Dir(path), err)
+	}
+
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("could not write drop-in file %s: %w", path, err)
+	}
+
+	s.logger.Info("drop-in file written", "unit", unit, "path", path)
+	return nil
+}
+
+// RemoveDropIn removes the drop-in file, and the drop-in directory if it is left empty.
+// Nothing is done if the file doesn't exist. The configuration must be reloaded to apply it.
+func (s *Connector) RemoveDropIn(_ context.Context, unit, name string) error {
+	path := s.DropInPath(unit, name)
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not remove drop-in file %s: %w", path, err)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err == nil && len(entries) == 0 {
+		if err := os.Remove(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("could not remove drop-in directory %s: %w", filepath.Dir(path), err)
+		}
+	}
+
+	s.logger.Info("drop-in file removed", "unit", unit, "path", path)
+	return nil
+}
+
+// GetDropInPaths returns the drop-in files loaded by systemd for the unit
+func (s *Connector) GetDropInPaths(ctx context.Context, unit string) ([]string, error) {
+	dropInPaths, err := s.dbusConnection.GetUnitPropertyContext(ctx, unit, "DropInPaths")
+	if err != nil {
+		s.logger.Error("failed to get drop-in paths for unit", "unit", unit, "error", err)
+		return nil, fmt.Errorf("failed to get drop-in paths for unit %s: %w", unit, err)
+	}
+
+	paths, ok := dropInPaths.Value.Value().([]string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for drop-in paths of unit %s: %T",
+			unit, dropInPaths.Value.Value())
+	}
+
+	return paths, nil
+}
+
+// IsDirectiveApplied checks if the service property exposing the directive has the configured value.
+// Directives without a known property are considered applied, as they cannot be checked.
+func (s *Connector) IsDirectiveApplied(
+	ctx context.Context,
+	unit, section string,
+	directive DropInDirective,
+) (bool, error) {
+	property, found := verifiableDirectives[directive.Name]
+	if section != serviceSection || !found {
+		return true, nil
+	}
+
+	expected, err := property.parse(directive.Value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %s for directive %s: %w", directive.Value, directive.Name, err)
+	}
+
+	current, err := s.dbusConnection.GetServicePropertyContext(ctx, unit, property.property)
+	if err != nil {
+		s.logger.Error("failed to get service property", "unit", unit, "property", property.property, "error", err)
+		return false, fmt.Errorf("failed to get property %s for unit %s: %w", property.property, unit, err)
+	}
+
+	return current.Value.Value() == expected, nil
+}
+
+// parseTimespan parses a systemd time span, e.g. 90, 5min, 1.5s or 1min 30s, to microseconds.
+// As systemd, the fractional part is truncated to microseconds.
+func parseTimespan(value string) (any, error) {
+	value = strings.TrimSpace(value)
+	if value == infinityValue {
+		return uint64(math.MaxUint64), nil
+	}
+
+	if value == "" {
+		return nil, errors.New("empty time span")
+	}
+
+	var total uint64
+	for value != "" {
+		match := timespanPartPatternCompiled.FindStringSubmatch(value)
+		if match == nil {
+			return nil, fmt.Errorf("invalid time span %s", value)
+		}
+
+		unit, found := timespanUnits[match[3]]
+		if !found {
+			return nil, fmt.Errorf("invalid time span unit %s", match[3])
+		}
+
+		amount, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		total += amount * unit
+
+		for _, digit := range match[2] {
+			unit /= 10
+			total += uint64(digit-'0') * unit
+		}
+
+		value = value[len(match[0]):]
+	}
+
+	return total, nil
+}
+
+// parseLimit parses a resource limit, e.g. 1048576, 64K or infinity. The property exposes
+// the hard limit, which is the second value if soft and hard limits are given, e.g. 1024:4096
+func parseLimit(value string) (any, error) {
+	if _, hard, found := strings.Cut(value, ":"); found {
+		value = hard
+	}
+
+	value = strings.TrimSpace(value)
+	if value == infinityValue {
+		return uint64(math.MaxUint64), nil
+	}
+
+	multiplier := uint64(1)
+	if unit, found := limitUnits[value[max(len(value)-1, 0):]]; found {
+		multiplier = unit
+		value = value[:len(value)-1]
+	}
+
+	limit, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid limit %s", value)
+	}
+
+	return limit * multiplier, nil
+}
+
+func parseString(value string) (any, error) {
+	return strings.TrimSpace(value), nil
+}
@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package systemd_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	innerDbus "github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/dbus/mocks"
+	"github.com/trento-project/workbench/internal/support"
+	"github.com/trento-project/workbench/internal/systemd"
+)
+
+type DropInTestSuite struct {
+	suite.Suite
+	dbusMock      *mocks.MockConnector
+	unitDirectory string
+	connector     systemd.Systemd
+}
+
+func TestDropIn(t *testing.T) {
+	suite.Run(t, new(DropInTestSuite))
+}
+
+func (suite *DropInTestSuite) SetupTest() {
+	ctx := context.Background()
+	suite.dbusMock = mocks.NewMockConnector(suite.T())
+	suite.unitDirectory = suite.T().TempDir()
+	suite.connector, _ = systemd.NewSystemd(
+		ctx,
+		support.NewDefaultLogger(slog.LevelInfo),
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+		systemd.WithUnitDirectory(suite.unitDirectory),
+	)
+}
+
+func (suite *DropInTestSuite) TestValidateDropIn() {
+	suite.NoError(systemd.ValidateDropInUnit("sapinit.service"))
+	suite.NoError(systemd.ValidateDropInUnit("SAPPRD_00.service"))
+	suite.EqualError(systemd.ValidateDropInUnit("../passwd"), "invalid unit name ../passwd")
+
+	suite.NoError(systemd.ValidateDropInName("50-timeout.conf"))
+	suite.EqualError(systemd.ValidateDropInName("../timeout.conf"), "invalid drop-in name ../timeout.conf")
+
+	cases := []struct {
+		section   string
+		directive systemd.DropInDirective
+		err       string
+	}{
+		{section: "Service", directive: systemd.DropInDirective{Name: "TimeoutStopSec", Value: "5min 30s"}},
+		{section: "Service", directive: systemd.DropInDirective{Name: "LimitNOFILE", Value: "1024:1048576"}},
+		{section: "Service", directive: systemd.DropInDirective{Name: "RestartSec", Value: "1.5s"}},
+		{section: "Service", directive: systemd.DropInDirective{Name: "TimeoutStartSec", Value: "0.5min 10.25s"}},
+		{
+			section:   "Unit",
+			directive: systemd.DropInDirective{Name: "After", Value: "network-online.target"},
+			err:       "invalid drop-in section Unit, only the Service section is supported",
+		},
+		{
+			section:   "Service",
+			directive: systemd.DropInDirective{Name: "ExecStartPre", Value: "/bin/true"},
+			err: "directive ExecStartPre is not allowed, allowed directives: KillMode, LimitCORE, LimitMEMLOCK, " +
+				"LimitNOFILE, LimitNPROC, Restart, RestartSec, TimeoutStartSec, TimeoutStopSec",
+		},
+		{
+			section:   "Service",
+			directive: systemd.DropInDirective{Name: "Restart", Value: "no\nExecStart=/bin/false"},
+			err:       "invalid value for directive Restart, it cannot contain line breaks",
+		},
+		{
+			section:   "Service",
+			directive: systemd.DropInDirective{Name: "RestartSec", Value: "1.s"},
+			err:       "invalid value 1.s for directive RestartSec: invalid time span .s",
+		},
+		{
+			section:   "Service",
+			directive: systemd.DropInDirective{Name: "TimeoutStopSec", Value: "5 fortnights"},
+			err:       "invalid value 5 fortnights for directive TimeoutStopSec: invalid time span unit fortnights",
+		},
+		{
+			section:   "Service",
+			directive: systemd.DropInDirective{Name: "LimitMEMLOCK", Value: "lots"},
+			err:       "invalid value lots for directive LimitMEMLOCK: invalid limit lots",
+		},
+	}
+
+	for _, tc := range cases {
+		err := systemd.ValidateDropInDirective(tc.section, tc.directive)
+		if tc.err == "" {
+			suite.NoError(err)
+		} else {
+			suite.EqualError(err, tc.err)
+		}
+	}
+}
+
+func (suite *DropInTestSuite) TestFormatDropIn() {
+	content := systemd.FormatDropIn([]systemd.DropInSection{
+		{Name: "Unit", Directives: []systemd.DropInDirective{{Name: "After", Value: "network-online.target"}}},
+		{Name: "Socket", Directives: []systemd.DropInDirective{}},
+		{Name: "Service", Directives: []systemd.DropInDirective{
+			{Name: "LimitNOFILE", Value: "1048576"},
+			{Name: "TimeoutStopSec", Value: "300"},
+		}},
+	})
+
+	suite.Equal("[Unit]\nAfter=network-online.target\n\n[Service]\nLimitNOFILE=1048576\nTimeoutStopSec=300\n",
+		string(content))
+}
+
+func (suite *DropInTestSuite) TestWriteGetRemoveDropIn() {
+	ctx := context.Background()
+	path := filepath.Join(suite.unitDirectory, "sapinit.service.d", "50-timeout.conf")
+
+	suite.Equal(path, suite.connector.DropInPath("sapinit.service", "50-timeout.conf"))
+
+	_, found, err := suite.connector.GetDropIn(ctx, "sapinit.service", "50-timeout.conf")
+	suite.NoError(err)
+	suite.False(found)
+
+	suite.NoError(suite.connector.WriteDropIn(ctx, "sapinit.service", "50-timeout.conf",
+		[]byte("[Service]\nTimeoutStopSec=300\n")))
+
+	content, found, err := suite.connector.GetDropIn(ctx, "sapinit.service", "50-timeout.conf")
+	suite.NoError(err)
+	suite.True(found)
+	suite.Equal("[Service]\nTimeoutStopSec=300\n", string(content))
+
+	suite.NoError(suite.connector.RemoveDropIn(ctx, "sapinit.service", "50-timeout.conf"))
+	suite.NoDirExists(filepath.Dir(path))
+
+	suite.NoError(suite.connector.RemoveDropIn(ctx, "sapinit.service", "50-timeout.conf"))
+}
+
+func (suite *DropInTestSuite) TestRemoveDropInKeepsOtherDropIns() {
+	ctx := context.Background()
+	directory := filepath.Join(suite.unitDirectory, "sapinit.service.d")
+	suite.Require().NoError(os.MkdirAll(directory, 0o755))
+	suite.Require().NoError(os.WriteFile(filepath.Join(directory, "10-vendor.conf"), []byte("[Unit]\n"), 0o644))
+	suite.Require().NoError(os.WriteFile(filepath.Join(directory, "50-timeout.conf"), []byte("[Unit]\n"), 0o644))
+
+	suite.NoError(suite.connector.RemoveDropIn(ctx, "sapinit.service", "50-timeout.conf"))
+	suite.NoFileExists(filepath.Join(directory, "50-timeout.conf"))
+	suite.FileExists(filepath.Join(directory, "10-vendor.conf"))
+}
+
+func (suite *DropInTestSuite) TestGetDropInPaths() {
+	ctx := context.Background()
+
+	suite.dbusMock.On("GetUnitPropertyContext", ctx, "sapinit.service", "DropInPaths").
+		Return(&dbus.Property{
+			Name:  "DropInPaths",
+			Value: innerDbus.MakeVariant([]string{"/etc/systemd/system/sapinit.service.d/50-timeout.conf"}),
+		}, nil).
+		Once()
+
+	paths, err := suite.connector.GetDropInPaths(ctx, "sapinit.service")
+	suite.NoError(err)
+	suite.Equal([]string{"/etc/systemd/system/sapinit.service.d/50-timeout.conf"}, paths)
+}
+
+func (suite *DropInTestSuite) TestIsDirectiveApplied() {
+	ctx := context.Background()
+
+	suite.dbusMock.On("GetServicePropertyContext", ctx, "sapinit.service", "TimeoutStopUSec").
+		Return(&dbus.Property{
+			Name:  "TimeoutStopUSec",
+			Value: innerDbus.MakeVariant(uint64(330000000)),
+		}, nil).
+		Once()
+	suite.dbusMock.On("GetServicePropertyContext", ctx, "sapinit.service", "RestartUSec").
+		Return(&dbus.Property{
+			Name:  "RestartUSec",
+			Value: innerDbus.MakeVariant(uint64(1500000)),
+		}, nil).
+		Once()
+	suite.dbusMock.On("GetServicePropertyContext", ctx, "SAPPRD_00.service", "LimitNOFILE").
+		Return(&dbus.Property{
+			Name:  "LimitNOFILE",
+			Value: innerDbus.MakeVariant(uint64(524288)),
+		}, nil).
+		Once()
+	suite.dbusMock.On("GetServicePropertyContext", ctx, "SAPPRD_00.service", "LimitMEMLOCK").
+		Return(&dbus.Property{
+			Name:  "LimitMEMLOCK",
+			Value: innerDbus.MakeVariant(uint64(math.MaxUint64)),
+		}, nil).
+		Once()
+	suite.dbusMock.On("GetServicePropertyContext", ctx, "SAPPRD_00.service", "Restart").
+		Return(nil, errors.New("unit not loaded")).
+		Once()
+
+	applied, err := suite.connector.IsDirectiveApplied(ctx, "sapinit.service", "Service",
+		systemd.DropInDirective{Name: "TimeoutStopSec", Value: "5min 30s"})
+	suite.NoError(err)
+	suite.True(applied)
+
+	applied, err = suite.connector.IsDirectiveApplied(ctx, "sapinit.service", "Service",
+		systemd.DropInDirective{Name: "RestartSec", Value: "1.5s"})
+	suite.NoError(err)
+	suite.True(applied)
+
+	applied, err = suite.connector.IsDirectiveApplied(ctx, "SAPPRD_00.service", "Service",
+		systemd.DropInDirective{Name: "LimitNOFILE", Value: "1048576"})
+	suite.NoError(err)
+	suite.False(applied)
+
+	applied, err = suite.connector.IsDirectiveApplied(ctx, "SAPPRD_00.service", "Service",
+		systemd.DropInDirective{Name: "LimitMEMLOCK", Value: "infinity"})
+	suite.NoError(err)
+	suite.True(applied)
+
+	applied, err = suite.connector.IsDirectiveApplied(ctx, "SAPPRD_00.service", "Unit",
+		systemd.DropInDirective{Name: "After", Value: "network-online.target"})
+	suite.NoError(err)
+	suite.True(applied)
+
+	_, err = suite.connector.IsDirectiveApplied(ctx, "SAPPRD_00.service", "Service",
+		systemd.DropInDirective{Name: "Restart", Value: "on-failure"})
+	suite.EqualError(err, "failed to get property Restart for unit SAPPRD_00.service: unit not loaded")
+}
+
+func (suite *DropInTestSuite) TestDaemonReload() {
+	ctx := context.Background()
+
+	suite.dbusMock.On("ReloadContext", ctx).Return(errors.New("access denied")).Once()
+
+	suite.EqualError(suite.connector.DaemonReload(ctx), "failed to reload systemd configuration: access denied")
+}
@@ -9,6 +9,7 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	systemd "github.com/trento-project/workbench/internal/systemd"
 )
 
 // MockSystemd is an autogenerated mock type for the Systemd type
@@ -56,22 +57,80 @@ func (_c *MockSystemd_Close_Call) RunAndReturn(run func()) *MockSystemd_Close_Ca
 	return _c
 }
 
+// DaemonReload provides a mock function with given fields: ctx
+func (_m *MockSystemd) DaemonReload(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DaemonReload")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSystemd_DaemonReload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DaemonReload'
+type MockSystemd_DaemonReload_Call struct {
+	*mock.Call
+}
+
+// DaemonReload is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSystemd_Expecter) DaemonReload(ctx interface{}) *MockSystemd_DaemonReload_Call {
+	return &MockSystemd_DaemonReload_Call{Call: _e.mock.On("DaemonReload", ctx)}
+}
+
+func (_c *MockSystemd_DaemonReload_Call) Run(run func(ctx context.Context)) *MockSystemd_DaemonReload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_DaemonReload_Call) Return(_a0 error) *MockSystemd_DaemonReload_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSystemd_DaemonReload_Call) RunAndReturn(run func(context.Context) error) *MockSystemd_DaemonReload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Disable provides a mock function with given fields: ctx, service
-func (_m *MockSystemd) Disable(ctx context.Context, service string) error {
+func (_m *MockSystemd) Disable(ctx context.Context, service string) ([]systemd.UnitFileChange, error) {
 	ret := _m.Called(ctx, service)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Disable")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 []systemd.UnitFileChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]systemd.UnitFileChange, error)); ok {
+		return rf(ctx, service)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []systemd.UnitFileChange); ok {
 		r0 = rf(ctx, service)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]systemd.UnitFileChange)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, service)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // MockSystemd_Disable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Disable'
@@ -93,32 +152,150 @@ func (_c *MockSystemd_Disable_Call) Run(run func(ctx context.Context, service st
 	return _c
 }
 
-func (_c *MockSystemd_Disable_Call) Return(_a0 error) *MockSystemd_Disable_Call {
+func (_c *MockSystemd_Disable_Call) Return(_a0 []systemd.UnitFileChange, _a1 error) *MockSystemd_Disable_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSystemd_Disable_Call) RunAndReturn(run func(context.Context, string) ([]systemd.UnitFileChange, error)) *MockSystemd_Disable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DisableRuntime provides a mock function with given fields: ctx, service
+func (_m *MockSystemd) DisableRuntime(ctx context.Context, service string) ([]systemd.UnitFileChange, error) {
+	ret := _m.Called(ctx, service)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableRuntime")
+	}
+
+	var r0 []systemd.UnitFileChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]systemd.UnitFileChange, error)); ok {
+		return rf(ctx, service)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []systemd.UnitFileChange); ok {
+		r0 = rf(ctx, service)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]systemd.UnitFileChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, service)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSystemd_DisableRuntime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableRuntime'
+type MockSystemd_DisableRuntime_Call struct {
+	*mock.Call
+}
+
+// DisableRuntime is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+func (_e *MockSystemd_Expecter) DisableRuntime(ctx interface{}, service interface{}) *MockSystemd_DisableRuntime_Call {
+	return &MockSystemd_DisableRuntime_Call{Call: _e.mock.On("DisableRuntime", ctx, service)}
+}
+
+func (_c *MockSystemd_DisableRuntime_Call) Run(run func(ctx context.Context, service string)) *MockSystemd_DisableRuntime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_DisableRuntime_Call) Return(_a0 []systemd.UnitFileChange, _a1 error) *MockSystemd_DisableRuntime_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSystemd_DisableRuntime_Call) RunAndReturn(run func(context.Context, string) ([]systemd.UnitFileChange, error)) *MockSystemd_DisableRuntime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DropInPath provides a mock function with given fields: unit, name
+func (_m *MockSystemd) DropInPath(unit string, name string) string {
+	ret := _m.Called(unit, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropInPath")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(unit, name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockSystemd_DropInPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropInPath'
+type MockSystemd_DropInPath_Call struct {
+	*mock.Call
+}
+
+// DropInPath is a helper method to define mock.On call
+//   - unit string
+//   - name string
+func (_e *MockSystemd_Expecter) DropInPath(unit interface{}, name interface{}) *MockSystemd_DropInPath_Call {
+	return &MockSystemd_DropInPath_Call{Call: _e.mock.On("DropInPath", unit, name)}
+}
+
+func (_c *MockSystemd_DropInPath_Call) Run(run func(unit string, name string)) *MockSystemd_DropInPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_DropInPath_Call) Return(_a0 string) *MockSystemd_DropInPath_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockSystemd_Disable_Call) RunAndReturn(run func(context.Context, string) error) *MockSystemd_Disable_Call {
+func (_c *MockSystemd_DropInPath_Call) RunAndReturn(run func(string, string) string) *MockSystemd_DropInPath_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Enable provides a mock function with given fields: ctx, service
-func (_m *MockSystemd) Enable(ctx context.Context, service string) error {
+func (_m *MockSystemd) Enable(ctx context.Context, service string) ([]systemd.UnitFileChange, error) {
 	ret := _m.Called(ctx, service)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Enable")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 []systemd.UnitFileChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]systemd.UnitFileChange, error)); ok {
+		return rf(ctx, service)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []systemd.UnitFileChange); ok {
 		r0 = rf(ctx, service)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]systemd.UnitFileChange)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, service)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // MockSystemd_Enable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enable'
@@ -140,33 +317,35 @@ func (_c *MockSystemd_Enable_Call) Run(run func(ctx context.Context, service str
 	return _c
 }
 
-func (_c *MockSystemd_Enable_Call) Return(_a0 error) *MockSystemd_Enable_Call {
-	_c.Call.Return(_a0)
+func (_c *MockSystemd_Enable_Call) Return(_a0 []systemd.UnitFileChange, _a1 error) *MockSystemd_Enable_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSystemd_Enable_Call) RunAndReturn(run func(context.Context, string) error) *MockSystemd_Enable_Call {
+func (_c *MockSystemd_Enable_Call) RunAndReturn(run func(context.Context, string) ([]systemd.UnitFileChange, error)) *MockSystemd_Enable_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IsEnabled provides a mock function with given fields: ctx, service
-func (_m *MockSystemd) IsEnabled(ctx context.Context, service string) (bool, error) {
+// EnableRuntime provides a mock function with given fields: ctx, service
+func (_m *MockSystemd) EnableRuntime(ctx context.Context, service string) ([]systemd.UnitFileChange, error) {
 	ret := _m.Called(ctx, service)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsEnabled")
+		panic("no return value specified for EnableRuntime")
 	}
 
-	var r0 bool
+	var r0 []systemd.UnitFileChange
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]systemd.UnitFileChange, error)); ok {
 		return rf(ctx, service)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) []systemd.UnitFileChange); ok {
 		r0 = rf(ctx, service)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]systemd.UnitFileChange)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -178,31 +357,988 @@ func (_m *MockSystemd) IsEnabled(ctx context.Context, service string) (bool, err
 	return r0, r1
 }
 
-// MockSystemd_IsEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsEnabled'
-type MockSystemd_IsEnabled_Call struct {
+// MockSystemd_EnableRuntime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableRuntime'
+type MockSystemd_EnableRuntime_Call struct {
 	*mock.Call
 }
 
-// IsEnabled is a helper method to define mock.On call
+// EnableRuntime is a helper method to define mock.On call
 //   - ctx context.Context
 //   - service string
-func (_e *MockSystemd_Expecter) IsEnabled(ctx interface{}, service interface{}) *MockSystemd_IsEnabled_Call {
-	return &MockSystemd_IsEnabled_Call{Call: _e.mock.On("IsEnabled", ctx, service)}
+func (_e *MockSystemd_Expecter) EnableRuntime(ctx interface{}, service interface{}) *MockSystemd_EnableRuntime_Call {
+	return &MockSystemd_EnableRuntime_Call{Call: _e.mock.On("EnableRuntime", ctx, service)}
 }
 
-func (_c *MockSystemd_IsEnabled_Call) Run(run func(ctx context.Context, service string)) *MockSystemd_IsEnabled_Call {
+func (_c *MockSystemd_EnableRuntime_Call) Run(run func(ctx context.Context, service string)) *MockSystemd_EnableRuntime_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockSystemd_IsEnabled_Call) Return(_a0 bool, _a1 error) *MockSystemd_IsEnabled_Call {
+func (_c *MockSystemd_EnableRuntime_Call) Return(_a0 []systemd.UnitFileChange, _a1 error) *MockSystemd_EnableRuntime_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSystemd_IsEnabled_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockSystemd_IsEnabled_Call {
+func (_c *MockSystemd_EnableRuntime_Call) RunAndReturn(run func(context.Context, string) ([]systemd.UnitFileChange, error)) *MockSystemd_EnableRuntime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveState provides a mock function with given fields: ctx, service
+func (_m *MockSystemd) GetActiveState(ctx context.Context, service string) (string, error) {
+	ret := _m.Called(ctx, service)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveState")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, service)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, service)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, service)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSystemd_GetActiveState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveState'
+type MockSystemd_GetActiveState_Call struct {
+	*mock.Call
+}
+
+// GetActiveState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+func (_e *MockSystemd_Expecter) GetActiveState(ctx interface{}, service interface{}) *MockSystemd_GetActiveState_Call {
+	return &MockSystemd_GetActiveState_Call{Call: _e.mock.On("GetActiveState", ctx, service)}
+}
+
+func (_c *MockSystemd_GetActiveState_Call) Run(run func(ctx context.Context, service string)) *MockSystemd_GetActiveState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_GetActiveState_Call) Return(_a0 string, _a1 error) *MockSystemd_GetActiveState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSystemd_GetActiveState_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockSystemd_GetActiveState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDropIn provides a mock function with given fields: ctx, unit, name
+func (_m *MockSystemd) GetDropIn(ctx context.Context, unit string, name string) ([]byte, bool, error) {
+	ret := _m.Called(ctx, unit, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDropIn")
+	}
+
+	var r0 []byte
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]byte, bool, error)); ok {
+		return rf(ctx, unit, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []byte); ok {
+		r0 = rf(ctx, unit, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) bool); ok {
+		r1 = rf(ctx, unit, name)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, unit, name)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSystemd_GetDropIn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDropIn'
+type MockSystemd_GetDropIn_Call struct {
+	*mock.Call
+}
+
+// GetDropIn is a helper method to define mock.On call
+//   - ctx context.Context
+//   - unit string
+//   - name string
+func (_e *MockSystemd_Expecter) GetDropIn(ctx interface{}, unit interface{}, name interface{}) *MockSystemd_GetDropIn_Call {
+	return &MockSystemd_GetDropIn_Call{Call: _e.mock.On("GetDropIn", ctx, unit, name)}
+}
+
+func (_c *MockSystemd_GetDropIn_Call) Run(run func(ctx context.Context, unit string, name string)) *MockSystemd_GetDropIn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_GetDropIn_Call) Return(_a0 []byte, _a1 bool, _a2 error) *MockSystemd_GetDropIn_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSystemd_GetDropIn_Call) RunAndReturn(run func(context.Context, string, string) ([]byte, bool, error)) *MockSystemd_GetDropIn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDropInPaths provides a mock function with given fields: ctx, unit
+func (_m *MockSystemd) GetDropInPaths(ctx context.Context, unit string) ([]string, error) {
+	ret := _m.Called(ctx, unit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDropInPaths")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, unit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, unit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, unit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSystemd_GetDropInPaths_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDropInPaths'
+type MockSystemd_GetDropInPaths_Call struct {
+	*mock.Call
+}
+
+// GetDropInPaths is a helper method to define mock.On call
+//   - ctx context.Context
+//   - unit string
+func (_e *MockSystemd_Expecter) GetDropInPaths(ctx interface{}, unit interface{}) *MockSystemd_GetDropInPaths_Call {
+	return &MockSystemd_GetDropInPaths_Call{Call: _e.mock.On("GetDropInPaths", ctx, unit)}
+}
+
+func (_c *MockSystemd_GetDropInPaths_Call) Run(run func(ctx context.Context, unit string)) *MockSystemd_GetDropInPaths_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_GetDropInPaths_Call) Return(_a0 []string, _a1 error) *MockSystemd_GetDropInPaths_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSystemd_GetDropInPaths_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *MockSystemd_GetDropInPaths_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubState provides a mock function with given fields: ctx, service
+func (_m *MockSystemd) GetSubState(ctx context.Context, service string) (string, error) {
+	ret := _m.Called(ctx, service)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubState")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, service)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, service)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, service)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSystemd_GetSubState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubState'
+type MockSystemd_GetSubState_Call struct {
+	*mock.Call
+}
+
+// GetSubState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+func (_e *MockSystemd_Expecter) GetSubState(ctx interface{}, service interface{}) *MockSystemd_GetSubState_Call {
+	return &MockSystemd_GetSubState_Call{Call: _e.mock.On("GetSubState", ctx, service)}
+}
+
+func (_c *MockSystemd_GetSubState_Call) Run(run func(ctx context.Context, service string)) *MockSystemd_GetSubState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_GetSubState_Call) Return(_a0 string, _a1 error) *MockSystemd_GetSubState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSystemd_GetSubState_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockSystemd_GetSubState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUnitState provides a mock function with given fields: ctx, service
+func (_m *MockSystemd) GetUnitState(ctx context.Context, service string) (systemd.UnitState, error) {
+	ret := _m.Called(ctx, service)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnitState")
+	}
+
+	var r0 systemd.UnitState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (systemd.UnitState, error)); ok {
+		return rf(ctx, service)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) systemd.UnitState); ok {
+		r0 = rf(ctx, service)
+	} else {
+		r0 = ret.Get(0).(systemd.UnitState)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, service)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSystemd_GetUnitState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnitState'
+type MockSystemd_GetUnitState_Call struct {
+	*mock.Call
+}
+
+// GetUnitState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+func (_e *MockSystemd_Expecter) GetUnitState(ctx interface{}, service interface{}) *MockSystemd_GetUnitState_Call {
+	return &MockSystemd_GetUnitState_Call{Call: _e.mock.On("GetUnitState", ctx, service)}
+}
+
+func (_c *MockSystemd_GetUnitState_Call) Run(run func(ctx context.Context, service string)) *MockSystemd_GetUnitState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_GetUnitState_Call) Return(_a0 systemd.UnitState, _a1 error) *MockSystemd_GetUnitState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSystemd_GetUnitState_Call) RunAndReturn(run func(context.Context, string) (systemd.UnitState, error)) *MockSystemd_GetUnitState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsActive provides a mock function with given fields: ctx, service
+func (_m *MockSystemd) IsActive(ctx context.Context, service string) (bool, error) {
+	ret := _m.Called(ctx, service)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsActive")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, service)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, service)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, service)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSystemd_IsActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsActive'
+type MockSystemd_IsActive_Call struct {
+	*mock.Call
+}
+
+// IsActive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+func (_e *MockSystemd_Expecter) IsActive(ctx interface{}, service interface{}) *MockSystemd_IsActive_Call {
+	return &MockSystemd_IsActive_Call{Call: _e.mock.On("IsActive", ctx, service)}
+}
+
+func (_c *MockSystemd_IsActive_Call) Run(run func(ctx context.Context, service string)) *MockSystemd_IsActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_IsActive_Call) Return(_a0 bool, _a1 error) *MockSystemd_IsActive_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSystemd_IsActive_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockSystemd_IsActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsDirectiveApplied provides a mock function with given fields: ctx, unit, section, directive
+func (_m *MockSystemd) IsDirectiveApplied(ctx context.Context, unit string, section string, directive systemd.DropInDirective) (bool, error) {
+	ret := _m.Called(ctx, unit, section, directive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsDirectiveApplied")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, systemd.DropInDirective) (bool, error)); ok {
+		return rf(ctx, unit, section, directive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, systemd.DropInDirective) bool); ok {
+		r0 = rf(ctx, unit, section, directive)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, systemd.DropInDirective) error); ok {
+		r1 = rf(ctx, unit, section, directive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSystemd_IsDirectiveApplied_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsDirectiveApplied'
+type MockSystemd_IsDirectiveApplied_Call struct {
+	*mock.Call
+}
+
+// IsDirectiveApplied is a helper method to define mock.On call
+//   - ctx context.Context
+//   - unit string
+//   - section string
+//   - directive systemd.DropInDirective
+func (_e *MockSystemd_Expecter) IsDirectiveApplied(ctx interface{}, unit interface{}, section interface{}, directive interface{}) *MockSystemd_IsDirectiveApplied_Call {
+	return &MockSystemd_IsDirectiveApplied_Call{Call: _e.mock.On("IsDirectiveApplied", ctx, unit, section, directive)}
+}
+
+func (_c *MockSystemd_IsDirectiveApplied_Call) Run(run func(ctx context.Context, unit string, section string, directive systemd.DropInDirective)) *MockSystemd_IsDirectiveApplied_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(systemd.DropInDirective))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_IsDirectiveApplied_Call) Return(_a0 bool, _a1 error) *MockSystemd_IsDirectiveApplied_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSystemd_IsDirectiveApplied_Call) RunAndReturn(run func(context.Context, string, string, systemd.DropInDirective) (bool, error)) *MockSystemd_IsDirectiveApplied_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsEnabled provides a mock function with given fields: ctx, service
+func (_m *MockSystemd) IsEnabled(ctx context.Context, service string) (bool, error) {
+	ret := _m.Called(ctx, service)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsEnabled")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, service)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, service)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, service)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSystemd_IsEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsEnabled'
+type MockSystemd_IsEnabled_Call struct {
+	*mock.Call
+}
+
+// IsEnabled is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+func (_e *MockSystemd_Expecter) IsEnabled(ctx interface{}, service interface{}) *MockSystemd_IsEnabled_Call {
+	return &MockSystemd_IsEnabled_Call{Call: _e.mock.On("IsEnabled", ctx, service)}
+}
+
+func (_c *MockSystemd_IsEnabled_Call) Run(run func(ctx context.Context, service string)) *MockSystemd_IsEnabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_IsEnabled_Call) Return(_a0 bool, _a1 error) *MockSystemd_IsEnabled_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSystemd_IsEnabled_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockSystemd_IsEnabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Mask provides a mock function with given fields: ctx, service, runtime
+func (_m *MockSystemd) Mask(ctx context.Context, service string, runtime bool) error {
+	ret := _m.Called(ctx, service, runtime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Mask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, service, runtime)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSystemd_Mask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Mask'
+type MockSystemd_Mask_Call struct {
+	*mock.Call
+}
+
+// Mask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+//   - runtime bool
+func (_e *MockSystemd_Expecter) Mask(ctx interface{}, service interface{}, runtime interface{}) *MockSystemd_Mask_Call {
+	return &MockSystemd_Mask_Call{Call: _e.mock.On("Mask", ctx, service, runtime)}
+}
+
+func (_c *MockSystemd_Mask_Call) Run(run func(ctx context.Context, service string, runtime bool)) *MockSystemd_Mask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_Mask_Call) Return(_a0 error) *MockSystemd_Mask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSystemd_Mask_Call) RunAndReturn(run func(context.Context, string, bool) error) *MockSystemd_Mask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reload provides a mock function with given fields: ctx, service
+func (_m *MockSystemd) Reload(ctx context.Context, service string) error {
+	ret := _m.Called(ctx, service)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reload")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, service)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSystemd_Reload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reload'
+type MockSystemd_Reload_Call struct {
+	*mock.Call
+}
+
+// Reload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+func (_e *MockSystemd_Expecter) Reload(ctx interface{}, service interface{}) *MockSystemd_Reload_Call {
+	return &MockSystemd_Reload_Call{Call: _e.mock.On("Reload", ctx, service)}
+}
+
+func (_c *MockSystemd_Reload_Call) Run(run func(ctx context.Context, service string)) *MockSystemd_Reload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_Reload_Call) Return(_a0 error) *MockSystemd_Reload_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSystemd_Reload_Call) RunAndReturn(run func(context.Context, string) error) *MockSystemd_Reload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveDropIn provides a mock function with given fields: ctx, unit, name
+func (_m *MockSystemd) RemoveDropIn(ctx context.Context, unit string, name string) error {
+	ret := _m.Called(ctx, unit, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDropIn")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, unit, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSystemd_RemoveDropIn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveDropIn'
+type MockSystemd_RemoveDropIn_Call struct {
+	*mock.Call
+}
+
+// RemoveDropIn is a helper method to define mock.On call
+//   - ctx context.Context
+//   - unit string
+//   - name string
+func (_e *MockSystemd_Expecter) RemoveDropIn(ctx interface{}, unit interface{}, name interface{}) *MockSystemd_RemoveDropIn_Call {
+	return &MockSystemd_RemoveDropIn_Call{Call: _e.mock.On("RemoveDropIn", ctx, unit, name)}
+}
+
+func (_c *MockSystemd_RemoveDropIn_Call) Run(run func(ctx context.Context, unit string, name string)) *MockSystemd_RemoveDropIn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_RemoveDropIn_Call) Return(_a0 error) *MockSystemd_RemoveDropIn_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSystemd_RemoveDropIn_Call) RunAndReturn(run func(context.Context, string, string) error) *MockSystemd_RemoveDropIn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restart provides a mock function with given fields: ctx, service
+func (_m *MockSystemd) Restart(ctx context.Context, service string) error {
+	ret := _m.Called(ctx, service)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restart")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, service)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSystemd_Restart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restart'
+type MockSystemd_Restart_Call struct {
+	*mock.Call
+}
+
+// Restart is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+func (_e *MockSystemd_Expecter) Restart(ctx interface{}, service interface{}) *MockSystemd_Restart_Call {
+	return &MockSystemd_Restart_Call{Call: _e.mock.On("Restart", ctx, service)}
+}
+
+func (_c *MockSystemd_Restart_Call) Run(run func(ctx context.Context, service string)) *MockSystemd_Restart_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_Restart_Call) Return(_a0 error) *MockSystemd_Restart_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSystemd_Restart_Call) RunAndReturn(run func(context.Context, string) error) *MockSystemd_Restart_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevertUnitFileChanges provides a mock function with given fields: ctx, service, changes
+func (_m *MockSystemd) RevertUnitFileChanges(ctx context.Context, service string, changes []systemd.UnitFileChange) error {
+	ret := _m.Called(ctx, service, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertUnitFileChanges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []systemd.UnitFileChange) error); ok {
+		r0 = rf(ctx, service, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSystemd_RevertUnitFileChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevertUnitFileChanges'
+type MockSystemd_RevertUnitFileChanges_Call struct {
+	*mock.Call
+}
+
+// RevertUnitFileChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+//   - changes []systemd.UnitFileChange
+func (_e *MockSystemd_Expecter) RevertUnitFileChanges(ctx interface{}, service interface{}, changes interface{}) *MockSystemd_RevertUnitFileChanges_Call {
+	return &MockSystemd_RevertUnitFileChanges_Call{Call: _e.mock.On("RevertUnitFileChanges", ctx, service, changes)}
+}
+
+func (_c *MockSystemd_RevertUnitFileChanges_Call) Run(run func(ctx context.Context, service string, changes []systemd.UnitFileChange)) *MockSystemd_RevertUnitFileChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]systemd.UnitFileChange))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_RevertUnitFileChanges_Call) Return(_a0 error) *MockSystemd_RevertUnitFileChanges_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSystemd_RevertUnitFileChanges_Call) RunAndReturn(run func(context.Context, string, []systemd.UnitFileChange) error) *MockSystemd_RevertUnitFileChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx, service
+func (_m *MockSystemd) Start(ctx context.Context, service string) error {
+	ret := _m.Called(ctx, service)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, service)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSystemd_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockSystemd_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+func (_e *MockSystemd_Expecter) Start(ctx interface{}, service interface{}) *MockSystemd_Start_Call {
+	return &MockSystemd_Start_Call{Call: _e.mock.On("Start", ctx, service)}
+}
+
+func (_c *MockSystemd_Start_Call) Run(run func(ctx context.Context, service string)) *MockSystemd_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_Start_Call) Return(_a0 error) *MockSystemd_Start_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSystemd_Start_Call) RunAndReturn(run func(context.Context, string) error) *MockSystemd_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stop provides a mock function with given fields: ctx, service
+func (_m *MockSystemd) Stop(ctx context.Context, service string) error {
+	ret := _m.Called(ctx, service)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stop")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, service)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSystemd_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
+type MockSystemd_Stop_Call struct {
+	*mock.Call
+}
+
+// Stop is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+func (_e *MockSystemd_Expecter) Stop(ctx interface{}, service interface{}) *MockSystemd_Stop_Call {
+	return &MockSystemd_Stop_Call{Call: _e.mock.On("Stop", ctx, service)}
+}
+
+func (_c *MockSystemd_Stop_Call) Run(run func(ctx context.Context, service string)) *MockSystemd_Stop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_Stop_Call) Return(_a0 error) *MockSystemd_Stop_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSystemd_Stop_Call) RunAndReturn(run func(context.Context, string) error) *MockSystemd_Stop_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unmask provides a mock function with given fields: ctx, service, runtime
+func (_m *MockSystemd) Unmask(ctx context.Context, service string, runtime bool) error {
+	ret := _m.Called(ctx, service, runtime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unmask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, service, runtime)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSystemd_Unmask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unmask'
+type MockSystemd_Unmask_Call struct {
+	*mock.Call
+}
+
+// Unmask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+//   - runtime bool
+func (_e *MockSystemd_Expecter) Unmask(ctx interface{}, service interface{}, runtime interface{}) *MockSystemd_Unmask_Call {
+	return &MockSystemd_Unmask_Call{Call: _e.mock.On("Unmask", ctx, service, runtime)}
+}
+
+func (_c *MockSystemd_Unmask_Call) Run(run func(ctx context.Context, service string, runtime bool)) *MockSystemd_Unmask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_Unmask_Call) Return(_a0 error) *MockSystemd_Unmask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSystemd_Unmask_Call) RunAndReturn(run func(context.Context, string, bool) error) *MockSystemd_Unmask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WaitForActiveState provides a mock function with given fields: ctx, service, options
+func (_m *MockSystemd) WaitForActiveState(ctx context.Context, service string, options systemd.WaitForActiveStateOptions) (string, error) {
+	ret := _m.Called(ctx, service, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitForActiveState")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, systemd.WaitForActiveStateOptions) (string, error)); ok {
+		return rf(ctx, service, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, systemd.WaitForActiveStateOptions) string); ok {
+		r0 = rf(ctx, service, options)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, systemd.WaitForActiveStateOptions) error); ok {
+		r1 = rf(ctx, service, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSystemd_WaitForActiveState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WaitForActiveState'
+type MockSystemd_WaitForActiveState_Call struct {
+	*mock.Call
+}
+
+// WaitForActiveState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - service string
+//   - options systemd.WaitForActiveStateOptions
+func (_e *MockSystemd_Expecter) WaitForActiveState(ctx interface{}, service interface{}, options interface{}) *MockSystemd_WaitForActiveState_Call {
+	return &MockSystemd_WaitForActiveState_Call{Call: _e.mock.On("WaitForActiveState", ctx, service, options)}
+}
+
+func (_c *MockSystemd_WaitForActiveState_Call) Run(run func(ctx context.Context, service string, options systemd.WaitForActiveStateOptions)) *MockSystemd_WaitForActiveState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(systemd.WaitForActiveStateOptions))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_WaitForActiveState_Call) Return(_a0 string, _a1 error) *MockSystemd_WaitForActiveState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSystemd_WaitForActiveState_Call) RunAndReturn(run func(context.Context, string, systemd.WaitForActiveStateOptions) (string, error)) *MockSystemd_WaitForActiveState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WriteDropIn provides a mock function with given fields: ctx, unit, name, content
+func (_m *MockSystemd) WriteDropIn(ctx context.Context, unit string, name string, content []byte) error {
+	ret := _m.Called(ctx, unit, name, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteDropIn")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) error); ok {
+		r0 = rf(ctx, unit, name, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSystemd_WriteDropIn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteDropIn'
+type MockSystemd_WriteDropIn_Call struct {
+	*mock.Call
+}
+
+// WriteDropIn is a helper method to define mock.On call
+//   - ctx context.Context
+//   - unit string
+//   - name string
+//   - content []byte
+func (_e *MockSystemd_Expecter) WriteDropIn(ctx interface{}, unit interface{}, name interface{}, content interface{}) *MockSystemd_WriteDropIn_Call {
+	return &MockSystemd_WriteDropIn_Call{Call: _e.mock.On("WriteDropIn", ctx, unit, name, content)}
+}
+
+func (_c *MockSystemd_WriteDropIn_Call) Run(run func(ctx context.Context, unit string, name string, content []byte)) *MockSystemd_WriteDropIn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockSystemd_WriteDropIn_Call) Return(_a0 error) *MockSystemd_WriteDropIn_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSystemd_WriteDropIn_Call) RunAndReturn(run func(context.Context, string, string, []byte) error) *MockSystemd_WriteDropIn_Call {
 	_c.Call.Return(run)
 	return _c
 }
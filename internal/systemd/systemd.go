@@ -5,22 +5,68 @@ package systemd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
 
 	"github.com/trento-project/workbench/internal/dbus"
 )
 
+// UnitState is the installation and activation state of a unit, as reported by
+// the UnitFileState and ActiveState properties, e.g. enabled-runtime and active
+type UnitState struct {
+	UnitFileState string
+	ActiveState   string
+}
+
+// UnitFileChange is a single change applied to the unit file symlinks when enabling or disabling a unit.
+// Type is symlink when the link in Filename pointing to Destination was created and unlink when
+// the link in Filename was removed.
+type UnitFileChange struct {
+	Type        string
+	Filename    string
+	Destination string
+}
+
+const (
+	UnitFileChangeSymlink = "symlink"
+	UnitFileChangeUnlink  = "unlink"
+)
+
 type Systemd interface {
-	Enable(ctx context.Context, service string) error
-	Disable(ctx context.Context, service string) error
+	Enable(ctx context.Context, service string) ([]UnitFileChange, error)
+	EnableRuntime(ctx context.Context, service string) ([]UnitFileChange, error)
+	Disable(ctx context.Context, service string) ([]UnitFileChange, error)
+	DisableRuntime(ctx context.Context, service string) ([]UnitFileChange, error)
+	RevertUnitFileChanges(ctx context.Context, service string, changes []UnitFileChange) error
+	Mask(ctx context.Context, service string, runtime bool) error
+	Unmask(ctx context.Context, service string, runtime bool) error
 	IsEnabled(ctx context.Context, service string) (bool, error)
+	GetUnitState(ctx context.Context, service string) (UnitState, error)
+	Start(ctx context.Context, service string) error
+	Stop(ctx context.Context, service string) error
+	Restart(ctx context.Context, service string) error
+	Reload(ctx context.Context, service string) error
+	IsActive(ctx context.Context, service string) (bool, error)
+	GetActiveState(ctx context.Context, service string) (string, error)
+	GetSubState(ctx context.Context, service string) (string, error)
+	WaitForActiveState(ctx context.Context, service string, options WaitForActiveStateOptions) (string, error)
+	DropInPath(unit, name string) string
+	GetDropIn(ctx context.Context, unit, name string) ([]byte, bool, error)
+	WriteDropIn(ctx context.Context, unit, name string, content []byte) error
+	RemoveDropIn(ctx context.Context, unit, name string) error
+	GetDropInPaths(ctx context.Context, unit string) ([]string, error)
+	IsDirectiveApplied(ctx context.Context, unit, section string, directive DropInDirective) (bool, error)
+	DaemonReload(ctx context.Context) error
 	Close()
 }
 
 type Connector struct {
 	dbusConnection dbus.Connector
 	logger         *slog.Logger
+	unitDirectory  string
 }
 
 type ConnectorOption func(*Connector)
@@ -43,6 +89,13 @@ func NewDefaultSystemdLoader() Loader {
 	return &defaultSystemdLoader{}
 }
 
+// WithUnitDirectory sets the directory where the unit drop-ins are stored, /etc/systemd/system by default
+func WithUnitDirectory(unitDirectory string) ConnectorOption {
+	return func(s *Connector) {
+		s.unitDirectory = unitDirectory
+	}
+}
+
 func WithCustomDbusConnector(dbusConnection dbus.Connector) ConnectorOption {
 	return func(s *Connector) {
 		s.dbusConnection = dbusConnection
@@ -51,7 +104,8 @@ func WithCustomDbusConnector(dbusConnection dbus.Connector) ConnectorOption {
 
 func NewSystemd(ctx context.Context, logger *slog.Logger, options ...ConnectorOption) (Systemd, error) {
 	systemdInstance := &Connector{
-		logger: logger,
+		logger:        logger,
+		unitDirectory: DefaultUnitDirectory,
 	}
 
 	for _, opt := range options {
@@ -62,7 +116,7 @@ func NewSystemd(ctx context.Context, logger *slog.Logger, options ...ConnectorOp
 		return systemdInstance, nil
 	}
 
-	dbusConnection, err := dbus.NewConnector(ctx)
+	dbusConnection, err := dbus.DefaultManager().Connection(ctx)
 	if err != nil {
 		logger.Error("failed to create dbus connection", "error", err)
 		return nil, err
@@ -72,48 +126,212 @@ func NewSystemd(ctx context.Context, logger *slog.Logger, options ...ConnectorOp
 	return systemdInstance, nil
 }
 
-func (s *Connector) Enable(ctx context.Context, service string) error {
-	_, _, err := s.dbusConnection.EnableUnitFilesContext(ctx, []string{service}, false, true)
+// Enable enables the service and returns the symlinks created or removed by systemd
+func (s *Connector) Enable(ctx context.Context, service string) ([]UnitFileChange, error) {
+	return s.enable(ctx, service, false)
+}
+
+// EnableRuntime enables the service only until the next reboot, linking the unit in /run
+func (s *Connector) EnableRuntime(ctx context.Context, service string) ([]UnitFileChange, error) {
+	return s.enable(ctx, service, true)
+}
+
+// Disable disables the service and returns the symlinks removed by systemd
+func (s *Connector) Disable(ctx context.Context, service string) ([]UnitFileChange, error) {
+	return s.disable(ctx, service, false)
+}
+
+// DisableRuntime removes the runtime enablement of the service
+func (s *Connector) DisableRuntime(ctx context.Context, service string) ([]UnitFileChange, error) {
+	return s.disable(ctx, service, true)
+}
+
+// RevertUnitFileChanges undoes the changes returned by an enable or disable operation:
+// created symlinks are removed and removed symlinks are created again.
+// Removed symlinks without a known destination are pointed to the unit fragment path.
+func (s *Connector) RevertUnitFileChanges(ctx context.Context, service string, changes []UnitFileChange) error {
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+
+		switch change.Type {
+		case UnitFileChangeSymlink:
+			if err := os.Remove(change.Filename); err != nil && !errors.Is(err, os.ErrNotExist) {
+				s.logger.Error("failed to remove unit file symlink", "service", service, "file", change.Filename, "error", err)
+				return fmt.Errorf("failed to remove symlink %s of service %s: %w", change.Filename, service, err)
+			}
+		case UnitFileChangeUnlink:
+			destination := change.Destination
+			if destination == "" {
+				fragmentPath, err := s.getStringProperty(ctx, service, "FragmentPath", "fragment path")
+				if err != nil {
+					return err
+				}
+				destination = fragmentPath
+			}
+
+			if err := os.MkdirAll(filepath.Dir(change.Filename), 0o755); err != nil {
+				return fmt.Errorf("failed to create directory for symlink %s of service %s: %w", change.Filename, service, err)
+			}
+			if err := os.Symlink(destination, change.Filename); err != nil && !errors.Is(err, os.ErrExist) {
+				s.logger.Error("failed to restore unit file symlink", "service", service, "file", change.Filename, "error", err)
+				return fmt.Errorf("failed to restore symlink %s of service %s: %w", change.Filename, service, err)
+			}
+		default:
+			return fmt.Errorf("unknown unit file change type %s for service %s", change.Type, service)
+		}
+	}
+
+	return s.reload(ctx, service)
+}
+
+// Mask links the unit to /dev/null so it cannot be started, not even manually.
+// Runtime masks are removed with the next reboot.
+func (s *Connector) Mask(ctx context.Context, service string, runtime bool) error {
+	_, err := s.dbusConnection.MaskUnitFilesContext(ctx, []string{service}, runtime, true)
 	if err != nil {
-		s.logger.Error("failed to enable service", "service", service, "error", err)
-		return fmt.Errorf("failed to enable service %s: %w", service, err)
+		s.logger.Error("failed to mask service", "service", service, "runtime", runtime, "error", err)
+		return fmt.Errorf("failed to mask service %s: %w", service, err)
 	}
 
 	return s.reload(ctx, service)
 }
 
-func (s *Connector) Disable(ctx context.Context, service string) error {
-	_, err := s.dbusConnection.DisableUnitFilesContext(ctx, []string{service}, false)
+func (s *Connector) Unmask(ctx context.Context, service string, runtime bool) error {
+	_, err := s.dbusConnection.UnmaskUnitFilesContext(ctx, []string{service}, runtime)
 	if err != nil {
-		s.logger.Error("failed to disable service", "service", service, "error", err)
-		return fmt.Errorf("failed to disable service %s: %w", service, err)
+		s.logger.Error("failed to unmask service", "service", service, "runtime", runtime, "error", err)
+		return fmt.Errorf("failed to unmask service %s: %w", service, err)
 	}
 
 	return s.reload(ctx, service)
 }
 
 func (s *Connector) IsEnabled(ctx context.Context, service string) (bool, error) {
-	unitFileState, err := s.dbusConnection.GetUnitPropertyContext(ctx, service, "UnitFileState")
+	unitFileState, err := s.getStringProperty(ctx, service, "UnitFileState", "unit file state")
 	if err != nil {
-		s.logger.Error("failed to get unit file state for service", "service", service, "error", err)
-		return false, fmt.Errorf("failed to get unit file state for service %s: %w", service, err)
+		return false, err
 	}
 
-	value, ok := unitFileState.Value.Value().(string)
-	if !ok {
-		s.logger.Error("unexpected type for unit file state", "service", service,
-			"type", fmt.Sprintf("%T", unitFileState.Value.Value()))
-		return false, fmt.Errorf("unexpected type for unit file state of service %s: %T",
-			service, unitFileState.Value.Value())
+	return unitFileState == "enabled", nil
+}
+
+// GetUnitState returns the unit file state and the active state of the service
+func (s *Connector) GetUnitState(ctx context.Context, service string) (UnitState, error) {
+	unitFileState, err := s.getStringProperty(ctx, service, "UnitFileState", "unit file state")
+	if err != nil {
+		return UnitState{}, err
+	}
+
+	activeState, err := s.getStringProperty(ctx, service, "ActiveState", "active state")
+	if err != nil {
+		return UnitState{}, err
 	}
 
-	return value == "enabled", nil
+	return UnitState{UnitFileState: unitFileState, ActiveState: activeState}, nil
+}
+
+// Start starts the service and waits until the start job finishes
+func (s *Connector) Start(ctx context.Context, service string) error {
+	return s.runJob(ctx, "start", service, s.dbusConnection.StartUnitContext)
+}
+
+// Stop stops the service and waits until the stop job finishes
+func (s *Connector) Stop(ctx context.Context, service string) error {
+	return s.runJob(ctx, "stop", service, s.dbusConnection.StopUnitContext)
+}
+
+// Restart restarts the service, starting it if it is not running, and waits until the restart job finishes
+func (s *Connector) Restart(ctx context.Context, service string) error {
+	return s.runJob(ctx, "restart", service, s.dbusConnection.RestartUnitContext)
+}
+
+// Reload asks the service to reload its configuration and waits until the reload job finishes
+func (s *Connector) Reload(ctx context.Context, service string) error {
+	return s.runJob(ctx, "reload", service, s.dbusConnection.ReloadUnitContext)
+}
+
+func (s *Connector) IsActive(ctx context.Context, service string) (bool, error) {
+	activeState, err := s.getStringProperty(ctx, service, "ActiveState", "active state")
+	if err != nil {
+		return false, err
+	}
+
+	return activeState == "active", nil
+}
+
+// GetActiveState returns the high level activation state of the service, e.g. active or failed
+func (s *Connector) GetActiveState(ctx context.Context, service string) (string, error) {
+	return s.getStringProperty(ctx, service, "ActiveState", "active state")
+}
+
+// GetSubState returns the low level activation state of the service, which depends on the
+// unit type, e.g. running or exited
+func (s *Connector) GetSubState(ctx context.Context, service string) (string, error) {
+	return s.getStringProperty(ctx, service, "SubState", "sub state")
 }
 
 func (s *Connector) Close() {
 	s.dbusConnection.Close()
 }
 
+func (s *Connector) enable(ctx context.Context, service string, runtime bool) ([]UnitFileChange, error) {
+	_, enableChanges, err := s.dbusConnection.EnableUnitFilesContext(ctx, []string{service}, runtime, true)
+	if err != nil {
+		s.logger.Error("failed to enable service", "service", service, "runtime", runtime, "error", err)
+		return nil, fmt.Errorf("failed to enable service %s: %w", service, err)
+	}
+
+	changes := make([]UnitFileChange, 0, len(enableChanges))
+	for _, change := range enableChanges {
+		changes = append(changes, UnitFileChange(change))
+	}
+
+	return changes, s.reload(ctx, service)
+}
+
+func (s *Connector) disable(ctx context.Context, service string, runtime bool) ([]UnitFileChange, error) {
+	disableChanges, err := s.dbusConnection.DisableUnitFilesContext(ctx, []string{service}, runtime)
+	if err != nil {
+		s.logger.Error("failed to disable service", "service", service, "runtime", runtime, "error", err)
+		return nil, fmt.Errorf("failed to disable service %s: %w", service, err)
+	}
+
+	changes := make([]UnitFileChange, 0, len(disableChanges))
+	for _, change := range disableChanges {
+		changes = append(changes, UnitFileChange(change))
+	}
+
+	return changes, s.reload(ctx, service)
+}
+
+// getStringProperty reads a string property of the unit. The description is used in the error messages.
+func (s *Connector) getStringProperty(ctx context.Context, service, property, description string) (string, error) {
+	unitProperty, err := s.dbusConnection.GetUnitPropertyContext(ctx, service, property)
+	if err != nil {
+		s.logger.Error("failed to get "+description+" for service", "service", service, "error", err)
+		return "", fmt.Errorf("failed to get %s for service %s: %w", description, service, err)
+	}
+
+	value, ok := unitProperty.Value.Value().(string)
+	if !ok {
+		s.logger.Error("unexpected type for "+description, "service", service,
+			"type", fmt.Sprintf("%T", unitProperty.Value.Value()))
+		return "", fmt.Errorf("unexpected type for %s of service %s: %T",
+			description, service, unitProperty.Value.Value())
+	}
+
+	return value, nil
+}
+
+// DaemonReload reloads the systemd manager configuration, including the unit files and drop-ins
+func (s *Connector) DaemonReload(ctx context.Context) error {
+	if err := s.dbusConnection.ReloadContext(ctx); err != nil {
+		s.logger.Error("failed to reload systemd configuration", "error", err)
+		return fmt.Errorf("failed to reload systemd configuration: %w", err)
+	}
+	return nil
+}
+
 func (s *Connector) reload(ctx context.Context, service string) error {
 	err := s.dbusConnection.ReloadContext(ctx)
 	if err != nil {
@@ -122,3 +340,29 @@ func (s *Connector) reload(ctx context.Context, service string) error {
 	}
 	return nil
 }
+
+type unitJobFunc func(ctx context.Context, name string, mode string, ch chan<- string) (int, error)
+
+// runJob queues a unit job in replace mode and waits for its result.
+// The job result is "done" on success, any other value is considered a failure.
+func (s *Connector) runJob(ctx context.Context, action, service string, job unitJobFunc) error {
+	resultChannel := make(chan string, 1)
+
+	jobID, err := job(ctx, service, "replace", resultChannel)
+	if err != nil {
+		s.logger.Error("failed to "+action+" service", "service", service, "error", err)
+		return fmt.Errorf("failed to %s service %s: %w", action, service, err)
+	}
+	s.logger.Debug("service job queued", "action", action, "service", service, "job", jobID)
+
+	select {
+	case result := <-resultChannel:
+		if result != "done" {
+			s.logger.Error("service job failed", "action", action, "service", service, "job", jobID, "result", result)
+			return fmt.Errorf("failed to %s service %s: job result %s", action, service, result)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to %s service %s: %w", action, service, ctx.Err())
+	}
+}
@@ -7,10 +7,13 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/coreos/go-systemd/v22/dbus"
 	innerDbus "github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/trento-project/workbench/internal/dbus/mocks"
 	"github.com/trento-project/workbench/internal/support"
@@ -135,7 +138,7 @@ func (suite *SystemdTestSuite) TestEnableServiceFailure() {
 		systemd.WithCustomDbusConnector(suite.dbusMock),
 	)
 
-	err := systemdConnector.Enable(ctx, "foo.service")
+	_, err := systemdConnector.Enable(ctx, "foo.service")
 
 	suite.Error(err)
 	suite.ErrorContains(err, "failed to enable service foo.service: exit status 1")
@@ -169,7 +172,7 @@ func (suite *SystemdTestSuite) TestEnableServiceFailureOnReload() {
 		systemd.WithCustomDbusConnector(suite.dbusMock),
 	)
 
-	err := systemdConnector.Enable(ctx, "foo.service")
+	_, err := systemdConnector.Enable(ctx, "foo.service")
 
 	suite.Error(err)
 	suite.ErrorContains(err, "failed to reload service foo.service: exit status 1")
@@ -203,7 +206,7 @@ func (suite *SystemdTestSuite) TestSuccessfulEnableService() {
 		systemd.WithCustomDbusConnector(suite.dbusMock),
 	)
 
-	err := systemdConnector.Enable(ctx, "foo.service")
+	_, err := systemdConnector.Enable(ctx, "foo.service")
 
 	suite.NoError(err)
 }
@@ -227,7 +230,7 @@ func (suite *SystemdTestSuite) TestDisableServiceFailure() {
 		systemd.WithCustomDbusConnector(suite.dbusMock),
 	)
 
-	err := systemdConnector.Disable(ctx, "foo.service")
+	_, err := systemdConnector.Disable(ctx, "foo.service")
 
 	suite.Error(err)
 	suite.ErrorContains(err, "failed to disable service foo.service: exit status 1")
@@ -259,7 +262,7 @@ func (suite *SystemdTestSuite) TestDisableServiceFailureOnReload() {
 		systemd.WithCustomDbusConnector(suite.dbusMock),
 	)
 
-	err := systemdConnector.Disable(ctx, "foo.service")
+	_, err := systemdConnector.Disable(ctx, "foo.service")
 
 	suite.Error(err)
 	suite.ErrorContains(err, "failed to reload service foo.service: exit status 1")
@@ -291,7 +294,503 @@ func (suite *SystemdTestSuite) TestSuccessfulDisableService() {
 		systemd.WithCustomDbusConnector(suite.dbusMock),
 	)
 
-	err := systemdConnector.Disable(ctx, "foo.service")
+	_, err := systemdConnector.Disable(ctx, "foo.service")
 
 	suite.NoError(err)
 }
+
+func (suite *SystemdTestSuite) TestServiceIsActive() {
+	ctx := context.Background()
+
+	suite.dbusMock.On(
+		"GetUnitPropertyContext",
+		ctx,
+		"foo.service",
+		"ActiveState",
+	).Return(&dbus.Property{
+		Name:  "ActiveState",
+		Value: innerDbus.MakeVariant("active"),
+	}, nil).
+		Once()
+	suite.dbusMock.On(
+		"GetUnitPropertyContext",
+		ctx,
+		"bar.service",
+		"ActiveState",
+	).Return(&dbus.Property{
+		Name:  "ActiveState",
+		Value: innerDbus.MakeVariant("inactive"),
+	}, nil).
+		Once()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	active, err := systemdConnector.IsActive(ctx, "foo.service")
+	suite.NoError(err)
+	suite.True(active)
+
+	active, err = systemdConnector.IsActive(ctx, "bar.service")
+	suite.NoError(err)
+	suite.False(active)
+}
+
+func (suite *SystemdTestSuite) TestStartService() {
+	ctx := context.Background()
+
+	suite.dbusMock.On(
+		"StartUnitContext",
+		ctx,
+		"foo.service",
+		"replace",
+		mock.AnythingOfType("chan<- string"),
+	).Run(func(args mock.Arguments) {
+		args.Get(3).(chan<- string) <- "done"
+	}).Return(1, nil).
+		Once()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	suite.NoError(systemdConnector.Start(ctx, "foo.service"))
+}
+
+func (suite *SystemdTestSuite) TestStartServiceJobFailure() {
+	ctx := context.Background()
+
+	suite.dbusMock.On(
+		"StartUnitContext",
+		ctx,
+		"foo.service",
+		"replace",
+		mock.AnythingOfType("chan<- string"),
+	).Run(func(args mock.Arguments) {
+		args.Get(3).(chan<- string) <- "failed"
+	}).Return(1, nil).
+		Once()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	err := systemdConnector.Start(ctx, "foo.service")
+	suite.EqualError(err, "failed to start service foo.service: job result failed")
+}
+
+func (suite *SystemdTestSuite) TestStopServiceFailure() {
+	ctx := context.Background()
+
+	suite.dbusMock.On(
+		"StopUnitContext",
+		ctx,
+		"foo.service",
+		"replace",
+		mock.AnythingOfType("chan<- string"),
+	).Return(0, errors.New("Unit foo.service not loaded.")).
+		Once()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	err := systemdConnector.Stop(ctx, "foo.service")
+	suite.EqualError(err, "failed to stop service foo.service: Unit foo.service not loaded.")
+}
+
+func (suite *SystemdTestSuite) TestStopServiceCancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	suite.dbusMock.On(
+		"StopUnitContext",
+		ctx,
+		"foo.service",
+		"replace",
+		mock.AnythingOfType("chan<- string"),
+	).Run(func(_ mock.Arguments) {
+		cancel()
+	}).Return(1, nil).
+		Once()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	err := systemdConnector.Stop(ctx, "foo.service")
+	suite.ErrorIs(err, context.Canceled)
+}
+
+func (suite *SystemdTestSuite) TestGetUnitState() {
+	ctx := context.Background()
+
+	suite.dbusMock.On(
+		"GetUnitPropertyContext",
+		ctx,
+		"foo.service",
+		"UnitFileState",
+	).Return(&dbus.Property{
+		Name:  "UnitFileState",
+		Value: innerDbus.MakeVariant("enabled-runtime"),
+	}, nil).
+		Once()
+	suite.dbusMock.On(
+		"GetUnitPropertyContext",
+		ctx,
+		"foo.service",
+		"ActiveState",
+	).Return(&dbus.Property{
+		Name:  "ActiveState",
+		Value: innerDbus.MakeVariant("failed"),
+	}, nil).
+		Once()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	state, err := systemdConnector.GetUnitState(ctx, "foo.service")
+	suite.NoError(err)
+	suite.Equal(systemd.UnitState{UnitFileState: "enabled-runtime", ActiveState: "failed"}, state)
+}
+
+func (suite *SystemdTestSuite) TestGetUnitStateUnexpectedType() {
+	ctx := context.Background()
+
+	suite.dbusMock.On(
+		"GetUnitPropertyContext",
+		ctx,
+		"foo.service",
+		"UnitFileState",
+	).Return(&dbus.Property{
+		Name:  "UnitFileState",
+		Value: innerDbus.MakeVariant(1),
+	}, nil).
+		Once()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	_, err := systemdConnector.GetUnitState(ctx, "foo.service")
+	suite.EqualError(err, "unexpected type for unit file state of service foo.service: int")
+}
+
+func (suite *SystemdTestSuite) TestSuccessfulEnableRuntimeService() {
+	ctx := context.Background()
+
+	enableCall := suite.dbusMock.On(
+		"EnableUnitFilesContext",
+		ctx,
+		[]string{"foo.service"},
+		true,
+		true,
+	).Return(
+		true,
+		[]dbus.EnableUnitFileChange{},
+		nil,
+	).Once()
+
+	suite.dbusMock.On(
+		"ReloadContext",
+		ctx,
+	).Return(nil).
+		Once().
+		NotBefore(enableCall)
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	_, err := systemdConnector.EnableRuntime(ctx, "foo.service")
+
+	suite.NoError(err)
+}
+
+func (suite *SystemdTestSuite) TestSuccessfulMaskService() {
+	ctx := context.Background()
+
+	maskCall := suite.dbusMock.On(
+		"MaskUnitFilesContext",
+		ctx,
+		[]string{"foo.service"},
+		true,
+		true,
+	).Return(
+		[]dbus.MaskUnitFileChange{},
+		nil,
+	).Once()
+
+	suite.dbusMock.On(
+		"ReloadContext",
+		ctx,
+	).Return(nil).
+		Once().
+		NotBefore(maskCall)
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	err := systemdConnector.Mask(ctx, "foo.service", true)
+
+	suite.NoError(err)
+}
+
+func (suite *SystemdTestSuite) TestUnmaskServiceFailure() {
+	ctx := context.Background()
+
+	suite.dbusMock.On(
+		"UnmaskUnitFilesContext",
+		ctx,
+		[]string{"foo.service"},
+		false,
+	).Return(
+		nil,
+		errors.New("access denied"),
+	).Once()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	err := systemdConnector.Unmask(ctx, "foo.service", false)
+
+	suite.EqualError(err, "failed to unmask service foo.service: access denied")
+}
+
+func (suite *SystemdTestSuite) TestRestartService() {
+	ctx := context.Background()
+
+	suite.dbusMock.On(
+		"RestartUnitContext",
+		ctx,
+		"foo.service",
+		"replace",
+		mock.AnythingOfType("chan<- string"),
+	).Run(func(args mock.Arguments) {
+		args.Get(3).(chan<- string) <- "done"
+	}).Return(2, nil).
+		Once()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	suite.NoError(systemdConnector.Restart(ctx, "foo.service"))
+}
+
+func (suite *SystemdTestSuite) TestReloadServiceJobFailure() {
+	ctx := context.Background()
+
+	suite.dbusMock.On(
+		"ReloadUnitContext",
+		ctx,
+		"foo.service",
+		"replace",
+		mock.AnythingOfType("chan<- string"),
+	).Run(func(args mock.Arguments) {
+		args.Get(3).(chan<- string) <- "dependency"
+	}).Return(3, nil).
+		Once()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	err := systemdConnector.Reload(ctx, "foo.service")
+	suite.EqualError(err, "failed to reload service foo.service: job result dependency")
+}
+
+func (suite *SystemdTestSuite) TestGetActiveAndSubState() {
+	ctx := context.Background()
+
+	suite.dbusMock.On(
+		"GetUnitPropertyContext",
+		ctx,
+		"foo.service",
+		"ActiveState",
+	).Return(&dbus.Property{
+		Name:  "ActiveState",
+		Value: innerDbus.MakeVariant("active"),
+	}, nil).
+		Once()
+	suite.dbusMock.On(
+		"GetUnitPropertyContext",
+		ctx,
+		"foo.service",
+		"SubState",
+	).Return(nil, errors.New("unit not loaded")).
+		Once()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	activeState, err := systemdConnector.GetActiveState(ctx, "foo.service")
+	suite.NoError(err)
+	suite.Equal("active", activeState)
+
+	_, err = systemdConnector.GetSubState(ctx, "foo.service")
+	suite.EqualError(err, "failed to get sub state for service foo.service: unit not loaded")
+}
+
+func (suite *SystemdTestSuite) TestEnableServiceReturnsChanges() {
+	ctx := context.Background()
+
+	suite.dbusMock.On(
+		"EnableUnitFilesContext",
+		ctx,
+		[]string{"foo.service"},
+		false,
+		true,
+	).Return(
+		true,
+		[]dbus.EnableUnitFileChange{
+			{
+				Type:        "symlink",
+				Filename:    "/etc/systemd/system/multi-user.target.wants/foo.service",
+				Destination: "/usr/lib/systemd/system/foo.service",
+			},
+		},
+		nil,
+	).Once()
+
+	suite.dbusMock.On("ReloadContext", ctx).Return(nil).Once()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	changes, err := systemdConnector.Enable(ctx, "foo.service")
+
+	suite.NoError(err)
+	suite.Equal([]systemd.UnitFileChange{
+		{
+			Type:        systemd.UnitFileChangeSymlink,
+			Filename:    "/etc/systemd/system/multi-user.target.wants/foo.service",
+			Destination: "/usr/lib/systemd/system/foo.service",
+		},
+	}, changes)
+}
+
+func (suite *SystemdTestSuite) TestDisableServiceReturnsChanges() {
+	ctx := context.Background()
+
+	suite.dbusMock.On(
+		"DisableUnitFilesContext",
+		ctx,
+		[]string{"foo.service"},
+		true,
+	).Return(
+		[]dbus.DisableUnitFileChange{
+			{
+				Type:     "unlink",
+				Filename: "/run/systemd/system/multi-user.target.wants/foo.service",
+			},
+		},
+		nil,
+	).Once()
+
+	suite.dbusMock.On("ReloadContext", ctx).Return(nil).Once()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	changes, err := systemdConnector.DisableRuntime(ctx, "foo.service")
+
+	suite.NoError(err)
+	suite.Equal([]systemd.UnitFileChange{
+		{
+			Type:     systemd.UnitFileChangeUnlink,
+			Filename: "/run/systemd/system/multi-user.target.wants/foo.service",
+		},
+	}, changes)
+}
+
+func (suite *SystemdTestSuite) TestRevertUnitFileChanges() {
+	ctx := context.Background()
+	directory := suite.T().TempDir()
+	createdLink := filepath.Join(directory, "multi-user.target.wants", "foo.service")
+	removedLink := filepath.Join(directory, "sockets.target.wants", "foo.service")
+	unitFile := filepath.Join(directory, "foo.service")
+
+	suite.Require().NoError(os.MkdirAll(filepath.Dir(createdLink), 0o755))
+	suite.Require().NoError(os.Symlink(unitFile, createdLink))
+
+	suite.dbusMock.On(
+		"GetUnitPropertyContext",
+		ctx,
+		"foo.service",
+		"FragmentPath",
+	).Return(&dbus.Property{
+		Name:  "FragmentPath",
+		Value: innerDbus.MakeVariant(unitFile),
+	}, nil).Once()
+
+	suite.dbusMock.On("ReloadContext", ctx).Return(nil).Once()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	err := systemdConnector.RevertUnitFileChanges(ctx, "foo.service", []systemd.UnitFileChange{
+		{Type: systemd.UnitFileChangeSymlink, Filename: createdLink, Destination: unitFile},
+		{Type: systemd.UnitFileChangeUnlink, Filename: removedLink},
+	})
+
+	suite.NoError(err)
+	suite.NoFileExists(createdLink)
+	destination, err := os.Readlink(removedLink)
+	suite.NoError(err)
+	suite.Equal(unitFile, destination)
+}
+
+func (suite *SystemdTestSuite) TestRevertUnitFileChangesUnknownType() {
+	ctx := context.Background()
+
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+
+	err := systemdConnector.RevertUnitFileChanges(ctx, "foo.service", []systemd.UnitFileChange{
+		{Type: "copy", Filename: "/etc/systemd/system/foo.service"},
+	})
+
+	suite.EqualError(err, "unknown unit file change type copy for service foo.service")
+}
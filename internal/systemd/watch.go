@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const defaultActiveStateTimeout = 30 * time.Second
+
+type WaitForActiveStateOptions struct {
+	// States are the accepted active states, e.g. inactive and failed for a stopped unit.
+	States []string
+	// Timeout is the maximum time to wait until the unit reaches one of the states. Defaults to 30 seconds.
+	Timeout time.Duration
+}
+
+// WaitForActiveState waits until the unit reaches one of the given active states.
+// Instead of polling, the unit state is updated with the PropertiesChanged and JobRemoved signals
+// sent by systemd. The last active state is returned, along with an error if the timeout expires first.
+func (s *Connector) WaitForActiveState(
+	ctx context.Context,
+	service string,
+	options WaitForActiveStateOptions,
+) (string, error) {
+	if options.Timeout <= 0 {
+		options.Timeout = defaultActiveStateTimeout
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	events, unsubscribe, err := s.dbusConnection.SubscribeUnitEvents(timeoutCtx)
+	if err != nil {
+		s.logger.Error("failed to subscribe to unit events", "service", service, "error", err)
+		return "", fmt.Errorf("failed to watch service %s: %w", service, err)
+	}
+	defer unsubscribe()
+
+	// the state is read once subscribed, so no change is lost in between
+	activeState, err := s.GetActiveState(timeoutCtx, service)
+	if err != nil {
+		return "", err
+	}
+
+	for !slices.Contains(options.States, activeState) {
+		s.logger.Debug("waiting for service state", "service", service, "active_state", activeState,
+			"expected", options.States)
+
+		select {
+		case <-timeoutCtx.Done():
+			if ctx.Err() != nil {
+				return activeState, ctx.Err()
+			}
+			return activeState, fmt.Errorf("timeout waiting for service %s to be %s, active state: %s",
+				service, strings.Join(options.States, " or "), activeState)
+		case event := <-events:
+			if event.Unit != service {
+				continue
+			}
+
+			if len(event.Properties) == 0 {
+				activeState, err = s.GetActiveState(timeoutCtx, service)
+				if err != nil {
+					return "", err
+				}
+				continue
+			}
+
+			if value, found := event.Properties["ActiveState"]; found {
+				if state, ok := value.Value().(string); ok {
+					activeState = state
+				}
+			}
+		}
+	}
+
+	return activeState, nil
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package systemd_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	innerDbus "github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	internalDbus "github.com/trento-project/workbench/internal/dbus"
+	"github.com/trento-project/workbench/internal/dbus/mocks"
+	"github.com/trento-project/workbench/internal/support"
+	"github.com/trento-project/workbench/internal/systemd"
+)
+
+type WatchTestSuite struct {
+	suite.Suite
+	dbusMock *mocks.MockConnector
+	logger   *slog.Logger
+}
+
+func TestWatch(t *testing.T) {
+	suite.Run(t, new(WatchTestSuite))
+}
+
+func (suite *WatchTestSuite) SetupTest() {
+	suite.dbusMock = mocks.NewMockConnector(suite.T())
+	suite.logger = support.NewDefaultLogger(slog.LevelInfo).With("test", "watch_test_suite")
+}
+
+func (suite *WatchTestSuite) newSystemd(ctx context.Context) systemd.Systemd {
+	systemdConnector, _ := systemd.NewSystemd(
+		ctx,
+		suite.logger,
+		systemd.WithCustomDbusConnector(suite.dbusMock),
+	)
+	return systemdConnector
+}
+
+func (suite *WatchTestSuite) expectSubscription(events chan internalDbus.UnitEvent) {
+	unsubscribed := false
+	suite.dbusMock.On("SubscribeUnitEvents", mock.Anything).
+		Return((<-chan internalDbus.UnitEvent)(events), func() { unsubscribed = true }, nil).
+		Once()
+	suite.T().Cleanup(func() {
+		suite.True(unsubscribed)
+	})
+}
+
+func (suite *WatchTestSuite) expectActiveState(state string) {
+	suite.dbusMock.On("GetUnitPropertyContext", mock.Anything, "foo.service", "ActiveState").
+		Return(&dbus.Property{Name: "ActiveState", Value: innerDbus.MakeVariant(state)}, nil).
+		Once()
+}
+
+func (suite *WatchTestSuite) TestWaitForActiveStateAlreadyReached() {
+	ctx := context.Background()
+	suite.expectSubscription(make(chan internalDbus.UnitEvent))
+	suite.expectActiveState("active")
+
+	state, err := suite.newSystemd(ctx).WaitForActiveState(ctx, "foo.service", systemd.WaitForActiveStateOptions{
+		States: []string{"active"},
+	})
+
+	suite.NoError(err)
+	suite.Equal("active", state)
+}
+
+func (suite *WatchTestSuite) TestWaitForActiveStatePropertiesChanged() {
+	ctx := context.Background()
+	events := make(chan internalDbus.UnitEvent, 3)
+	suite.expectSubscription(events)
+	suite.expectActiveState("activating")
+
+	events <- internalDbus.UnitEvent{
+		Unit:       "bar.service",
+		Properties: map[string]innerDbus.Variant{"ActiveState": innerDbus.MakeVariant("active")},
+	}
+	events <- internalDbus.UnitEvent{
+		Unit:       "foo.service",
+		Properties: map[string]innerDbus.Variant{"SubState": innerDbus.MakeVariant("start")},
+	}
+	events <- internalDbus.UnitEvent{
+		Unit:       "foo.service",
+		Properties: map[string]innerDbus.Variant{"ActiveState": innerDbus.MakeVariant("active")},
+	}
+
+	state, err := suite.newSystemd(ctx).WaitForActiveState(ctx, "foo.service", systemd.WaitForActiveStateOptions{
+		States: []string{"active"},
+	})
+
+	suite.NoError(err)
+	suite.Equal("active", state)
+}
+
+func (suite *WatchTestSuite) TestWaitForActiveStateJobRemoved() {
+	ctx := context.Background()
+	events := make(chan internalDbus.UnitEvent, 1)
+	suite.expectSubscription(events)
+	suite.expectActiveState("deactivating")
+	suite.expectActiveState("failed")
+
+	events <- internalDbus.UnitEvent{Unit: "foo.service"}
+
+	state, err := suite.newSystemd(ctx).WaitForActiveState(ctx, "foo.service", systemd.WaitForActiveStateOptions{
+		States: []string{"inactive", "failed"},
+	})
+
+	suite.NoError(err)
+	suite.Equal("failed", state)
+}
+
+func (suite *WatchTestSuite) TestWaitForActiveStateTimeout() {
+	ctx := context.Background()
+	suite.expectSubscription(make(chan internalDbus.UnitEvent))
+	suite.expectActiveState("activating")
+
+	state, err := suite.newSystemd(ctx).WaitForActiveState(ctx, "foo.service", systemd.WaitForActiveStateOptions{
+		States:  []string{"active"},
+		Timeout: 10 * time.Millisecond,
+	})
+
+	suite.EqualError(err, "timeout waiting for service foo.service to be active, active state: activating")
+	suite.Equal("activating", state)
+}
+
+func (suite *WatchTestSuite) TestWaitForActiveStateSubscriptionFailure() {
+	ctx := context.Background()
+	suite.dbusMock.On("SubscribeUnitEvents", mock.Anything).
+		Return(nil, nil, errors.New("access denied")).
+		Once()
+
+	_, err := suite.newSystemd(ctx).WaitForActiveState(ctx, "foo.service", systemd.WaitForActiveStateOptions{
+		States: []string{"active"},
+	})
+
+	suite.EqualError(err, "failed to watch service foo.service: access denied")
+}
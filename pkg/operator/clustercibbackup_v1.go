@@ -0,0 +1,281 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/trento-project/workbench/internal/cluster"
+)
+
+const (
+	ClusterCIBBackupOperatorName = "clustercibbackup"
+	defaultCIBBackupDirectory    = "/var/lib/workbench/cib"
+	cibChecksumFileExtension     = ".sha256"
+	snapshotWrittenField         = "snapshot_written"
+)
+
+type ClusterCIBBackupOption Option[ClusterCIBBackup]
+
+type cibVersionDiffOutput struct {
+	AdminEpoch int `json:"admin_epoch"`
+	Epoch      int `json:"epoch"`
+	NumUpdates int `json:"num_updates"`
+}
+
+type cibSnapshotDiffOutput struct {
+	Path       string                `json:"path"`
+	Exists     bool                  `json:"exists"`
+	Checksum   string                `json:"checksum,omitempty"`
+	CIBVersion *cibVersionDiffOutput `json:"cib_version,omitempty"`
+}
+
+// ClusterCIBBackup operator captures a snapshot of the Pacemaker CIB in a local file.
+//
+// Arguments:
+//  path: Absolute path of the snapshot file. Defaults to /var/lib/workbench/cib/cib-<operationID>.xml
+//
+// The snapshot is the `cibadmin --query` output. A sha256 checksum file, with the same path
+// and the .sha256 extension, is stored next to it using the sha256sum format.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   Checks that the cluster is running in the host and queries the live CIB.
+//   If the snapshot file already exists with the same CIB version and configuration,
+//   the operation is skipped. If it exists with different content, the operation fails.
+//
+// - COMMIT:
+//   Writes the snapshot and checksum files.
+//
+// - VERIFY:
+//   Reads the snapshot file back and validates it against the checksum file and the queried CIB.
+//
+// - ROLLBACK:
+//   Removes the snapshot and checksum files written by the operator.
+
+type ClusterCIBBackup struct {
+	baseOperator
+	clusterClient cluster.Cluster
+	operationID   string
+	path          string
+	cib           *cluster.CIB
+}
+
+func WithCustomClusterCIBBackupClient(clusterClient cluster.Cluster) ClusterCIBBackupOption {
+	return func(o *ClusterCIBBackup) {
+		o.clusterClient = clusterClient
+	}
+}
+
+func NewClusterCIBBackup(
+	arguments Arguments,
+	operationID string,
+	options Options[ClusterCIBBackup],
+) *Executor {
+	backup := &ClusterCIBBackup{
+		baseOperator:  newBaseOperator(ClusterCIBBackupOperatorName, operationID, arguments, options.BaseOperatorOptions...),
+		clusterClient: cluster.NewDefaultClusterClient(),
+		operationID:   operationID,
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(backup)
+	}
+
+	return &Executor{
+		phaser:      backup,
+		operationID: operationID,
+		logger:      backup.logger,
+	}
+}
+
+func (c *ClusterCIBBackup) plan(ctx context.Context) (bool, error) {
+	defaultPath := filepath.Join(defaultCIBBackupDirectory, fmt.Sprintf("cib-%s.xml", c.operationID))
+	path, err := parseCIBSnapshotPath(c.arguments, defaultPath)
+	if err != nil {
+		return false, err
+	}
+	c.path = path
+
+	if !c.clusterClient.IsHostOnline(ctx) {
+		return false, errors.New("cluster is not running on host")
+	}
+
+	cib, err := c.clusterClient.GetCIB(ctx)
+	if err != nil {
+		return false, err
+	}
+	c.cib = cib
+
+	if _, err := os.Stat(c.path); errors.Is(err, os.ErrNotExist) {
+		c.resources[beforeDiffField] = cibSnapshotDiffOutput{Path: c.path}
+		return false, nil
+	}
+
+	snapshot, err := readCIBSnapshot(c.path)
+	if err != nil {
+		return false, fmt.Errorf("snapshot file %s already exists and it is not valid: %w", c.path, err)
+	}
+
+	if !sameCIBConfiguration(snapshot, cib) {
+		return false, fmt.Errorf("snapshot file %s already exists with a different CIB version", c.path)
+	}
+
+	c.logger.Info("CIB snapshot already exists, skipping operation", "path", c.path)
+	c.resources[beforeDiffField] = newCIBSnapshotDiffOutput(c.path, snapshot)
+	c.resources[afterDiffField] = newCIBSnapshotDiffOutput(c.path, snapshot)
+
+	return true, nil
+}
+
+func (c *ClusterCIBBackup) commit(_ context.Context) error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return fmt.Errorf("error creating snapshot directory: %w", err)
+	}
+
+	c.resources[snapshotWrittenField] = true
+
+	if err := os.WriteFile(c.path, c.cib.Content, 0o600); err != nil {
+		return fmt.Errorf("error writing snapshot file: %w", err)
+	}
+
+	checksum := fmt.Sprintf("%s  %s\n", c.cib.Checksum(), filepath.Base(c.path))
+	if err := os.WriteFile(c.path+cibChecksumFileExtension, []byte(checksum), 0o600); err != nil {
+		return fmt.Errorf("error writing snapshot checksum file: %w", err)
+	}
+
+	c.logger.Info("CIB snapshot written", "path", c.path, "epoch", c.cib.Epoch, "admin_epoch", c.cib.AdminEpoch)
+
+	return nil
+}
+
+func (c *ClusterCIBBackup) verify(_ context.Context) error {
+	snapshot, err := readCIBSnapshot(c.path)
+	if err != nil {
+		return err
+	}
+
+	if snapshot.Checksum() != c.cib.Checksum() {
+		return fmt.Errorf("snapshot file %s content doesn't match the queried CIB", c.path)
+	}
+
+	c.resources[afterDiffField] = newCIBSnapshotDiffOutput(c.path, snapshot)
+
+	return nil
+}
+
+func (c *ClusterCIBBackup) rollback(_ context.Context) error {
+	if written, _ := c.resources[snapshotWrittenField].(bool); !written {
+		return nil
+	}
+
+	var rollbackErr error
+	for _, file := range []string{c.path, c.path + cibChecksumFileExtension} {
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			rollbackErr = errors.Join(rollbackErr, err)
+		}
+	}
+
+	return rollbackErr
+}
+
+func (c *ClusterCIBBackup) operationDiff(_ context.Context) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		diffOutput, ok := c.resources[field].(cibSnapshotDiffOutput)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to CIB snapshot diff output",
+				field, c.resources[field]))
+		}
+
+		output, err := json.Marshal(diffOutput)
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	return diff
+}
+
+func parseCIBSnapshotPath(rawArguments Arguments, defaultPath string) (string, error) {
+	pathArgument, found := rawArguments["path"]
+	if !found {
+		if defaultPath == "" {
+			return "", errors.New("argument path not provided, could not use the operator")
+		}
+		return defaultPath, nil
+	}
+
+	path, ok := pathArgument.(string)
+	if !ok {
+		return "", fmt.Errorf("could not parse path argument as string, argument provided: %v", pathArgument)
+	}
+
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("path argument must be an absolute path, argument provided: %s", path)
+	}
+
+	return filepath.Clean(path), nil
+}
+
+// readCIBSnapshot reads a CIB snapshot file, validating its content against the checksum file
+func readCIBSnapshot(path string) (*cluster.CIB, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot file: %w", err)
+	}
+
+	checksumContent, err := os.ReadFile(path + cibChecksumFileExtension)
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot checksum file: %w", err)
+	}
+
+	expectedChecksum, _, _ := strings.Cut(strings.TrimSpace(string(checksumContent)), " ")
+	sum := sha256.Sum256(content)
+	if checksum := hex.EncodeToString(sum[:]); checksum != expectedChecksum {
+		return nil, fmt.Errorf("snapshot file checksum mismatch, expected %s, found %s", expectedChecksum, checksum)
+	}
+
+	snapshot, err := cluster.ParseCIB(content)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing snapshot file: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+func sameCIBConfiguration(cib, other *cluster.CIB) bool {
+	return cib.AdminEpoch == other.AdminEpoch &&
+		cib.Epoch == other.Epoch &&
+		bytes.Equal(cib.Configuration, other.Configuration)
+}
+
+func newCIBVersionDiffOutput(cib *cluster.CIB) *cibVersionDiffOutput {
+	return &cibVersionDiffOutput{
+		AdminEpoch: cib.AdminEpoch,
+		Epoch:      cib.Epoch,
+		NumUpdates: cib.NumUpdates,
+	}
+}
+
+func newCIBSnapshotDiffOutput(path string, snapshot *cluster.CIB) cibSnapshotDiffOutput {
+	return cibSnapshotDiffOutput{
+		Path:       path,
+		Exists:     true,
+		Checksum:   snapshot.Checksum(),
+		CIBVersion: newCIBVersionDiffOutput(snapshot),
+	}
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/cluster/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+type ClusterCIBBackupOperatorTestSuite struct {
+	suite.Suite
+	mockClusterClient *mocks.MockCluster
+	snapshotPath      string
+}
+
+func TestClusterCIBBackupOperator(t *testing.T) {
+	suite.Run(t, new(ClusterCIBBackupOperatorTestSuite))
+}
+
+func (suite *ClusterCIBBackupOperatorTestSuite) SetupTest() {
+	suite.mockClusterClient = mocks.NewMockCluster(suite.T())
+	suite.snapshotPath = filepath.Join(suite.T().TempDir(), "backups", "cib.xml")
+}
+
+func (suite *ClusterCIBBackupOperatorTestSuite) buildOperator(arguments operator.Arguments) *operator.Executor {
+	return operator.NewClusterCIBBackup(
+		arguments,
+		"test-op",
+		operator.Options[operator.ClusterCIBBackup]{
+			OperatorOptions: []operator.Option[operator.ClusterCIBBackup]{
+				operator.Option[operator.ClusterCIBBackup](operator.WithCustomClusterCIBBackupClient(suite.mockClusterClient)),
+			},
+		},
+	)
+}
+
+func fixtureCIB(t *testing.T) *cluster.CIB {
+	cib, err := cluster.ParseCIB(helpers.ReadFixture("cluster/cibadmin_query.output"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cib
+}
+
+// writeCIBSnapshot writes a CIB snapshot and its checksum file as the backup operator does
+func writeCIBSnapshot(t *testing.T, path string, cib *cluster.CIB) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, cib.Content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	checksum := cib.Checksum() + "  " + filepath.Base(path) + "\n"
+	if err := os.WriteFile(path+".sha256", []byte(checksum), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (suite *ClusterCIBBackupOperatorTestSuite) TestClusterCIBBackupInvalidPath() {
+	ctx := context.Background()
+
+	report := suite.buildOperator(operator.Arguments{"path": "backups/cib.xml"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("path argument must be an absolute path, argument provided: backups/cib.xml", report.Error.Message)
+}
+
+func (suite *ClusterCIBBackupOperatorTestSuite) TestClusterCIBBackupClusterNotRunning() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Once()
+
+	report := suite.buildOperator(operator.Arguments{"path": suite.snapshotPath}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("cluster is not running on host", report.Error.Message)
+}
+
+func (suite *ClusterCIBBackupOperatorTestSuite) TestClusterCIBBackupSuccess() {
+	ctx := context.Background()
+	cib := fixtureCIB(suite.T())
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetCIB", ctx).Return(cib, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"path": suite.snapshotPath}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"path":"` + suite.snapshotPath + `","exists":false}`,
+		"after": `{"path":"` + suite.snapshotPath + `","exists":true,"checksum":"` + cib.Checksum() +
+			`","cib_version":{"admin_epoch":1,"epoch":42,"num_updates":7}}`,
+	}, report.Success.Diff)
+
+	content, err := os.ReadFile(suite.snapshotPath)
+	suite.NoError(err)
+	suite.Equal(cib.Content, content)
+
+	checksum, err := os.ReadFile(suite.snapshotPath + ".sha256")
+	suite.NoError(err)
+	suite.Equal(cib.Checksum()+"  cib.xml\n", string(checksum))
+}
+
+func (suite *ClusterCIBBackupOperatorTestSuite) TestClusterCIBBackupAlreadyExists() {
+	ctx := context.Background()
+	cib := fixtureCIB(suite.T())
+	writeCIBSnapshot(suite.T(), suite.snapshotPath, cib)
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetCIB", ctx).Return(cib, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"path": suite.snapshotPath}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.Equal(report.Success.Diff["before"], report.Success.Diff["after"])
+}
+
+func (suite *ClusterCIBBackupOperatorTestSuite) TestClusterCIBBackupExistingDifferentSnapshot() {
+	ctx := context.Background()
+	cib := fixtureCIB(suite.T())
+	writeCIBSnapshot(suite.T(), suite.snapshotPath, cib)
+
+	updatedCIB := *cib
+	updatedCIB.Epoch = 43
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetCIB", ctx).Return(&updatedCIB, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"path": suite.snapshotPath}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"snapshot file "+suite.snapshotPath+" already exists with a different CIB version",
+		report.Error.Message,
+	)
+}
+
+func (suite *ClusterCIBBackupOperatorTestSuite) TestClusterCIBBackupCorruptedSnapshot() {
+	ctx := context.Background()
+	cib := fixtureCIB(suite.T())
+	writeCIBSnapshot(suite.T(), suite.snapshotPath, cib)
+	suite.NoError(os.WriteFile(suite.snapshotPath, []byte("<cib/>"), 0o600))
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetCIB", ctx).Return(cib, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"path": suite.snapshotPath}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.Contains(report.Error.Message, "already exists and it is not valid: snapshot file checksum mismatch")
+}
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/trento-project/workbench/internal/cluster"
+)
+
+const (
+	ClusterCIBRestoreOperatorName = "clustercibrestore"
+	configurationDiffField        = "configuration_diff"
+)
+
+type ClusterCIBRestoreOption Option[ClusterCIBRestore]
+
+type clusterCIBRestoreArguments struct {
+	path          string
+	expectedEpoch *int
+}
+
+// ClusterCIBRestore operator restores the Pacemaker CIB configuration from a snapshot
+// captured with the clustercibbackup operator.
+//
+// Arguments:
+//  path (required): Absolute path of the snapshot file
+//  expected_epoch: Epoch of the live CIB the restore is planned against. The operation is refused
+//  if the live CIB epoch is different. If not given, the live CIB epoch and admin_epoch must be
+//  the ones recorded in the snapshot
+//
+// Only the configuration section is restored, as the status section is owned by the cluster.
+// The operation diff includes the live CIB version before and after the restore, and the
+// configuration differences between the live CIB and the snapshot, in the crm_diff format.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   Checks that the cluster is running in the host, reads the snapshot validating its checksum
+//   and queries the live CIB. If the live configuration is the same as the snapshot one,
+//   the operation is skipped. Otherwise, the operation fails if the live CIB epoch is not the
+//   expected one, and the snapshot is validated with `crm_verify` in a shadow CIB.
+//
+// - COMMIT:
+//   Checks that the cluster is idle and that the live CIB epoch didn't move since the PLAN phase,
+//   and replaces the live CIB configuration with the snapshot one.
+//
+// - VERIFY:
+//   Checks that the live CIB configuration is the same as the snapshot one.
+//
+// - ROLLBACK:
+//   Replaces the live CIB configuration with the one queried in the PLAN phase.
+
+type ClusterCIBRestore struct {
+	baseOperator
+	clusterClient   cluster.Cluster
+	parsedArguments *clusterCIBRestoreArguments
+	snapshot        *cluster.CIB
+	liveCIB         *cluster.CIB
+}
+
+func WithCustomClusterCIBRestoreClient(clusterClient cluster.Cluster) ClusterCIBRestoreOption {
+	return func(o *ClusterCIBRestore) {
+		o.clusterClient = clusterClient
+	}
+}
+
+func NewClusterCIBRestore(
+	arguments Arguments,
+	operationID string,
+	options Options[ClusterCIBRestore],
+) *Executor {
+	restore := &ClusterCIBRestore{
+		baseOperator:  newBaseOperator(ClusterCIBRestoreOperatorName, operationID, arguments, options.BaseOperatorOptions...),
+		clusterClient: cluster.NewDefaultClusterClient(),
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(restore)
+	}
+
+	return &Executor{
+		phaser:      restore,
+		operationID: operationID,
+		logger:      restore.logger,
+	}
+}
+
+func (c *ClusterCIBRestore) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseClusterCIBRestoreArguments(c.arguments)
+	if err != nil {
+		return false, err
+	}
+	c.parsedArguments = opArguments
+
+	if !c.clusterClient.IsHostOnline(ctx) {
+		return false, errors.New("cluster is not running on host")
+	}
+
+	snapshot, err := readCIBSnapshot(c.parsedArguments.path)
+	if err != nil {
+		return false, err
+	}
+	c.snapshot = snapshot
+
+	liveCIB, err := c.clusterClient.GetCIB(ctx)
+	if err != nil {
+		return false, err
+	}
+	c.liveCIB = liveCIB
+	c.resources[beforeDiffField] = newCIBVersionDiffOutput(liveCIB)
+
+	configurationDiff, err := c.clusterClient.DiffCIBConfigurations(ctx, liveCIB, snapshot)
+	if err != nil {
+		return false, err
+	}
+	c.resources[configurationDiffField] = configurationDiff
+
+	if configurationDiff == "" {
+		c.logger.Info("live CIB configuration already matches the snapshot, skipping operation")
+		c.resources[afterDiffField] = newCIBVersionDiffOutput(liveCIB)
+		return true, nil
+	}
+
+	expectedEpoch := c.parsedArguments.expectedEpoch
+	switch {
+	case expectedEpoch != nil && *expectedEpoch != liveCIB.Epoch:
+		return false, fmt.Errorf(
+			"live CIB epoch moved unexpectedly, expected %d, found %d", *expectedEpoch, liveCIB.Epoch,
+		)
+	case expectedEpoch == nil && (liveCIB.AdminEpoch != snapshot.AdminEpoch || liveCIB.Epoch != snapshot.Epoch):
+		return false, fmt.Errorf(
+			"live CIB epoch moved since the snapshot, expected %d:%d, found %d:%d, "+
+				"set expected_epoch to restore the snapshot over the live CIB",
+			snapshot.AdminEpoch, snapshot.Epoch, liveCIB.AdminEpoch, liveCIB.Epoch,
+		)
+	}
+
+	if err := c.clusterClient.VerifyCIBInShadow(ctx, snapshot); err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+func (c *ClusterCIBRestore) commit(ctx context.Context) error {
+	if err := ensureClusterIsIdle(ctx, c.clusterClient, 0); err != nil {
+		return err
+	}
+
+	currentCIB, err := c.clusterClient.GetCIB(ctx)
+	if err != nil {
+		return err
+	}
+
+	if currentCIB.AdminEpoch != c.liveCIB.AdminEpoch || currentCIB.Epoch != c.liveCIB.Epoch {
+		return fmt.Errorf(
+			"live CIB epoch moved unexpectedly during the operation, expected %d:%d, found %d:%d",
+			c.liveCIB.AdminEpoch, c.liveCIB.Epoch, currentCIB.AdminEpoch, currentCIB.Epoch,
+		)
+	}
+
+	return c.clusterClient.ReplaceCIBConfiguration(ctx, c.snapshot)
+}
+
+func (c *ClusterCIBRestore) verify(ctx context.Context) error {
+	liveCIB, err := c.clusterClient.GetCIB(ctx)
+	if err != nil {
+		return err
+	}
+
+	configurationDiff, err := c.clusterClient.DiffCIBConfigurations(ctx, liveCIB, c.snapshot)
+	if err != nil {
+		return err
+	}
+
+	if configurationDiff != "" {
+		return fmt.Errorf("live CIB configuration doesn't match the snapshot after the restore: %s", configurationDiff)
+	}
+
+	c.resources[afterDiffField] = newCIBVersionDiffOutput(liveCIB)
+
+	return nil
+}
+
+func (c *ClusterCIBRestore) rollback(ctx context.Context) error {
+	if err := c.clusterClient.ReplaceCIBConfiguration(ctx, c.liveCIB); err != nil {
+		return fmt.Errorf("error rolling back CIB configuration: %w", err)
+	}
+
+	return nil
+}
+
+func (c *ClusterCIBRestore) operationDiff(_ context.Context) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		diffOutput, ok := c.resources[field].(*cibVersionDiffOutput)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to CIB version diff output",
+				field, c.resources[field]))
+		}
+
+		output, err := json.Marshal(diffOutput)
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	configurationDiff, ok := c.resources[configurationDiffField].(string)
+	if !ok {
+		panic(fmt.Sprintf("invalid configuration diff value: cannot parse '%v' to string",
+			c.resources[configurationDiffField]))
+	}
+	diff[configurationDiffField] = configurationDiff
+
+	return diff
+}
+
+func parseClusterCIBRestoreArguments(rawArguments Arguments) (*clusterCIBRestoreArguments, error) {
+	path, err := parseCIBSnapshotPath(rawArguments, "")
+	if err != nil {
+		return nil, err
+	}
+
+	parsedArguments := &clusterCIBRestoreArguments{
+		path: path,
+	}
+
+	expectedEpochArgument, found := rawArguments["expected_epoch"]
+	if !found {
+		return parsedArguments, nil
+	}
+
+	expectedEpoch, ok := expectedEpochArgument.(float64)
+	if !ok || expectedEpoch < 0 || expectedEpoch != float64(int(expectedEpoch)) {
+		return nil, fmt.Errorf(
+			"could not parse expected_epoch argument as a non negative integer, argument provided: %v",
+			expectedEpochArgument,
+		)
+	}
+
+	epoch := int(expectedEpoch)
+	parsedArguments.expectedEpoch = &epoch
+
+	return parsedArguments, nil
+}
@@ -0,0 +1,241 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/cluster/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+const cibConfigurationDiff = `<diff format="2"><change operation="delete" path="/cib/configuration/constraints"/></diff>`
+
+type ClusterCIBRestoreOperatorTestSuite struct {
+	suite.Suite
+	mockClusterClient *mocks.MockCluster
+	snapshotPath      string
+	snapshot          *cluster.CIB
+	liveCIB           *cluster.CIB
+}
+
+func TestClusterCIBRestoreOperator(t *testing.T) {
+	suite.Run(t, new(ClusterCIBRestoreOperatorTestSuite))
+}
+
+func (suite *ClusterCIBRestoreOperatorTestSuite) SetupTest() {
+	suite.mockClusterClient = mocks.NewMockCluster(suite.T())
+	suite.snapshotPath = filepath.Join(suite.T().TempDir(), "cib.xml")
+
+	suite.snapshot = fixtureCIB(suite.T())
+	writeCIBSnapshot(suite.T(), suite.snapshotPath, suite.snapshot)
+
+	liveCIB := *suite.snapshot
+	liveCIB.Epoch = 45
+	liveCIB.NumUpdates = 2
+	suite.liveCIB = &liveCIB
+}
+
+func (suite *ClusterCIBRestoreOperatorTestSuite) buildOperator(arguments operator.Arguments) *operator.Executor {
+	return operator.NewClusterCIBRestore(
+		arguments,
+		"test-op",
+		operator.Options[operator.ClusterCIBRestore]{
+			OperatorOptions: []operator.Option[operator.ClusterCIBRestore]{
+				operator.Option[operator.ClusterCIBRestore](operator.WithCustomClusterCIBRestoreClient(suite.mockClusterClient)),
+			},
+		},
+	)
+}
+
+func (suite *ClusterCIBRestoreOperatorTestSuite) TestClusterCIBRestoreMissingPath() {
+	ctx := context.Background()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("argument path not provided, could not use the operator", report.Error.Message)
+}
+
+func (suite *ClusterCIBRestoreOperatorTestSuite) TestClusterCIBRestoreInvalidExpectedEpoch() {
+	ctx := context.Background()
+
+	report := suite.buildOperator(operator.Arguments{
+		"path":           suite.snapshotPath,
+		"expected_epoch": 4.5,
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"could not parse expected_epoch argument as a non negative integer, argument provided: 4.5",
+		report.Error.Message,
+	)
+}
+
+func (suite *ClusterCIBRestoreOperatorTestSuite) TestClusterCIBRestoreEpochMoved() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetCIB", ctx).Return(suite.liveCIB, nil).Once()
+	suite.mockClusterClient.On("DiffCIBConfigurations", ctx, suite.liveCIB, suite.snapshot).
+		Return(cibConfigurationDiff, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"path":           suite.snapshotPath,
+		"expected_epoch": float64(44),
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("live CIB epoch moved unexpectedly, expected 44, found 45", report.Error.Message)
+}
+
+func (suite *ClusterCIBRestoreOperatorTestSuite) TestClusterCIBRestoreEpochMovedSinceSnapshot() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetCIB", ctx).Return(suite.liveCIB, nil).Once()
+	suite.mockClusterClient.On("DiffCIBConfigurations", ctx, suite.liveCIB, suite.snapshot).
+		Return(cibConfigurationDiff, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"path": suite.snapshotPath}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"live CIB epoch moved since the snapshot, expected 1:42, found 1:45, "+
+			"set expected_epoch to restore the snapshot over the live CIB",
+		report.Error.Message,
+	)
+}
+
+func (suite *ClusterCIBRestoreOperatorTestSuite) TestClusterCIBRestoreSnapshotEpoch() {
+	ctx := context.Background()
+
+	liveCIB := *suite.snapshot
+	liveCIB.NumUpdates = 9
+	restoredCIB := *suite.snapshot
+	restoredCIB.Epoch = 43
+	restoredCIB.NumUpdates = 0
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetCIB", ctx).Return(&liveCIB, nil).Twice()
+	suite.mockClusterClient.On("DiffCIBConfigurations", ctx, &liveCIB, suite.snapshot).
+		Return(cibConfigurationDiff, nil).Once()
+	suite.mockClusterClient.On("VerifyCIBInShadow", ctx, suite.snapshot).Return(nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
+	suite.mockClusterClient.On("ReplaceCIBConfiguration", ctx, suite.snapshot).Return(nil).Once()
+	suite.mockClusterClient.On("GetCIB", ctx).Return(&restoredCIB, nil).Once()
+	suite.mockClusterClient.On("DiffCIBConfigurations", ctx, &restoredCIB, suite.snapshot).Return("", nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"path": suite.snapshotPath}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(`{"admin_epoch":1,"epoch":43,"num_updates":0}`, report.Success.Diff["after"])
+}
+
+func (suite *ClusterCIBRestoreOperatorTestSuite) TestClusterCIBRestoreAlreadyRestored() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetCIB", ctx).Return(suite.liveCIB, nil).Once()
+	suite.mockClusterClient.On("DiffCIBConfigurations", ctx, suite.liveCIB, suite.snapshot).Return("", nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"path": suite.snapshotPath}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before":             `{"admin_epoch":1,"epoch":45,"num_updates":2}`,
+		"after":              `{"admin_epoch":1,"epoch":45,"num_updates":2}`,
+		"configuration_diff": "",
+	}, report.Success.Diff)
+}
+
+func (suite *ClusterCIBRestoreOperatorTestSuite) TestClusterCIBRestoreVerificationFailed() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetCIB", ctx).Return(suite.liveCIB, nil).Once()
+	suite.mockClusterClient.On("DiffCIBConfigurations", ctx, suite.liveCIB, suite.snapshot).
+		Return(cibConfigurationDiff, nil).Once()
+	suite.mockClusterClient.On("VerifyCIBInShadow", ctx, suite.snapshot).
+		Return(errors.New("CIB verification failed")).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"path":           suite.snapshotPath,
+		"expected_epoch": float64(45),
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("CIB verification failed", report.Error.Message)
+}
+
+func (suite *ClusterCIBRestoreOperatorTestSuite) TestClusterCIBRestoreEpochMovedDuringOperation() {
+	ctx := context.Background()
+
+	movedCIB := *suite.liveCIB
+	movedCIB.Epoch = 46
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetCIB", ctx).Return(suite.liveCIB, nil).Once()
+	suite.mockClusterClient.On("DiffCIBConfigurations", ctx, suite.liveCIB, suite.snapshot).
+		Return(cibConfigurationDiff, nil).Once()
+	suite.mockClusterClient.On("VerifyCIBInShadow", ctx, suite.snapshot).Return(nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
+	suite.mockClusterClient.On("GetCIB", ctx).Return(&movedCIB, nil).Once()
+	suite.mockClusterClient.On("ReplaceCIBConfiguration", ctx, suite.liveCIB).Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"path":           suite.snapshotPath,
+		"expected_epoch": float64(45),
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"live CIB epoch moved unexpectedly during the operation, expected 1:45, found 1:46",
+		report.Error.Message,
+	)
+}
+
+func (suite *ClusterCIBRestoreOperatorTestSuite) TestClusterCIBRestoreSuccess() {
+	ctx := context.Background()
+
+	restoredCIB := *suite.snapshot
+	restoredCIB.Epoch = 46
+	restoredCIB.NumUpdates = 0
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetCIB", ctx).Return(suite.liveCIB, nil).Twice()
+	suite.mockClusterClient.On("DiffCIBConfigurations", ctx, suite.liveCIB, suite.snapshot).
+		Return(cibConfigurationDiff, nil).Once()
+	suite.mockClusterClient.On("VerifyCIBInShadow", ctx, suite.snapshot).Return(nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
+	suite.mockClusterClient.On("ReplaceCIBConfiguration", ctx, suite.snapshot).Return(nil).Once()
+	suite.mockClusterClient.On("GetCIB", ctx).Return(&restoredCIB, nil).Once()
+	suite.mockClusterClient.On("DiffCIBConfigurations", ctx, &restoredCIB, suite.snapshot).Return("", nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"path":           suite.snapshotPath,
+		"expected_epoch": float64(45),
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before":             `{"admin_epoch":1,"epoch":45,"num_updates":2}`,
+		"after":              `{"admin_epoch":1,"epoch":46,"num_updates":0}`,
+		"configuration_diff": cibConfigurationDiff,
+	}, report.Success.Diff)
+}
@@ -0,0 +1,446 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/trento-project/workbench/internal/cluster"
+)
+
+const (
+	ClusterConstraintOperatorName = "clusterconstraint"
+	constraintActionAdd           = "add"
+	constraintActionRemove        = "remove"
+	constraintActionList          = "list"
+	defaultConstraintScore        = "INFINITY"
+)
+
+type ClusterConstraintOption Option[ClusterConstraint]
+
+type clusterConstraintArguments struct {
+	action       string
+	resourceID   string
+	nodeID       string
+	kind         cluster.ConstraintKind
+	constraintID string
+	score        string
+	role         string
+}
+
+type locationConstraintDiff struct {
+	ID    string `json:"id"`
+	Kind  string `json:"kind"`
+	Node  string `json:"node,omitempty"`
+	Score string `json:"score,omitempty"`
+	Role  string `json:"role,omitempty"`
+	Rules bool   `json:"rules,omitempty"`
+}
+
+type clusterConstraintDiffOutput struct {
+	ResourceID  string                   `json:"resource_id"`
+	Constraints []locationConstraintDiff `json:"constraints"`
+}
+
+// ClusterConstraint operator adds, removes or lists the location constraints of a cluster resource.
+//
+// Arguments:
+//  action (required): One of add, remove or list
+//  resource_id (required): ID of the resource
+//  type: Constraint type, required to add or remove a constraint. One of:
+//    ban: Keeps the resource away from the node, as `crm resource ban` does
+//    prefer: Moves the resource to the node, as `crm resource move` does
+//    location: Named location constraint with the given score
+//  node_id: Name of the node. Required to add a constraint and to remove a ban constraint
+//  constraint_id: ID of the location constraint. Required for the location type
+//  score: Score of the location constraint, INFINITY by default
+//  role: Role of the resource the location constraint applies to, e.g. Promoted
+//
+// The ban and prefer constraints use the same IDs as the crm_resource created ones, cli-ban-<resource>-on-<node>
+// and cli-prefer-<resource>, so the existing constraints are handled and they can be removed with
+// `crm resource clear` as well. The operation diff includes the location constraints of the resource
+// before and after the change. Constraints defined with rules are reported, but they cannot be changed.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   Checks that the cluster is running in the host and that the resource exists, and the node as well
+//   when a constraint is added, and gets the location constraints of the resource.
+//   The list action finishes here. If the constraint is already added, or it is not found
+//   when it is removed, the operation is skipped.
+//
+// - COMMIT:
+//   Checks that the cluster is idle and adds the constraint using `crm configure location`,
+//   replacing the existing one with the same ID, or removes it using `crm configure delete`.
+//
+// - VERIFY:
+//   Checks that the constraint is found with the requested node and score, or that it is removed.
+//
+// - ROLLBACK:
+//   Checks that the cluster is idle and restores the original constraint set, removing the added
+//   constraint and adding back the replaced or removed one.
+
+type ClusterConstraint struct {
+	baseOperator
+	clusterClient   cluster.Cluster
+	parsedArguments *clusterConstraintArguments
+	// target is the constraint to add or remove
+	target cluster.LocationConstraint
+	// original is the constraint with the target ID found in the PLAN phase, nil if it didn't exist
+	original *cluster.LocationConstraint
+}
+
+func WithCustomClusterConstraintClient(clusterClient cluster.Cluster) ClusterConstraintOption {
+	return func(o *ClusterConstraint) {
+		o.clusterClient = clusterClient
+	}
+}
+
+func NewClusterConstraint(
+	arguments Arguments,
+	operationID string,
+	options Options[ClusterConstraint],
+) *Executor {
+	constraint := &ClusterConstraint{
+		baseOperator: newBaseOperator(
+			ClusterConstraintOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+		clusterClient: cluster.NewDefaultClusterClient(),
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(constraint)
+	}
+
+	return &Executor{
+		phaser:      constraint,
+		operationID: operationID,
+		logger:      constraint.logger,
+	}
+}
+
+func (c *ClusterConstraint) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseClusterConstraintArguments(c.arguments)
+	if err != nil {
+		return false, err
+	}
+	c.parsedArguments = opArguments
+	c.target = opArguments.targetConstraint()
+
+	if !c.clusterClient.IsHostOnline(ctx) {
+		return false, errors.New("cluster is not running on host")
+	}
+
+	if err := c.validateResourceAndNode(ctx); err != nil {
+		return false, err
+	}
+
+	constraints, err := c.clusterClient.GetLocationConstraints(ctx)
+	if err != nil {
+		return false, err
+	}
+	c.resources[beforeDiffField] = c.resourceConstraints(constraints)
+
+	if opArguments.action == constraintActionList {
+		c.resources[afterDiffField] = c.resources[beforeDiffField]
+		return true, nil
+	}
+
+	if existing := findConstraint(constraints, c.target.ID); existing != nil {
+		if existing.Resource != opArguments.resourceID {
+			return false, fmt.Errorf("constraint %s belongs to resource %s", existing.ID, existing.Resource)
+		}
+		if existing.Rules {
+			return false, fmt.Errorf("constraint %s is defined with rules and cannot be changed", existing.ID)
+		}
+		c.original = existing
+	}
+
+	switch {
+	case opArguments.action == constraintActionAdd && c.original != nil && sameConstraint(*c.original, c.target):
+		c.logger.Info("constraint already added, skipping operation", "id", c.target.ID)
+	case opArguments.action == constraintActionRemove && c.original == nil:
+		c.logger.Info("constraint not found, skipping operation", "id", c.target.ID)
+	default:
+		return false, nil
+	}
+
+	c.resources[afterDiffField] = c.resources[beforeDiffField]
+	return true, nil
+}
+
+func (c *ClusterConstraint) commit(ctx context.Context) error {
+	if err := ensureClusterIsIdle(ctx, c.clusterClient, 0); err != nil {
+		return err
+	}
+
+	if c.original != nil {
+		if err := c.clusterClient.DeleteConstraint(ctx, c.original.ID); err != nil {
+			return err
+		}
+	}
+
+	if c.parsedArguments.action == constraintActionRemove {
+		return nil
+	}
+
+	return c.clusterClient.AddLocationConstraint(ctx, c.target)
+}
+
+func (c *ClusterConstraint) verify(ctx context.Context) error {
+	constraints, err := c.clusterClient.GetLocationConstraints(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := findConstraint(constraints, c.target.ID)
+	switch c.parsedArguments.action {
+	case constraintActionAdd:
+		if current == nil || !sameConstraint(*current, c.target) {
+			return fmt.Errorf("verify constraint failed, constraint %s was not added in commit phase", c.target.ID)
+		}
+	case constraintActionRemove:
+		if current != nil {
+			return fmt.Errorf("verify constraint failed, constraint %s was not removed in commit phase", c.target.ID)
+		}
+	}
+
+	c.resources[afterDiffField] = c.resourceConstraints(constraints)
+	return nil
+}
+
+func (c *ClusterConstraint) rollback(ctx context.Context) error {
+	if err := ensureClusterIsIdle(ctx, c.clusterClient, 0); err != nil {
+		return err
+	}
+
+	constraints, err := c.clusterClient.GetLocationConstraints(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := findConstraint(constraints, c.target.ID)
+	if current != nil && c.original != nil && sameConstraint(*current, *c.original) {
+		return nil
+	}
+
+	if current != nil {
+		if err := c.clusterClient.DeleteConstraint(ctx, current.ID); err != nil {
+			return fmt.Errorf("error rolling back constraint %s: %w", current.ID, err)
+		}
+	}
+
+	if c.original != nil {
+		if err := c.clusterClient.AddLocationConstraint(ctx, *c.original); err != nil {
+			return fmt.Errorf("error rolling back constraint %s: %w", c.original.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *ClusterConstraint) operationDiff(_ context.Context) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		constraints, ok := c.resources[field].([]cluster.LocationConstraint)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to location constraints",
+				field, c.resources[field]))
+		}
+
+		diffConstraints := make([]locationConstraintDiff, 0, len(constraints))
+		for _, constraint := range constraints {
+			diffConstraints = append(diffConstraints, locationConstraintDiff{
+				ID:    constraint.ID,
+				Kind:  string(constraint.Kind()),
+				Node:  constraint.Node,
+				Score: constraint.Score,
+				Role:  constraint.Role,
+				Rules: constraint.Rules,
+			})
+		}
+
+		output, err := json.Marshal(clusterConstraintDiffOutput{
+			ResourceID:  c.parsedArguments.resourceID,
+			Constraints: diffConstraints,
+		})
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	return diff
+}
+
+// validateResourceAndNode checks that the resource, or the clone or group containing it,
+// is found in the cluster status, and the node as well when a constraint is added.
+// Stale constraints of nodes that were removed from the cluster can be removed.
+func (c *ClusterConstraint) validateResourceAndNode(ctx context.Context) error {
+	status, err := c.clusterClient.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	resourceID, nodeID := c.parsedArguments.resourceID, c.parsedArguments.nodeID
+	if !slices.ContainsFunc(status.Resources, func(resource cluster.ResourceStatus) bool {
+		return resource.ID == resourceID || resource.Parent == resourceID
+	}) {
+		return fmt.Errorf("resource %s not found in the cluster", resourceID)
+	}
+
+	isNodeFound := slices.ContainsFunc(status.Nodes, func(node cluster.NodeStatus) bool {
+		return node.Name == nodeID
+	})
+	if c.parsedArguments.action == constraintActionAdd && !isNodeFound {
+		return fmt.Errorf("node %s not found in the cluster", nodeID)
+	}
+
+	return nil
+}
+
+func (c *ClusterConstraint) resourceConstraints(constraints []cluster.LocationConstraint) []cluster.LocationConstraint {
+	resourceConstraints := []cluster.LocationConstraint{}
+	for _, constraint := range constraints {
+		if constraint.Resource == c.parsedArguments.resourceID {
+			resourceConstraints = append(resourceConstraints, constraint)
+		}
+	}
+	return resourceConstraints
+}
+
+func findConstraint(constraints []cluster.LocationConstraint, constraintID string) *cluster.LocationConstraint {
+	index := slices.IndexFunc(constraints, func(constraint cluster.LocationConstraint) bool {
+		return constraint.ID == constraintID
+	})
+	if index == -1 {
+		return nil
+	}
+	return &constraints[index]
+}
+
+// sameConstraint compares the node preference of the constraints. Positive scores might be stored
+// with or without the plus sign.
+func sameConstraint(a, b cluster.LocationConstraint) bool {
+	return a.Resource == b.Resource &&
+		a.Node == b.Node &&
+		a.Role == b.Role &&
+		strings.TrimPrefix(a.Score, "+") == strings.TrimPrefix(b.Score, "+")
+}
+
+func (a *clusterConstraintArguments) targetConstraint() cluster.LocationConstraint {
+	switch a.kind {
+	case cluster.BanConstraintKind:
+		return cluster.NewBanConstraint(a.resourceID, a.nodeID)
+	case cluster.PreferConstraintKind:
+		return cluster.NewPreferConstraint(a.resourceID, a.nodeID)
+	default:
+		return cluster.LocationConstraint{
+			ID:       a.constraintID,
+			Resource: a.resourceID,
+			Node:     a.nodeID,
+			Score:    a.score,
+			Role:     a.role,
+		}
+	}
+}
+
+func parseClusterConstraintArguments(rawArguments Arguments) (*clusterConstraintArguments, error) {
+	parsedArguments := &clusterConstraintArguments{score: defaultConstraintScore}
+	var kind string
+
+	for _, argument := range []struct {
+		name  string
+		value *string
+	}{
+		{name: "action", value: &parsedArguments.action},
+		{name: "resource_id", value: &parsedArguments.resourceID},
+		{name: "node_id", value: &parsedArguments.nodeID},
+		{name: "type", value: &kind},
+		{name: "constraint_id", value: &parsedArguments.constraintID},
+		{name: "score", value: &parsedArguments.score},
+		{name: "role", value: &parsedArguments.role},
+	} {
+		rawValue, found := rawArguments[argument.name]
+		if !found {
+			continue
+		}
+
+		parsedValue, ok := rawValue.(string)
+		if !ok {
+			return nil, fmt.Errorf(
+				"could not parse %s argument as string, argument provided: %v", argument.name, rawValue,
+			)
+		}
+		*argument.value = parsedValue
+	}
+	parsedArguments.kind = cluster.ConstraintKind(kind)
+
+	if parsedArguments.action == "" {
+		return nil, errors.New("argument action not provided, could not use the operator")
+	}
+
+	if parsedArguments.resourceID == "" {
+		return nil, errors.New("argument resource_id not provided, could not use the operator")
+	}
+
+	switch parsedArguments.action {
+	case constraintActionList:
+		return parsedArguments, nil
+	case constraintActionAdd, constraintActionRemove:
+	default:
+		return nil, fmt.Errorf(
+			"invalid action %s, accepted values: %s, %s, %s",
+			parsedArguments.action, constraintActionAdd, constraintActionRemove, constraintActionList,
+		)
+	}
+
+	if err := validateConstraintKindArguments(parsedArguments); err != nil {
+		return nil, err
+	}
+
+	if parsedArguments.action == constraintActionAdd {
+		if err := cluster.ValidateLocationConstraint(parsedArguments.targetConstraint()); err != nil {
+			return nil, err
+		}
+	}
+
+	return parsedArguments, nil
+}
+
+func validateConstraintKindArguments(arguments *clusterConstraintArguments) error {
+	switch arguments.kind {
+	case "":
+		return errors.New("argument type not provided, could not use the operator")
+	case cluster.BanConstraintKind, cluster.PreferConstraintKind:
+		if arguments.constraintID != "" {
+			return fmt.Errorf("argument constraint_id cannot be used with %s constraints", arguments.kind)
+		}
+	case cluster.LocationConstraintKind:
+		if arguments.constraintID == "" {
+			return errors.New("argument constraint_id not provided, could not use the operator")
+		}
+		if strings.HasPrefix(arguments.constraintID, "cli-") {
+			return fmt.Errorf("constraint_id %s is reserved for ban and prefer constraints", arguments.constraintID)
+		}
+	default:
+		return fmt.Errorf(
+			"invalid type %s, accepted values: %s, %s, %s", arguments.kind,
+			cluster.BanConstraintKind, cluster.PreferConstraintKind, cluster.LocationConstraintKind,
+		)
+	}
+
+	needsNode := arguments.action == constraintActionAdd || arguments.kind == cluster.BanConstraintKind
+	if needsNode && arguments.nodeID == "" {
+		return errors.New("argument node_id not provided, could not use the operator")
+	}
+
+	return nil
+}
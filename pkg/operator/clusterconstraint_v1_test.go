@@ -0,0 +1,410 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/cluster/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+type ClusterConstraintOperatorTestSuite struct {
+	suite.Suite
+	mockClusterClient *mocks.MockCluster
+}
+
+func TestClusterConstraintOperator(t *testing.T) {
+	suite.Run(t, new(ClusterConstraintOperatorTestSuite))
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) SetupTest() {
+	suite.mockClusterClient = mocks.NewMockCluster(suite.T())
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) buildOperator(arguments operator.Arguments) *operator.Executor {
+	return operator.NewClusterConstraint(
+		arguments,
+		"test-op",
+		operator.Options[operator.ClusterConstraint]{
+			OperatorOptions: []operator.Option[operator.ClusterConstraint]{
+				operator.Option[operator.ClusterConstraint](
+					operator.WithCustomClusterConstraintClient(suite.mockClusterClient),
+				),
+			},
+		},
+	)
+}
+
+func constraintsClusterStatus() *cluster.Status {
+	return &cluster.Status{
+		Nodes: []cluster.NodeStatus{{Name: "vmhana01"}, {Name: "vmhana02"}},
+		Resources: []cluster.ResourceStatus{
+			{ID: "rsc_ip_PRD_HDB00"},
+			{ID: "rsc_SAPHana_PRD_HDB00", Parent: "msl_SAPHana_PRD_HDB00"},
+		},
+	}
+}
+
+func locationConstraints() []cluster.LocationConstraint {
+	return []cluster.LocationConstraint{
+		{
+			ID:       "cli-ban-msl_SAPHana_PRD_HDB00-on-vmhana01",
+			Resource: "msl_SAPHana_PRD_HDB00",
+			Node:     "vmhana01",
+			Score:    "-INFINITY",
+		},
+		{
+			ID:       "cli-prefer-rsc_ip_PRD_HDB00",
+			Resource: "rsc_ip_PRD_HDB00",
+			Node:     "vmhana02",
+			Score:    "INFINITY",
+			Role:     "Started",
+		},
+		{ID: "loc_SAPHanaCon_ping", Resource: "msl_SAPHana_PRD_HDB00", Rules: true},
+	}
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) TestClusterConstraintInvalidArguments() {
+	cases := []struct {
+		arguments operator.Arguments
+		err       string
+	}{
+		{
+			arguments: operator.Arguments{"resource_id": "rsc_ip_PRD_HDB00"},
+			err:       "argument action not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{"action": "list"},
+			err:       "argument resource_id not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{"action": "move", "resource_id": "rsc_ip_PRD_HDB00"},
+			err:       "invalid action move, accepted values: add, remove, list",
+		},
+		{
+			arguments: operator.Arguments{"action": "add", "resource_id": "rsc_ip_PRD_HDB00", "node_id": 1},
+			err:       "could not parse node_id argument as string, argument provided: 1",
+		},
+		{
+			arguments: operator.Arguments{"action": "add", "resource_id": "rsc_ip_PRD_HDB00"},
+			err:       "argument type not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{"action": "add", "resource_id": "rsc_ip_PRD_HDB00", "type": "colocation"},
+			err:       "invalid type colocation, accepted values: ban, prefer, location",
+		},
+		{
+			arguments: operator.Arguments{"action": "add", "resource_id": "rsc_ip_PRD_HDB00", "type": "ban"},
+			err:       "argument node_id not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{
+				"action": "add", "resource_id": "rsc_ip_PRD_HDB00", "type": "prefer", "constraint_id": "loc",
+			},
+			err: "argument constraint_id cannot be used with prefer constraints",
+		},
+		{
+			arguments: operator.Arguments{
+				"action": "remove", "resource_id": "rsc_ip_PRD_HDB00", "type": "location",
+			},
+			err: "argument constraint_id not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{
+				"action": "add", "resource_id": "rsc_ip_PRD_HDB00", "type": "location",
+				"constraint_id": "cli-prefer-rsc_ip_PRD_HDB00", "node_id": "vmhana01",
+			},
+			err: "constraint_id cli-prefer-rsc_ip_PRD_HDB00 is reserved for ban and prefer constraints",
+		},
+		{
+			arguments: operator.Arguments{
+				"action": "add", "resource_id": "rsc_ip_PRD_HDB00", "type": "location",
+				"constraint_id": "loc_ip", "node_id": "vmhana01", "score": "high",
+			},
+			err: "invalid score high for constraint loc_ip",
+		},
+	}
+
+	for _, tt := range cases {
+		report := suite.buildOperator(tt.arguments).Run(context.Background())
+
+		suite.Nil(report.Success)
+		suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+		suite.EqualValues(tt.err, report.Error.Message)
+	}
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) TestClusterConstraintResourceNotFound() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(constraintsClusterStatus(), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"action": "list", "resource_id": "rsc_unknown",
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("resource rsc_unknown not found in the cluster", report.Error.Message)
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) TestClusterConstraintNodeNotFound() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(constraintsClusterStatus(), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"action": "add", "resource_id": "msl_SAPHana_PRD_HDB00", "type": "ban", "node_id": "vmhana03",
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("node vmhana03 not found in the cluster", report.Error.Message)
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) TestClusterConstraintList() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(constraintsClusterStatus(), nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).Return(locationConstraints(), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"action": "list", "resource_id": "msl_SAPHana_PRD_HDB00",
+	}).Run(ctx)
+
+	expectedConstraints := `{"resource_id":"msl_SAPHana_PRD_HDB00","constraints":[` +
+		`{"id":"cli-ban-msl_SAPHana_PRD_HDB00-on-vmhana01","kind":"ban","node":"vmhana01","score":"-INFINITY"},` +
+		`{"id":"loc_SAPHanaCon_ping","kind":"location","rules":true}]}`
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": expectedConstraints,
+		"after":  expectedConstraints,
+	}, report.Success.Diff)
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) TestClusterConstraintAlreadyAdded() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(constraintsClusterStatus(), nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).Return(locationConstraints(), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"action": "add", "resource_id": "msl_SAPHana_PRD_HDB00", "type": "ban", "node_id": "vmhana01",
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) TestClusterConstraintRemoveNotFound() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(constraintsClusterStatus(), nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).Return(locationConstraints(), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"action": "remove", "resource_id": "msl_SAPHana_PRD_HDB00", "type": "ban", "node_id": "vmhana02",
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) TestClusterConstraintRulesNotChanged() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(constraintsClusterStatus(), nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).Return(locationConstraints(), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"action":        "remove",
+		"resource_id":   "msl_SAPHana_PRD_HDB00",
+		"type":          "location",
+		"constraint_id": "loc_SAPHanaCon_ping",
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("constraint loc_SAPHanaCon_ping is defined with rules and cannot be changed", report.Error.Message)
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) TestClusterConstraintAddLocation() {
+	ctx := context.Background()
+
+	newConstraint := cluster.LocationConstraint{
+		ID:       "loc_ip_PRD_HDB00_vmhana01",
+		Resource: "rsc_ip_PRD_HDB00",
+		Node:     "vmhana01",
+		Score:    "100",
+	}
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(constraintsClusterStatus(), nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).Return(locationConstraints(), nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
+	suite.mockClusterClient.On("AddLocationConstraint", ctx, newConstraint).Return(nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).
+		Return(append(locationConstraints(), newConstraint), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"action":        "add",
+		"resource_id":   "rsc_ip_PRD_HDB00",
+		"type":          "location",
+		"constraint_id": "loc_ip_PRD_HDB00_vmhana01",
+		"node_id":       "vmhana01",
+		"score":         "100",
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"resource_id":"rsc_ip_PRD_HDB00","constraints":[` +
+			`{"id":"cli-prefer-rsc_ip_PRD_HDB00","kind":"prefer","node":"vmhana02","score":"INFINITY","role":"Started"}]}`,
+		"after": `{"resource_id":"rsc_ip_PRD_HDB00","constraints":[` +
+			`{"id":"cli-prefer-rsc_ip_PRD_HDB00","kind":"prefer","node":"vmhana02","score":"INFINITY","role":"Started"},` +
+			`{"id":"loc_ip_PRD_HDB00_vmhana01","kind":"location","node":"vmhana01","score":"100"}]}`,
+	}, report.Success.Diff)
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) TestClusterConstraintReplacePrefer() {
+	ctx := context.Background()
+
+	newConstraint := cluster.NewPreferConstraint("rsc_ip_PRD_HDB00", "vmhana01")
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(constraintsClusterStatus(), nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).Return(locationConstraints(), nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
+	suite.mockClusterClient.On("DeleteConstraint", ctx, "cli-prefer-rsc_ip_PRD_HDB00").Return(nil).Once()
+	suite.mockClusterClient.On("AddLocationConstraint", ctx, newConstraint).Return(nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).
+		Return([]cluster.LocationConstraint{newConstraint}, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"action":      "add",
+		"resource_id": "rsc_ip_PRD_HDB00",
+		"type":        "prefer",
+		"node_id":     "vmhana01",
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"resource_id":"rsc_ip_PRD_HDB00","constraints":[` +
+			`{"id":"cli-prefer-rsc_ip_PRD_HDB00","kind":"prefer","node":"vmhana02","score":"INFINITY","role":"Started"}]}`,
+		"after": `{"resource_id":"rsc_ip_PRD_HDB00","constraints":[` +
+			`{"id":"cli-prefer-rsc_ip_PRD_HDB00","kind":"prefer","node":"vmhana01","score":"INFINITY"}]}`,
+	}, report.Success.Diff)
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) TestClusterConstraintRemoveBan() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(constraintsClusterStatus(), nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).Return(locationConstraints(), nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
+	suite.mockClusterClient.On("DeleteConstraint", ctx, "cli-ban-msl_SAPHana_PRD_HDB00-on-vmhana01").
+		Return(nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).
+		Return(locationConstraints()[1:], nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"action": "remove", "resource_id": "msl_SAPHana_PRD_HDB00", "type": "ban", "node_id": "vmhana01",
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"resource_id":"msl_SAPHana_PRD_HDB00","constraints":[` +
+			`{"id":"cli-ban-msl_SAPHana_PRD_HDB00-on-vmhana01","kind":"ban","node":"vmhana01","score":"-INFINITY"},` +
+			`{"id":"loc_SAPHanaCon_ping","kind":"location","rules":true}]}`,
+		"after": `{"resource_id":"msl_SAPHana_PRD_HDB00","constraints":[` +
+			`{"id":"loc_SAPHanaCon_ping","kind":"location","rules":true}]}`,
+	}, report.Success.Diff)
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) TestClusterConstraintRollbackRemoved() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(constraintsClusterStatus(), nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).Return(locationConstraints(), nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Twice()
+	suite.mockClusterClient.On("DeleteConstraint", ctx, "cli-ban-msl_SAPHana_PRD_HDB00-on-vmhana01").
+		Return(nil).Once()
+	// the constraint is found in verify, and it is already removed in rollback
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).Return(locationConstraints(), nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).Return(locationConstraints()[1:], nil).Once()
+	suite.mockClusterClient.On("AddLocationConstraint", ctx, locationConstraints()[0]).Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"action": "remove", "resource_id": "msl_SAPHana_PRD_HDB00", "type": "ban", "node_id": "vmhana01",
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"verify constraint failed, constraint cli-ban-msl_SAPHana_PRD_HDB00-on-vmhana01 was not removed in commit phase",
+		report.Error.Message,
+	)
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) TestClusterConstraintRollbackAdded() {
+	ctx := context.Background()
+
+	newConstraint := cluster.NewBanConstraint("rsc_ip_PRD_HDB00", "vmhana01")
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(constraintsClusterStatus(), nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).Return(locationConstraints(), nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Twice()
+	suite.mockClusterClient.On("AddLocationConstraint", ctx, newConstraint).Return(nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).
+		Return(nil, errors.New("error querying cluster constraints")).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).
+		Return(append(locationConstraints(), newConstraint), nil).Once()
+	suite.mockClusterClient.On("DeleteConstraint", ctx, "cli-ban-rsc_ip_PRD_HDB00-on-vmhana01").Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"action": "add", "resource_id": "rsc_ip_PRD_HDB00", "type": "ban", "node_id": "vmhana01",
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.EqualValues("error querying cluster constraints", report.Error.Message)
+}
+
+func (suite *ClusterConstraintOperatorTestSuite) TestClusterConstraintCommitNotIdle() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(constraintsClusterStatus(), nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).Return(locationConstraints(), nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(false, nil).Once()
+	// nothing to restore in rollback
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
+	suite.mockClusterClient.On("GetLocationConstraints", ctx).Return(locationConstraints(), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"action": "add", "resource_id": "rsc_ip_PRD_HDB00", "type": "ban", "node_id": "vmhana01",
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues("cluster is not in S_IDLE state", report.Error.Message)
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/trento-project/workbench/internal/cluster"
+)
+
+const (
+	waitForIdleArgument = "wait_for_idle"
+	// crmClusterIdleTimeout is the time the CRM cluster start and stop operators wait until the cluster is idle
+	crmClusterIdleTimeout = time.Minute
+)
+
+// parseWaitForIdle parses the optional wait_for_idle argument, the time in seconds to wait
+// until the cluster is idle. 0 is returned if the argument is not given, so the idle state
+// is checked only once.
+func parseWaitForIdle(rawArguments Arguments) (time.Duration, error) {
+	argument, found := rawArguments[waitForIdleArgument]
+	if !found {
+		return 0, nil
+	}
+
+	seconds, ok := argument.(float64)
+	if !ok {
+		return 0, fmt.Errorf(
+			"could not parse %s argument as a number, argument provided: %v",
+			waitForIdleArgument,
+			argument,
+		)
+	}
+
+	if seconds < 0 {
+		return 0, fmt.Errorf("invalid %s value: %v, it must be a positive number", waitForIdleArgument, argument)
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// ensureClusterIsIdle checks if the cluster is in the S_IDLE state. If a wait time is given,
+// the check is repeated until the cluster is idle or the time expires, and the ongoing transition
+// details are included in the returned error.
+func ensureClusterIsIdle(ctx context.Context, clusterClient cluster.Cluster, waitForIdle time.Duration) error {
+	if waitForIdle > 0 {
+		return clusterClient.WaitForIdle(ctx, cluster.WaitForIdleOptions{Timeout: waitForIdle})
+	}
+
+	isIdle, err := clusterClient.IsIdle(ctx)
+	if err != nil {
+		return fmt.Errorf("error checking if cluster is idle: %w", err)
+	}
+
+	if !isIdle {
+		return errors.New("cluster is not in S_IDLE state")
+	}
+
+	return nil
+}
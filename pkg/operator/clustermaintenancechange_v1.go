@@ -8,9 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"regexp"
-	"strconv"
-	"strings"
+	"time"
 
 	"github.com/trento-project/workbench/internal/cluster"
 	"github.com/trento-project/workbench/internal/support"
@@ -18,27 +16,16 @@ import (
 
 const (
 	ClusterMaintenanceChangeOperatorName = "clustermaintenancechange"
-	nodeStatePattern                     = "value=(.*)"
-	maintenanceOn                        = "on"
-	maintenanceOff                       = "off"
 )
 
-type clusterMaintenanceScope int
-
-const (
-	clusterScope clusterMaintenanceScope = iota
-	resourceScope
-	nodeScope
-)
-
-var nodeStatePatternCompiled = regexp.MustCompile(nodeStatePattern)
-
 type ClusterMaintenanceChangeOption Option[ClusterMaintenanceChange]
 
 type clusterMaintenanceChangeArguments struct {
-	maintenance bool
-	resourceID  string
-	nodeID      string
+	maintenance        bool
+	resourceID         string
+	nodeID             string
+	preflightOverrides []cluster.PreflightCheckName
+	waitForIdle        time.Duration
 }
 
 type diffOutput struct {
@@ -48,8 +35,8 @@ type diffOutput struct {
 }
 
 // ClusterMaintenanceChange is an operator responsible for changing cluster maintenance,
-// cluster resources or cluster node managed state. `crmsh` is the tool used to apply the write and most of
-// the read operations in the cluster.
+// cluster resources or cluster node managed state. The maintenance state is read and changed with the
+// cluster management toolchain installed in the host, `crmsh` or `pcs`.
 // The used commands differ if the state to change is the whole cluster, a particular resource or node.
 //
 // Find some helpful references about maintenance transitions and used commands:
@@ -61,12 +48,16 @@ type diffOutput struct {
 // - https://crmsh.github.io/man-4.6/#cmdhelp_maintenance
 // - https://crmsh.github.io/man-4.6/#cmdhelp_resource
 // - https://crmsh.github.io/man-4.6/#cmdhelp_node
+// - https://clusterlabs.org/projects/pacemaker/doc/2.1/Clusters_from_Scratch/html/apb-pcs.html
 //
 // The operator accepts the next arguments:
 // - maintenance (bool): The desired maintenance state for the cluster, resource or node.
 //                       If true, the cluster, resource or node are set in maintenance mode.
 // - resource_id (string): If given, the operator changes the maintenance state of the resource.
 // - node_id (string): If given, the operator changes the maintenance state of the node.
+// - preflight_overrides ([]string): List of cluster pre-flight checks whose failure doesn't block the operation.
+// - wait_for_idle (number): Seconds to wait until the cluster is in IDLE state before changing the state.
+//                           If not given, the operation fails if the cluster is not idle.
 // If resource_id or node_id are not given the operator changes the general maintenance state of the cluster.
 // resource_id and node_id mutually exclusive.
 
@@ -75,9 +66,14 @@ type diffOutput struct {
 //
 // - PLAN:
 //   Check if a pacemaker cluster is present and store the current state.
+//   If the state has to be changed, the cluster pre-flight checks (quorum, DC election, failed actions,
+//   pending fencing, stonith enabled and SAP HANA system replication sync) are run. The failed actions
+//   check is not run when the maintenance state is removed, as the resources are refreshed before doing so.
+//   The SAP HANA system replication sync check only applies to clusters managing SAP HANA resources.
 //
 // - COMMIT:
-//   Change the cluster, resource or node state if the cluster is in IDLE state.
+//   Change the cluster, resource or node state if the cluster is in IDLE state, waiting for it
+//   if wait_for_idle is given.
 //   If the maintenance state is removed, the cluster state is refreshed.
 //
 // - VERIFY:
@@ -90,15 +86,16 @@ type diffOutput struct {
 
 type ClusterMaintenanceChange struct {
 	baseOperator
-	executor        support.CmdExecutor
 	clusterClient   cluster.Cluster
-	scope           clusterMaintenanceScope
 	parsedArguments *clusterMaintenanceChangeArguments
 }
 
+// WithCustomClusterMaintenanceExecutor sets a cluster client running the commands with the given executor.
+//
+// Deprecated: use WithCustomClusterMaintenanceClient instead.
 func WithCustomClusterMaintenanceExecutor(executor support.CmdExecutor) ClusterMaintenanceChangeOption {
 	return func(o *ClusterMaintenanceChange) {
-		o.executor = executor
+		o.clusterClient = cluster.NewClusterClient(executor, o.logger)
 	}
 }
 
@@ -117,7 +114,6 @@ func NewClusterMaintenanceChange(
 		baseOperator: newBaseOperator(
 			ClusterMaintenanceChangeOperatorName, operationID, arguments, options.BaseOperatorOptions...,
 		),
-		executor:      support.CliExecutor{},
 		clusterClient: cluster.NewDefaultClusterClient(),
 	}
 
@@ -139,21 +135,14 @@ func (c *ClusterMaintenanceChange) plan(ctx context.Context) (bool, error) {
 	}
 	c.parsedArguments = opArguments
 
-	switch {
-	case c.parsedArguments.resourceID != "":
-		c.scope = resourceScope
-	case c.parsedArguments.nodeID != "":
-		c.scope = nodeScope
-	default:
-		c.scope = clusterScope
-	}
-
 	// check if a cluster is available and running
 	if !c.clusterClient.IsHostOnline(ctx) {
 		return false, errors.New("cluster is not runnint on host")
 	}
 
-	currentState, err := getMaintenanceState(ctx, c.executor, c.scope, c.parsedArguments)
+	currentState, err := c.clusterClient.GetMaintenanceState(
+		ctx, c.parsedArguments.resourceID, c.parsedArguments.nodeID,
+	)
 	if err != nil {
 		return false, err
 	}
@@ -166,17 +155,32 @@ func (c *ClusterMaintenanceChange) plan(ctx context.Context) (bool, error) {
 		return true, nil
 	}
 
+	preflightChecks := []cluster.PreflightCheckName{
+		cluster.QuorumCheck,
+		cluster.DCElectedCheck,
+		cluster.PendingFencingCheck,
+		cluster.StonithEnabledCheck,
+		cluster.HanaSRSyncCheck,
+	}
+	if c.parsedArguments.maintenance {
+		preflightChecks = append(preflightChecks, cluster.FailedActionsCheck)
+	}
+
+	err = runClusterPreflightChecks(ctx, c.clusterClient, cluster.PreflightOptions{
+		Checks:    preflightChecks,
+		Overrides: c.parsedArguments.preflightOverrides,
+	}, c.resources)
+	if err != nil {
+		return false, err
+	}
+
 	return false, nil
 }
 
 func (c *ClusterMaintenanceChange) commit(ctx context.Context) error {
-	isIdle, err := c.clusterClient.IsIdle(ctx)
+	err := ensureClusterIsIdle(ctx, c.clusterClient, c.parsedArguments.waitForIdle)
 	if err != nil {
-		return fmt.Errorf("error checking if cluster is idle: %w", err)
-	}
-
-	if !isIdle {
-		return errors.New("cluster is not in S_IDLE state")
+		return err
 	}
 
 	// refresh cluster or resource before removing maintenance state
@@ -188,7 +192,9 @@ func (c *ClusterMaintenanceChange) commit(ctx context.Context) error {
 		}
 	}
 
-	err = setMaintenanceState(ctx, c.executor, c.scope, c.parsedArguments.maintenance, c.parsedArguments)
+	err = c.clusterClient.SetMaintenanceState(
+		ctx, c.parsedArguments.resourceID, c.parsedArguments.nodeID, c.parsedArguments.maintenance,
+	)
 	if err != nil {
 		return fmt.Errorf("error updating maintenance state: %w", err)
 	}
@@ -196,7 +202,9 @@ func (c *ClusterMaintenanceChange) commit(ctx context.Context) error {
 }
 
 func (c *ClusterMaintenanceChange) verify(ctx context.Context) error {
-	currentState, err := getMaintenanceState(ctx, c.executor, c.scope, c.parsedArguments)
+	currentState, err := c.clusterClient.GetMaintenanceState(
+		ctx, c.parsedArguments.resourceID, c.parsedArguments.nodeID,
+	)
 	if err != nil {
 		return err
 	}
@@ -213,17 +221,15 @@ func (c *ClusterMaintenanceChange) verify(ctx context.Context) error {
 }
 
 func (c *ClusterMaintenanceChange) rollback(ctx context.Context) error {
-	isIdle, err := c.clusterClient.IsIdle(ctx)
+	err := ensureClusterIsIdle(ctx, c.clusterClient, c.parsedArguments.waitForIdle)
 	if err != nil {
-		return fmt.Errorf("error checking if cluster is idle: %w", err)
-	}
-
-	if !isIdle {
-		return errors.New("cluster is not in S_IDLE state")
+		return err
 	}
 
 	initialState, _ := c.resources[beforeDiffField].(bool)
-	err = setMaintenanceState(ctx, c.executor, c.scope, initialState, c.parsedArguments)
+	err = c.clusterClient.SetMaintenanceState(
+		ctx, c.parsedArguments.resourceID, c.parsedArguments.nodeID, initialState,
+	)
 	if err != nil {
 		return fmt.Errorf("error rolling back maintenance state: %w", err)
 	}
@@ -268,150 +274,9 @@ func (c *ClusterMaintenanceChange) operationDiff(_ context.Context) map[string]a
 	}
 	diff["after"] = string(after)
 
-	return diff
-}
-
-// getMaintanceState returns the current state of the cluster
-// Find additional information here:
-// https://clusterlabs.org/projects/pacemaker/doc/2.1/Pacemaker_Explained/html/resources.html#resource-meta-attributes
-func getMaintenanceState(
-	ctx context.Context,
-	executor support.CmdExecutor,
-	scope clusterMaintenanceScope,
-	args *clusterMaintenanceChangeArguments,
-) (bool, error) {
-	switch scope {
-	case resourceScope:
-		{
-			// get "maintenance" attribute of the resource. This has preference over is-managed attribute
-			output, err := executor.Exec(ctx, "crm", "resource", "meta", args.resourceID, "show", "maintenance")
-			if err != nil {
-				return false, fmt.Errorf("error getting maintenance attribute: %w", err)
-			}
-
-			if !strings.Contains(string(output), "not found") {
-				boolValue, err := parseStateOutput(output)
-				if err != nil {
-					return false, fmt.Errorf("error decoding maintenance attribute: %w", err)
-				}
-
-				return boolValue, nil
-			}
-
-			// get "is-managed" attribute of the resource
-			output, err = executor.Exec(ctx, "crm", "resource", "meta", args.resourceID, "show", "is-managed")
-			if err != nil {
-				return false, fmt.Errorf("error getting is-managed attribute: %w", err)
-			}
-
-			// none of maintenance or is-managed attributes found. Defaulting to not in maintenance
-			if strings.Contains(string(output), "not found") {
-				return false, nil
-			}
-
-			boolValue, err := parseStateOutput(output)
-			if err != nil {
-				return false, fmt.Errorf("error decoding is-managed attribute: %w", err)
-			}
-
-			// is-managed has the opposite logic than maintenance attribute
-			return !boolValue, nil
-		}
-	case nodeScope:
-		{
-			// this command fails if the node is unknown. Check the output to see if the node is recognized
-			// possible outputs:
-			// maintenance on: scope=nodes  name=maintenance value=true
-			// maintenance off: scope=nodes  name=maintenance value=off
-			// yes, it returns true/off instead of true/false, on/off...
-			// node not found output:
-			// Could not map name=node-name to a UUID
-			maintenanceMode, err := executor.Exec(ctx, "crm", "node", "attribute", args.nodeID, "show", "maintenance")
-			if err != nil && strings.Contains(string(maintenanceMode), "Could not map") {
-				return false, fmt.Errorf("error getting node maintenance attribute: %w", err)
-			}
-
-			values := nodeStatePatternCompiled.FindSubmatch(maintenanceMode)
-			if len(values) == 2 && string(values[1]) == "true" {
-				return true, nil
-			}
-
-			return false, nil
-		}
-	default:
-		{
-			maintenanceMode, err := executor.Exec(ctx, "crm", "configure", "get_property", "-t", "maintenance-mode")
-			if err != nil {
-				return false, fmt.Errorf("error getting maintenance-mode: %w", err)
-			}
-
-			boolValue, err := parseStateOutput(maintenanceMode)
-			if err != nil {
-				return false, fmt.Errorf("error decoding maintenance-mode attribute: %w", err)
-			}
-
-			return boolValue, nil
-		}
-	}
-}
-
-func setMaintenanceState(
-	ctx context.Context,
-	executor support.CmdExecutor,
-	scope clusterMaintenanceScope,
-	state bool,
-	args *clusterMaintenanceChangeArguments,
-) error {
-	strState := maintenanceOff
-	if state {
-		strState = maintenanceOn
-	}
-
-	switch scope {
-	case resourceScope:
-		{
-			_, err := executor.Exec(ctx, "crm", "maintenance", strState, args.resourceID)
-			return err
-		}
-	case nodeScope:
-		{
-			if state {
-				_, err := executor.Exec(ctx, "crm", "--force", "node", "maintenance", args.nodeID)
-				return err
-			}
-			_, err := executor.Exec(ctx, "crm", "--force", "node", "ready", args.nodeID)
-			return err
-		}
-	default:
-		{
-			_, err := executor.Exec(ctx, "crm", "maintenance", strState)
-			return err
-		}
-	}
-}
-
-// Depending on the queried resource, the crm command might print some "debug" lines
-// before returning the actual state of the attribute.
-// The actual state is always a boolean value, either 'true' or 'false'
-// The debug lines are cleaned up before parsing the final boolean state of the attribute.
-// Example output:
-// linux # crm resource meta msl_SAPHana_PRD_HDB00 show maintenance
-// msl_SAPHana_PRD_HDB00 is active on more than one node, returning the default value for maintenance
-// false
-func parseStateOutput(output []byte) (bool, error) {
-	trimmedString := strings.TrimSpace(string(output))
-	if len(trimmedString) == 0 {
-		return false, fmt.Errorf("empty command output")
-	}
-
-	lines := strings.Split(trimmedString, "\n")
-	lastLine := lines[len(lines)-1]
+	addPreflightChecksDiff(diff, c.resources)
 
-	boolValue, err := strconv.ParseBool(lastLine)
-	if err != nil {
-		return false, err
-	}
-	return boolValue, nil
+	return diff
 }
 
 func parseClusterMaintenanceArguments(rawArguments Arguments) (*clusterMaintenanceChangeArguments, error) {
@@ -457,9 +322,21 @@ func parseClusterMaintenanceArguments(rawArguments Arguments) (*clusterMaintenan
 		}
 	}
 
+	preflightOverrides, err := parsePreflightOverrides(rawArguments)
+	if err != nil {
+		return nil, err
+	}
+
+	waitForIdle, err := parseWaitForIdle(rawArguments)
+	if err != nil {
+		return nil, err
+	}
+
 	return &clusterMaintenanceChangeArguments{
-		maintenance: maintenance,
-		resourceID:  resourceID,
-		nodeID:      nodeID,
+		maintenance:        maintenance,
+		resourceID:         resourceID,
+		nodeID:             nodeID,
+		preflightOverrides: preflightOverrides,
+		waitForIdle:        waitForIdle,
 	}, nil
 }
@@ -7,18 +7,31 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
 	clusterMocks "github.com/trento-project/workbench/internal/cluster/mocks"
-	"github.com/trento-project/workbench/internal/support/mocks"
+	supportMocks "github.com/trento-project/workbench/internal/support/mocks"
 	"github.com/trento-project/workbench/pkg/operator"
 )
 
-const fakeID = "some-id"
+const (
+	fakeID                    = "some-id"
+	passedPreflightChecksDiff = `[{"name":"quorum","passed":true}]`
+)
+
+func passedPreflightReport() *cluster.PreflightReport {
+	return &cluster.PreflightReport{
+		Results: []cluster.PreflightCheckResult{
+			{Name: cluster.QuorumCheck, Passed: true},
+		},
+	}
+}
 
 type ClusterMaintenanceChangeOperatorTestSuite struct {
 	suite.Suite
-	mockCmdExecutor   *mocks.MockCmdExecutor
 	mockClusterClient *clusterMocks.MockCluster
 }
 
@@ -27,7 +40,6 @@ func TestClusterMaintenanceChangeOperator(t *testing.T) {
 }
 
 func (suite *ClusterMaintenanceChangeOperatorTestSuite) SetupTest() {
-	suite.mockCmdExecutor = mocks.NewMockCmdExecutor(suite.T())
 	suite.mockClusterClient = clusterMocks.NewMockCluster(suite.T())
 }
 
@@ -35,36 +47,26 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	ctx := context.Background()
 
 	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Checks: []cluster.PreflightCheckName{
+			cluster.QuorumCheck,
+			cluster.DCElectedCheck,
+			cluster.PendingFencingCheck,
+			cluster.StonithEnabledCheck,
+			cluster.HanaSRSyncCheck,
+			cluster.FailedActionsCheck,
+		},
+		Overrides: []cluster.PreflightCheckName{},
+	}).
+		Return(passedPreflightReport(), nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"configure",
-		"get_property",
-		"-t",
-		"maintenance-mode",
-	).Return([]byte("false"), nil).Once()
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(false, nil).Once()
 
 	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"maintenance",
-		"on",
-	).Return([]byte("ok"), nil)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"configure",
-		"get_property",
-		"-t",
-		"maintenance-mode",
-	).Return([]byte("true"), nil)
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", "", true).Return(nil)
+
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(true, nil)
 
 	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
 		operator.Arguments{
@@ -73,7 +75,6 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
 			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
 				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
 			},
 		},
@@ -82,8 +83,9 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	report := clusterMaintenanceChangeOperator.Run(ctx)
 
 	expectedDiff := map[string]any{
-		"before": "{\"maintenance\":false}",
-		"after":  "{\"maintenance\":true}",
+		"before":           "{\"maintenance\":false}",
+		"after":            "{\"maintenance\":true}",
+		"preflight_checks": passedPreflightChecksDiff,
 	}
 
 	suite.Nil(report.Error)
@@ -95,38 +97,27 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	ctx := context.Background()
 
 	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Checks: []cluster.PreflightCheckName{
+			cluster.QuorumCheck,
+			cluster.DCElectedCheck,
+			cluster.PendingFencingCheck,
+			cluster.StonithEnabledCheck,
+			cluster.HanaSRSyncCheck,
+		},
+		Overrides: []cluster.PreflightCheckName{},
+	}).
+		Return(passedPreflightReport(), nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"configure",
-		"get_property",
-		"-t",
-		"maintenance-mode",
-	).Return([]byte("true"), nil).Once()
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(true, nil).Once()
 
 	suite.mockClusterClient.
 		On("IsIdle", ctx).Return(true, nil).
 		On("ResourceRefresh", ctx, "", "").Return(nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"maintenance",
-		"off",
-	).Return([]byte("ok"), nil)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"configure",
-		"get_property",
-		"-t",
-		"maintenance-mode",
-	).Return([]byte("false"), nil)
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", "", false).Return(nil)
+
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(false, nil)
 
 	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
 		operator.Arguments{
@@ -135,7 +126,6 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
 			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
 				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
 			},
 		},
@@ -144,8 +134,9 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	report := clusterMaintenanceChangeOperator.Run(ctx)
 
 	expectedDiff := map[string]any{
-		"before": "{\"maintenance\":true}",
-		"after":  "{\"maintenance\":false}",
+		"before":           "{\"maintenance\":true}",
+		"after":            "{\"maintenance\":false}",
+		"preflight_checks": passedPreflightChecksDiff,
 	}
 
 	suite.Nil(report.Error)
@@ -158,117 +149,16 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	resourceID := fakeID
 
 	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, mock.AnythingOfType("cluster.PreflightOptions")).
+		Return(passedPreflightReport(), nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"resource",
-		"meta",
-		resourceID,
-		"show",
-		"maintenance",
-	).Return([]byte("false"), nil).Once()
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, resourceID, "").Return(false, nil).Once()
 
 	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"maintenance",
-		"on",
-		resourceID,
-	).Return([]byte("ok"), nil)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"resource",
-		"meta",
-		resourceID,
-		"show",
-		"maintenance",
-	).Return([]byte("true"), nil)
-
-	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
-		operator.Arguments{
-			"maintenance": true,
-			"resource_id": resourceID,
-		},
-		"test-op",
-		operator.Options[operator.ClusterMaintenanceChange]{
-			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
-			},
-		},
-	)
-
-	report := clusterMaintenanceChangeOperator.Run(ctx)
-
-	expectedDiff := map[string]any{
-		"before": "{\"maintenance\":false,\"resource_id\":\"some-id\"}",
-		"after":  "{\"maintenance\":true,\"resource_id\":\"some-id\"}",
-	}
-
-	suite.Nil(report.Error)
-	suite.Equal(report.Success.LastPhase, operator.VERIFY)
-	suite.EqualValues(report.Success.Diff, expectedDiff)
-}
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, resourceID, "", true).Return(nil)
 
-func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceChangeResourceWithIsManagedSuccess() {
-	ctx := context.Background()
-	resourceID := fakeID
-
-	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"resource",
-		"meta",
-		resourceID,
-		"show",
-		"maintenance",
-	).Return([]byte("not found"), nil)
-
-	// is-managed has the reverse boolean logic than `maintenance`
-	// so is-managed=true means maintenance=false
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"resource",
-		"meta",
-		resourceID,
-		"show",
-		"is-managed",
-	).Return([]byte("true"), nil).Once()
-
-	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"maintenance",
-		"on",
-		resourceID,
-	).Return([]byte("ok"), nil)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"resource",
-		"meta",
-		resourceID,
-		"show",
-		"is-managed",
-	).Return([]byte("false"), nil)
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, resourceID, "").Return(true, nil)
 
 	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
 		operator.Arguments{
@@ -278,7 +168,6 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
 			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
 				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
 			},
 		},
@@ -287,84 +176,9 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	report := clusterMaintenanceChangeOperator.Run(ctx)
 
 	expectedDiff := map[string]any{
-		"before": "{\"maintenance\":false,\"resource_id\":\"some-id\"}",
-		"after":  "{\"maintenance\":true,\"resource_id\":\"some-id\"}",
-	}
-
-	suite.Nil(report.Error)
-	suite.Equal(report.Success.LastPhase, operator.VERIFY)
-	suite.EqualValues(report.Success.Diff, expectedDiff)
-}
-
-func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceChangeResourceDefaultSuccess() {
-	ctx := context.Background()
-	resourceID := fakeID
-
-	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"resource",
-		"meta",
-		resourceID,
-		"show",
-		"maintenance",
-	).Return([]byte("not found"), nil).Once()
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"resource",
-		"meta",
-		resourceID,
-		"show",
-		"is-managed",
-	).Return([]byte("not found"), nil)
-
-	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"maintenance",
-		"on",
-		resourceID,
-	).Return([]byte("ok"), nil)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"resource",
-		"meta",
-		resourceID,
-		"show",
-		"maintenance",
-	).Return([]byte("true"), nil)
-
-	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
-		operator.Arguments{
-			"maintenance": true,
-			"resource_id": resourceID,
-		},
-		"test-op",
-		operator.Options[operator.ClusterMaintenanceChange]{
-			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
-			},
-		},
-	)
-
-	report := clusterMaintenanceChangeOperator.Run(ctx)
-
-	expectedDiff := map[string]any{
-		"before": "{\"maintenance\":false,\"resource_id\":\"some-id\"}",
-		"after":  "{\"maintenance\":true,\"resource_id\":\"some-id\"}",
+		"before":           "{\"maintenance\":false,\"resource_id\":\"some-id\"}",
+		"after":            "{\"maintenance\":true,\"resource_id\":\"some-id\"}",
+		"preflight_checks": passedPreflightChecksDiff,
 	}
 
 	suite.Nil(report.Error)
@@ -377,109 +191,16 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	nodeID := fakeID
 
 	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, mock.AnythingOfType("cluster.PreflightOptions")).
+		Return(passedPreflightReport(), nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"node",
-		"attribute",
-		nodeID,
-		"show",
-		"maintenance",
-	).Return([]byte("scope=nodes  name=maintenance value=off"), nil).Once()
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", nodeID).Return(false, nil).Once()
 
 	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"--force",
-		"node",
-		"maintenance",
-		nodeID,
-	).Return([]byte("ok"), nil)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"node",
-		"attribute",
-		nodeID,
-		"show",
-		"maintenance",
-	).Return([]byte("scope=nodes  name=maintenance value=true"), nil)
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", nodeID, true).Return(nil)
 
-	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
-		operator.Arguments{
-			"maintenance": true,
-			"node_id":     nodeID,
-		},
-		"test-op",
-		operator.Options[operator.ClusterMaintenanceChange]{
-			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
-			},
-		},
-	)
-
-	report := clusterMaintenanceChangeOperator.Run(ctx)
-
-	expectedDiff := map[string]any{
-		"before": "{\"maintenance\":false,\"node_id\":\"some-id\"}",
-		"after":  "{\"maintenance\":true,\"node_id\":\"some-id\"}",
-	}
-
-	suite.Nil(report.Error)
-	suite.Equal(report.Success.LastPhase, operator.VERIFY)
-	suite.EqualValues(report.Success.Diff, expectedDiff)
-}
-
-func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceChangeNodeSuccessOnWithoutPreviousState() {
-	ctx := context.Background()
-	nodeID := fakeID
-
-	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"node",
-		"attribute",
-		nodeID,
-		"show",
-		"maintenance",
-	).Return(
-		[]byte("scope=nodes  name=maintenance value=(null)"),
-		errors.New("error getting node state"),
-	).Once()
-
-	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"--force",
-		"node",
-		"maintenance",
-		nodeID,
-	).Return([]byte("ok"), nil)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"node",
-		"attribute",
-		nodeID,
-		"show",
-		"maintenance",
-	).Return([]byte("scope=nodes  name=maintenance value=true"), nil)
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", nodeID).Return(true, nil)
 
 	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
 		operator.Arguments{
@@ -489,7 +210,6 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
 			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
 				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
 			},
 		},
@@ -498,8 +218,9 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	report := clusterMaintenanceChangeOperator.Run(ctx)
 
 	expectedDiff := map[string]any{
-		"before": "{\"maintenance\":false,\"node_id\":\"some-id\"}",
-		"after":  "{\"maintenance\":true,\"node_id\":\"some-id\"}",
+		"before":           "{\"maintenance\":false,\"node_id\":\"some-id\"}",
+		"after":            "{\"maintenance\":true,\"node_id\":\"some-id\"}",
+		"preflight_checks": passedPreflightChecksDiff,
 	}
 
 	suite.Nil(report.Error)
@@ -512,42 +233,18 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	nodeID := fakeID
 
 	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, mock.AnythingOfType("cluster.PreflightOptions")).
+		Return(passedPreflightReport(), nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"node",
-		"attribute",
-		nodeID,
-		"show",
-		"maintenance",
-	).Return([]byte("scope=nodes  name=maintenance value=true"), nil).Once()
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", nodeID).Return(true, nil).Once()
 
 	suite.mockClusterClient.
 		On("IsIdle", ctx).Return(true, nil).
 		On("ResourceRefresh", ctx, "", "").Return(nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"--force",
-		"node",
-		"ready",
-		nodeID,
-	).Return([]byte("ok"), nil)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"node",
-		"attribute",
-		nodeID,
-		"show",
-		"maintenance",
-	).Return([]byte("scope=nodes  name=maintenance value=off"), nil)
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", nodeID, false).Return(nil)
+
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", nodeID).Return(false, nil)
 
 	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
 		operator.Arguments{
@@ -557,7 +254,6 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
 			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
 				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
 			},
 		},
@@ -566,8 +262,9 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	report := clusterMaintenanceChangeOperator.Run(ctx)
 
 	expectedDiff := map[string]any{
-		"before": "{\"maintenance\":true,\"node_id\":\"some-id\"}",
-		"after":  "{\"maintenance\":false,\"node_id\":\"some-id\"}",
+		"before":           "{\"maintenance\":true,\"node_id\":\"some-id\"}",
+		"after":            "{\"maintenance\":false,\"node_id\":\"some-id\"}",
+		"preflight_checks": passedPreflightChecksDiff,
 	}
 
 	suite.Nil(report.Error)
@@ -582,9 +279,7 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		operator.Arguments{},
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
-			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
-			},
+			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{},
 		},
 	)
 
@@ -604,9 +299,7 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		},
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
-			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
-			},
+			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{},
 		},
 	)
 
@@ -627,9 +320,7 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		},
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
-			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
-			},
+			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{},
 		},
 	)
 
@@ -650,9 +341,7 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		},
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
-			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
-			},
+			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{},
 		},
 	)
 
@@ -674,9 +363,7 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		},
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
-			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
-			},
+			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{},
 		},
 	)
 
@@ -699,7 +386,6 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
 			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
 				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
 			},
 		},
@@ -712,20 +398,11 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	suite.EqualValues("cluster is not runnint on host", report.Error.Message)
 }
 
-func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceChangePlanGetMaintenanceError() {
+func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceChangeCustomExecutor() {
 	ctx := context.Background()
 
-	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"configure",
-		"get_property",
-		"-t",
-		"maintenance-mode",
-	).Return([]byte("error"), errors.New("cannot get state"))
+	mockExecutor := supportMocks.NewMockCmdExecutor(suite.T())
+	mockExecutor.On("Exec", ctx, "crm", "status").Return([]byte("error"), errors.New("exit status 1"))
 
 	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
 		operator.Arguments{
@@ -734,8 +411,7 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
 			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
+				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(mockExecutor)),
 			},
 		},
 	)
@@ -744,23 +420,16 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 
 	suite.Nil(report.Success)
 	suite.Equal(report.Error.ErrorPhase, operator.PLAN)
-	suite.EqualValues("error getting maintenance-mode: cannot get state", report.Error.Message)
+	suite.EqualValues("cluster is not runnint on host", report.Error.Message)
 }
 
-func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceChangePlanEmptyMaintenanceState() {
+func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceChangePlanGetMaintenanceError() {
 	ctx := context.Background()
 
 	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"configure",
-		"get_property",
-		"-t",
-		"maintenance-mode",
-	).Return([]byte(""), nil)
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").
+		Return(false, errors.New("error getting maintenance-mode: cannot get state"))
 
 	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
 		operator.Arguments{
@@ -769,7 +438,6 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
 			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
 				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
 			},
 		},
@@ -779,7 +447,7 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 
 	suite.Nil(report.Success)
 	suite.Equal(report.Error.ErrorPhase, operator.PLAN)
-	suite.EqualValues("error decoding maintenance-mode attribute: empty command output", report.Error.Message)
+	suite.EqualValues("error getting maintenance-mode: cannot get state", report.Error.Message)
 }
 
 func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceChangePlanNodeNotFound() {
@@ -788,16 +456,8 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 
 	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"node",
-		"attribute",
-		nodeID,
-		"show",
-		"maintenance",
-	).Return([]byte("Could not map name=some-id to a UUID"), errors.New("error getting node"))
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", nodeID).
+		Return(false, errors.New("error getting node maintenance attribute: error getting node"))
 
 	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
 		operator.Arguments{
@@ -807,7 +467,6 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
 			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
 				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
 			},
 		},
@@ -825,15 +484,7 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 
 	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"configure",
-		"get_property",
-		"-t",
-		"maintenance-mode",
-	).Return([]byte("true"), nil)
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(true, nil)
 
 	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
 		operator.Arguments{
@@ -842,7 +493,6 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
 			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
 				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
 			},
 		},
@@ -864,27 +514,15 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	ctx := context.Background()
 
 	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, mock.AnythingOfType("cluster.PreflightOptions")).
+		Return(passedPreflightReport(), nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"configure",
-		"get_property",
-		"-t",
-		"maintenance-mode",
-	).Return([]byte("false"), nil)
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(false, nil)
 
 	suite.mockClusterClient.On("IsIdle", ctx).Return(false, nil).Once()
 	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"maintenance",
-		"off",
-	).Return([]byte("ok"), nil)
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", "", false).Return(nil)
 
 	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
 		operator.Arguments{
@@ -893,7 +531,6 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
 			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
 				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
 			},
 		},
@@ -906,48 +543,116 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	suite.EqualValues("cluster is not in S_IDLE state", report.Error.Message)
 }
 
+func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceChangeWaitForIdleSuccess() {
+	ctx := context.Background()
+	nodeID := fakeID
+	waitForIdleOptions := cluster.WaitForIdleOptions{Timeout: 60 * time.Second}
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, mock.AnythingOfType("cluster.PreflightOptions")).
+		Return(passedPreflightReport(), nil)
+
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", nodeID).Return(false, nil).Once()
+	suite.mockClusterClient.On("WaitForIdle", ctx, waitForIdleOptions).Return(nil).Once()
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", nodeID, true).Return(nil).Once()
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", nodeID).Return(true, nil).Once()
+
+	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
+		operator.Arguments{
+			"maintenance":   true,
+			"node_id":       nodeID,
+			"wait_for_idle": float64(60),
+		},
+		"test-op",
+		operator.Options[operator.ClusterMaintenanceChange]{
+			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
+				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
+			},
+		},
+	)
+
+	report := clusterMaintenanceChangeOperator.Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+}
+
+func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceChangeCommitWaitForIdleTimeout() {
+	ctx := context.Background()
+	waitForIdleOptions := cluster.WaitForIdleOptions{Timeout: 30 * time.Second}
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, mock.AnythingOfType("cluster.PreflightOptions")).
+		Return(passedPreflightReport(), nil)
+
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(false, nil)
+
+	suite.mockClusterClient.On("WaitForIdle", ctx, waitForIdleOptions).
+		Return(&cluster.IdleTimeoutError{
+			Timeout:    30 * time.Second,
+			Transition: &cluster.Transition{DC: "vmhana01", ControllerState: "S_POLICY_ENGINE"},
+		}).Once()
+	suite.mockClusterClient.On("WaitForIdle", ctx, waitForIdleOptions).Return(nil).Once()
+
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", "", false).Return(nil).Once()
+
+	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
+		operator.Arguments{
+			"maintenance":   true,
+			"wait_for_idle": float64(30),
+		},
+		"test-op",
+		operator.Options[operator.ClusterMaintenanceChange]{
+			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
+				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
+			},
+		},
+	)
+
+	report := clusterMaintenanceChangeOperator.Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues("cluster is not in S_IDLE state after 30s, controller state: S_POLICY_ENGINE", report.Error.Message)
+}
+
+func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceChangeInvalidWaitForIdleArgument() {
+	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
+		operator.Arguments{
+			"maintenance":   true,
+			"wait_for_idle": "1m",
+		},
+		"test-op",
+		operator.Options[operator.ClusterMaintenanceChange]{
+			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
+				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
+			},
+		},
+	)
+
+	report := clusterMaintenanceChangeOperator.Run(context.Background())
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("could not parse wait_for_idle argument as a number, argument provided: 1m", report.Error.Message)
+}
+
 func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceChangeVerifyError() {
 	ctx := context.Background()
 
 	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, mock.AnythingOfType("cluster.PreflightOptions")).
+		Return(passedPreflightReport(), nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"configure",
-		"get_property",
-		"-t",
-		"maintenance-mode",
-	).Return([]byte("false"), nil).Once()
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(false, nil).Once()
 
 	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"maintenance",
-		"on",
-	).Return([]byte("ok"), nil).Once()
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"configure",
-		"get_property",
-		"-t",
-		"maintenance-mode",
-	).Return([]byte("false"), nil)
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"maintenance",
-		"off",
-	).Return([]byte("ok"), nil)
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", "", true).Return(nil).Once()
+
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(false, nil)
+
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", "", false).Return(nil)
 
 	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
 		operator.Arguments{
@@ -956,7 +661,6 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
 			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
 				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
 			},
 		},
@@ -973,26 +677,14 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	ctx := context.Background()
 
 	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, mock.AnythingOfType("cluster.PreflightOptions")).
+		Return(passedPreflightReport(), nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"configure",
-		"get_property",
-		"-t",
-		"maintenance-mode",
-	).Return([]byte("false"), nil)
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(false, nil)
 
 	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"maintenance",
-		"on",
-	).Return([]byte("error"), errors.New("error changing"))
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", "", true).Return(errors.New("error changing"))
 
 	suite.mockClusterClient.On("IsIdle", ctx).Return(false, nil)
 
@@ -1003,7 +695,6 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
 			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
 				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
 			},
 		},
@@ -1020,34 +711,16 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	ctx := context.Background()
 
 	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, mock.AnythingOfType("cluster.PreflightOptions")).
+		Return(passedPreflightReport(), nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"configure",
-		"get_property",
-		"-t",
-		"maintenance-mode",
-	).Return([]byte("false"), nil)
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(false, nil)
 
 	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil)
 
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"maintenance",
-		"on",
-	).Return([]byte("error"), errors.New("error changing"))
-
-	suite.mockCmdExecutor.On(
-		"Exec",
-		ctx,
-		"crm",
-		"maintenance",
-		"off",
-	).Return([]byte("error"), errors.New("error reverting"))
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", "", true).Return(errors.New("error changing"))
+
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", "", false).Return(errors.New("error reverting"))
 
 	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
 		operator.Arguments{
@@ -1056,7 +729,6 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 		"test-op",
 		operator.Options[operator.ClusterMaintenanceChange]{
 			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
-				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceExecutor(suite.mockCmdExecutor)),
 				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
 			},
 		},
@@ -1068,3 +740,74 @@ func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceCh
 	suite.Equal(report.Error.ErrorPhase, operator.ROLLBACK)
 	suite.EqualValues("error rolling back maintenance state: error reverting\nerror updating maintenance state: error changing", report.Error.Message)
 }
+
+func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceChangePlanPreflightChecksFailed() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true)
+
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(false, nil).Once()
+
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Checks: []cluster.PreflightCheckName{
+			cluster.QuorumCheck,
+			cluster.DCElectedCheck,
+			cluster.PendingFencingCheck,
+			cluster.StonithEnabledCheck,
+			cluster.HanaSRSyncCheck,
+			cluster.FailedActionsCheck,
+		},
+		Overrides: []cluster.PreflightCheckName{cluster.StonithEnabledCheck},
+	}).Return(&cluster.PreflightReport{
+		Results: []cluster.PreflightCheckResult{
+			{Name: cluster.QuorumCheck, Passed: false, Message: "cluster partition doesn't have quorum"},
+			{Name: cluster.StonithEnabledCheck, Passed: false, Overridden: true, Message: "stonith is not enabled"},
+		},
+	}, nil)
+
+	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
+		operator.Arguments{
+			"maintenance":         true,
+			"preflight_overrides": []any{"stonith_enabled"},
+		},
+		"test-op",
+		operator.Options[operator.ClusterMaintenanceChange]{
+			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
+				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
+			},
+		},
+	)
+
+	report := clusterMaintenanceChangeOperator.Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.Equal("cluster pre-flight checks failed: quorum: cluster partition doesn't have quorum", report.Error.Message)
+	suite.Equal(map[string]any{
+		"preflight_checks": `[{"name":"quorum","passed":false,"message":"cluster partition doesn't have quorum"},` +
+			`{"name":"stonith_enabled","passed":false,"overridden":true,"message":"stonith is not enabled"}]`,
+	}, report.Error.Diff)
+}
+
+func (suite *ClusterMaintenanceChangeOperatorTestSuite) TestClusterMaintenanceChangeInvalidPreflightOverridesArgument() {
+	ctx := context.Background()
+
+	clusterMaintenanceChangeOperator := operator.NewClusterMaintenanceChange(
+		operator.Arguments{
+			"maintenance":         true,
+			"preflight_overrides": []any{"unknown"},
+		},
+		"test-op",
+		operator.Options[operator.ClusterMaintenanceChange]{
+			OperatorOptions: []operator.Option[operator.ClusterMaintenanceChange]{
+				operator.Option[operator.ClusterMaintenanceChange](operator.WithCustomClusterMaintenanceClient(suite.mockClusterClient)),
+			},
+		},
+	)
+
+	report := clusterMaintenanceChangeOperator.Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.Equal("invalid preflight_overrides value: unknown", report.Error.Message)
+}
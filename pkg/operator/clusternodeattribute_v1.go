@@ -0,0 +1,357 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/trento-project/workbench/internal/cluster"
+)
+
+const (
+	ClusterNodeAttributeOperatorName = "clusternodeattribute"
+	nodeAttributesArgument           = "attributes"
+	transientNodeAttributesArgument  = "transient_attributes"
+	nodeUtilizationArgument          = "utilization"
+)
+
+type ClusterNodeAttributeOption Option[ClusterNodeAttribute]
+
+type clusterNodeAttribute struct {
+	attributeType cluster.NodeAttributeType
+	name          string
+	// value is nil if the attribute has to be deleted
+	value *string
+}
+
+type clusterNodeAttributeArguments struct {
+	nodeID     string
+	attributes []clusterNodeAttribute
+}
+
+// clusterNodeAttributeValues are the attribute values by type and name.
+// A nil value means that the attribute is not set.
+type clusterNodeAttributeValues map[cluster.NodeAttributeType]map[string]*string
+
+type clusterNodeAttributesDiffOutput struct {
+	NodeID              string             `json:"node_id"`
+	Attributes          map[string]*string `json:"attributes,omitempty"`
+	TransientAttributes map[string]*string `json:"transient_attributes,omitempty"`
+	Utilization         map[string]*string `json:"utilization,omitempty"`
+}
+
+// ClusterNodeAttribute operator sets or deletes attributes and utilization values of a cluster node.
+//
+// Arguments:
+//  node_id (required): Name of the cluster node
+//  attributes: Map with the permanent node attributes to change, e.g. {"hana_prd_site": "NUREMBERG"}
+//  transient_attributes: Map with the transient node attributes to change. They are removed when
+//                        the node leaves the cluster.
+//  utilization: Map with the node utilization values to change, e.g. {"cpu": 8, "memory": 32768}
+//
+// At least one attribute must be given. A null value deletes the attribute. Utilization values must be
+// non negative integers. The maintenance and standby attributes cannot be changed with this operator.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   Checks that the cluster is running in the host and gets the current value of the attributes.
+//   The operation fails if the node doesn't exist. If all the attributes already have the requested
+//   values, the operation is skipped.
+//
+// - COMMIT:
+//   Checks that the cluster is idle and changes the attributes using `crm node attribute|status-attr|utilization`,
+//   or the equivalent pcs commands.
+//
+// - VERIFY:
+//   Checks that all the attributes have the requested values.
+//
+// - ROLLBACK:
+//   Checks that the cluster is idle and restores the previous values. Attributes that were not set
+//   are deleted.
+
+type ClusterNodeAttribute struct {
+	baseOperator
+	clusterClient   cluster.Cluster
+	parsedArguments *clusterNodeAttributeArguments
+}
+
+func WithCustomClusterNodeAttributeClient(clusterClient cluster.Cluster) ClusterNodeAttributeOption {
+	return func(o *ClusterNodeAttribute) {
+		o.clusterClient = clusterClient
+	}
+}
+
+func NewClusterNodeAttribute(
+	arguments Arguments,
+	operationID string,
+	options Options[ClusterNodeAttribute],
+) *Executor {
+	nodeAttribute := &ClusterNodeAttribute{
+		baseOperator: newBaseOperator(
+			ClusterNodeAttributeOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+		clusterClient: cluster.NewDefaultClusterClient(),
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(nodeAttribute)
+	}
+
+	return &Executor{
+		phaser:      nodeAttribute,
+		operationID: operationID,
+		logger:      nodeAttribute.logger,
+	}
+}
+
+func (c *ClusterNodeAttribute) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseClusterNodeAttributeArguments(c.arguments)
+	if err != nil {
+		return false, err
+	}
+	c.parsedArguments = opArguments
+
+	if !c.clusterClient.IsHostOnline(ctx) {
+		return false, errors.New("cluster is not running on host")
+	}
+
+	currentValues, err := c.getAttributeValues(ctx)
+	if err != nil {
+		return false, err
+	}
+	c.resources[beforeDiffField] = currentValues
+
+	if c.attributesApplied(currentValues) {
+		c.logger.Info("node attributes already set, skipping operation", "node", c.parsedArguments.nodeID)
+		c.resources[afterDiffField] = currentValues
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (c *ClusterNodeAttribute) commit(ctx context.Context) error {
+	if err := ensureClusterIsIdle(ctx, c.clusterClient, 0); err != nil {
+		return err
+	}
+
+	initialValues, _ := c.resources[beforeDiffField].(clusterNodeAttributeValues)
+
+	for _, attribute := range c.parsedArguments.attributes {
+		if attribute.value != nil {
+			err := c.clusterClient.SetNodeAttribute(
+				ctx, c.parsedArguments.nodeID, attribute.attributeType, attribute.name, *attribute.value,
+			)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if initialValues[attribute.attributeType][attribute.name] == nil {
+			continue
+		}
+
+		err := c.clusterClient.DeleteNodeAttribute(
+			ctx, c.parsedArguments.nodeID, attribute.attributeType, attribute.name,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ClusterNodeAttribute) verify(ctx context.Context) error {
+	currentValues, err := c.getAttributeValues(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !c.attributesApplied(currentValues) {
+		return errors.New("verify node attributes failed, the requested values were not set in commit phase")
+	}
+
+	c.resources[afterDiffField] = currentValues
+	return nil
+}
+
+func (c *ClusterNodeAttribute) rollback(ctx context.Context) error {
+	if err := ensureClusterIsIdle(ctx, c.clusterClient, 0); err != nil {
+		return err
+	}
+
+	initialValues, _ := c.resources[beforeDiffField].(clusterNodeAttributeValues)
+
+	var rollbackErr error
+	for _, attribute := range c.parsedArguments.attributes {
+		initialValue := initialValues[attribute.attributeType][attribute.name]
+
+		var err error
+		if initialValue == nil {
+			err = c.clusterClient.DeleteNodeAttribute(
+				ctx, c.parsedArguments.nodeID, attribute.attributeType, attribute.name,
+			)
+		} else {
+			err = c.clusterClient.SetNodeAttribute(
+				ctx, c.parsedArguments.nodeID, attribute.attributeType, attribute.name, *initialValue,
+			)
+		}
+		rollbackErr = errors.Join(rollbackErr, err)
+	}
+
+	if rollbackErr != nil {
+		return fmt.Errorf("error rolling back node attributes: %w", rollbackErr)
+	}
+
+	return nil
+}
+
+func (c *ClusterNodeAttribute) operationDiff(_ context.Context) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		values, ok := c.resources[field].(clusterNodeAttributeValues)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to node attribute values",
+				field, c.resources[field]))
+		}
+
+		output, err := json.Marshal(clusterNodeAttributesDiffOutput{
+			NodeID:              c.parsedArguments.nodeID,
+			Attributes:          values[cluster.PermanentNodeAttribute],
+			TransientAttributes: values[cluster.TransientNodeAttribute],
+			Utilization:         values[cluster.UtilizationNodeAttribute],
+		})
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	return diff
+}
+
+func (c *ClusterNodeAttribute) getAttributeValues(ctx context.Context) (clusterNodeAttributeValues, error) {
+	values := make(clusterNodeAttributeValues)
+	for _, attribute := range c.parsedArguments.attributes {
+		value, found, err := c.clusterClient.GetNodeAttribute(
+			ctx, c.parsedArguments.nodeID, attribute.attributeType, attribute.name,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := values[attribute.attributeType]; !ok {
+			values[attribute.attributeType] = make(map[string]*string)
+		}
+
+		values[attribute.attributeType][attribute.name] = nil
+		if found {
+			values[attribute.attributeType][attribute.name] = &value
+		}
+	}
+
+	return values, nil
+}
+
+func (c *ClusterNodeAttribute) attributesApplied(values clusterNodeAttributeValues) bool {
+	for _, attribute := range c.parsedArguments.attributes {
+		value := values[attribute.attributeType][attribute.name]
+		switch {
+		case attribute.value == nil && value != nil:
+			return false
+		case attribute.value != nil && (value == nil || *value != *attribute.value):
+			return false
+		}
+	}
+	return true
+}
+
+func parseClusterNodeAttributeArguments(rawArguments Arguments) (*clusterNodeAttributeArguments, error) {
+	nodeIDArgument, found := rawArguments["node_id"]
+	if !found {
+		return nil, errors.New("argument node_id not provided, could not use the operator")
+	}
+
+	nodeID, ok := nodeIDArgument.(string)
+	if !ok || nodeID == "" {
+		return nil, fmt.Errorf("could not parse node_id argument as string, argument provided: %v", nodeIDArgument)
+	}
+
+	attributes := []clusterNodeAttribute{}
+
+	for argument, attributeType := range map[string]cluster.NodeAttributeType{
+		nodeAttributesArgument:          cluster.PermanentNodeAttribute,
+		transientNodeAttributesArgument: cluster.TransientNodeAttribute,
+		nodeUtilizationArgument:         cluster.UtilizationNodeAttribute,
+	} {
+		rawAttributes, found := rawArguments[argument]
+		if !found {
+			continue
+		}
+
+		attributesMap, ok := rawAttributes.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf(
+				"could not parse %s argument as a map, argument provided: %v", argument, rawAttributes,
+			)
+		}
+
+		for name, rawValue := range attributesMap {
+			attribute, err := parseClusterNodeAttribute(attributeType, name, rawValue)
+			if err != nil {
+				return nil, err
+			}
+
+			attributes = append(attributes, attribute)
+		}
+	}
+
+	if len(attributes) == 0 {
+		return nil, fmt.Errorf(
+			"arguments %s, %s or %s not provided, could not use the operator",
+			nodeAttributesArgument, transientNodeAttributesArgument, nodeUtilizationArgument,
+		)
+	}
+
+	// keep a stable order to apply the changes
+	slices.SortFunc(attributes, func(a, b clusterNodeAttribute) int {
+		return cmp.Or(cmp.Compare(a.attributeType, b.attributeType), cmp.Compare(a.name, b.name))
+	})
+
+	return &clusterNodeAttributeArguments{nodeID: nodeID, attributes: attributes}, nil
+}
+
+// parseClusterNodeAttribute parses the value of an attribute. A nil value means that the attribute is deleted.
+func parseClusterNodeAttribute(
+	attributeType cluster.NodeAttributeType,
+	name string,
+	rawValue any,
+) (clusterNodeAttribute, error) {
+	attribute := clusterNodeAttribute{attributeType: attributeType, name: name}
+
+	if rawValue == nil {
+		return attribute, cluster.ValidateNodeAttributeName(attributeType, name)
+	}
+
+	value, err := parsePropertyValue(rawValue)
+	if err != nil {
+		return attribute, fmt.Errorf("could not parse %s attribute value: %w", name, err)
+	}
+
+	if err := cluster.ValidateNodeAttribute(attributeType, name, value); err != nil {
+		return attribute, err
+	}
+	attribute.value = &value
+
+	return attribute, nil
+}
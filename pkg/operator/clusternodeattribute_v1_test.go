@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/cluster/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+type ClusterNodeAttributeOperatorTestSuite struct {
+	suite.Suite
+	mockClusterClient *mocks.MockCluster
+}
+
+func TestClusterNodeAttributeOperator(t *testing.T) {
+	suite.Run(t, new(ClusterNodeAttributeOperatorTestSuite))
+}
+
+func (suite *ClusterNodeAttributeOperatorTestSuite) SetupTest() {
+	suite.mockClusterClient = mocks.NewMockCluster(suite.T())
+}
+
+func (suite *ClusterNodeAttributeOperatorTestSuite) buildOperator(arguments operator.Arguments) *operator.Executor {
+	return operator.NewClusterNodeAttribute(
+		arguments,
+		"test-op",
+		operator.Options[operator.ClusterNodeAttribute]{
+			OperatorOptions: []operator.Option[operator.ClusterNodeAttribute]{
+				operator.Option[operator.ClusterNodeAttribute](operator.WithCustomClusterNodeAttributeClient(suite.mockClusterClient)),
+			},
+		},
+	)
+}
+
+func (suite *ClusterNodeAttributeOperatorTestSuite) TestClusterNodeAttributeInvalidArguments() {
+	ctx := context.Background()
+
+	cases := []struct {
+		arguments operator.Arguments
+		err       string
+	}{
+		{
+			arguments: operator.Arguments{"attributes": map[string]any{"hana_prd_site": "PRAGUE"}},
+			err:       "argument node_id not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{"node_id": 1},
+			err:       "could not parse node_id argument as string, argument provided: 1",
+		},
+		{
+			arguments: operator.Arguments{"node_id": "vmhana01"},
+			err: "arguments attributes, transient_attributes or utilization not provided, " +
+				"could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{"node_id": "vmhana01", "utilization": []string{"cpu=8"}},
+			err:       "could not parse utilization argument as a map, argument provided: [cpu=8]",
+		},
+		{
+			arguments: operator.Arguments{"node_id": "vmhana01", "utilization": map[string]any{"cpu": "many"}},
+			err:       "invalid utilization value many for cpu, it must be a non negative integer",
+		},
+		{
+			arguments: operator.Arguments{"node_id": "vmhana01", "attributes": map[string]any{"maintenance": nil}},
+			err:       "node attribute maintenance cannot be changed",
+		},
+		{
+			arguments: operator.Arguments{"node_id": "vmhana01", "attributes": map[string]any{"site": []string{}}},
+			err:       "could not parse site attribute value: unsupported value type, value provided: []",
+		},
+	}
+
+	for _, tc := range cases {
+		report := suite.buildOperator(tc.arguments).Run(ctx)
+
+		suite.Nil(report.Success)
+		suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+		suite.EqualValues(tc.err, report.Error.Message)
+	}
+}
+
+func (suite *ClusterNodeAttributeOperatorTestSuite) TestClusterNodeAttributeClusterNotRunning() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"node_id":    "vmhana01",
+		"attributes": map[string]any{"hana_prd_site": "PRAGUE"},
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("cluster is not running on host", report.Error.Message)
+}
+
+func (suite *ClusterNodeAttributeOperatorTestSuite) TestClusterNodeAttributeNodeNotFound() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetNodeAttribute", ctx, "vmhana03", cluster.PermanentNodeAttribute, "hana_prd_site").
+		Return("", false, errors.New("node vmhana03 not found")).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"node_id":    "vmhana03",
+		"attributes": map[string]any{"hana_prd_site": "PRAGUE"},
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("node vmhana03 not found", report.Error.Message)
+}
+
+func (suite *ClusterNodeAttributeOperatorTestSuite) TestClusterNodeAttributeSuccess() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetNodeAttribute", ctx, "vmhana01", cluster.PermanentNodeAttribute, "hana_prd_site").
+		Return("NUREMBERG", true, nil).Once()
+	suite.mockClusterClient.On("GetNodeAttribute", ctx, "vmhana01", cluster.TransientNodeAttribute, "hana_prd_op_mode").
+		Return("logreplay", true, nil).Once()
+	suite.mockClusterClient.On("GetNodeAttribute", ctx, "vmhana01", cluster.UtilizationNodeAttribute, "cpu").
+		Return("", false, nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
+	suite.mockClusterClient.On("SetNodeAttribute", ctx, "vmhana01", cluster.PermanentNodeAttribute,
+		"hana_prd_site", "PRAGUE").Return(nil).Once()
+	suite.mockClusterClient.On("DeleteNodeAttribute", ctx, "vmhana01", cluster.TransientNodeAttribute,
+		"hana_prd_op_mode").Return(nil).Once()
+	suite.mockClusterClient.On("SetNodeAttribute", ctx, "vmhana01", cluster.UtilizationNodeAttribute,
+		"cpu", "8").Return(nil).Once()
+	suite.mockClusterClient.On("GetNodeAttribute", ctx, "vmhana01", cluster.PermanentNodeAttribute, "hana_prd_site").
+		Return("PRAGUE", true, nil).Once()
+	suite.mockClusterClient.On("GetNodeAttribute", ctx, "vmhana01", cluster.TransientNodeAttribute, "hana_prd_op_mode").
+		Return("", false, nil).Once()
+	suite.mockClusterClient.On("GetNodeAttribute", ctx, "vmhana01", cluster.UtilizationNodeAttribute, "cpu").
+		Return("8", true, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"node_id":              "vmhana01",
+		"attributes":           map[string]any{"hana_prd_site": "PRAGUE"},
+		"transient_attributes": map[string]any{"hana_prd_op_mode": nil},
+		"utilization":          map[string]any{"cpu": float64(8)},
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"node_id":"vmhana01","attributes":{"hana_prd_site":"NUREMBERG"},` +
+			`"transient_attributes":{"hana_prd_op_mode":"logreplay"},"utilization":{"cpu":null}}`,
+		"after": `{"node_id":"vmhana01","attributes":{"hana_prd_site":"PRAGUE"},` +
+			`"transient_attributes":{"hana_prd_op_mode":null},"utilization":{"cpu":"8"}}`,
+	}, report.Success.Diff)
+}
+
+func (suite *ClusterNodeAttributeOperatorTestSuite) TestClusterNodeAttributeAlreadyApplied() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetNodeAttribute", ctx, "vmhana01", cluster.PermanentNodeAttribute, "hana_prd_site").
+		Return("PRAGUE", true, nil).Once()
+	suite.mockClusterClient.On("GetNodeAttribute", ctx, "vmhana01", cluster.PermanentNodeAttribute, "rack").
+		Return("", false, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"node_id":    "vmhana01",
+		"attributes": map[string]any{"hana_prd_site": "PRAGUE", "rack": nil},
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"node_id":"vmhana01","attributes":{"hana_prd_site":"PRAGUE","rack":null}}`,
+		"after":  `{"node_id":"vmhana01","attributes":{"hana_prd_site":"PRAGUE","rack":null}}`,
+	}, report.Success.Diff)
+}
+
+func (suite *ClusterNodeAttributeOperatorTestSuite) TestClusterNodeAttributeCommitNotIdle() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetNodeAttribute", ctx, "vmhana01", cluster.UtilizationNodeAttribute, "memory").
+		Return("16384", true, nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(false, nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
+	suite.mockClusterClient.On("SetNodeAttribute", ctx, "vmhana01", cluster.UtilizationNodeAttribute,
+		"memory", "16384").Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"node_id":     "vmhana01",
+		"utilization": map[string]any{"memory": float64(32768)},
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues("cluster is not in S_IDLE state", report.Error.Message)
+}
+
+func (suite *ClusterNodeAttributeOperatorTestSuite) TestClusterNodeAttributeVerifyErrorRollback() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetNodeAttribute", ctx, "vmhana01", cluster.PermanentNodeAttribute, "hana_prd_site").
+		Return("NUREMBERG", true, nil).Once()
+	suite.mockClusterClient.On("GetNodeAttribute", ctx, "vmhana01", cluster.PermanentNodeAttribute, "rack").
+		Return("", false, nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Twice()
+	suite.mockClusterClient.On("SetNodeAttribute", ctx, "vmhana01", cluster.PermanentNodeAttribute,
+		"hana_prd_site", "PRAGUE").Return(nil).Once()
+	suite.mockClusterClient.On("SetNodeAttribute", ctx, "vmhana01", cluster.PermanentNodeAttribute,
+		"rack", "r1").Return(nil).Once()
+	suite.mockClusterClient.On("GetNodeAttribute", ctx, "vmhana01", cluster.PermanentNodeAttribute, "hana_prd_site").
+		Return("PRAGUE", true, nil).Once()
+	suite.mockClusterClient.On("GetNodeAttribute", ctx, "vmhana01", cluster.PermanentNodeAttribute, "rack").
+		Return("", false, nil).Once()
+	suite.mockClusterClient.On("SetNodeAttribute", ctx, "vmhana01", cluster.PermanentNodeAttribute,
+		"hana_prd_site", "NUREMBERG").Return(nil).Once()
+	suite.mockClusterClient.On("DeleteNodeAttribute", ctx, "vmhana01", cluster.PermanentNodeAttribute,
+		"rack").Return(errors.New("error deleting node attribute rack")).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"node_id":    "vmhana01",
+		"attributes": map[string]any{"hana_prd_site": "PRAGUE", "rack": "r1"},
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.ROLLBACK, report.Error.ErrorPhase)
+	suite.EqualValues("error rolling back node attributes: error deleting node attribute rack\n"+
+		"verify node attributes failed, the requested values were not set in commit phase", report.Error.Message)
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/trento-project/workbench/internal/cluster"
+)
+
+const (
+	preflightOverridesArgument = "preflight_overrides"
+	preflightChecksDiffField   = "preflight_checks"
+)
+
+// parsePreflightOverrides parses the optional preflight_overrides argument, a list with the
+// names of the cluster pre-flight checks whose failure doesn't block the operation.
+// Available values: quorum, dc_elected, failed_actions, pending_fencing, stonith_enabled, last_running_instance,
+// quorum_loss, hana_sr_sync
+func parsePreflightOverrides(rawArguments Arguments) ([]cluster.PreflightCheckName, error) {
+	overrides := []cluster.PreflightCheckName{}
+
+	argument, found := rawArguments[preflightOverridesArgument]
+	if !found {
+		return overrides, nil
+	}
+
+	var rawOverrides []any
+	switch value := argument.(type) {
+	case []any:
+		rawOverrides = value
+	case []string:
+		for _, override := range value {
+			rawOverrides = append(rawOverrides, override)
+		}
+	default:
+		return nil, fmt.Errorf(
+			"could not parse %s argument as a list, argument provided: %v",
+			preflightOverridesArgument,
+			argument,
+		)
+	}
+
+	for _, rawOverride := range rawOverrides {
+		override, ok := rawOverride.(string)
+		if !ok || !cluster.IsValidPreflightCheck(override) {
+			return nil, fmt.Errorf("invalid %s value: %v", preflightOverridesArgument, rawOverride)
+		}
+		overrides = append(overrides, cluster.PreflightCheckName(override))
+	}
+
+	return overrides, nil
+}
+
+// runClusterPreflightChecks runs the cluster pre-flight checks and stores the results
+// in the given resources, so they are included in the operation diff.
+// It returns an error if any of the non overridden checks fails, with the results attached
+// so they are included in the error report as well.
+func runClusterPreflightChecks(
+	ctx context.Context,
+	clusterClient cluster.Cluster,
+	options cluster.PreflightOptions,
+	resources map[string]any,
+) error {
+	report, err := clusterClient.RunPreflightChecks(ctx, options)
+	if err != nil {
+		return fmt.Errorf("error running cluster pre-flight checks: %w", err)
+	}
+
+	resources[preflightChecksDiffField] = report.Results
+
+	if err := report.Error(); err != nil {
+		diff := make(map[string]any)
+		addPreflightChecksDiff(diff, resources)
+		return withDiff(err, diff)
+	}
+
+	return nil
+}
+
+// addPreflightChecksDiff adds the cluster pre-flight checks results to the diff if they were run
+func addPreflightChecksDiff(diff map[string]any, resources map[string]any) {
+	results, found := resources[preflightChecksDiffField]
+	if !found {
+		return
+	}
+
+	preflightChecks, err := json.Marshal(results)
+	if err != nil {
+		panic(fmt.Sprintf("error marshalling pre-flight checks diff output: %v", err))
+	}
+	diff[preflightChecksDiffField] = string(preflightChecks)
+}
@@ -0,0 +1,298 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/trento-project/workbench/internal/cluster"
+)
+
+const (
+	ClusterPropertyChangeOperatorName = "clusterpropertychange"
+	propertiesArgument                = "properties"
+	resourceDefaultsArgument          = "resource_defaults"
+)
+
+type ClusterPropertyChangeOption Option[ClusterPropertyChange]
+
+type clusterProperty struct {
+	set   cluster.PropertySet
+	name  string
+	value string
+}
+
+// clusterPropertyValues are the property values by set and name. A nil value means that the property is not set.
+type clusterPropertyValues map[cluster.PropertySet]map[string]*string
+
+type clusterPropertiesDiffOutput struct {
+	Properties       map[string]*string `json:"properties,omitempty"`
+	ResourceDefaults map[string]*string `json:"resource_defaults,omitempty"`
+}
+
+// ClusterPropertyChange operator changes cluster options and resource defaults.
+//
+// Arguments:
+//  properties: Map with the cluster options to change, e.g. {"stonith-timeout": "150s"}
+//  resource_defaults: Map with the resource defaults to change, e.g. {"resource-stickiness": 1000}
+//
+// At least one property must be given. Values are validated according to the property type:
+// booleans, integers, scores, durations or a list of accepted values. Only the properties
+// known by the operator can be changed. maintenance-mode is changed with the
+// clustermaintenancechange operator.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   Checks that the cluster is running in the host and gets the current value of the properties.
+//   If all the properties already have the requested values, the operation is skipped.
+//
+// - COMMIT:
+//   Checks that the cluster is idle and sets the properties using `crm_attribute`.
+//
+// - VERIFY:
+//   Checks that all the properties have the requested values.
+//
+// - ROLLBACK:
+//   Checks that the cluster is idle and restores the previous values. Properties that were not set
+//   are deleted, so the default values apply again.
+
+type ClusterPropertyChange struct {
+	baseOperator
+	clusterClient cluster.Cluster
+	properties    []clusterProperty
+}
+
+func WithCustomClusterPropertyClient(clusterClient cluster.Cluster) ClusterPropertyChangeOption {
+	return func(o *ClusterPropertyChange) {
+		o.clusterClient = clusterClient
+	}
+}
+
+func NewClusterPropertyChange(
+	arguments Arguments,
+	operationID string,
+	options Options[ClusterPropertyChange],
+) *Executor {
+	propertyChange := &ClusterPropertyChange{
+		baseOperator: newBaseOperator(
+			ClusterPropertyChangeOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+		clusterClient: cluster.NewDefaultClusterClient(),
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(propertyChange)
+	}
+
+	return &Executor{
+		phaser:      propertyChange,
+		operationID: operationID,
+		logger:      propertyChange.logger,
+	}
+}
+
+func (c *ClusterPropertyChange) plan(ctx context.Context) (bool, error) {
+	properties, err := parseClusterPropertyChangeArguments(c.arguments)
+	if err != nil {
+		return false, err
+	}
+	c.properties = properties
+
+	if !c.clusterClient.IsHostOnline(ctx) {
+		return false, errors.New("cluster is not running on host")
+	}
+
+	currentValues, err := c.getPropertyValues(ctx)
+	if err != nil {
+		return false, err
+	}
+	c.resources[beforeDiffField] = currentValues
+
+	if c.propertiesApplied(currentValues) {
+		c.logger.Info("cluster properties already set, skipping operation")
+		c.resources[afterDiffField] = currentValues
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (c *ClusterPropertyChange) commit(ctx context.Context) error {
+	if err := ensureClusterIsIdle(ctx, c.clusterClient, 0); err != nil {
+		return err
+	}
+
+	for _, property := range c.properties {
+		if err := c.clusterClient.SetProperty(ctx, property.set, property.name, property.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ClusterPropertyChange) verify(ctx context.Context) error {
+	currentValues, err := c.getPropertyValues(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !c.propertiesApplied(currentValues) {
+		return errors.New("verify cluster properties failed, the requested values were not set in commit phase")
+	}
+
+	c.resources[afterDiffField] = currentValues
+	return nil
+}
+
+func (c *ClusterPropertyChange) rollback(ctx context.Context) error {
+	if err := ensureClusterIsIdle(ctx, c.clusterClient, 0); err != nil {
+		return err
+	}
+
+	initialValues, _ := c.resources[beforeDiffField].(clusterPropertyValues)
+
+	var rollbackErr error
+	for _, property := range c.properties {
+		initialValue := initialValues[property.set][property.name]
+
+		var err error
+		if initialValue == nil {
+			err = c.clusterClient.DeleteProperty(ctx, property.set, property.name)
+		} else {
+			err = c.clusterClient.SetProperty(ctx, property.set, property.name, *initialValue)
+		}
+		rollbackErr = errors.Join(rollbackErr, err)
+	}
+
+	if rollbackErr != nil {
+		return fmt.Errorf("error rolling back cluster properties: %w", rollbackErr)
+	}
+
+	return nil
+}
+
+func (c *ClusterPropertyChange) operationDiff(_ context.Context) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		values, ok := c.resources[field].(clusterPropertyValues)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to cluster property values",
+				field, c.resources[field]))
+		}
+
+		output, err := json.Marshal(clusterPropertiesDiffOutput{
+			Properties:       values[cluster.ClusterOptionsSet],
+			ResourceDefaults: values[cluster.ResourceDefaultsSet],
+		})
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	return diff
+}
+
+func (c *ClusterPropertyChange) getPropertyValues(ctx context.Context) (clusterPropertyValues, error) {
+	values := make(clusterPropertyValues)
+	for _, property := range c.properties {
+		value, found, err := c.clusterClient.GetProperty(ctx, property.set, property.name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := values[property.set]; !ok {
+			values[property.set] = make(map[string]*string)
+		}
+
+		values[property.set][property.name] = nil
+		if found {
+			values[property.set][property.name] = &value
+		}
+	}
+
+	return values, nil
+}
+
+func (c *ClusterPropertyChange) propertiesApplied(values clusterPropertyValues) bool {
+	for _, property := range c.properties {
+		value := values[property.set][property.name]
+		if value == nil || !cluster.EqualPropertyValues(property.set, property.name, *value, property.value) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseClusterPropertyChangeArguments(rawArguments Arguments) ([]clusterProperty, error) {
+	properties := []clusterProperty{}
+
+	for argument, set := range map[string]cluster.PropertySet{
+		propertiesArgument:       cluster.ClusterOptionsSet,
+		resourceDefaultsArgument: cluster.ResourceDefaultsSet,
+	} {
+		rawProperties, found := rawArguments[argument]
+		if !found {
+			continue
+		}
+
+		propertiesMap, ok := rawProperties.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf(
+				"could not parse %s argument as a map, argument provided: %v", argument, rawProperties,
+			)
+		}
+
+		for name, rawValue := range propertiesMap {
+			value, err := parsePropertyValue(rawValue)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse %s property value: %w", name, err)
+			}
+
+			if err := cluster.ValidateProperty(set, name, value); err != nil {
+				return nil, err
+			}
+
+			properties = append(properties, clusterProperty{set: set, name: name, value: value})
+		}
+	}
+
+	if len(properties) == 0 {
+		return nil, fmt.Errorf(
+			"arguments %s or %s not provided, could not use the operator", propertiesArgument, resourceDefaultsArgument,
+		)
+	}
+
+	// keep a stable order to apply the changes
+	slices.SortFunc(properties, func(a, b clusterProperty) int {
+		return cmp.Or(cmp.Compare(a.set, b.set), cmp.Compare(a.name, b.name))
+	})
+
+	return properties, nil
+}
+
+func parsePropertyValue(rawValue any) (string, error) {
+	switch value := rawValue.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case float64:
+		if value != float64(int64(value)) {
+			return "", fmt.Errorf("only integer numbers are supported, value provided: %v", value)
+		}
+		return strconv.FormatInt(int64(value), 10), nil
+	default:
+		return "", fmt.Errorf("unsupported value type, value provided: %v", value)
+	}
+}
@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/cluster/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+type ClusterPropertyChangeOperatorTestSuite struct {
+	suite.Suite
+	mockClusterClient *mocks.MockCluster
+}
+
+func TestClusterPropertyChangeOperator(t *testing.T) {
+	suite.Run(t, new(ClusterPropertyChangeOperatorTestSuite))
+}
+
+func (suite *ClusterPropertyChangeOperatorTestSuite) SetupTest() {
+	suite.mockClusterClient = mocks.NewMockCluster(suite.T())
+}
+
+func (suite *ClusterPropertyChangeOperatorTestSuite) buildOperator(arguments operator.Arguments) *operator.Executor {
+	return operator.NewClusterPropertyChange(
+		arguments,
+		"test-op",
+		operator.Options[operator.ClusterPropertyChange]{
+			OperatorOptions: []operator.Option[operator.ClusterPropertyChange]{
+				operator.Option[operator.ClusterPropertyChange](operator.WithCustomClusterPropertyClient(suite.mockClusterClient)),
+			},
+		},
+	)
+}
+
+func (suite *ClusterPropertyChangeOperatorTestSuite) TestClusterPropertyChangeInvalidArguments() {
+	ctx := context.Background()
+
+	cases := []struct {
+		arguments operator.Arguments
+		err       string
+	}{
+		{
+			arguments: operator.Arguments{},
+			err:       "arguments properties or resource_defaults not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{"properties": "stonith-enabled=true"},
+			err:       "could not parse properties argument as a map, argument provided: stonith-enabled=true",
+		},
+		{
+			arguments: operator.Arguments{"properties": map[string]any{"stonith-timeout": 1.5}},
+			err: "could not parse stonith-timeout property value: " +
+				"only integer numbers are supported, value provided: 1.5",
+		},
+		{
+			arguments: operator.Arguments{"properties": map[string]any{"stonith-timeout": "soon"}},
+			err:       "invalid value soon for property stonith-timeout of type duration",
+		},
+		{
+			arguments: operator.Arguments{"properties": map[string]any{"maintenance-mode": true}},
+			err:       "property maintenance-mode is not supported in crm_config",
+		},
+	}
+
+	for _, tc := range cases {
+		report := suite.buildOperator(tc.arguments).Run(ctx)
+
+		suite.Nil(report.Success)
+		suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+		suite.EqualValues(tc.err, report.Error.Message)
+	}
+}
+
+func (suite *ClusterPropertyChangeOperatorTestSuite) TestClusterPropertyChangeAlreadyApplied() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetProperty", ctx, cluster.ClusterOptionsSet, "stonith-enabled").
+		Return("true", true, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"properties": map[string]any{"stonith-enabled": true},
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"properties":{"stonith-enabled":"true"}}`,
+		"after":  `{"properties":{"stonith-enabled":"true"}}`,
+	}, report.Success.Diff)
+}
+
+func (suite *ClusterPropertyChangeOperatorTestSuite) TestClusterPropertyChangeAlreadyAppliedEquivalentValues() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetProperty", ctx, cluster.ClusterOptionsSet, "stonith-enabled").
+		Return("yes", true, nil).Once()
+	suite.mockClusterClient.On("GetProperty", ctx, cluster.ClusterOptionsSet, "stonith-timeout").
+		Return("60", true, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"properties": map[string]any{"stonith-enabled": true, "stonith-timeout": "60s"},
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+}
+
+func (suite *ClusterPropertyChangeOperatorTestSuite) TestClusterPropertyChangeVerifyEquivalentValues() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetProperty", ctx, cluster.ClusterOptionsSet, "stonith-timeout").
+		Return("30s", true, nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
+	suite.mockClusterClient.On("SetProperty", ctx, cluster.ClusterOptionsSet, "stonith-timeout", "1min").
+		Return(nil).Once()
+	suite.mockClusterClient.On("GetProperty", ctx, cluster.ClusterOptionsSet, "stonith-timeout").
+		Return("60s", true, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"properties": map[string]any{"stonith-timeout": "1min"},
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"properties":{"stonith-timeout":"30s"}}`,
+		"after":  `{"properties":{"stonith-timeout":"60s"}}`,
+	}, report.Success.Diff)
+}
+
+func (suite *ClusterPropertyChangeOperatorTestSuite) TestClusterPropertyChangeNotIdle() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetProperty", ctx, cluster.ClusterOptionsSet, "stonith-timeout").
+		Return("144", true, nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(false, nil).Twice()
+
+	report := suite.buildOperator(operator.Arguments{
+		"properties": map[string]any{"stonith-timeout": "150s"},
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.ROLLBACK, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"cluster is not in S_IDLE state\ncluster is not in S_IDLE state",
+		report.Error.Message,
+	)
+}
+
+func (suite *ClusterPropertyChangeOperatorTestSuite) TestClusterPropertyChangeSuccess() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetProperty", ctx, cluster.ClusterOptionsSet, "stonith-timeout").
+		Return("144", true, nil).Once()
+	suite.mockClusterClient.On("GetProperty", ctx, cluster.ResourceDefaultsSet, "resource-stickiness").
+		Return("", false, nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
+	suite.mockClusterClient.On("SetProperty", ctx, cluster.ClusterOptionsSet, "stonith-timeout", "150s").
+		Return(nil).Once()
+	suite.mockClusterClient.On("SetProperty", ctx, cluster.ResourceDefaultsSet, "resource-stickiness", "1000").
+		Return(nil).Once()
+	suite.mockClusterClient.On("GetProperty", ctx, cluster.ClusterOptionsSet, "stonith-timeout").
+		Return("150s", true, nil).Once()
+	suite.mockClusterClient.On("GetProperty", ctx, cluster.ResourceDefaultsSet, "resource-stickiness").
+		Return("1000", true, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"properties":        map[string]any{"stonith-timeout": "150s"},
+		"resource_defaults": map[string]any{"resource-stickiness": float64(1000)},
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"properties":{"stonith-timeout":"144"},"resource_defaults":{"resource-stickiness":null}}`,
+		"after":  `{"properties":{"stonith-timeout":"150s"},"resource_defaults":{"resource-stickiness":"1000"}}`,
+	}, report.Success.Diff)
+}
+
+func (suite *ClusterPropertyChangeOperatorTestSuite) TestClusterPropertyChangeCommitErrorRollback() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetProperty", ctx, cluster.ClusterOptionsSet, "stonith-timeout").
+		Return("144", true, nil).Once()
+	suite.mockClusterClient.On("GetProperty", ctx, cluster.ResourceDefaultsSet, "resource-stickiness").
+		Return("", false, nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Twice()
+	suite.mockClusterClient.On("SetProperty", ctx, cluster.ClusterOptionsSet, "stonith-timeout", "150s").
+		Return(nil).Once()
+	suite.mockClusterClient.On("SetProperty", ctx, cluster.ResourceDefaultsSet, "resource-stickiness", "1000").
+		Return(errors.New("error setting property")).Once()
+	suite.mockClusterClient.On("SetProperty", ctx, cluster.ClusterOptionsSet, "stonith-timeout", "144").
+		Return(nil).Once()
+	suite.mockClusterClient.On("DeleteProperty", ctx, cluster.ResourceDefaultsSet, "resource-stickiness").
+		Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"properties":        map[string]any{"stonith-timeout": "150s"},
+		"resource_defaults": map[string]any{"resource-stickiness": "1000"},
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues("error setting property", report.Error.Message)
+}
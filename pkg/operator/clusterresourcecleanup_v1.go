@@ -0,0 +1,325 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support"
+)
+
+const (
+	ClusterResourceCleanupOperatorName = "clusterresourcecleanup"
+)
+
+// clone instance suffix of the resource IDs, e.g. rsc_SAPHana_PRD_HDB00:0
+var cloneInstanceSuffixPatternCompiled = regexp.MustCompile(`:\d+$`)
+
+type ClusterResourceCleanupOption Option[ClusterResourceCleanup]
+
+type clusterResourceCleanupArguments struct {
+	resourceID string
+	nodeID     string
+}
+
+type clusterResourceFailure struct {
+	Resource      string   `json:"resource"`
+	Node          string   `json:"node"`
+	FailCount     int      `json:"fail_count"`
+	FailedActions []string `json:"failed_actions"`
+}
+
+type clusterResourceCleanupDiffOutput struct {
+	ResourceID string                   `json:"resource_id,omitempty"`
+	NodeID     string                   `json:"node_id,omitempty"`
+	Failures   []clusterResourceFailure `json:"failures"`
+}
+
+// ClusterResourceCleanup operator cleans up the failed actions and fail counts of the cluster resources.
+//
+// Arguments:
+//  resource_id: ID of the resource to clean up. Resources contained in the given clone or group are included
+//  node_id: Name of the node where the resources are cleaned up
+//
+// If no arguments are provided, all the resources are cleaned up in all the nodes.
+// The operation diff includes the list of failures per resource and node before and after the cleanup,
+// with the fail count and the failed actions.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   Checks that the cluster is running in the host and gets the resource failures.
+//   If there are no failures, the operation is skipped.
+//
+// - COMMIT:
+//   Checks that the cluster is idle and cleans up the resources using `crm_resource --cleanup`.
+//
+// - VERIFY:
+//   Waits until the cluster is idle again, which means that the resources reprobe is completed,
+//   and checks that the failures were cleared, using exponential backoff retries.
+//
+// - ROLLBACK:
+//   This phase is a no-op, as the cleared failures cannot be restored.
+
+type ClusterResourceCleanup struct {
+	baseOperator
+	clusterClient   cluster.Cluster
+	retryOptions    support.BackoffOptions
+	parsedArguments *clusterResourceCleanupArguments
+}
+
+func WithCustomClusterResourceCleanupClient(clusterClient cluster.Cluster) ClusterResourceCleanupOption {
+	return func(o *ClusterResourceCleanup) {
+		o.clusterClient = clusterClient
+	}
+}
+
+func WithCustomRetryResourceCleanup(
+	maxRetries int,
+	initialDelay, maxDelay time.Duration,
+	factor int,
+) ClusterResourceCleanupOption {
+	return func(o *ClusterResourceCleanup) {
+		o.retryOptions = support.BackoffOptions{
+			InitialDelay: initialDelay,
+			MaxDelay:     maxDelay,
+			MaxRetries:   maxRetries,
+			Factor:       factor,
+		}
+	}
+}
+
+func NewClusterResourceCleanup(
+	arguments Arguments,
+	operationID string,
+	options Options[ClusterResourceCleanup],
+) *Executor {
+	cleanup := &ClusterResourceCleanup{
+		baseOperator: newBaseOperator(
+			ClusterResourceCleanupOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+		clusterClient: cluster.NewDefaultClusterClient(),
+		// wait before each execution: 0s, 1.5s, 4.5s, 13.5s, 40.5s
+		retryOptions: support.BackoffOptions{
+			InitialDelay: 500 * time.Millisecond,
+			MaxDelay:     1 * time.Minute,
+			MaxRetries:   5,
+			Factor:       3,
+		},
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(cleanup)
+	}
+
+	return &Executor{
+		phaser:      cleanup,
+		operationID: operationID,
+		logger:      cleanup.logger,
+	}
+}
+
+func (c *ClusterResourceCleanup) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseClusterResourceCleanupArguments(c.arguments)
+	if err != nil {
+		return false, err
+	}
+	c.parsedArguments = opArguments
+
+	if !c.clusterClient.IsHostOnline(ctx) {
+		return false, errors.New("cluster is not running on host")
+	}
+
+	failures, err := c.getFailures(ctx)
+	if err != nil {
+		return false, err
+	}
+	c.resources[beforeDiffField] = failures
+
+	if len(failures) == 0 {
+		c.logger.Info("no resource failures found, skipping cleanup operation")
+		c.resources[afterDiffField] = failures
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (c *ClusterResourceCleanup) commit(ctx context.Context) error {
+	if err := ensureClusterIsIdle(ctx, c.clusterClient, 0); err != nil {
+		return err
+	}
+
+	return c.clusterClient.ResourceCleanup(ctx, c.parsedArguments.resourceID, c.parsedArguments.nodeID)
+}
+
+func (c *ClusterResourceCleanup) verify(ctx context.Context) error {
+	result := <-support.AsyncExponentialBackoff(
+		ctx,
+		c.retryOptions,
+		func() ([]clusterResourceFailure, error) {
+			isIdle, err := c.clusterClient.IsIdle(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("error checking if cluster is idle: %w", err)
+			} else if !isIdle {
+				return nil, errors.New("cluster is not idle, resources reprobe is in progress")
+			}
+
+			failures, err := c.getFailures(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(failures) > 0 {
+				return failures, fmt.Errorf("resource failures not cleared: %s", formatResourceFailures(failures))
+			}
+
+			return failures, nil
+		},
+	)
+	if result.Err != nil {
+		return result.Err
+	}
+
+	c.resources[afterDiffField] = result.Result
+	return nil
+}
+
+func (c *ClusterResourceCleanup) rollback(_ context.Context) error {
+	c.logger.Info("Rollback is not applicable for cluster resource cleanup operation.")
+	return nil
+}
+
+func (c *ClusterResourceCleanup) operationDiff(_ context.Context) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		failures, ok := c.resources[field].([]clusterResourceFailure)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to resource failures",
+				field, c.resources[field]))
+		}
+
+		output, err := json.Marshal(clusterResourceCleanupDiffOutput{
+			ResourceID: c.parsedArguments.resourceID,
+			NodeID:     c.parsedArguments.nodeID,
+			Failures:   failures,
+		})
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	return diff
+}
+
+// getFailures returns the failed actions and fail counts of the resources grouped by resource and node,
+// filtered by the resource and node arguments
+func (c *ClusterResourceCleanup) getFailures(ctx context.Context) ([]clusterResourceFailure, error) {
+	status, err := c.clusterClient.GetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// map[resource/node]failure
+	failuresByResource := make(map[string]*clusterResourceFailure)
+	getFailure := func(resource, node string) *clusterResourceFailure {
+		resource = cloneInstanceSuffixPatternCompiled.ReplaceAllString(resource, "")
+		key := resource + "/" + node
+		if _, found := failuresByResource[key]; !found {
+			failuresByResource[key] = &clusterResourceFailure{
+				Resource:      resource,
+				Node:          node,
+				FailedActions: []string{},
+			}
+		}
+		return failuresByResource[key]
+	}
+
+	for _, failCount := range status.FailCounts {
+		getFailure(failCount.Resource, failCount.Node).FailCount = failCount.FailCount
+	}
+
+	for _, action := range status.Failures {
+		failure := getFailure(action.ResourceID(), action.Node)
+		failure.FailedActions = append(failure.FailedActions,
+			fmt.Sprintf("%s (%s)", action.OperationKey, action.ExitStatus))
+	}
+
+	resourceID, nodeID := c.parsedArguments.resourceID, c.parsedArguments.nodeID
+	failures := []clusterResourceFailure{}
+	for _, failure := range failuresByResource {
+		if nodeID != "" && failure.Node != nodeID {
+			continue
+		}
+
+		if resourceID != "" && !resourceBelongsTo(status, failure.Resource, resourceID) {
+			continue
+		}
+
+		failures = append(failures, *failure)
+	}
+
+	slices.SortFunc(failures, func(a, b clusterResourceFailure) int {
+		return cmp.Or(cmp.Compare(a.Resource, b.Resource), cmp.Compare(a.Node, b.Node))
+	})
+
+	return failures, nil
+}
+
+// resourceBelongsTo returns true if the resource is the given one or it is contained in it
+func resourceBelongsTo(status *cluster.Status, resourceID, parentID string) bool {
+	if resourceID == parentID {
+		return true
+	}
+
+	return slices.ContainsFunc(status.Resources, func(resource cluster.ResourceStatus) bool {
+		return resource.ID == resourceID && resource.Parent == parentID
+	})
+}
+
+func formatResourceFailures(failures []clusterResourceFailure) string {
+	formatted := make([]string, 0, len(failures))
+	for _, failure := range failures {
+		formatted = append(formatted, fmt.Sprintf("%s on %s", failure.Resource, failure.Node))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func parseClusterResourceCleanupArguments(rawArguments Arguments) (*clusterResourceCleanupArguments, error) {
+	parsedArguments := &clusterResourceCleanupArguments{}
+
+	for _, argument := range []struct {
+		name  string
+		value *string
+	}{
+		{name: "resource_id", value: &parsedArguments.resourceID},
+		{name: "node_id", value: &parsedArguments.nodeID},
+	} {
+		rawValue, found := rawArguments[argument.name]
+		if !found {
+			continue
+		}
+
+		parsedValue, ok := rawValue.(string)
+		if !ok {
+			return nil, fmt.Errorf(
+				"could not parse %s argument as string, argument provided: %v", argument.name, rawValue,
+			)
+		}
+		*argument.value = parsedValue
+	}
+
+	return parsedArguments, nil
+}
@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/cluster/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+type ClusterResourceCleanupOperatorTestSuite struct {
+	suite.Suite
+	mockClusterClient *mocks.MockCluster
+}
+
+func TestClusterResourceCleanupOperator(t *testing.T) {
+	suite.Run(t, new(ClusterResourceCleanupOperatorTestSuite))
+}
+
+func (suite *ClusterResourceCleanupOperatorTestSuite) SetupTest() {
+	suite.mockClusterClient = mocks.NewMockCluster(suite.T())
+}
+
+func (suite *ClusterResourceCleanupOperatorTestSuite) buildOperator(arguments operator.Arguments) *operator.Executor {
+	return operator.NewClusterResourceCleanup(
+		arguments,
+		"test-op",
+		operator.Options[operator.ClusterResourceCleanup]{
+			OperatorOptions: []operator.Option[operator.ClusterResourceCleanup]{
+				operator.Option[operator.ClusterResourceCleanup](
+					operator.WithCustomClusterResourceCleanupClient(suite.mockClusterClient),
+				),
+				operator.Option[operator.ClusterResourceCleanup](operator.WithCustomRetryResourceCleanup(2, 0, 0, 1)),
+			},
+		},
+	)
+}
+
+func failedResourcesStatus() *cluster.Status {
+	return &cluster.Status{
+		Resources: []cluster.ResourceStatus{
+			{ID: "rsc_ip_PRD_HDB00"},
+			{ID: "rsc_SAPHana_PRD_HDB00", Parent: "msl_SAPHana_PRD_HDB00", Cloned: true, Promotable: true},
+		},
+		Failures: []cluster.FailedAction{
+			{
+				OperationKey: "rsc_SAPHana_PRD_HDB00_monitor_60000",
+				Node:         "vmhana01",
+				ExitStatus:   "not running",
+				Task:         "monitor",
+				Interval:     "60000",
+			},
+			{
+				OperationKey: "rsc_ip_PRD_HDB00_start_0",
+				Node:         "vmhana02",
+				ExitStatus:   "error",
+				Task:         "start",
+				Interval:     "0",
+			},
+		},
+		FailCounts: []cluster.FailCount{
+			{Resource: "rsc_SAPHana_PRD_HDB00", Node: "vmhana01", FailCount: 2, MigrationThreshold: 5000},
+			{Resource: "rsc_ip_PRD_HDB00", Node: "vmhana02", FailCount: 1000000, MigrationThreshold: 5000},
+		},
+	}
+}
+
+func (suite *ClusterResourceCleanupOperatorTestSuite) TestClusterResourceCleanupInvalidArguments() {
+	report := suite.buildOperator(operator.Arguments{"node_id": 1}).Run(context.Background())
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("could not parse node_id argument as string, argument provided: 1", report.Error.Message)
+}
+
+func (suite *ClusterResourceCleanupOperatorTestSuite) TestClusterResourceCleanupClusterNotRunning() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Once()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("cluster is not running on host", report.Error.Message)
+}
+
+func (suite *ClusterResourceCleanupOperatorTestSuite) TestClusterResourceCleanupNoFailures() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(failedResourcesStatus(), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"node_id": "vmhana03"}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"node_id":"vmhana03","failures":[]}`,
+		"after":  `{"node_id":"vmhana03","failures":[]}`,
+	}, report.Success.Diff)
+}
+
+func (suite *ClusterResourceCleanupOperatorTestSuite) TestClusterResourceCleanupNotIdle() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(failedResourcesStatus(), nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(false, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues("cluster is not in S_IDLE state", report.Error.Message)
+}
+
+func (suite *ClusterResourceCleanupOperatorTestSuite) TestClusterResourceCleanupSuccess() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(failedResourcesStatus(), nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
+	suite.mockClusterClient.On("ResourceCleanup", ctx, "", "").Return(nil).Once()
+	// reprobe in progress
+	suite.mockClusterClient.On("IsIdle", ctx).Return(false, nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(&cluster.Status{}, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"failures":[` +
+			`{"resource":"rsc_SAPHana_PRD_HDB00","node":"vmhana01","fail_count":2,` +
+			`"failed_actions":["rsc_SAPHana_PRD_HDB00_monitor_60000 (not running)"]},` +
+			`{"resource":"rsc_ip_PRD_HDB00","node":"vmhana02","fail_count":1000000,` +
+			`"failed_actions":["rsc_ip_PRD_HDB00_start_0 (error)"]}]}`,
+		"after": `{"failures":[]}`,
+	}, report.Success.Diff)
+}
+
+func (suite *ClusterResourceCleanupOperatorTestSuite) TestClusterResourceCleanupCloneResource() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(failedResourcesStatus(), nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Twice()
+	suite.mockClusterClient.On("ResourceCleanup", ctx, "msl_SAPHana_PRD_HDB00", "vmhana01").Return(nil).Once()
+
+	// the failures of other resources are not cleaned up
+	remainingStatus := failedResourcesStatus()
+	remainingStatus.Failures = remainingStatus.Failures[1:]
+	remainingStatus.FailCounts = remainingStatus.FailCounts[1:]
+	suite.mockClusterClient.On("GetStatus", ctx).Return(remainingStatus, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"resource_id": "msl_SAPHana_PRD_HDB00",
+		"node_id":     "vmhana01",
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"resource_id":"msl_SAPHana_PRD_HDB00","node_id":"vmhana01","failures":[` +
+			`{"resource":"rsc_SAPHana_PRD_HDB00","node":"vmhana01","fail_count":2,` +
+			`"failed_actions":["rsc_SAPHana_PRD_HDB00_monitor_60000 (not running)"]}]}`,
+		"after": `{"resource_id":"msl_SAPHana_PRD_HDB00","node_id":"vmhana01","failures":[]}`,
+	}, report.Success.Diff)
+}
+
+func (suite *ClusterResourceCleanupOperatorTestSuite) TestClusterResourceCleanupCommitError() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(failedResourcesStatus(), nil).Once()
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Once()
+	suite.mockClusterClient.On("ResourceCleanup", ctx, "rsc_ip_PRD_HDB00", "").
+		Return(errors.New("failed to clean up resource")).Once()
+
+	report := suite.buildOperator(operator.Arguments{"resource_id": "rsc_ip_PRD_HDB00"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues("failed to clean up resource", report.Error.Message)
+}
+
+func (suite *ClusterResourceCleanupOperatorTestSuite) TestClusterResourceCleanupFailuresNotCleared() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(failedResourcesStatus(), nil).Times(3)
+	suite.mockClusterClient.On("IsIdle", ctx).Return(true, nil).Times(3)
+	suite.mockClusterClient.On("ResourceCleanup", ctx, "", "vmhana02").Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"node_id": "vmhana02"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"operation failed after 2 attempts: resource failures not cleared: rsc_ip_PRD_HDB00 on vmhana02",
+		report.Error.Message,
+	)
+}
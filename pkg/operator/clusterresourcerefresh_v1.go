@@ -10,6 +10,8 @@
 // - resource_id (string): The ID of a specific resource to refresh.
 // - node_id (string): The ID of a specific node where the resource should be refreshed.
 //                     This can only be provided if `resource_id` is also specified.
+// - wait_for_idle (number): Seconds to wait until the cluster is in IDLE state before refreshing.
+//                           If not given, the operation fails if the cluster is not idle.
 //
 // If no arguments are provided, all resources in the cluster are refreshed.
 //
@@ -19,6 +21,7 @@
 //   Checks if the cluster is available and in an IDLE state. If not, the operation fails.
 //
 // - COMMIT:
+//   Waits until the cluster is in IDLE state if wait_for_idle is given.
 //   Refreshes the cluster resources using `crm resource refresh`.
 //
 // - VERIFY:
@@ -39,6 +42,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/trento-project/workbench/internal/cluster"
 )
@@ -48,8 +52,9 @@ const (
 )
 
 type clusterResourceRefreshArguments struct {
-	resourceID string
-	nodeID     string
+	resourceID  string
+	nodeID      string
+	waitForIdle time.Duration
 }
 
 type ClusterResourceRefresh struct {
@@ -112,12 +117,9 @@ func (c *ClusterResourceRefresh) plan(ctx context.Context) (bool, error) {
 }
 
 func (c *ClusterResourceRefresh) commit(ctx context.Context) error {
-	isIdle, err := c.clusterClient.IsIdle(ctx)
+	err := ensureClusterIsIdle(ctx, c.clusterClient, c.parsedArguments.waitForIdle)
 	if err != nil {
-		return fmt.Errorf("error checking if cluster is idle: %w", err)
-	}
-	if !isIdle {
-		return fmt.Errorf("cluster is not in S_IDLE state")
+		return err
 	}
 
 	return c.clusterClient.ResourceRefresh(ctx, c.parsedArguments.resourceID, c.parsedArguments.nodeID)
@@ -209,5 +211,10 @@ func parseClusterResourceRefreshArguments(rawArguments Arguments) (*clusterResou
 		}
 	}
 
-	return &clusterResourceRefreshArguments{resourceID: resourceID, nodeID: nodeID}, nil
+	waitForIdle, err := parseWaitForIdle(rawArguments)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clusterResourceRefreshArguments{resourceID: resourceID, nodeID: nodeID, waitForIdle: waitForIdle}, nil
 }
@@ -7,8 +7,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
 	clusterMocks "github.com/trento-project/workbench/internal/cluster/mocks"
 	"github.com/trento-project/workbench/pkg/operator"
 )
@@ -275,3 +277,102 @@ func (suite *ClusterResourceRefreshOperatorTestSuite) TestClusterResourceRefresh
 	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
 	suite.Equal(commitError.Error(), report.Error.Message)
 }
+
+func (suite *ClusterResourceRefreshOperatorTestSuite) TestClusterResourceRefreshSuccessWaitForIdle() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.
+		On("IsHostOnline", ctx).Return(true).Once().
+		On("WaitForIdle", ctx, cluster.WaitForIdleOptions{Timeout: 90 * time.Second}).Return(nil).Once().
+		On("ResourceRefresh", ctx, "", "").Return(nil).Once()
+
+	clusterResourceRefreshOperator := operator.NewClusterResourceRefresh(
+		operator.Arguments{
+			"wait_for_idle": float64(90),
+		},
+		"test-op",
+		operator.Options[operator.ClusterResourceRefresh]{
+			OperatorOptions: []operator.Option[operator.ClusterResourceRefresh]{
+				operator.Option[operator.ClusterResourceRefresh](operator.WithCustomClusterResourceRefreshClient(suite.mockClusterClient)),
+			},
+		},
+	)
+
+	report := clusterResourceRefreshOperator.Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+}
+
+func (suite *ClusterResourceRefreshOperatorTestSuite) TestClusterResourceRefreshCommitWaitForIdleTimeout() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.
+		On("IsHostOnline", ctx).Return(true).Once().
+		On("WaitForIdle", ctx, cluster.WaitForIdleOptions{Timeout: 1500 * time.Millisecond}).
+		Return(&cluster.IdleTimeoutError{
+			Timeout: 1500 * time.Millisecond,
+			Transition: &cluster.Transition{
+				DC:              "vmhana01",
+				ControllerState: "S_TRANSITION_ENGINE",
+				PendingActions: []cluster.PendingAction{
+					{Resource: "rsc_ip_PRD_HDB00", Node: "vmhana02", Action: "Starting"},
+				},
+			},
+		}).Once()
+
+	clusterResourceRefreshOperator := operator.NewClusterResourceRefresh(
+		operator.Arguments{
+			"wait_for_idle": 1.5,
+		},
+		"test-op",
+		operator.Options[operator.ClusterResourceRefresh]{
+			OperatorOptions: []operator.Option[operator.ClusterResourceRefresh]{
+				operator.Option[operator.ClusterResourceRefresh](operator.WithCustomClusterResourceRefreshClient(suite.mockClusterClient)),
+			},
+		},
+	)
+
+	report := clusterResourceRefreshOperator.Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.Equal("cluster is not in S_IDLE state after 1.5s, controller state: S_TRANSITION_ENGINE, "+
+		"pending actions: rsc_ip_PRD_HDB00 Starting on vmhana02", report.Error.Message)
+}
+
+func (suite *ClusterResourceRefreshOperatorTestSuite) TestClusterResourceRefreshPlanInvalidWaitForIdle() {
+	cases := []struct {
+		waitForIdle any
+		message     string
+	}{
+		{
+			waitForIdle: "30s",
+			message:     "could not parse wait_for_idle argument as a number, argument provided: 30s",
+		},
+		{
+			waitForIdle: float64(-1),
+			message:     "invalid wait_for_idle value: -1, it must be a positive number",
+		},
+	}
+
+	for _, tt := range cases {
+		clusterResourceRefreshOperator := operator.NewClusterResourceRefresh(
+			operator.Arguments{
+				"wait_for_idle": tt.waitForIdle,
+			},
+			"test-op",
+			operator.Options[operator.ClusterResourceRefresh]{
+				OperatorOptions: []operator.Option[operator.ClusterResourceRefresh]{
+					operator.Option[operator.ClusterResourceRefresh](operator.WithCustomClusterResourceRefreshClient(suite.mockClusterClient)),
+				},
+			},
+		)
+
+		report := clusterResourceRefreshOperator.Run(context.Background())
+
+		suite.Nil(report.Success)
+		suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+		suite.Equal(tt.message, report.Error.Message)
+	}
+}
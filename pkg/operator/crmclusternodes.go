@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support"
+)
+
+const (
+	clusterNodeOnline  = "online"
+	clusterNodeOffline = "offline"
+)
+
+type crmClusterNodesStopDiffOutput struct {
+	Stopped bool              `json:"stopped"`
+	Nodes   map[string]string `json:"nodes"`
+}
+
+type crmClusterNodesStartDiffOutput struct {
+	Started bool              `json:"started"`
+	Nodes   map[string]string `json:"nodes"`
+}
+
+// clusterNodesWithState returns the sorted names of the nodes where the cluster stack is in the given state
+func clusterNodesWithState(states map[string]bool, active bool) []string {
+	nodes := []string{}
+	for node, state := range states {
+		if state == active {
+			nodes = append(nodes, node)
+		}
+	}
+	slices.Sort(nodes)
+
+	return nodes
+}
+
+// promotedClusterNodes returns the sorted names of the nodes running a promoted instance
+// of a promotable resource, the primary nodes in a SAP HANA system replication scenario.
+func promotedClusterNodes(status *cluster.Status) []string {
+	nodes := []string{}
+	for _, resource := range status.Resources {
+		if !resource.Promotable || !resource.IsPromoted() {
+			continue
+		}
+		for _, node := range resource.Nodes {
+			if !slices.Contains(nodes, node) {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	slices.Sort(nodes)
+
+	return nodes
+}
+
+// waitForClusterStackStates waits until the cluster stack reaches the expected state
+// in the given nodes, using exponential backoff retries.
+// It returns the last cluster stack states of all the nodes.
+func waitForClusterStackStates(
+	ctx context.Context,
+	clusterClient cluster.Cluster,
+	retryOptions support.BackoffOptions,
+	nodes []string,
+	active bool,
+) (map[string]bool, error) {
+	var states map[string]bool
+
+	result := <-support.AsyncExponentialBackoff(
+		ctx,
+		retryOptions,
+		func() (bool, error) {
+			var err error
+			states, err = clusterClient.GetClusterStackStates(ctx)
+			if err != nil {
+				return false, err
+			}
+
+			for _, node := range nodes {
+				if states[node] != active {
+					return false, fmt.Errorf(
+						"CRM cluster in node %s is %s, expected %s state",
+						node, clusterNodeState(states[node]), clusterNodeState(active),
+					)
+				}
+			}
+			return true, nil
+		},
+	)
+
+	return states, result.Err
+}
+
+func clusterNodeState(active bool) string {
+	if active {
+		return clusterNodeOnline
+	}
+	return clusterNodeOffline
+}
+
+func clusterNodesDiff(states map[string]bool) map[string]string {
+	nodes := make(map[string]string, len(states))
+	for node, state := range states {
+		nodes[node] = clusterNodeState(state)
+	}
+	return nodes
+}
+
+// crmClusterNodesOperationDiff builds the before and after diff of the operators changing
+// the cluster stack state of multiple nodes, including the state of each node
+func crmClusterNodesOperationDiff(resources map[string]any, active bool) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		states, ok := resources[field].(map[string]bool)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%s' to map[string]bool",
+				field, resources[field]))
+		}
+
+		var diffOutput any = crmClusterNodesStopDiffOutput{
+			Stopped: len(clusterNodesWithState(states, true)) == 0,
+			Nodes:   clusterNodesDiff(states),
+		}
+		if active {
+			diffOutput = crmClusterNodesStartDiffOutput{
+				Started: len(clusterNodesWithState(states, false)) == 0,
+				Nodes:   clusterNodesDiff(states),
+			}
+		}
+
+		output, err := json.Marshal(diffOutput)
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	addPreflightChecksDiff(diff, resources)
+
+	return diff
+}
@@ -0,0 +1,303 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// CrmClusterStartV2 operator starts the CRM cluster in all the cluster nodes.
+// It is opt-in, so it has to be requested as crmclusterstart@v2.
+//
+// The operator accepts the following optional arguments:
+//   - primary_node (string): Node started before the rest of nodes, usually the node where the
+//     SAP HANA primary was running when the cluster was stopped.
+//   - leave_maintenance (bool): Refresh the cluster resources and disable the cluster maintenance mode
+//     once all the nodes are online, reverting what CrmClusterStopV2 does by default. Defaults to true.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   Gets the cluster stack state of every cluster node. If the cluster is already online in all
+//   the nodes, and the maintenance mode doesn't need to be disabled, the operation is skipped.
+//   The primary_node must be a cluster node.
+//
+// - COMMIT:
+//   Starts the primary node first, if given, and waits until it is online. Then the rest of the offline
+//   nodes are started. If all the nodes are offline and no primary node is given, the cluster is started
+//   with the --all flag.
+//   If requested, the cluster resources are refreshed and the maintenance mode is disabled.
+//
+// - VERIFY:
+//   Verifies that the CRM cluster is online in all the nodes, using exponential backoff retries,
+//   and that the maintenance mode is disabled if requested.
+//
+// - ROLLBACK:
+//   Enables the maintenance mode again if it was disabled by the operator, and stops the CRM cluster
+//   in the nodes started by the operator.
+//
+// # Details
+//
+// The operation diff includes the cluster stack state of each node before and after the operation.
+
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support"
+)
+
+const (
+	maintenanceDisabledField = "maintenance_disabled"
+)
+
+type CrmClusterStartV2 struct {
+	baseOperator
+	clusterClient   cluster.Cluster
+	retryOptions    support.BackoffOptions
+	parsedArguments *crmClusterStartV2Arguments
+	// startStages are the groups of nodes started sequentially
+	startStages [][]string
+}
+
+type CrmClusterStartV2Option Option[CrmClusterStartV2]
+
+type crmClusterStartV2Arguments struct {
+	primaryNode      string
+	leaveMaintenance bool
+}
+
+func WithCustomClusterClientStartV2(clusterClient cluster.Cluster) CrmClusterStartV2Option {
+	return func(c *CrmClusterStartV2) {
+		c.clusterClient = clusterClient
+	}
+}
+
+func WithCustomRetryStartV2(maxRetries int, initialDelay, maxDelay time.Duration, factor int) CrmClusterStartV2Option {
+	return func(c *CrmClusterStartV2) {
+		c.retryOptions = support.BackoffOptions{
+			InitialDelay: initialDelay,
+			MaxDelay:     maxDelay,
+			MaxRetries:   maxRetries,
+			Factor:       factor,
+		}
+	}
+}
+
+func NewCrmClusterStartV2(arguments Arguments,
+	operationID string,
+	options Options[CrmClusterStartV2]) *Executor {
+	crmClusterStart := &CrmClusterStartV2{
+		baseOperator: newBaseOperator(
+			CrmClusterStartOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+		clusterClient: cluster.NewDefaultClusterClient(),
+		// wait before each execution: 0s, 1.5s, 4.5s, 13.5s, 40.5s
+		retryOptions: support.BackoffOptions{
+			InitialDelay: 500 * time.Millisecond,
+			MaxDelay:     1 * time.Minute,
+			MaxRetries:   5,
+			Factor:       3,
+		},
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(crmClusterStart)
+	}
+
+	return &Executor{
+		phaser:      crmClusterStart,
+		operationID: operationID,
+		logger:      crmClusterStart.logger,
+	}
+}
+
+func (c *CrmClusterStartV2) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseCrmClusterStartV2Arguments(c.arguments)
+	if err != nil {
+		return false, err
+	}
+	c.parsedArguments = opArguments
+
+	states, err := c.clusterClient.GetClusterStackStates(ctx)
+	if err != nil {
+		return false, err
+	}
+	c.resources[beforeDiffField] = states
+
+	primaryNode := c.parsedArguments.primaryNode
+	if _, found := states[primaryNode]; primaryNode != "" && !found {
+		return false, fmt.Errorf("primary_node %s is not a cluster node", primaryNode)
+	}
+
+	offlineNodes := clusterNodesWithState(states, false)
+	if len(offlineNodes) == 0 {
+		maintenance := false
+		if c.parsedArguments.leaveMaintenance {
+			maintenance, err = c.clusterClient.GetMaintenanceState(ctx, "", "")
+			if err != nil {
+				return false, err
+			}
+		}
+
+		if !maintenance {
+			c.logger.Info("CRM cluster is already online in all the nodes, skipping start operation")
+			c.resources[afterDiffField] = states
+			return true, nil
+		}
+
+		c.logger.Info("CRM cluster is already online in all the nodes, only leaving maintenance mode")
+		return false, nil
+	}
+
+	switch {
+	case slices.Contains(offlineNodes, primaryNode) && len(offlineNodes) > 1:
+		otherNodes := slices.DeleteFunc(slices.Clone(offlineNodes), func(node string) bool {
+			return node == primaryNode
+		})
+		c.startStages = [][]string{{primaryNode}, otherNodes}
+	case primaryNode == "" && len(offlineNodes) == len(states):
+		// all the nodes are started at once
+		c.startStages = [][]string{{}}
+	default:
+		c.startStages = [][]string{offlineNodes}
+	}
+
+	return false, nil
+}
+
+func (c *CrmClusterStartV2) commit(ctx context.Context) error {
+	for _, nodes := range c.startStages {
+		c.logger.Info("Starting CRM cluster in nodes", "nodes", nodes)
+		if err := c.clusterClient.StartClusterNodes(ctx, nodes...); err != nil {
+			return fmt.Errorf("error starting CRM cluster: %w", err)
+		}
+
+		_, err := waitForClusterStackStates(ctx, c.clusterClient, c.retryOptions, nodes, true)
+		if err != nil {
+			return fmt.Errorf("error waiting for CRM cluster to start: %w", err)
+		}
+	}
+
+	if !c.parsedArguments.leaveMaintenance {
+		return nil
+	}
+
+	if err := ensureClusterIsIdle(ctx, c.clusterClient, crmClusterIdleTimeout); err != nil {
+		return fmt.Errorf("cluster is not in IDLE state, cannot unset maintenance mode: %w", err)
+	}
+
+	maintenance, err := c.clusterClient.GetMaintenanceState(ctx, "", "")
+	if err != nil {
+		return err
+	}
+
+	if !maintenance {
+		c.logger.Info("Cluster maintenance mode already disabled")
+		return nil
+	}
+
+	if err := c.clusterClient.ResourceRefresh(ctx, "", ""); err != nil {
+		return fmt.Errorf("error refreshing cluster resources: %w", err)
+	}
+
+	err = c.clusterClient.SetMaintenanceState(ctx, "", "", false)
+	if err != nil {
+		return fmt.Errorf("error unsetting cluster maintenance mode: %w", err)
+	}
+	c.resources[maintenanceDisabledField] = true
+
+	return nil
+}
+
+func (c *CrmClusterStartV2) rollback(ctx context.Context) error {
+	if disabled, _ := c.resources[maintenanceDisabledField].(bool); disabled {
+		if err := ensureClusterIsIdle(ctx, c.clusterClient, crmClusterIdleTimeout); err != nil {
+			return fmt.Errorf("cluster is not in IDLE state, cannot set maintenance mode: %w", err)
+		}
+
+		err := c.clusterClient.SetMaintenanceState(ctx, "", "", true)
+		if err != nil {
+			return fmt.Errorf("error setting cluster maintenance mode: %w", err)
+		}
+	}
+
+	beforeStates, _ := c.resources[beforeDiffField].(map[string]bool)
+	offlineNodes := clusterNodesWithState(beforeStates, false)
+
+	// the nodes are stopped in the reverse order, so the primary node is the last one
+	for index := len(c.startStages) - 1; index >= 0; index-- {
+		nodes := c.startStages[index]
+		if len(nodes) == 0 {
+			nodes = offlineNodes
+		}
+
+		c.logger.Info("Stopping CRM cluster in nodes", "nodes", nodes)
+		if err := c.clusterClient.StopClusterNodes(ctx, nodes...); err != nil {
+			return fmt.Errorf("error rolling back CRM cluster start: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *CrmClusterStartV2) verify(ctx context.Context) error {
+	beforeStates, _ := c.resources[beforeDiffField].(map[string]bool)
+	nodes := make([]string, 0, len(beforeStates))
+	for node := range beforeStates {
+		nodes = append(nodes, node)
+	}
+
+	states, err := waitForClusterStackStates(ctx, c.clusterClient, c.retryOptions, nodes, true)
+	if err != nil {
+		return err
+	}
+
+	if c.parsedArguments.leaveMaintenance {
+		maintenance, err := c.clusterClient.GetMaintenanceState(ctx, "", "")
+		if err != nil {
+			return err
+		}
+		if maintenance {
+			return errors.New("cluster maintenance mode is still enabled")
+		}
+	}
+
+	c.resources[afterDiffField] = states
+	return nil
+}
+
+func (c *CrmClusterStartV2) operationDiff(_ context.Context) map[string]any {
+	return crmClusterNodesOperationDiff(c.resources, true)
+}
+
+func parseCrmClusterStartV2Arguments(rawArguments Arguments) (*crmClusterStartV2Arguments, error) {
+	parsedArguments := &crmClusterStartV2Arguments{
+		leaveMaintenance: true,
+	}
+
+	if primaryNodeArgument, found := rawArguments["primary_node"]; found {
+		primaryNode, ok := primaryNodeArgument.(string)
+		if !ok || primaryNode == "" {
+			return nil, fmt.Errorf(
+				"could not parse primary_node argument as string, argument provided: %v",
+				primaryNodeArgument,
+			)
+		}
+		parsedArguments.primaryNode = primaryNode
+	}
+
+	if leaveMaintenanceArgument, found := rawArguments["leave_maintenance"]; found {
+		leaveMaintenance, ok := leaveMaintenanceArgument.(bool)
+		if !ok {
+			return nil, fmt.Errorf(
+				"could not parse leave_maintenance argument as bool, argument provided: %v",
+				leaveMaintenanceArgument,
+			)
+		}
+		parsedArguments.leaveMaintenance = leaveMaintenance
+	}
+
+	return parsedArguments, nil
+}
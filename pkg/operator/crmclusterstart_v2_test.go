@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	clusterMocks "github.com/trento-project/workbench/internal/cluster/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+type CrmClusterStartV2OperatorTestSuite struct {
+	suite.Suite
+	mockClusterClient *clusterMocks.MockCluster
+}
+
+func TestCrmClusterStartV2Operator(t *testing.T) {
+	suite.Run(t, new(CrmClusterStartV2OperatorTestSuite))
+}
+
+func (suite *CrmClusterStartV2OperatorTestSuite) SetupTest() {
+	suite.mockClusterClient = clusterMocks.NewMockCluster(suite.T())
+}
+
+func (suite *CrmClusterStartV2OperatorTestSuite) buildOperator(arguments operator.Arguments) *operator.Executor {
+	return operator.NewCrmClusterStartV2(
+		arguments,
+		"test-op",
+		operator.Options[operator.CrmClusterStartV2]{
+			OperatorOptions: []operator.Option[operator.CrmClusterStartV2]{
+				operator.Option[operator.CrmClusterStartV2](operator.WithCustomClusterClientStartV2(suite.mockClusterClient)),
+				operator.Option[operator.CrmClusterStartV2](operator.WithCustomRetryStartV2(2, 10*time.Millisecond, 100*time.Millisecond, 1)),
+			},
+		},
+	)
+}
+
+func (suite *CrmClusterStartV2OperatorTestSuite) TestCrmClusterStartV2AlreadyOnline() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": true, "vmhana02": true}, nil).Once()
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(false, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"started":true,"nodes":{"vmhana01":"online","vmhana02":"online"}}`,
+		"after":  `{"started":true,"nodes":{"vmhana01":"online","vmhana02":"online"}}`,
+	}, report.Success.Diff)
+}
+
+func (suite *CrmClusterStartV2OperatorTestSuite) TestCrmClusterStartV2AlreadyOnlineInMaintenance() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": true, "vmhana02": true}, nil).Twice()
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(true, nil).Twice()
+	suite.mockClusterClient.On("WaitForIdle", ctx, cluster.WaitForIdleOptions{Timeout: time.Minute}).Return(nil).Once()
+	suite.mockClusterClient.On("ResourceRefresh", ctx, "", "").Return(nil).Once()
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", "", false).Return(nil).Once()
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(false, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"started":true,"nodes":{"vmhana01":"online","vmhana02":"online"}}`,
+		"after":  `{"started":true,"nodes":{"vmhana01":"online","vmhana02":"online"}}`,
+	}, report.Success.Diff)
+}
+
+func (suite *CrmClusterStartV2OperatorTestSuite) TestCrmClusterStartV2AlreadyOnlineKeepingMaintenance() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": true, "vmhana02": true}, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"leave_maintenance": false}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+}
+
+func (suite *CrmClusterStartV2OperatorTestSuite) TestCrmClusterStartV2InvalidArguments() {
+	ctx := context.Background()
+
+	report := suite.buildOperator(operator.Arguments{"primary_node": 1}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("could not parse primary_node argument as string, argument provided: 1", report.Error.Message)
+}
+
+func (suite *CrmClusterStartV2OperatorTestSuite) TestCrmClusterStartV2UnknownPrimaryNode() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": false, "vmhana02": false}, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"primary_node": "vmhana03"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("primary_node vmhana03 is not a cluster node", report.Error.Message)
+}
+
+func (suite *CrmClusterStartV2OperatorTestSuite) TestCrmClusterStartV2AllNodes() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": false, "vmhana02": false}, nil).Once()
+	startAll := suite.mockClusterClient.On("StartClusterNodes", ctx).Return(nil).Once()
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": true, "vmhana02": true}, nil).NotBefore(startAll)
+	suite.mockClusterClient.On("WaitForIdle", ctx, cluster.WaitForIdleOptions{Timeout: time.Minute}).Return(nil).Once()
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(false, nil).Twice()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"started":false,"nodes":{"vmhana01":"offline","vmhana02":"offline"}}`,
+		"after":  `{"started":true,"nodes":{"vmhana01":"online","vmhana02":"online"}}`,
+	}, report.Success.Diff)
+}
+
+func (suite *CrmClusterStartV2OperatorTestSuite) TestCrmClusterStartV2PrimaryFirstLeavingMaintenance() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": false, "vmhana02": false}, nil).Once()
+	startPrimary := suite.mockClusterClient.On("StartClusterNodes", ctx, "vmhana02").Return(nil).Once()
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": false, "vmhana02": true}, nil).Once().NotBefore(startPrimary)
+	startSecondary := suite.mockClusterClient.On("StartClusterNodes", ctx, "vmhana01").Return(nil).Once().
+		NotBefore(startPrimary)
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": true, "vmhana02": true}, nil).Twice().NotBefore(startSecondary)
+	suite.mockClusterClient.On("WaitForIdle", ctx, cluster.WaitForIdleOptions{Timeout: time.Minute}).Return(nil).Once()
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(true, nil).Once()
+	suite.mockClusterClient.On("ResourceRefresh", ctx, "", "").Return(nil).Once()
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", "", false).Return(nil).Once()
+	suite.mockClusterClient.On("GetMaintenanceState", ctx, "", "").Return(false, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"primary_node":      "vmhana02",
+		"leave_maintenance": true,
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(`{"started":true,"nodes":{"vmhana01":"online","vmhana02":"online"}}`, report.Success.Diff["after"])
+}
+
+func (suite *CrmClusterStartV2OperatorTestSuite) TestCrmClusterStartV2CommitErrorRollback() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": true, "vmhana02": false}, nil).Once()
+	startNode := suite.mockClusterClient.On("StartClusterNodes", ctx, "vmhana02").Return(nil).Once()
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": true, "vmhana02": false}, nil).NotBefore(startNode)
+	suite.mockClusterClient.On("StopClusterNodes", ctx, "vmhana02").Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"error waiting for CRM cluster to start: operation failed after 2 attempts: "+
+			"CRM cluster in node vmhana02 is offline, expected online state",
+		report.Error.Message,
+	)
+}
@@ -3,12 +3,17 @@
 
 // CrmClusterStop operator stops a CRM cluster.
 //
+// The operator accepts the following optional arguments:
+// - preflight_overrides ([]string): List of cluster pre-flight checks whose failure doesn't block the operation.
+//
 // # Execution Phases
 //
 // - PLAN:
 //   Checks if the CRM cluster is already offline. If it is, the operation is skipped.
-//   If the cluster is online, it checks if the cluster is idle before proceeding.
-//   If the cluster is not idle, it returns an error.
+//   If the cluster is online, the cluster pre-flight checks are run. The operation fails
+//   if any of the non overridden checks fails, for example when stopping the local node
+//   stops the last running instance of a resource, or when it makes the cluster lose quorum
+//   according to the corosync votes. The latter is overridden with the quorum_loss value.
 //
 // - COMMIT:
 //   Stops the CRM cluster using the crmClient's StopCluster method.
@@ -99,6 +104,11 @@ func NewCrmClusterStop(arguments Arguments,
 }
 
 func (c *CrmClusterStop) plan(ctx context.Context) (bool, error) {
+	preflightOverrides, err := parsePreflightOverrides(c.arguments)
+	if err != nil {
+		return false, err
+	}
+
 	// check if the cluster is not started.
 	isOnline := c.clusterClient.IsHostOnline(ctx)
 	c.resources[beforeDiffField] = !isOnline
@@ -109,6 +119,13 @@ func (c *CrmClusterStop) plan(ctx context.Context) (bool, error) {
 		return true, nil
 	}
 
+	err = runClusterPreflightChecks(ctx, c.clusterClient, cluster.PreflightOptions{
+		Overrides: preflightOverrides,
+	}, c.resources)
+	if err != nil {
+		return false, err
+	}
+
 	return false, nil
 }
 
@@ -196,6 +213,8 @@ func (c *CrmClusterStop) operationDiff(_ context.Context) map[string]any {
 	}
 	diff["after"] = string(after)
 
+	addPreflightChecksDiff(diff, c.resources)
+
 	return diff
 }
 
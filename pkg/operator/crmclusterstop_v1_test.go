@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
 	"github.com/trento-project/workbench/internal/cluster/mocks"
 	"github.com/trento-project/workbench/pkg/operator"
 )
@@ -59,6 +60,9 @@ func (suite *CrmClusterStopOperatorTestSuite) TestCrmClusterStopClusterRollbackF
 
 	mockCrmClient := mocks.NewMockCluster(suite.T())
 	mockCrmClient.On("IsHostOnline", ctx).Return(true).Once()
+	mockCrmClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Overrides: []cluster.PreflightCheckName{},
+	}).Return(passedPreflightReport(), nil).Once()
 	mockCrmClient.On("IsIdle", ctx).Return(false, nil)
 	mockCrmClient.On("StartCluster", ctx).Return(errors.New("failed to start cluster"))
 
@@ -87,6 +91,9 @@ func (suite *CrmClusterStopOperatorTestSuite) TestCrmClusterStopClusterRollbackS
 
 	mockCrmClient := mocks.NewMockCluster(suite.T())
 	mockCrmClient.On("IsHostOnline", ctx).Return(true).Once()
+	mockCrmClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Overrides: []cluster.PreflightCheckName{},
+	}).Return(passedPreflightReport(), nil).Once()
 	mockCrmClient.On("IsIdle", ctx).Return(true, nil)
 	mockCrmClient.On("StopCluster", ctx).Return(errors.New("failed to stop cluster"))
 	mockCrmClient.On("StartCluster", ctx).Return(nil).Once()
@@ -115,6 +122,9 @@ func (suite *CrmClusterStopOperatorTestSuite) TestCrmClusterStopClusterStartVeri
 
 	mockCrmClient := mocks.NewMockCluster(suite.T())
 	mockCrmClient.On("IsHostOnline", ctx).Return(true).Once()
+	mockCrmClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Overrides: []cluster.PreflightCheckName{},
+	}).Return(passedPreflightReport(), nil).Once()
 	mockCrmClient.On("IsIdle", ctx).Return(true, nil)
 	mockCrmClient.On("StopCluster", ctx).Return(nil)
 	mockCrmClient.On("IsHostOnline", ctx).Return(true)
@@ -145,6 +155,9 @@ func (suite *CrmClusterStopOperatorTestSuite) TestCrmClusterStopVerifySuccess()
 
 	mockCrmClient := mocks.NewMockCluster(suite.T())
 	mockCrmClient.On("IsHostOnline", ctx).Return(true).Once()
+	mockCrmClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Overrides: []cluster.PreflightCheckName{},
+	}).Return(passedPreflightReport(), nil).Once()
 	mockCrmClient.On("IsIdle", ctx).Return(true, nil).Once()
 	mockCrmClient.On("StopCluster", ctx).Return(nil).Once()
 	mockCrmClient.On("IsHostOnline", ctx).Return(false)
@@ -165,8 +178,98 @@ func (suite *CrmClusterStopOperatorTestSuite) TestCrmClusterStopVerifySuccess()
 
 	suite.NotNil(report.Success)
 	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before":           `{"stopped":false}`,
+		"after":            `{"stopped":true}`,
+		"preflight_checks": passedPreflightChecksDiff,
+	}, report.Success.Diff)
+}
+
+func (suite *CrmClusterStopOperatorTestSuite) TestCrmClusterStopPreflightChecksFailed() {
+	ctx := context.Background()
+
+	mockCrmClient := mocks.NewMockCluster(suite.T())
+	mockCrmClient.On("IsHostOnline", ctx).Return(true).Once()
+	mockCrmClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Overrides: []cluster.PreflightCheckName{cluster.FailedActionsCheck},
+	}).Return(&cluster.PreflightReport{
+		Results: []cluster.PreflightCheckResult{
+			{
+				Name:    cluster.LastRunningInstanceCheck,
+				Passed:  false,
+				Message: "stopping node vmhana01 stops the last running instance of resources: msl_SAPHana_PRD_HDB00",
+			},
+		},
+	}, nil).Once()
+
+	crmClusterStopOperator := operator.NewCrmClusterStop(
+		operator.Arguments{
+			"preflight_overrides": []any{"failed_actions"},
+		},
+		"test-op",
+		operator.Options[operator.CrmClusterStop]{
+			OperatorOptions: []operator.Option[operator.CrmClusterStop]{
+				operator.Option[operator.CrmClusterStop](operator.WithCustomClusterClientStop(mockCrmClient)),
+			},
+		},
+	)
+
+	report := crmClusterStopOperator.Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.Equal(
+		"cluster pre-flight checks failed: last_running_instance: stopping node vmhana01 stops "+
+			"the last running instance of resources: msl_SAPHana_PRD_HDB00",
+		report.Error.Message,
+	)
+	suite.Equal(map[string]any{
+		"preflight_checks": `[{"name":"last_running_instance","passed":false,` +
+			`"message":"stopping node vmhana01 stops the last running instance of resources: msl_SAPHana_PRD_HDB00"}]`,
+	}, report.Error.Diff)
+}
+
+func (suite *CrmClusterStopOperatorTestSuite) TestCrmClusterStopQuorumLossOverridden() {
+	ctx := context.Background()
+
+	mockCrmClient := mocks.NewMockCluster(suite.T())
+	mockCrmClient.On("IsHostOnline", ctx).Return(true).Once()
+	mockCrmClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Overrides: []cluster.PreflightCheckName{cluster.QuorumLossCheck},
+	}).Return(&cluster.PreflightReport{
+		Results: []cluster.PreflightCheckResult{
+			{
+				Name:       cluster.QuorumLossCheck,
+				Passed:     false,
+				Overridden: true,
+				Message:    "stopping node vmhana01 makes the cluster lose quorum, 2 votes required",
+			},
+		},
+	}, nil).Once()
+	mockCrmClient.On("IsIdle", ctx).Return(true, nil).Once()
+	mockCrmClient.On("StopCluster", ctx).Return(nil).Once()
+	mockCrmClient.On("IsHostOnline", ctx).Return(false)
+
+	crmClusterStopOperator := operator.NewCrmClusterStop(
+		operator.Arguments{
+			"preflight_overrides": []any{"quorum_loss"},
+		},
+		"test-op",
+		operator.Options[operator.CrmClusterStop]{
+			OperatorOptions: []operator.Option[operator.CrmClusterStop]{
+				operator.Option[operator.CrmClusterStop](operator.WithCustomClusterClientStop(mockCrmClient)),
+			},
+		},
+	)
+
+	report := crmClusterStopOperator.Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
 	suite.EqualValues(map[string]any{
 		"before": `{"stopped":false}`,
 		"after":  `{"stopped":true}`,
+		"preflight_checks": `[{"name":"quorum_loss","passed":false,"overridden":true,` +
+			`"message":"stopping node vmhana01 makes the cluster lose quorum, 2 votes required"}]`,
 	}, report.Success.Diff)
 }
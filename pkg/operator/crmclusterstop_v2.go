@@ -0,0 +1,303 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// CrmClusterStopV2 operator stops the CRM cluster in all the cluster nodes.
+// It is opt-in, so it has to be requested as crmclusterstop@v2.
+//
+// The operator accepts the following optional arguments:
+// - maintenance (bool): Put the cluster in maintenance mode before stopping it. Defaults to true.
+// - preflight_overrides ([]string): List of cluster pre-flight checks whose failure doesn't block the operation.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   Gets the cluster stack state of every cluster node. If the cluster is already offline in all
+//   the nodes, the operation is skipped.
+//   Otherwise, the stop order is computed: nodes running the promoted instance of a promotable
+//   resource (the SAP HANA primary) are stopped after the rest of nodes.
+//   The quorum, dc_elected and pending_fencing pre-flight checks are run if the local node is online.
+//
+// - COMMIT:
+//   Puts the cluster in maintenance mode, if requested and not already set.
+//   Stops the secondary nodes first, waits until they are offline, and then stops the primary nodes.
+//   If there are no primary nodes and all the nodes are online, the cluster is stopped with the --all flag.
+//
+// - VERIFY:
+//   Verifies that the CRM cluster is offline in all the nodes, using exponential backoff retries.
+//
+// - ROLLBACK:
+//   Starts the CRM cluster again in the nodes that were online, primary nodes first, and
+//   disables the maintenance mode if it was enabled by the operator.
+//
+// # Details
+//
+// The operation diff includes the cluster stack state of each node before and after the operation.
+
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/support"
+)
+
+const (
+	maintenanceEnabledField = "maintenance_enabled"
+)
+
+type CrmClusterStopV2 struct {
+	baseOperator
+	clusterClient      cluster.Cluster
+	retryOptions       support.BackoffOptions
+	parsedArguments    *crmClusterStopV2Arguments
+	initialMaintenance bool
+	// stopStages are the groups of nodes stopped sequentially
+	stopStages [][]string
+}
+
+type CrmClusterStopV2Option Option[CrmClusterStopV2]
+
+type crmClusterStopV2Arguments struct {
+	maintenance        bool
+	preflightOverrides []cluster.PreflightCheckName
+}
+
+func WithCustomClusterClientStopV2(clusterClient cluster.Cluster) CrmClusterStopV2Option {
+	return func(c *CrmClusterStopV2) {
+		c.clusterClient = clusterClient
+	}
+}
+
+func WithCustomRetryStopV2(maxRetries int, initialDelay, maxDelay time.Duration, factor int) CrmClusterStopV2Option {
+	return func(c *CrmClusterStopV2) {
+		c.retryOptions = support.BackoffOptions{
+			InitialDelay: initialDelay,
+			MaxDelay:     maxDelay,
+			MaxRetries:   maxRetries,
+			Factor:       factor,
+		}
+	}
+}
+
+func NewCrmClusterStopV2(arguments Arguments,
+	operationID string,
+	options Options[CrmClusterStopV2]) *Executor {
+	crmClusterStop := &CrmClusterStopV2{
+		baseOperator: newBaseOperator(
+			CrmClusterStopOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+		clusterClient: cluster.NewDefaultClusterClient(),
+		// wait before each execution: 0s, 1.5s, 4.5s, 13.5s, 40.5s
+		retryOptions: support.BackoffOptions{
+			InitialDelay: 500 * time.Millisecond,
+			MaxDelay:     1 * time.Minute,
+			MaxRetries:   5,
+			Factor:       3,
+		},
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(crmClusterStop)
+	}
+
+	return &Executor{
+		phaser:      crmClusterStop,
+		operationID: operationID,
+		logger:      crmClusterStop.logger,
+	}
+}
+
+func (c *CrmClusterStopV2) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseCrmClusterStopV2Arguments(c.arguments)
+	if err != nil {
+		return false, err
+	}
+	c.parsedArguments = opArguments
+
+	states, err := c.clusterClient.GetClusterStackStates(ctx)
+	if err != nil {
+		return false, err
+	}
+	c.resources[beforeDiffField] = states
+
+	onlineNodes := clusterNodesWithState(states, true)
+	if len(onlineNodes) == 0 {
+		c.logger.Info("CRM cluster is not online in any node, skipping stop operation")
+		c.resources[afterDiffField] = states
+		return true, nil
+	}
+
+	if !c.clusterClient.IsHostOnline(ctx) {
+		if c.parsedArguments.maintenance {
+			return false, errors.New("CRM cluster is not online in the local node, cannot set maintenance mode")
+		}
+		c.stopStages = [][]string{onlineNodes}
+		return false, nil
+	}
+
+	err = runClusterPreflightChecks(ctx, c.clusterClient, cluster.PreflightOptions{
+		Checks: []cluster.PreflightCheckName{
+			cluster.QuorumCheck,
+			cluster.DCElectedCheck,
+			cluster.PendingFencingCheck,
+		},
+		Overrides: c.parsedArguments.preflightOverrides,
+	}, c.resources)
+	if err != nil {
+		return false, err
+	}
+
+	status, err := c.clusterClient.GetStatus(ctx)
+	if err != nil {
+		return false, err
+	}
+	c.initialMaintenance = status.MaintenanceMode
+
+	primaryNodes := []string{}
+	secondaryNodes := []string{}
+	promotedNodes := promotedClusterNodes(status)
+	for _, node := range onlineNodes {
+		if slices.Contains(promotedNodes, node) {
+			primaryNodes = append(primaryNodes, node)
+		} else {
+			secondaryNodes = append(secondaryNodes, node)
+		}
+	}
+
+	switch {
+	case len(primaryNodes) == 0 && len(onlineNodes) == len(states):
+		// all the nodes are stopped at once
+		c.stopStages = [][]string{{}}
+	case len(primaryNodes) == 0 || len(secondaryNodes) == 0:
+		c.stopStages = [][]string{onlineNodes}
+	default:
+		c.stopStages = [][]string{secondaryNodes, primaryNodes}
+	}
+
+	return false, nil
+}
+
+func (c *CrmClusterStopV2) commit(ctx context.Context) error {
+	if c.parsedArguments.maintenance && !c.initialMaintenance {
+		if err := ensureClusterIsIdle(ctx, c.clusterClient, crmClusterIdleTimeout); err != nil {
+			return fmt.Errorf("cluster is not in IDLE state, cannot set maintenance mode: %w", err)
+		}
+
+		err := c.clusterClient.SetMaintenanceState(ctx, "", "", true)
+		if err != nil {
+			return fmt.Errorf("error setting cluster maintenance mode: %w", err)
+		}
+		c.resources[maintenanceEnabledField] = true
+	}
+
+	beforeStates, _ := c.resources[beforeDiffField].(map[string]bool)
+	onlineNodes := clusterNodesWithState(beforeStates, true)
+
+	for _, nodes := range c.stopStages {
+		c.logger.Info("Stopping CRM cluster in nodes", "nodes", nodes)
+		if err := c.clusterClient.StopClusterNodes(ctx, nodes...); err != nil {
+			return fmt.Errorf("error stopping CRM cluster: %w", err)
+		}
+
+		// an empty stage stops all the nodes at once
+		if len(nodes) == 0 {
+			nodes = onlineNodes
+		}
+
+		_, err := waitForClusterStackStates(ctx, c.clusterClient, c.retryOptions, nodes, false)
+		if err != nil {
+			return fmt.Errorf("error waiting for CRM cluster to stop: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *CrmClusterStopV2) rollback(ctx context.Context) error {
+	beforeStates, _ := c.resources[beforeDiffField].(map[string]bool)
+	onlineNodes := clusterNodesWithState(beforeStates, true)
+
+	for index := len(c.stopStages) - 1; index >= 0; index-- {
+		nodes := c.stopStages[index]
+		if len(nodes) == 0 {
+			nodes = onlineNodes
+		}
+
+		c.logger.Info("Starting CRM cluster in nodes", "nodes", nodes)
+		if err := c.clusterClient.StartClusterNodes(ctx, nodes...); err != nil {
+			return fmt.Errorf("error rolling back CRM cluster stop: %w", err)
+		}
+	}
+
+	if _, err := waitForClusterStackStates(ctx, c.clusterClient, c.retryOptions, onlineNodes, true); err != nil {
+		return fmt.Errorf("error waiting for CRM cluster to start: %w", err)
+	}
+
+	if enabled, _ := c.resources[maintenanceEnabledField].(bool); !enabled {
+		return nil
+	}
+
+	if err := ensureClusterIsIdle(ctx, c.clusterClient, crmClusterIdleTimeout); err != nil {
+		return fmt.Errorf("cluster is not in IDLE state, cannot unset maintenance mode: %w", err)
+	}
+
+	if err := c.clusterClient.ResourceRefresh(ctx, "", ""); err != nil {
+		return fmt.Errorf("error refreshing cluster resources: %w", err)
+	}
+
+	err := c.clusterClient.SetMaintenanceState(ctx, "", "", false)
+	if err != nil {
+		return fmt.Errorf("error unsetting cluster maintenance mode: %w", err)
+	}
+
+	return nil
+}
+
+func (c *CrmClusterStopV2) verify(ctx context.Context) error {
+	beforeStates, _ := c.resources[beforeDiffField].(map[string]bool)
+	nodes := make([]string, 0, len(beforeStates))
+	for node := range beforeStates {
+		nodes = append(nodes, node)
+	}
+
+	states, err := waitForClusterStackStates(ctx, c.clusterClient, c.retryOptions, nodes, false)
+	if err != nil {
+		return err
+	}
+
+	c.resources[afterDiffField] = states
+	return nil
+}
+
+func (c *CrmClusterStopV2) operationDiff(_ context.Context) map[string]any {
+	return crmClusterNodesOperationDiff(c.resources, false)
+}
+
+func parseCrmClusterStopV2Arguments(rawArguments Arguments) (*crmClusterStopV2Arguments, error) {
+	maintenance := true
+	if maintenanceArgument, found := rawArguments["maintenance"]; found {
+		parsedMaintenance, ok := maintenanceArgument.(bool)
+		if !ok {
+			return nil, fmt.Errorf(
+				"could not parse maintenance argument as bool, argument provided: %v",
+				maintenanceArgument,
+			)
+		}
+		maintenance = parsedMaintenance
+	}
+
+	preflightOverrides, err := parsePreflightOverrides(rawArguments)
+	if err != nil {
+		return nil, err
+	}
+
+	return &crmClusterStopV2Arguments{
+		maintenance:        maintenance,
+		preflightOverrides: preflightOverrides,
+	}, nil
+}
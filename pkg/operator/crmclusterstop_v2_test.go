@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	clusterMocks "github.com/trento-project/workbench/internal/cluster/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+type CrmClusterStopV2OperatorTestSuite struct {
+	suite.Suite
+	mockClusterClient *clusterMocks.MockCluster
+}
+
+func TestCrmClusterStopV2Operator(t *testing.T) {
+	suite.Run(t, new(CrmClusterStopV2OperatorTestSuite))
+}
+
+func (suite *CrmClusterStopV2OperatorTestSuite) SetupTest() {
+	suite.mockClusterClient = clusterMocks.NewMockCluster(suite.T())
+}
+
+func (suite *CrmClusterStopV2OperatorTestSuite) buildOperator(arguments operator.Arguments) *operator.Executor {
+	return operator.NewCrmClusterStopV2(
+		arguments,
+		"test-op",
+		operator.Options[operator.CrmClusterStopV2]{
+			OperatorOptions: []operator.Option[operator.CrmClusterStopV2]{
+				operator.Option[operator.CrmClusterStopV2](operator.WithCustomClusterClientStopV2(suite.mockClusterClient)),
+				operator.Option[operator.CrmClusterStopV2](operator.WithCustomRetryStopV2(2, 10*time.Millisecond, 100*time.Millisecond, 1)),
+			},
+		},
+	)
+}
+
+func promotedHanaStatus(maintenance bool) *cluster.Status {
+	return &cluster.Status{
+		MaintenanceMode: maintenance,
+		Resources: []cluster.ResourceStatus{
+			{
+				ID:         "rsc_SAPHana_PRD_HDB00",
+				Role:       "Promoted",
+				Active:     true,
+				Managed:    true,
+				Nodes:      []string{"vmhana01"},
+				Parent:     "msl_SAPHana_PRD_HDB00",
+				Cloned:     true,
+				Promotable: true,
+			},
+			{
+				ID:         "rsc_SAPHana_PRD_HDB00",
+				Role:       "Unpromoted",
+				Active:     true,
+				Managed:    true,
+				Nodes:      []string{"vmhana02"},
+				Parent:     "msl_SAPHana_PRD_HDB00",
+				Cloned:     true,
+				Promotable: true,
+			},
+		},
+	}
+}
+
+func (suite *CrmClusterStopV2OperatorTestSuite) TestCrmClusterStopV2AlreadyOffline() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": false, "vmhana02": false}, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"stopped":true,"nodes":{"vmhana01":"offline","vmhana02":"offline"}}`,
+		"after":  `{"stopped":true,"nodes":{"vmhana01":"offline","vmhana02":"offline"}}`,
+	}, report.Success.Diff)
+}
+
+func (suite *CrmClusterStopV2OperatorTestSuite) TestCrmClusterStopV2InvalidArgument() {
+	ctx := context.Background()
+
+	report := suite.buildOperator(operator.Arguments{"maintenance": "yes"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("could not parse maintenance argument as bool, argument provided: yes", report.Error.Message)
+}
+
+func (suite *CrmClusterStopV2OperatorTestSuite) TestCrmClusterStopV2LocalNodeOfflineWithMaintenance() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": false, "vmhana02": true}, nil).Once()
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Once()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("CRM cluster is not online in the local node, cannot set maintenance mode", report.Error.Message)
+}
+
+func (suite *CrmClusterStopV2OperatorTestSuite) TestCrmClusterStopV2PrimaryStoppedLast() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": true, "vmhana02": true}, nil).Once()
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Checks: []cluster.PreflightCheckName{
+			cluster.QuorumCheck,
+			cluster.DCElectedCheck,
+			cluster.PendingFencingCheck,
+		},
+		Overrides: []cluster.PreflightCheckName{},
+	}).Return(passedPreflightReport(), nil).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(promotedHanaStatus(false), nil).Once()
+	suite.mockClusterClient.On("WaitForIdle", ctx, cluster.WaitForIdleOptions{Timeout: time.Minute}).Return(nil).Once()
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", "", true).Return(nil).Once()
+
+	stopSecondary := suite.mockClusterClient.On("StopClusterNodes", ctx, "vmhana02").Return(nil).Once()
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": true, "vmhana02": false}, nil).Once().NotBefore(stopSecondary)
+	stopPrimary := suite.mockClusterClient.On("StopClusterNodes", ctx, "vmhana01").Return(nil).Once().
+		NotBefore(stopSecondary)
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": false, "vmhana02": false}, nil).Twice().NotBefore(stopPrimary)
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before":           `{"stopped":false,"nodes":{"vmhana01":"online","vmhana02":"online"}}`,
+		"after":            `{"stopped":true,"nodes":{"vmhana01":"offline","vmhana02":"offline"}}`,
+		"preflight_checks": passedPreflightChecksDiff,
+	}, report.Success.Diff)
+}
+
+func (suite *CrmClusterStopV2OperatorTestSuite) TestCrmClusterStopV2AllNodes() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"node1": true, "node2": true}, nil).Once()
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Checks: []cluster.PreflightCheckName{
+			cluster.QuorumCheck,
+			cluster.DCElectedCheck,
+			cluster.PendingFencingCheck,
+		},
+		Overrides: []cluster.PreflightCheckName{},
+	}).Return(passedPreflightReport(), nil).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(&cluster.Status{}, nil).Once()
+	stopAll := suite.mockClusterClient.On("StopClusterNodes", ctx).Return(nil).Once()
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"node1": false, "node2": false}, nil).NotBefore(stopAll)
+
+	report := suite.buildOperator(operator.Arguments{"maintenance": false}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(`{"stopped":true,"nodes":{"node1":"offline","node2":"offline"}}`, report.Success.Diff["after"])
+}
+
+func (suite *CrmClusterStopV2OperatorTestSuite) TestCrmClusterStopV2AllNodesWaitsForStop() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"node1": true, "node2": true}, nil).Once()
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Checks: []cluster.PreflightCheckName{
+			cluster.QuorumCheck,
+			cluster.DCElectedCheck,
+			cluster.PendingFencingCheck,
+		},
+		Overrides: []cluster.PreflightCheckName{},
+	}).Return(passedPreflightReport(), nil).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(&cluster.Status{}, nil).Once()
+	stopAll := suite.mockClusterClient.On("StopClusterNodes", ctx).Return(nil).Once()
+	stillOnline := suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"node1": true, "node2": false}, nil).Once().NotBefore(stopAll)
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"node1": false, "node2": false}, nil).Twice().NotBefore(stillOnline)
+
+	report := suite.buildOperator(operator.Arguments{"maintenance": false}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(`{"stopped":true,"nodes":{"node1":"offline","node2":"offline"}}`, report.Success.Diff["after"])
+	suite.mockClusterClient.AssertNumberOfCalls(suite.T(), "GetClusterStackStates", 4)
+}
+
+func (suite *CrmClusterStopV2OperatorTestSuite) TestCrmClusterStopV2CommitErrorRollback() {
+	ctx := context.Background()
+
+	suite.mockClusterClient.On("GetClusterStackStates", ctx).
+		Return(map[string]bool{"vmhana01": true, "vmhana02": true}, nil)
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Checks: []cluster.PreflightCheckName{
+			cluster.QuorumCheck,
+			cluster.DCElectedCheck,
+			cluster.PendingFencingCheck,
+		},
+		Overrides: []cluster.PreflightCheckName{},
+	}).Return(passedPreflightReport(), nil).Once()
+	suite.mockClusterClient.On("GetStatus", ctx).Return(promotedHanaStatus(false), nil).Once()
+	suite.mockClusterClient.On("WaitForIdle", ctx, cluster.WaitForIdleOptions{Timeout: time.Minute}).Return(nil).Twice()
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", "", true).Return(nil).Once()
+	suite.mockClusterClient.On("StopClusterNodes", ctx, "vmhana02").Return(errors.New("ssh error")).Once()
+	suite.mockClusterClient.On("StartClusterNodes", ctx, "vmhana01").Return(nil).Once()
+	suite.mockClusterClient.On("StartClusterNodes", ctx, "vmhana02").Return(nil).Once()
+	suite.mockClusterClient.On("ResourceRefresh", ctx, "", "").Return(nil).Once()
+	suite.mockClusterClient.On("SetMaintenanceState", ctx, "", "", false).Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues("error stopping CRM cluster: ssh error", report.Error.Message)
+}
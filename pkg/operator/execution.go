@@ -4,12 +4,15 @@
 package operator
 
 import (
+	"errors"
 	"fmt"
 )
 
 type ExecutionError struct {
 	ErrorPhase PhaseName
 	Message    string
+	// Diff has the details collected by the operator until the failure, if the operator reports any
+	Diff map[string]any
 }
 
 func (e ExecutionError) Error() string {
@@ -31,12 +34,42 @@ type ExecutionReport struct {
 	Error       *ExecutionError
 }
 
+// diffError is an error carrying the details collected by the operator before failing,
+// so they are included in the error report
+type diffError struct {
+	err  error
+	diff map[string]any
+}
+
+func (e *diffError) Error() string {
+	return e.err.Error()
+}
+
+func (e *diffError) Unwrap() error {
+	return e.err
+}
+
+// withDiff attaches the given diff to the error, so it is reported if the operation fails with it
+func withDiff(err error, diff map[string]any) error {
+	if err == nil {
+		return nil
+	}
+	return &diffError{err: err, diff: diff}
+}
+
 func executionReportWithError(err error, phase PhaseName, operationID string) *ExecutionReport {
+	var errorWithDiff *diffError
+	var diff map[string]any
+	if errors.As(err, &errorWithDiff) {
+		diff = errorWithDiff.diff
+	}
+
 	return &ExecutionReport{
 		OperationID: operationID,
 		Error: &ExecutionError{
 			Message:    err.Error(),
 			ErrorPhase: phase,
+			Diff:       diff,
 		},
 	}
 }
@@ -64,6 +64,7 @@ func TestExecutorPlanError(t *testing.T) {
 
 	assert.Equal(t, planError.Error(), report.Error.Message)
 	assert.Equal(t, operator.PLAN, report.Error.ErrorPhase)
+	assert.Nil(t, report.Error.Diff)
 	assert.Nil(t, report.Success)
 }
 
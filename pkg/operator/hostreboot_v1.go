@@ -3,52 +3,104 @@
 
 // HostReboot operator schedules a host reboot after a specified delay.
 //
+// The operator accepts the following optional arguments:
+// - delay (number): Time in minutes until the reboot. Default 1.
+// - at (string): Absolute time of the reboot, in RFC3339 format, e.g. 2026-03-01T22:00:00Z.
+//   It cannot be combined with delay.
+// - message (string): Wall message sent to the logged in users. Default "Host reboot scheduled by automation".
+// - immediate (bool): Reboot the host right away instead of scheduling the reboot.
+//   It cannot be combined with delay or at.
+// - preflight_overrides ([]string): List of cluster pre-flight checks whose failure doesn't block the operation.
+//
 // # Execution Phases
 //
 // - PLAN:
-//   Checks if there is already a scheduled reboot using systemd D-Bus.
-//   If a reboot is already scheduled, the operation is skipped.
+//   Checks if there is already a scheduled reboot using systemd-logind and systemd D-Bus.
+//   If a reboot is already scheduled, the operation is skipped. If a shutdown of other type,
+//   e.g. poweroff, is scheduled, the operation fails. The current wall message is saved.
+//   If the host is part of a running pacemaker cluster, the cluster pre-flight checks are run,
+//   failing if any of the non overridden checks fails.
 //
 // - COMMIT:
-//   Executes the `shutdown -r +1` command to schedule the reboot.
+//   Sets the wall message and schedules the reboot with the systemd-logind ScheduleShutdown method.
+//   In immediate mode the reboot is started with the systemd-logind Reboot method instead.
 //
 // - VERIFY:
-//   Verifies that the reboot has been correctly scheduled by checking systemd D-Bus again.
+//   Verifies that systemd-logind reports the scheduled reboot, and stores the scheduled time.
+//   In immediate mode, the check is done in systemd D-Bus and repeated whenever systemd reports
+//   a state change of the shutdown units, until the reboot is found or the verify timeout expires.
 //
 // - ROLLBACK:
-//   Cancels the scheduled reboot using `shutdown -c` command.
+//   Cancels the scheduled reboot with the systemd-logind CancelScheduledShutdown method.
+//   An immediate reboot cannot be cancelled once started. The saved wall message is restored.
 //
 // # Details
 //
 // This operator is designed to safely schedule host reboots, ensuring that multiple
-// reboot schedules are not created. It uses systemd D-Bus to check for existing
-// scheduled shutdowns and verify the reboot scheduling.
+// reboot schedules are not created. It uses systemd-logind to schedule the reboot, and
+// systemd D-Bus to check for existing scheduled shutdowns.
 
 package operator
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
+	"time"
 
+	"github.com/trento-project/workbench/internal/cluster"
 	"github.com/trento-project/workbench/internal/dbus"
+	"github.com/trento-project/workbench/internal/logind"
 	"github.com/trento-project/workbench/internal/support"
 )
 
 const (
-	HostRebootOperatorName = "hostreboot"
+	HostRebootOperatorName     = "hostreboot"
+	defaultRebootVerifyTimeout = 10 * time.Second
+	defaultRebootDelay         = time.Minute
+	defaultRebootMessage       = "Host reboot scheduled by automation"
+)
+
+var (
+	// rebootJobUnits are the special targets queued by systemd when shutting down, see
+	// https://www.freedesktop.org/software/systemd/man/latest/systemd.special.html
+	rebootJobUnits = []string{"reboot.target", "shutdown.target", "poweroff.target", "halt.target"}
+	// rebootTimerUnits are the timers used to schedule a shutdown
+	rebootTimerUnits = []string{"shutdown.timer", "reboot.timer"}
 )
 
+type hostRebootArguments struct {
+	delay              time.Duration
+	at                 time.Time
+	message            string
+	immediate          bool
+	preflightOverrides []cluster.PreflightCheckName
+}
+
 type HostReboot struct {
 	baseOperator
-	executor        support.CmdExecutor
-	dbusConstructor func(ctx context.Context) (dbus.Connector, error)
+	executor            support.CmdExecutor
+	clusterClient       cluster.Cluster
+	dbusConstructor     func(ctx context.Context) (dbus.Connector, error)
+	logindConstructor   func(logger *slog.Logger) (logind.Logind, error)
+	logindClient        logind.Logind
+	verifyTimeout       time.Duration
+	parsedArguments     *hostRebootArguments
+	beforeScheduledTime time.Time
+	afterScheduledTime  time.Time
+	previousWallMessage logind.WallMessage
+	// clusterOnline is true if the host was online in the cluster when the reboot was planned
+	clusterOnline bool
 }
 
 type HostRebootOption Option[HostReboot]
 
 type hostRebootDiffOutput struct {
-	Scheduled bool `json:"scheduled"`
+	Scheduled     bool   `json:"scheduled"`
+	ScheduledTime string `json:"scheduled_time,omitempty"`
 }
 
 func WithCustomHostRebootExecutor(executor support.CmdExecutor) HostRebootOption {
@@ -57,12 +109,25 @@ func WithCustomHostRebootExecutor(executor support.CmdExecutor) HostRebootOption
 	}
 }
 
+func WithCustomHostRebootClusterClient(clusterClient cluster.Cluster) HostRebootOption {
+	return func(o *HostReboot) {
+		o.clusterClient = clusterClient
+	}
+}
+
 func WithCustomDbusConstructor(constructor func(ctx context.Context) (dbus.Connector, error)) HostRebootOption {
 	return func(o *HostReboot) {
 		o.dbusConstructor = constructor
 	}
 }
 
+// WithRebootVerifyTimeout sets the time to wait until the reboot is found in the verify phase of the immediate mode
+func WithRebootVerifyTimeout(timeout time.Duration) HostRebootOption {
+	return func(o *HostReboot) {
+		o.verifyTimeout = timeout
+	}
+}
+
 func WithStaticDbusConnector(connector dbus.Connector) HostRebootOption {
 	return func(o *HostReboot) {
 		o.dbusConstructor = func(_ context.Context) (dbus.Connector, error) {
@@ -71,73 +136,188 @@ func WithStaticDbusConnector(connector dbus.Connector) HostRebootOption {
 	}
 }
 
+func WithCustomLogindConstructor(constructor func(logger *slog.Logger) (logind.Logind, error)) HostRebootOption {
+	return func(o *HostReboot) {
+		o.logindConstructor = constructor
+	}
+}
+
+func WithStaticLogind(logindClient logind.Logind) HostRebootOption {
+	return func(o *HostReboot) {
+		o.logindConstructor = func(_ *slog.Logger) (logind.Logind, error) {
+			return logindClient, nil
+		}
+	}
+}
+
+// defaultDbusConstructor returns the process wide D-Bus connection, so the connection is not
+// established again on every check. Closing it is a no-op.
 func defaultDbusConstructor(ctx context.Context) (dbus.Connector, error) {
-	connector, err := dbus.NewConnector(ctx)
+	connector, err := dbus.DefaultManager().Connection(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create D-Bus connector: %w", err)
 	}
 	return connector, nil
 }
 
+func defaultLogindConstructor(logger *slog.Logger) (logind.Logind, error) {
+	return logind.NewLogind(logger)
+}
+
 func NewHostReboot(arguments Arguments,
 	operationID string,
 	options Options[HostReboot]) *Executor {
+	hostReboot := newHostReboot(arguments, operationID, options)
+
+	return &Executor{
+		phaser:      hostReboot,
+		operationID: operationID,
+		logger:      hostReboot.logger,
+	}
+}
+
+func newHostReboot(arguments Arguments, operationID string, options Options[HostReboot]) *HostReboot {
 	hostReboot := &HostReboot{
 		baseOperator: newBaseOperator(
 			HostRebootOperatorName, operationID, arguments, options.BaseOperatorOptions...,
 		),
-		executor:        support.CliExecutor{},
-		dbusConstructor: defaultDbusConstructor,
+		executor:          support.CliExecutor{},
+		clusterClient:     cluster.NewDefaultClusterClient(),
+		dbusConstructor:   defaultDbusConstructor,
+		logindConstructor: defaultLogindConstructor,
+		verifyTimeout:     defaultRebootVerifyTimeout,
 	}
 
 	for _, opt := range options.OperatorOptions {
 		opt(hostReboot)
 	}
 
-	return &Executor{
-		phaser:      hostReboot,
-		operationID: operationID,
-		logger:      hostReboot.logger,
-	}
+	return hostReboot
 }
 
 func (h *HostReboot) plan(ctx context.Context) (bool, error) {
-	// Check if there is already a scheduled reboot
-	isScheduled, err := h.isRebootScheduled(ctx)
+	opArguments, err := parseHostRebootArguments(h.arguments)
+	if err != nil {
+		return false, err
+	}
+	h.parsedArguments = opArguments
+
+	logindClient, err := h.logindConstructor(h.logger)
+	if err != nil {
+		h.logger.Error("unable to initialize systemd-logind client", "error", err)
+		return false, fmt.Errorf("unable to initialize systemd-logind client: %w", err)
+	}
+	h.logindClient = logindClient
+
+	scheduledShutdown, err := h.logindClient.GetScheduledShutdown(ctx)
 	if err != nil {
 		return false, fmt.Errorf("error checking if reboot is scheduled: %w", err)
 	}
 
+	if scheduledShutdown.IsScheduled() && scheduledShutdown.Type != logind.ShutdownTypeReboot {
+		return false, fmt.Errorf("a %s is already scheduled at %s, it must be cancelled before scheduling a reboot",
+			scheduledShutdown.Type, formatScheduledTime(scheduledShutdown.Time))
+	}
+
+	// Check if there is already a scheduled reboot
+	isScheduled := scheduledShutdown.IsScheduled()
+	if !isScheduled {
+		isScheduled, err = h.isRebootScheduled(ctx)
+		if err != nil {
+			return false, fmt.Errorf("error checking if reboot is scheduled: %w", err)
+		}
+	}
+
 	h.resources[beforeDiffField] = isScheduled
+	h.beforeScheduledTime = scheduledShutdown.Time
 
 	if isScheduled {
 		h.resources[afterDiffField] = true
+		h.afterScheduledTime = scheduledShutdown.Time
 		return true, nil
 	}
 
+	h.previousWallMessage, err = h.logindClient.GetWallMessage(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// rebooting a cluster node stops the cluster services on it
+	h.clusterOnline = h.clusterClient.IsHostOnline(ctx)
+	if h.clusterOnline {
+		err = runClusterPreflightChecks(ctx, h.clusterClient, cluster.PreflightOptions{
+			Overrides: h.parsedArguments.preflightOverrides,
+		}, h.resources)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	return false, nil
 }
 
 func (h *HostReboot) commit(ctx context.Context) error {
-	_, err := h.executor.Exec(ctx, "shutdown", "-r", "+1", "Host reboot scheduled by automation")
+	wallMessage := logind.WallMessage{Message: h.parsedArguments.message, Enabled: true}
+	if err := h.logindClient.SetWallMessage(ctx, wallMessage); err != nil {
+		return err
+	}
+
+	if h.parsedArguments.immediate {
+		h.logger.Info("Rebooting host immediately")
+		return h.logindClient.Reboot(ctx)
+	}
+
+	at := h.parsedArguments.at
+	if at.IsZero() {
+		at = time.Now().Add(h.parsedArguments.delay)
+	}
 
-	return err
+	h.logger.Info("Scheduling host reboot", "time", at)
+	return h.logindClient.ScheduleShutdown(ctx, logind.ShutdownTypeReboot, at)
 }
 
 func (h *HostReboot) rollback(ctx context.Context) error {
-	_, err := h.executor.Exec(ctx, "shutdown", "-c")
-	return err
+	var cancelErr error
+	if h.parsedArguments.immediate {
+		h.logger.Info("Immediate reboot cannot be cancelled, only restoring the wall message")
+	} else {
+		var cancelled bool
+		cancelled, cancelErr = h.logindClient.CancelScheduledShutdown(ctx)
+		if cancelErr == nil && !cancelled {
+			h.logger.Info("No scheduled reboot to cancel")
+		}
+	}
+
+	return errors.Join(cancelErr, h.logindClient.SetWallMessage(ctx, h.previousWallMessage))
 }
 
 func (h *HostReboot) verify(ctx context.Context) error {
-	// Verify that the reboot has been scheduled
-	isScheduled, err := h.isRebootScheduled(ctx)
+	if h.parsedArguments.immediate {
+		return h.verifyImmediateReboot(ctx)
+	}
+
+	scheduledShutdown, err := h.logindClient.GetScheduledShutdown(ctx)
+	if err != nil {
+		return fmt.Errorf("error verifying reboot scheduling: %w", err)
+	}
+
+	if scheduledShutdown.Type != logind.ShutdownTypeReboot {
+		return errors.New("reboot verification failed: no scheduled reboot found")
+	}
+
+	h.resources[afterDiffField] = true
+	h.afterScheduledTime = scheduledShutdown.Time
+	return nil
+}
+
+func (h *HostReboot) verifyImmediateReboot(ctx context.Context) error {
+	isScheduled, err := h.waitForScheduledReboot(ctx)
 	if err != nil {
 		return fmt.Errorf("error verifying reboot scheduling: %w", err)
 	}
 
 	if !isScheduled {
-		return fmt.Errorf("reboot verification failed: no scheduled reboot found")
+		return errors.New("reboot verification failed: no scheduled reboot found")
 	}
 
 	h.resources[afterDiffField] = true
@@ -154,7 +334,8 @@ func (h *HostReboot) operationDiff(_ context.Context) map[string]any {
 	}
 
 	beforeDiffOutput := hostRebootDiffOutput{
-		Scheduled: beforeScheduled,
+		Scheduled:     beforeScheduled,
+		ScheduledTime: formatScheduledTime(h.beforeScheduledTime),
 	}
 	before, err := json.Marshal(beforeDiffOutput)
 	if err != nil {
@@ -172,7 +353,8 @@ func (h *HostReboot) operationDiff(_ context.Context) map[string]any {
 	}
 
 	afterDiffOutput := hostRebootDiffOutput{
-		Scheduled: afterScheduled,
+		Scheduled:     afterScheduled,
+		ScheduledTime: formatScheduledTime(h.afterScheduledTime),
 	}
 	after, err := json.Marshal(afterDiffOutput)
 	if err != nil {
@@ -180,9 +362,95 @@ func (h *HostReboot) operationDiff(_ context.Context) map[string]any {
 	}
 	diff["after"] = string(after)
 
+	addPreflightChecksDiff(diff, h.resources)
+
 	return diff
 }
 
+func (h *HostReboot) after(_ context.Context) {
+	if h.logindClient != nil {
+		h.logindClient.Close()
+	}
+}
+
+func formatScheduledTime(scheduledTime time.Time) string {
+	if scheduledTime.IsZero() {
+		return ""
+	}
+	return scheduledTime.UTC().Format(time.RFC3339)
+}
+
+func parseHostRebootArguments(rawArguments Arguments) (*hostRebootArguments, error) {
+	opArguments := &hostRebootArguments{
+		delay:   defaultRebootDelay,
+		message: defaultRebootMessage,
+	}
+
+	delayArgument, delayFound := rawArguments["delay"]
+	if delayFound {
+		minutes, ok := delayArgument.(float64)
+		if !ok {
+			return nil, fmt.Errorf("could not parse delay argument as a number, argument provided: %v", delayArgument)
+		}
+		if minutes <= 0 {
+			return nil, fmt.Errorf("invalid delay value: %v, it must be a positive number", delayArgument)
+		}
+		opArguments.delay = time.Duration(minutes * float64(time.Minute))
+	}
+
+	atArgument, atFound := rawArguments["at"]
+	if atFound {
+		if delayFound {
+			return nil, errors.New("delay and at arguments cannot be used together")
+		}
+		atString, ok := atArgument.(string)
+		if !ok {
+			return nil, fmt.Errorf("could not parse at argument as string, argument provided: %v", atArgument)
+		}
+		at, err := time.Parse(time.RFC3339, atString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid at value: %s, it must be a RFC3339 time: %w", atString, err)
+		}
+		if !at.After(time.Now()) {
+			return nil, fmt.Errorf("invalid at value: %s, it must be a time in the future", atString)
+		}
+		opArguments.at = at
+	}
+
+	if messageArgument, found := rawArguments["message"]; found {
+		message, ok := messageArgument.(string)
+		if !ok {
+			return nil, fmt.Errorf(
+				"could not parse message argument as string, argument provided: %v",
+				messageArgument,
+			)
+		}
+		opArguments.message = message
+	}
+
+	if immediateArgument, found := rawArguments["immediate"]; found {
+		immediate, ok := immediateArgument.(bool)
+		if !ok {
+			return nil, fmt.Errorf(
+				"could not parse immediate argument as bool, argument provided: %v",
+				immediateArgument,
+			)
+		}
+		if immediate && (delayFound || atFound) {
+			return nil, errors.New("immediate argument cannot be used together with delay or at")
+		}
+		opArguments.immediate = immediate
+	}
+
+	preflightOverrides, err := parsePreflightOverrides(rawArguments)
+	if err != nil {
+		return nil, err
+	}
+	opArguments.preflightOverrides = preflightOverrides
+
+	return opArguments, nil
+}
+
 // isRebootScheduled checks if there is a scheduled reboot by querying systemd via D-Bus
 func (h *HostReboot) isRebootScheduled(ctx context.Context) (bool, error) {
 	// Connect to systemd D-Bus
@@ -192,6 +460,58 @@ func (h *HostReboot) isRebootScheduled(ctx context.Context) (bool, error) {
 	}
 	defer conn.Close()
 
+	return h.checkRebootScheduled(ctx, conn)
+}
+
+// waitForScheduledReboot checks if there is a scheduled reboot, checking again every time systemd
+// reports a state change of the shutdown jobs and timers, until the verify timeout expires
+func (h *HostReboot) waitForScheduledReboot(ctx context.Context) (bool, error) {
+	conn, err := h.dbusConstructor(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to systemd D-Bus: %w", err)
+	}
+	defer conn.Close()
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, h.verifyTimeout)
+	defer cancel()
+
+	// subscribe before checking, so no change is lost in between
+	events, unsubscribe, err := conn.SubscribeUnitEvents(timeoutCtx)
+	if err != nil {
+		return false, fmt.Errorf("failed to subscribe to systemd unit events: %w", err)
+	}
+	defer unsubscribe()
+
+	for {
+		isScheduled, err := h.checkRebootScheduled(ctx, conn)
+		if err != nil || isScheduled {
+			return isScheduled, err
+		}
+
+		if !waitForShutdownUnitEvent(timeoutCtx, events) {
+			h.logger.Debug("No scheduled reboot found before the timeout", "timeout", h.verifyTimeout)
+			return false, nil
+		}
+	}
+}
+
+// waitForShutdownUnitEvent waits for a state change of a shutdown job or timer.
+// It returns false if the context is done first.
+func waitForShutdownUnitEvent(ctx context.Context, events <-chan dbus.UnitEvent) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case event := <-events:
+			if slices.Contains(rebootJobUnits, event.Unit) || slices.Contains(rebootTimerUnits, event.Unit) {
+				return true
+			}
+		}
+	}
+}
+
+// checkRebootScheduled looks for the shutdown jobs and timers in systemd, and for the shutdown processes
+func (h *HostReboot) checkRebootScheduled(ctx context.Context, conn dbus.Connector) (bool, error) {
 	// Check if there's a scheduled shutdown job
 	// We look for shutdown.target or reboot.target in scheduled jobs
 	// queries org.freedesktop.systemd1.Manager.ListJobs
@@ -202,11 +522,7 @@ func (h *HostReboot) isRebootScheduled(ctx context.Context) (bool, error) {
 
 	// Look for reboot or shutdown related jobs
 	for _, job := range jobs {
-		jobName := job.Unit
-		// jobs name as mentioned in systemd documentation
-		// https://www.freedesktop.org/software/systemd/man/latest/systemd.special.html
-		if jobName == "reboot.target" || jobName == "shutdown.target" ||
-			jobName == "poweroff.target" || jobName == "halt.target" {
+		if slices.Contains(rebootJobUnits, job.Unit) {
 			return true, nil
 		}
 	}
@@ -220,7 +536,7 @@ func (h *HostReboot) isRebootScheduled(ctx context.Context) (bool, error) {
 
 	for _, unit := range timers {
 		if unit.LoadState == "loaded" && unit.ActiveState == "active" &&
-			(unit.Name == "shutdown.timer" || unit.Name == "reboot.timer") {
+			slices.Contains(rebootTimerUnits, unit.Name) {
 			return true, nil
 		}
 	}
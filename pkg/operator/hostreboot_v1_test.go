@@ -8,10 +8,16 @@ import (
 	"errors"
 	"log/slog"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	clusterMocks "github.com/trento-project/workbench/internal/cluster/mocks"
 	"github.com/trento-project/workbench/internal/dbus"
 	dbusMocks "github.com/trento-project/workbench/internal/dbus/mocks"
+	"github.com/trento-project/workbench/internal/logind"
+	logindMocks "github.com/trento-project/workbench/internal/logind/mocks"
 	"github.com/trento-project/workbench/internal/support"
 	supportMocks "github.com/trento-project/workbench/internal/support/mocks"
 	"github.com/trento-project/workbench/pkg/operator"
@@ -21,15 +27,25 @@ import (
 
 type HostRebootOperatorTestSuite struct {
 	suite.Suite
-	logger *slog.Logger
+	logger            *slog.Logger
+	mockClusterClient *clusterMocks.MockCluster
+	mockLogind        *logindMocks.MockLogind
 }
 
 func buildHostRebootOperator(suite *HostRebootOperatorTestSuite,
 	mockCmdExecutor *supportMocks.MockCmdExecutor,
 	mockDbusConnector *dbusMocks.MockConnector,
+) *operator.Executor {
+	return buildHostRebootOperatorWithArguments(suite, operator.Arguments{}, mockCmdExecutor, mockDbusConnector)
+}
+
+func buildHostRebootOperatorWithArguments(suite *HostRebootOperatorTestSuite,
+	arguments operator.Arguments,
+	mockCmdExecutor *supportMocks.MockCmdExecutor,
+	mockDbusConnector *dbusMocks.MockConnector,
 ) *operator.Executor {
 	return operator.NewHostReboot(
-		operator.Arguments{},
+		arguments,
 		"test-op",
 		operator.Options[operator.HostReboot]{
 			BaseOperatorOptions: []operator.BaseOperatorOption{
@@ -38,17 +54,61 @@ func buildHostRebootOperator(suite *HostRebootOperatorTestSuite,
 			OperatorOptions: []operator.Option[operator.HostReboot]{
 				operator.Option[operator.HostReboot](operator.WithCustomHostRebootExecutor(mockCmdExecutor)),
 				operator.Option[operator.HostReboot](operator.WithStaticDbusConnector(mockDbusConnector)),
+				operator.Option[operator.HostReboot](operator.WithCustomHostRebootClusterClient(suite.mockClusterClient)),
+				operator.Option[operator.HostReboot](operator.WithStaticLogind(suite.mockLogind)),
+				operator.Option[operator.HostReboot](operator.WithRebootVerifyTimeout(50 * time.Millisecond)),
 			},
 		},
 	)
 }
 
+func expectUnitEventsSubscription(mockDbusConnector *dbusMocks.MockConnector, events chan dbus.UnitEvent) {
+	mockDbusConnector.On("SubscribeUnitEvents", mock.Anything).
+		Return((<-chan dbus.UnitEvent)(events), func() {}, nil).
+		Once()
+}
+
 func TestHostRebootOperator(t *testing.T) {
 	suite.Run(t, new(HostRebootOperatorTestSuite))
 }
 
 func (suite *HostRebootOperatorTestSuite) SetupTest() {
 	suite.logger = support.NewDefaultLogger(slog.LevelInfo)
+	suite.mockClusterClient = clusterMocks.NewMockCluster(suite.T())
+	suite.mockLogind = logindMocks.NewMockLogind(suite.T())
+}
+
+func (suite *HostRebootOperatorTestSuite) expectNoLogindScheduledShutdown(ctx context.Context) {
+	suite.mockLogind.On("GetScheduledShutdown", ctx).
+		Return(logind.ScheduledShutdown{}, nil).
+		Once()
+}
+
+// previousWallMessage is the wall message set in systemd-logind before running the operator
+var previousWallMessage = logind.WallMessage{Message: "", Enabled: true}
+
+func (suite *HostRebootOperatorTestSuite) expectWallMessage(ctx context.Context) {
+	suite.mockLogind.On("GetWallMessage", ctx).
+		Return(previousWallMessage, nil).
+		Once()
+}
+
+func (suite *HostRebootOperatorTestSuite) expectWallMessageRestored(ctx context.Context) {
+	suite.mockLogind.On("SetWallMessage", ctx, previousWallMessage).
+		Return(nil).
+		Once()
+}
+
+func (suite *HostRebootOperatorTestSuite) expectLogindClose() {
+	suite.mockLogind.On("Close").Return().Once()
+}
+
+// scheduledAfterNow matches a reboot time in the future, up to the given delay from now
+func scheduledAfterNow(delay time.Duration) any {
+	now := time.Now()
+	return mock.MatchedBy(func(at time.Time) bool {
+		return at.After(now) && !at.After(time.Now().Add(delay))
+	})
 }
 
 func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorSuccess() {
@@ -57,6 +117,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorSuccess() {
 	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
 
 	// Plan phase - check if reboot is already scheduled (it's not)
+	suite.expectNoLogindScheduledShutdown(ctx)
+
 	mockDbusConnector.On("ListJobsContext", ctx).
 		Return([]baseDbus.JobStatus{}, nil).
 		Once()
@@ -82,33 +144,32 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorSuccess() {
 		Return([]byte(""), errors.New("file not found")).
 		Once()
 
+	suite.expectWallMessage(ctx)
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Once()
+
 	// Commit phase - schedule reboot
-	mockCmdExecutor.On("Exec", ctx, "shutdown", "-r", "+1", "Host reboot scheduled by automation").
-		Return([]byte("Reboot scheduled"), nil).
+	suite.mockLogind.On("SetWallMessage", ctx, logind.WallMessage{Message: "Host reboot scheduled by automation", Enabled: true}).
+		Return(nil).
 		Once()
 
-	// Verify phase - check that reboot is now scheduled
-	rebootJob := baseDbus.JobStatus{
-		Id:      1,
-		JobType: "start",
-		Status:  "waiting",
-		Unit:    "reboot.target",
-		JobPath: "/org/freedesktop/systemd1/job/1",
-	}
-
-	mockDbusConnector.On("ListJobsContext", ctx).
-		Return([]baseDbus.JobStatus{rebootJob}, nil).
+	suite.mockLogind.On("ScheduleShutdown", ctx, "reboot", scheduledAfterNow(time.Minute)).
+		Return(nil).
 		Once()
 
-	mockDbusConnector.On("Close").
-		Return().
+	// Verify phase - check that reboot is now scheduled
+	scheduledTime := time.Date(2026, 3, 1, 22, 1, 0, 0, time.UTC)
+	suite.mockLogind.On("GetScheduledShutdown", ctx).
+		Return(logind.ScheduledShutdown{Type: "reboot", Time: scheduledTime}, nil).
 		Once()
 
+	suite.expectLogindClose()
+
 	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
 
 	expectedDiff := map[string]any{
 		"before": `{"scheduled":false}`,
-		"after":  `{"scheduled":true}`,
+		"after":  `{"scheduled":true,"scheduled_time":"2026-03-01T22:01:00Z"}`,
 	}
 
 	suite.Nil(report.Error)
@@ -122,6 +183,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorAlreadyScheduled
 	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
 	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
 
+	suite.expectNoLogindScheduledShutdown(ctx)
+
 	// Plan phase - reboot is already scheduled
 	shutdownJob := baseDbus.JobStatus{
 		Id:      1,
@@ -137,6 +200,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorAlreadyScheduled
 	mockDbusConnector.On("Close").
 		Return().
 		Once()
+	suite.expectLogindClose()
+
 	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
 
 	expectedDiff := map[string]any{
@@ -156,6 +221,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorDbusConnectionEr
 		return nil, errors.New("dbus constructor failure")
 	}
 
+	suite.expectNoLogindScheduledShutdown(ctx)
+
 	report := operator.NewHostReboot(
 		operator.Arguments{},
 		"test-op",
@@ -165,6 +232,7 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorDbusConnectionEr
 			},
 			OperatorOptions: []operator.Option[operator.HostReboot]{
 				operator.Option[operator.HostReboot](operator.WithCustomDbusConstructor(failingConstructor)),
+				operator.Option[operator.HostReboot](operator.WithStaticLogind(suite.mockLogind)),
 			},
 		},
 	).Run(ctx)
@@ -181,6 +249,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorListJobsError()
 	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
 	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
 
+	suite.expectNoLogindScheduledShutdown(ctx)
+
 	// Plan phase - ListJobs returns error
 	mockDbusConnector.On("ListJobsContext", ctx).
 		Return([]baseDbus.JobStatus{}, errors.New("D-Bus error")).
@@ -203,6 +273,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorListUnitsError()
 	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
 	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
 
+	suite.expectNoLogindScheduledShutdown(ctx)
+
 	// Plan phase - ListJobs succeeds but ListUnits returns error
 	mockDbusConnector.On("ListJobsContext", ctx).
 		Return([]baseDbus.JobStatus{}, nil).
@@ -224,11 +296,12 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorListUnitsError()
 
 func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorCommitError() {
 	ctx := context.Background()
-
 	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
 	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
 
 	// Plan phase - no reboot scheduled
+	suite.expectNoLogindScheduledShutdown(ctx)
+
 	mockDbusConnector.On("ListJobsContext", ctx).
 		Return([]baseDbus.JobStatus{}, nil).
 		Once()
@@ -254,30 +327,43 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorCommitError() {
 		Return([]byte(""), errors.New("file not found")).
 		Once()
 
-	// Commit phase - shutdown command fails
-	mockCmdExecutor.On("Exec", ctx, "shutdown", "-r", "+1", "Host reboot scheduled by automation").
-		Return([]byte(""), errors.New("shutdown command failed")).
+	suite.expectWallMessage(ctx)
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Once()
+
+	// Commit phase - scheduling the reboot fails
+	suite.mockLogind.On("SetWallMessage", ctx, logind.WallMessage{Message: "Host reboot scheduled by automation", Enabled: true}).
+		Return(nil).
 		Once()
 
-	// Rollback phase - cancel shutdown
-	mockCmdExecutor.On("Exec", ctx, "shutdown", "-c").
-		Return([]byte("Shutdown cancelled"), nil).
+	suite.mockLogind.On("ScheduleShutdown", ctx, "reboot", mock.AnythingOfType("time.Time")).
+		Return(errors.New("failed to schedule reboot: access denied")).
 		Once()
 
+	// Rollback phase - cancel the scheduled reboot
+	suite.mockLogind.On("CancelScheduledShutdown", ctx).
+		Return(false, nil).
+		Once()
+
+	suite.expectWallMessageRestored(ctx)
+
+	suite.expectLogindClose()
+
 	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
 
 	suite.Nil(report.Success)
 	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
-	suite.Contains(report.Error.Message, "shutdown command failed")
+	suite.Contains(report.Error.Message, "access denied")
 }
 
 func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorVerifyError() {
 	ctx := context.Background()
-
 	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
 	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
 
 	// Plan phase - no reboot scheduled
+	suite.expectNoLogindScheduledShutdown(ctx)
+
 	mockDbusConnector.On("ListJobsContext", ctx).
 		Return([]baseDbus.JobStatus{}, nil).
 		Once()
@@ -286,6 +372,10 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorVerifyError() {
 		Return([]baseDbus.UnitStatus{}, nil).
 		Once()
 
+	mockDbusConnector.On("Close").
+		Return().
+		Once()
+
 	// Check for active shutdown processes (none found)
 	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "shutdown").
 		Return([]byte(""), errors.New("no process found")).
@@ -294,95 +384,173 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorVerifyError() {
 	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "systemd-shutdown").
 		Return([]byte(""), errors.New("no process found")).
 		Once()
+
 	mockCmdExecutor.On("Exec", ctx, "test", "-f", "/run/systemd/shutdown/scheduled").
 		Return([]byte(""), errors.New("file not found")).
 		Once()
 
+	suite.expectWallMessage(ctx)
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Once()
+
 	// Commit phase - schedule reboot
-	mockCmdExecutor.On("Exec", ctx, "shutdown", "-r", "+1", "Host reboot scheduled by automation").
-		Return([]byte("Reboot scheduled"), nil).
+	suite.mockLogind.On("SetWallMessage", ctx, logind.WallMessage{Message: "Host reboot scheduled by automation", Enabled: true}).
+		Return(nil).
+		Once()
+
+	suite.mockLogind.On("ScheduleShutdown", ctx, "reboot", mock.AnythingOfType("time.Time")).
+		Return(nil).
 		Once()
 
 	// Verify phase - still no reboot found (verification fails)
+	suite.expectNoLogindScheduledShutdown(ctx)
+
+	// Rollback phase
+	suite.mockLogind.On("CancelScheduledShutdown", ctx).
+		Return(false, nil).
+		Once()
+
+	suite.expectWallMessageRestored(ctx)
+
+	suite.expectLogindClose()
+
+	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.Contains(report.Error.Message, "reboot verification failed: no scheduled reboot found")
+}
+
+func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorImmediateRebootAfterUnitEvent() {
+	ctx := context.Background()
+
+	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
+	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
+
+	suite.expectNoLogindScheduledShutdown(ctx)
+
+	// Plan and first verify check - no reboot scheduled
 	mockDbusConnector.On("ListJobsContext", ctx).
 		Return([]baseDbus.JobStatus{}, nil).
-		Once()
+		Twice()
 
 	mockDbusConnector.On("ListUnitsContext", ctx).
 		Return([]baseDbus.UnitStatus{}, nil).
-		Once()
-	mockDbusConnector.On("Close").
-		Return().
-		Once()
+		Twice()
 
-	// Check for active shutdown processes during verify (none found)
 	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "shutdown").
 		Return([]byte(""), errors.New("no process found")).
-		Once()
+		Twice()
 
 	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "systemd-shutdown").
 		Return([]byte(""), errors.New("no process found")).
-		Once()
+		Twice()
 
 	mockCmdExecutor.On("Exec", ctx, "test", "-f", "/run/systemd/shutdown/scheduled").
 		Return([]byte(""), errors.New("file not found")).
+		Twice()
+
+	suite.expectWallMessage(ctx)
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Once()
+
+	// Commit phase - reboot right away
+	suite.mockLogind.On("SetWallMessage", ctx, logind.WallMessage{Message: "Host reboot scheduled by automation", Enabled: true}).
+		Return(nil).
 		Once()
 
-	// Rollback phase
-	mockCmdExecutor.On("Exec", ctx, "shutdown", "-c").
-		Return([]byte("Shutdown cancelled"), nil).
+	suite.mockLogind.On("Reboot", ctx).
+		Return(nil).
+		Once()
+
+	// Verify phase - the reboot job is found after systemd reports it
+	events := make(chan dbus.UnitEvent, 2)
+	events <- dbus.UnitEvent{Unit: "sshd.service"}
+	events <- dbus.UnitEvent{Unit: "reboot.target"}
+	expectUnitEventsSubscription(mockDbusConnector, events)
+
+	mockDbusConnector.On("ListJobsContext", ctx).
+		Return([]baseDbus.JobStatus{{Id: 1, Unit: "reboot.target"}}, nil).
 		Once()
 
 	mockDbusConnector.On("Close").
 		Return().
-		Once()
+		Twice()
 
-	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
+	suite.expectLogindClose()
 
-	suite.Nil(report.Success)
-	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
-	suite.Contains(report.Error.Message, "reboot verification failed: no scheduled reboot found")
+	report := buildHostRebootOperatorWithArguments(suite, operator.Arguments{
+		"immediate": true,
+	}, mockCmdExecutor, mockDbusConnector).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"scheduled":false}`,
+		"after":  `{"scheduled":true}`,
+	}, report.Success.Diff)
 }
 
 func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorRollbackError() {
 	ctx := context.Background()
-
 	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
 	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
 
 	// Plan phase - no reboot scheduled
+	suite.expectNoLogindScheduledShutdown(ctx)
+
 	mockDbusConnector.On("ListJobsContext", ctx).
 		Return([]baseDbus.JobStatus{}, nil).
 		Once()
 
 	mockDbusConnector.On("ListUnitsContext", ctx).
-		Return([]baseDbus.UnitStatus{}, nil).Once()
+		Return([]baseDbus.UnitStatus{}, nil).
+		Once()
+
+	mockDbusConnector.On("Close").
+		Return().
+		Once()
 
 	// Check for active shutdown processes (none found)
 	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "shutdown").
-		Return([]byte(""), errors.New("no process found")).Once()
+		Return([]byte(""), errors.New("no process found")).
+		Once()
 
 	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "systemd-shutdown").
-		Return([]byte(""), errors.New("no process found")).Once()
+		Return([]byte(""), errors.New("no process found")).
+		Once()
 
 	mockCmdExecutor.On("Exec", ctx, "test", "-f", "/run/systemd/shutdown/scheduled").
-		Return([]byte(""), errors.New("file not found")).Once()
+		Return([]byte(""), errors.New("file not found")).
+		Once()
+
+	suite.expectWallMessage(ctx)
 
-	// Commit phase - shutdown command fails
-	mockCmdExecutor.On("Exec", ctx, "shutdown", "-r", "+1", "Host reboot scheduled by automation").
-		Return([]byte(""), errors.New("shutdown command failed")).Once()
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Once()
 
-	// Rollback phase - cancel shutdown also fails
-	mockCmdExecutor.On("Exec", ctx, "shutdown", "-c").
-		Return([]byte(""), errors.New("cancel command failed")).Once()
+	// Commit phase - scheduling the reboot fails
+	suite.mockLogind.On("SetWallMessage", ctx, logind.WallMessage{Message: "Host reboot scheduled by automation", Enabled: true}).
+		Return(nil).
+		Once()
 
-	mockDbusConnector.On("Close").Return().Once()
+	suite.mockLogind.On("ScheduleShutdown", ctx, "reboot", mock.AnythingOfType("time.Time")).
+		Return(errors.New("failed to schedule reboot")).
+		Once()
+
+	// Rollback phase - cancelling the reboot also fails
+	suite.mockLogind.On("CancelScheduledShutdown", ctx).
+		Return(false, errors.New("failed to cancel scheduled shutdown")).
+		Once()
+
+	suite.expectWallMessageRestored(ctx)
+
+	suite.expectLogindClose()
 
 	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
 
 	suite.Nil(report.Success)
 	suite.Equal(operator.ROLLBACK, report.Error.ErrorPhase)
-	suite.Contains(report.Error.Message, "cancel command failed")
+	suite.Contains(report.Error.Message, "failed to cancel scheduled shutdown")
 }
 
 func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorShutdownTimerDetection() {
@@ -391,6 +559,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorShutdownTimerDet
 	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
 	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
 
+	suite.expectNoLogindScheduledShutdown(ctx)
+
 	// Plan phase - no jobs but active shutdown timer
 	mockDbusConnector.On("ListJobsContext", ctx).
 		Return([]baseDbus.JobStatus{}, nil).
@@ -410,6 +580,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorShutdownTimerDet
 	mockDbusConnector.On("Close").
 		Return().
 		Once()
+	suite.expectLogindClose()
+
 	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
 
 	expectedDiff := map[string]any{
@@ -428,6 +600,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorRebootTimerDetec
 	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
 	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
 
+	suite.expectNoLogindScheduledShutdown(ctx)
+
 	// Plan phase - no jobs but active reboot timer
 	mockDbusConnector.On("ListJobsContext", ctx).
 		Return([]baseDbus.JobStatus{}, nil).
@@ -447,6 +621,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorRebootTimerDetec
 	mockDbusConnector.On("Close").
 		Return().
 		Once()
+	suite.expectLogindClose()
+
 	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
 
 	expectedDiff := map[string]any{
@@ -465,6 +641,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorActiveShutdownPr
 	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
 	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
 
+	suite.expectNoLogindScheduledShutdown(ctx)
+
 	// Plan phase - no jobs or timers but active shutdown process
 	mockDbusConnector.On("ListJobsContext", ctx).
 		Return([]baseDbus.JobStatus{}, nil).
@@ -481,6 +659,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorActiveShutdownPr
 		Return().
 		Once()
 
+	suite.expectLogindClose()
+
 	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
 
 	expectedDiff := map[string]any{
@@ -499,6 +679,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorActiveSystemdShu
 	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
 	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
 
+	suite.expectNoLogindScheduledShutdown(ctx)
+
 	// Plan phase - no jobs or timers, no shutdown process, but systemd-shutdown process
 	mockDbusConnector.On("ListJobsContext", ctx).
 		Return([]baseDbus.JobStatus{}, nil).
@@ -519,6 +701,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorActiveSystemdShu
 		Return().
 		Once()
 
+	suite.expectLogindClose()
+
 	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
 
 	expectedDiff := map[string]any{
@@ -537,6 +721,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorScheduledFileDet
 	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
 	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
 
+	suite.expectNoLogindScheduledShutdown(ctx)
+
 	// Plan phase - no jobs, timers, or processes, but scheduled file exists
 	mockDbusConnector.On("ListJobsContext", ctx).
 		Return([]baseDbus.JobStatus{}, nil).
@@ -560,6 +746,8 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorScheduledFileDet
 		Return().
 		Once()
 
+	suite.expectLogindClose()
+
 	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
 
 	expectedDiff := map[string]any{
@@ -571,3 +759,486 @@ func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorScheduledFileDet
 	suite.Equal(operator.PLAN, report.Success.LastPhase)
 	suite.EqualValues(expectedDiff, report.Success.Diff)
 }
+
+func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorClusterNodeSuccess() {
+	ctx := context.Background()
+	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
+	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
+
+	suite.expectNoLogindScheduledShutdown(ctx)
+
+	mockDbusConnector.On("ListJobsContext", ctx).
+		Return([]baseDbus.JobStatus{}, nil).
+		Once()
+
+	mockDbusConnector.On("ListUnitsContext", ctx).
+		Return([]baseDbus.UnitStatus{}, nil).
+		Once()
+
+	mockDbusConnector.On("Close").
+		Return().
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "shutdown").
+		Return([]byte(""), errors.New("no process found")).
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "systemd-shutdown").
+		Return([]byte(""), errors.New("no process found")).
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "test", "-f", "/run/systemd/shutdown/scheduled").
+		Return([]byte(""), errors.New("file not found")).
+		Once()
+
+	suite.expectWallMessage(ctx)
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Overrides: []cluster.PreflightCheckName{},
+	}).Return(passedPreflightReport(), nil).Once()
+
+	suite.mockLogind.On("SetWallMessage", ctx, logind.WallMessage{Message: "Host reboot scheduled by automation", Enabled: true}).
+		Return(nil).
+		Once()
+
+	suite.mockLogind.On("ScheduleShutdown", ctx, "reboot", mock.AnythingOfType("time.Time")).
+		Return(nil).
+		Once()
+
+	scheduledTime := time.Date(2026, 3, 1, 22, 1, 0, 0, time.UTC)
+	suite.mockLogind.On("GetScheduledShutdown", ctx).
+		Return(logind.ScheduledShutdown{Type: "reboot", Time: scheduledTime}, nil).
+		Once()
+
+	suite.expectLogindClose()
+
+	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
+
+	expectedDiff := map[string]any{
+		"before":           `{"scheduled":false}`,
+		"after":            `{"scheduled":true,"scheduled_time":"2026-03-01T22:01:00Z"}`,
+		"preflight_checks": passedPreflightChecksDiff,
+	}
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(expectedDiff, report.Success.Diff)
+}
+
+func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorClusterPreflightChecksFailed() {
+	ctx := context.Background()
+	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
+	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
+
+	suite.expectNoLogindScheduledShutdown(ctx)
+
+	mockDbusConnector.On("ListJobsContext", ctx).
+		Return([]baseDbus.JobStatus{}, nil).
+		Once()
+
+	mockDbusConnector.On("ListUnitsContext", ctx).
+		Return([]baseDbus.UnitStatus{}, nil).
+		Once()
+
+	mockDbusConnector.On("Close").
+		Return().
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "shutdown").
+		Return([]byte(""), errors.New("no process found")).
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "systemd-shutdown").
+		Return([]byte(""), errors.New("no process found")).
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "test", "-f", "/run/systemd/shutdown/scheduled").
+		Return([]byte(""), errors.New("file not found")).
+		Once()
+
+	suite.expectWallMessage(ctx)
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Overrides: []cluster.PreflightCheckName{},
+	}).Return(&cluster.PreflightReport{
+		Results: []cluster.PreflightCheckResult{
+			{Name: cluster.PendingFencingCheck, Passed: false, Message: "pending fencing actions found: reboot of vmhana02"},
+		},
+	}, nil).Once()
+
+	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.Equal(
+		"cluster pre-flight checks failed: pending_fencing: pending fencing actions found: reboot of vmhana02",
+		report.Error.Message,
+	)
+}
+
+func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorCustomArgumentsSuccess() {
+	ctx := context.Background()
+	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
+	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
+
+	// Plan phase - check if reboot is already scheduled (it's not)
+	suite.expectNoLogindScheduledShutdown(ctx)
+
+	mockDbusConnector.On("ListJobsContext", ctx).
+		Return([]baseDbus.JobStatus{}, nil).
+		Once()
+
+	mockDbusConnector.On("ListUnitsContext", ctx).
+		Return([]baseDbus.UnitStatus{}, nil).
+		Once()
+
+	mockDbusConnector.On("Close").
+		Return().
+		Once()
+
+	// Check for active shutdown processes (none found)
+	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "shutdown").
+		Return([]byte(""), errors.New("no process found")).
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "systemd-shutdown").
+		Return([]byte(""), errors.New("no process found")).
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "test", "-f", "/run/systemd/shutdown/scheduled").
+		Return([]byte(""), errors.New("file not found")).
+		Once()
+
+	suite.expectWallMessage(ctx)
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Once()
+
+	// Commit phase - schedule reboot at the given time with the given message
+	at := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	suite.mockLogind.On("SetWallMessage", ctx, logind.WallMessage{Message: "Kernel update, rebooting in the maintenance window", Enabled: true}).
+		Return(nil).
+		Once()
+
+	suite.mockLogind.On("ScheduleShutdown", ctx, "reboot", at).
+		Return(nil).
+		Once()
+
+	suite.mockLogind.On("GetScheduledShutdown", ctx).
+		Return(logind.ScheduledShutdown{Type: "reboot", Time: at}, nil).
+		Once()
+
+	suite.expectLogindClose()
+
+	report := buildHostRebootOperatorWithArguments(suite, operator.Arguments{
+		"at":      at.Format(time.RFC3339),
+		"message": "Kernel update, rebooting in the maintenance window",
+	}, mockCmdExecutor, mockDbusConnector).Run(ctx)
+
+	expectedDiff := map[string]any{
+		"before": `{"scheduled":false}`,
+		"after":  `{"scheduled":true,"scheduled_time":"` + at.Format(time.RFC3339) + `"}`,
+	}
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(expectedDiff, report.Success.Diff)
+}
+
+func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorDelaySuccess() {
+	ctx := context.Background()
+	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
+	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
+
+	// Plan phase - check if reboot is already scheduled (it's not)
+	suite.expectNoLogindScheduledShutdown(ctx)
+
+	mockDbusConnector.On("ListJobsContext", ctx).
+		Return([]baseDbus.JobStatus{}, nil).
+		Once()
+
+	mockDbusConnector.On("ListUnitsContext", ctx).
+		Return([]baseDbus.UnitStatus{}, nil).
+		Once()
+
+	mockDbusConnector.On("Close").
+		Return().
+		Once()
+
+	// Check for active shutdown processes (none found)
+	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "shutdown").
+		Return([]byte(""), errors.New("no process found")).
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "systemd-shutdown").
+		Return([]byte(""), errors.New("no process found")).
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "test", "-f", "/run/systemd/shutdown/scheduled").
+		Return([]byte(""), errors.New("file not found")).
+		Once()
+
+	suite.expectWallMessage(ctx)
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Once()
+
+	suite.mockLogind.On("SetWallMessage", ctx, logind.WallMessage{Message: "Host reboot scheduled by automation", Enabled: true}).
+		Return(nil).
+		Once()
+
+	suite.mockLogind.On("ScheduleShutdown", ctx, "reboot", scheduledAfterNow(90*time.Minute)).
+		Return(nil).
+		Once()
+
+	scheduledTime := time.Date(2026, 3, 1, 23, 30, 0, 0, time.UTC)
+	suite.mockLogind.On("GetScheduledShutdown", ctx).
+		Return(logind.ScheduledShutdown{Type: "reboot", Time: scheduledTime}, nil).
+		Once()
+
+	suite.expectLogindClose()
+
+	report := buildHostRebootOperatorWithArguments(suite, operator.Arguments{
+		"delay": float64(90),
+	}, mockCmdExecutor, mockDbusConnector).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.Equal(`{"scheduled":true,"scheduled_time":"2026-03-01T23:30:00Z"}`, report.Success.Diff["after"])
+}
+
+func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorInvalidArguments() {
+	ctx := context.Background()
+
+	cases := []struct {
+		name          string
+		arguments     operator.Arguments
+		expectedError string
+	}{
+		{
+			name:          "delay is not a number",
+			arguments:     operator.Arguments{"delay": "5"},
+			expectedError: "could not parse delay argument as a number, argument provided: 5",
+		},
+		{
+			name:          "delay is not positive",
+			arguments:     operator.Arguments{"delay": float64(0)},
+			expectedError: "invalid delay value: 0, it must be a positive number",
+		},
+		{
+			name:          "delay and at",
+			arguments:     operator.Arguments{"delay": float64(5), "at": "2100-01-01T00:00:00Z"},
+			expectedError: "delay and at arguments cannot be used together",
+		},
+		{
+			name:          "at is not a string",
+			arguments:     operator.Arguments{"at": float64(5)},
+			expectedError: "could not parse at argument as string, argument provided: 5",
+		},
+		{
+			name:      "at is not a RFC3339 time",
+			arguments: operator.Arguments{"at": "tomorrow"},
+			expectedError: "invalid at value: tomorrow, it must be a RFC3339 time: " +
+				`parsing time "tomorrow" as "2006-01-02T15:04:05Z07:00": cannot parse "tomorrow" as "2006"`,
+		},
+		{
+			name:          "at is in the past",
+			arguments:     operator.Arguments{"at": "2020-01-01T00:00:00Z"},
+			expectedError: "invalid at value: 2020-01-01T00:00:00Z, it must be a time in the future",
+		},
+		{
+			name:          "message is not a string",
+			arguments:     operator.Arguments{"message": true},
+			expectedError: "could not parse message argument as string, argument provided: true",
+		},
+		{
+			name:          "immediate is not a bool",
+			arguments:     operator.Arguments{"immediate": "yes"},
+			expectedError: "could not parse immediate argument as bool, argument provided: yes",
+		},
+		{
+			name:          "immediate and delay",
+			arguments:     operator.Arguments{"immediate": true, "delay": float64(5)},
+			expectedError: "immediate argument cannot be used together with delay or at",
+		},
+	}
+
+	for _, tt := range cases {
+		suite.Run(tt.name, func() {
+			report := buildHostRebootOperatorWithArguments(
+				suite,
+				tt.arguments,
+				supportMocks.NewMockCmdExecutor(suite.T()),
+				dbusMocks.NewMockConnector(suite.T()),
+			).Run(ctx)
+
+			suite.Nil(report.Success)
+			suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+			suite.EqualError(errors.New(report.Error.Message), tt.expectedError)
+		})
+	}
+}
+
+func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorAlreadyScheduledInLogind() {
+	ctx := context.Background()
+
+	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
+	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
+
+	// Plan phase - systemd-logind reports the scheduled reboot
+	scheduledTime := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+	suite.mockLogind.On("GetScheduledShutdown", ctx).
+		Return(logind.ScheduledShutdown{Type: "reboot", Time: scheduledTime}, nil).
+		Once()
+
+	suite.expectLogindClose()
+
+	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
+
+	expectedDiff := map[string]any{
+		"before": `{"scheduled":true,"scheduled_time":"2026-03-01T22:00:00Z"}`,
+		"after":  `{"scheduled":true,"scheduled_time":"2026-03-01T22:00:00Z"}`,
+	}
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(expectedDiff, report.Success.Diff)
+}
+
+func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorPoweroffScheduledInLogind() {
+	ctx := context.Background()
+
+	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
+	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
+
+	// Plan phase - systemd-logind reports a scheduled poweroff
+	scheduledTime := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+	suite.mockLogind.On("GetScheduledShutdown", ctx).
+		Return(logind.ScheduledShutdown{Type: "poweroff", Time: scheduledTime}, nil).
+		Once()
+
+	report := buildHostRebootOperator(suite, mockCmdExecutor, mockDbusConnector).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.Equal(
+		"a poweroff is already scheduled at 2026-03-01T22:00:00Z, it must be cancelled before scheduling a reboot",
+		report.Error.Message,
+	)
+}
+
+func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorLogindConnectionError() {
+	ctx := context.Background()
+
+	failingConstructor := func(_ *slog.Logger) (logind.Logind, error) {
+		return nil, errors.New("system bus not available")
+	}
+
+	report := operator.NewHostReboot(
+		operator.Arguments{},
+		"test-op",
+		operator.Options[operator.HostReboot]{
+			BaseOperatorOptions: []operator.BaseOperatorOption{
+				operator.WithCustomLogger(suite.logger),
+			},
+			OperatorOptions: []operator.Option[operator.HostReboot]{
+				operator.Option[operator.HostReboot](operator.WithCustomLogindConstructor(failingConstructor)),
+			},
+		},
+	).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.Equal(
+		"unable to initialize systemd-logind client: system bus not available",
+		report.Error.Message,
+	)
+}
+
+func (suite *HostRebootOperatorTestSuite) TestHostRebootOperatorImmediateRebootVerifyError() {
+	ctx := context.Background()
+	mockCmdExecutor := supportMocks.NewMockCmdExecutor(suite.T())
+	mockDbusConnector := dbusMocks.NewMockConnector(suite.T())
+
+	// Plan phase - check if reboot is already scheduled (it's not)
+	suite.expectNoLogindScheduledShutdown(ctx)
+
+	mockDbusConnector.On("ListJobsContext", ctx).
+		Return([]baseDbus.JobStatus{}, nil).
+		Once()
+
+	mockDbusConnector.On("ListUnitsContext", ctx).
+		Return([]baseDbus.UnitStatus{}, nil).
+		Once()
+
+	mockDbusConnector.On("Close").
+		Return().
+		Once()
+
+	// Check for active shutdown processes (none found)
+	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "shutdown").
+		Return([]byte(""), errors.New("no process found")).
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "systemd-shutdown").
+		Return([]byte(""), errors.New("no process found")).
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "test", "-f", "/run/systemd/shutdown/scheduled").
+		Return([]byte(""), errors.New("file not found")).
+		Once()
+
+	suite.expectWallMessage(ctx)
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Once()
+
+	suite.mockLogind.On("SetWallMessage", ctx, logind.WallMessage{Message: "Rebooting after kernel update", Enabled: true}).
+		Return(nil).
+		Once()
+
+	suite.mockLogind.On("Reboot", ctx).
+		Return(nil).
+		Once()
+
+	// Verify phase - the reboot job is never queued
+	expectUnitEventsSubscription(mockDbusConnector, make(chan dbus.UnitEvent))
+
+	mockDbusConnector.On("ListJobsContext", ctx).
+		Return([]baseDbus.JobStatus{}, nil).
+		Once()
+
+	mockDbusConnector.On("ListUnitsContext", ctx).
+		Return([]baseDbus.UnitStatus{}, nil).
+		Once()
+
+	mockDbusConnector.On("Close").
+		Return().
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "shutdown").
+		Return([]byte(""), errors.New("no process found")).
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "systemd-shutdown").
+		Return([]byte(""), errors.New("no process found")).
+		Once()
+
+	mockCmdExecutor.On("Exec", ctx, "test", "-f", "/run/systemd/shutdown/scheduled").
+		Return([]byte(""), errors.New("file not found")).
+		Once()
+
+	// Rollback phase - nothing to cancel
+	// Rollback phase - the immediate reboot cannot be cancelled, only the wall message is restored
+	suite.expectWallMessageRestored(ctx)
+
+	suite.expectLogindClose()
+
+	report := buildHostRebootOperatorWithArguments(suite, operator.Arguments{
+		"immediate": true,
+		"message":   "Rebooting after kernel update",
+	}, mockCmdExecutor, mockDbusConnector).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.Equal("reboot verification failed: no scheduled reboot found", report.Error.Message)
+}
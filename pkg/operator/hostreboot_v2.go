@@ -0,0 +1,582 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// HostRebootV2 operator schedules a host reboot, like HostReboot, and verifies the host once it is back.
+// It is opt-in, so it has to be requested as hostreboot@v2.
+//
+// The operator accepts the HostReboot arguments and the following optional arguments:
+//   - expected_kernel_version (string): Kernel release expected to be running after the reboot,
+//     e.g. 6.4.0-150600.23.53-default.
+//   - required_units ([]string): Systemd units that must be active after the reboot.
+//   - reboot_operation_id (string): Operation ID of an earlier hostreboot@v2 operation, made of letters,
+//     digits, '-' and '_'. When given, the operator only runs the post-reboot verification of that operation.
+//
+// Before scheduling the reboot, the operator persists a marker file in /var/lib/workbench/reboot,
+// with the current boot ID, read from /proc/sys/kernel/random/boot_id, and the expected checks.
+// Running the operator again with the same operation ID, or with the reboot_operation_id argument,
+// once the host is back finds the marker and runs the post-reboot verification.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   Reads the marker of the operation. If it exists and the boot ID has changed, the host has
+//   been rebooted and the post-reboot verification is run in the next phases.
+//   Otherwise, the HostReboot plan is run. When reboot_operation_id is given, the operation fails
+//   if the marker is not found or the host has not been rebooted yet.
+//
+// - COMMIT:
+//   Writes the marker file and schedules the reboot as HostReboot does.
+//   Nothing is done in the post-reboot verification, as the host is already rebooted.
+//
+// - VERIFY:
+//   Verifies that the reboot is scheduled as HostReboot does.
+//   In the post-reboot verification, the post-reboot checks are run: the boot ID is new, the running
+//   kernel is the expected one, the required units are active and the host has rejoined the cluster,
+//   if it was online in the cluster when the reboot was scheduled. The marker is removed if all the
+//   checks pass.
+//
+// - ROLLBACK:
+//   Cancels the scheduled reboot as HostReboot does and removes the marker file.
+//   Nothing is done in the post-reboot verification, so the marker is kept and the verification
+//   can be retried.
+//
+// # Details
+//
+// The post-reboot verification diff includes the boot ID and kernel version before and after the reboot,
+// the results of the post-reboot checks and the ID of the operation that scheduled the reboot.
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/trento-project/workbench/internal/support"
+	"github.com/trento-project/workbench/internal/systemd"
+)
+
+const (
+	defaultRebootMarkerDirectory = "/var/lib/workbench/reboot"
+	defaultBootIDPath            = "/proc/sys/kernel/random/boot_id"
+	defaultKernelReleasePath     = "/proc/sys/kernel/osrelease"
+	postRebootChecksDiffField    = "post_reboot_checks"
+	rebootOperationIDDiffField   = "reboot_operation_id"
+
+	newBootCheck         = "new_boot"
+	kernelVersionCheck   = "kernel_version"
+	unitsActiveCheck     = "units_active"
+	clusterRejoinedCheck = "cluster_rejoined"
+)
+
+// rebootOperationIDPatternCompiled matches the operation IDs allowed in the reboot marker file name,
+// preventing them from pointing outside the marker directory
+var rebootOperationIDPatternCompiled = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+type HostRebootV2 struct {
+	baseOperator
+	scheduler         *HostReboot
+	operationID       string
+	systemdLoader     systemd.Loader
+	systemdConnector  systemd.Systemd
+	retryOptions      support.BackoffOptions
+	markerDirectory   string
+	bootIDPath        string
+	kernelReleasePath string
+	parsedArguments   *hostRebootV2Arguments
+	marker            *rebootMarker
+	bootID            string
+	// postReboot is true if the host has been rebooted since the marker was written
+	postReboot   bool
+	afterReboot  postRebootDiffOutput
+	checkResults []postRebootCheckResult
+}
+
+type HostRebootV2Option Option[HostRebootV2]
+
+type hostRebootV2Arguments struct {
+	expectedKernelVersion string
+	requiredUnits         []string
+	rebootOperationID     string
+}
+
+// rebootMarker is persisted before scheduling the reboot, so the host can be verified once it is back
+type rebootMarker struct {
+	OperationID           string   `json:"operation_id"`
+	BootID                string   `json:"boot_id"`
+	KernelVersion         string   `json:"kernel_version"`
+	ExpectedKernelVersion string   `json:"expected_kernel_version,omitempty"`
+	RequiredUnits         []string `json:"required_units,omitempty"`
+	ClusterRejoin         bool     `json:"cluster_rejoin"`
+}
+
+type postRebootCheckResult struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+type postRebootDiffOutput struct {
+	BootID        string            `json:"boot_id"`
+	KernelVersion string            `json:"kernel_version"`
+	Units         map[string]string `json:"units,omitempty"`
+	ClusterOnline *bool             `json:"cluster_online,omitempty"`
+}
+
+// WithHostRebootV2SchedulerOptions sets the HostReboot options used to schedule the reboot
+func WithHostRebootV2SchedulerOptions(options ...HostRebootOption) HostRebootV2Option {
+	return func(o *HostRebootV2) {
+		for _, opt := range options {
+			opt(o.scheduler)
+		}
+	}
+}
+
+func WithCustomHostRebootV2SystemdLoader(systemdLoader systemd.Loader) HostRebootV2Option {
+	return func(o *HostRebootV2) {
+		o.systemdLoader = systemdLoader
+	}
+}
+
+func WithCustomRetryRebootV2(maxRetries int, initialDelay, maxDelay time.Duration, factor int) HostRebootV2Option {
+	return func(o *HostRebootV2) {
+		o.retryOptions = support.BackoffOptions{
+			InitialDelay: initialDelay,
+			MaxDelay:     maxDelay,
+			MaxRetries:   maxRetries,
+			Factor:       factor,
+		}
+	}
+}
+
+func WithCustomRebootMarkerDirectory(directory string) HostRebootV2Option {
+	return func(o *HostRebootV2) {
+		o.markerDirectory = directory
+	}
+}
+
+// WithCustomKernelPaths sets the files where the boot ID and the running kernel release are read from
+func WithCustomKernelPaths(bootIDPath, kernelReleasePath string) HostRebootV2Option {
+	return func(o *HostRebootV2) {
+		o.bootIDPath = bootIDPath
+		o.kernelReleasePath = kernelReleasePath
+	}
+}
+
+func NewHostRebootV2(arguments Arguments,
+	operationID string,
+	options Options[HostRebootV2]) *Executor {
+	hostReboot := &HostRebootV2{
+		baseOperator: newBaseOperator(
+			HostRebootOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+		scheduler: newHostReboot(arguments, operationID, Options[HostReboot]{
+			BaseOperatorOptions: options.BaseOperatorOptions,
+		}),
+		operationID:   operationID,
+		systemdLoader: systemd.NewDefaultSystemdLoader(),
+		// the cluster stack takes a while to start after the boot
+		retryOptions: support.BackoffOptions{
+			InitialDelay: 2 * time.Second,
+			MaxDelay:     1 * time.Minute,
+			MaxRetries:   8,
+			Factor:       2,
+		},
+		markerDirectory:   defaultRebootMarkerDirectory,
+		bootIDPath:        defaultBootIDPath,
+		kernelReleasePath: defaultKernelReleasePath,
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(hostReboot)
+	}
+
+	return &Executor{
+		phaser:      hostReboot,
+		operationID: operationID,
+		logger:      hostReboot.logger,
+	}
+}
+
+func (h *HostRebootV2) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseHostRebootV2Arguments(h.arguments)
+	if err != nil {
+		return false, err
+	}
+	h.parsedArguments = opArguments
+
+	markerOperationID := h.operationID
+	if opArguments.rebootOperationID != "" {
+		markerOperationID = opArguments.rebootOperationID
+	}
+
+	path, err := h.markerPath(markerOperationID)
+	if err != nil {
+		return false, err
+	}
+
+	h.marker, err = readRebootMarker(path)
+	if err != nil {
+		return false, err
+	}
+
+	h.bootID, err = readKernelValue(h.bootIDPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read boot ID: %w", err)
+	}
+
+	h.postReboot = h.marker != nil && h.marker.BootID != h.bootID
+
+	if opArguments.rebootOperationID != "" && !h.postReboot {
+		if h.marker == nil {
+			return false, fmt.Errorf("no reboot marker found for operation %s", markerOperationID)
+		}
+		return false, fmt.Errorf("host has not been rebooted since operation %s", markerOperationID)
+	}
+
+	if !h.postReboot {
+		return h.scheduler.plan(ctx)
+	}
+
+	h.logger.Info("Host rebooted, running post-reboot verification",
+		"reboot_operation_id", h.marker.OperationID, "boot_id", h.bootID)
+
+	if len(h.marker.RequiredUnits) > 0 {
+		systemdConnector, err := h.systemdLoader.NewSystemd(ctx, h.logger)
+		if err != nil {
+			h.logger.Error("unable to initialize systemd connector", "error", err)
+			return false, fmt.Errorf("unable to initialize systemd connector: %w", err)
+		}
+		h.systemdConnector = systemdConnector
+	}
+
+	return false, nil
+}
+
+func (h *HostRebootV2) commit(ctx context.Context) error {
+	if h.postReboot {
+		return nil
+	}
+
+	if err := h.writeRebootMarker(); err != nil {
+		return err
+	}
+
+	return h.scheduler.commit(ctx)
+}
+
+func (h *HostRebootV2) verify(ctx context.Context) error {
+	if !h.postReboot {
+		return h.scheduler.verify(ctx)
+	}
+
+	if err := h.runPostRebootChecks(ctx); err != nil {
+		return withDiff(err, h.operationDiff(ctx))
+	}
+
+	path, err := h.markerPath(h.marker.OperationID)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove reboot marker %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (h *HostRebootV2) rollback(ctx context.Context) error {
+	if h.postReboot {
+		h.logger.Info("Post-reboot verification failed, keeping the reboot marker",
+			"reboot_operation_id", h.marker.OperationID)
+		return nil
+	}
+
+	rollbackErr := h.scheduler.rollback(ctx)
+	path, err := h.markerPath(h.operationID)
+	if err != nil {
+		return errors.Join(rollbackErr, err)
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		rollbackErr = errors.Join(rollbackErr, err)
+	}
+
+	return rollbackErr
+}
+
+func (h *HostRebootV2) operationDiff(ctx context.Context) map[string]any {
+	if !h.postReboot {
+		return h.scheduler.operationDiff(ctx)
+	}
+
+	diff := make(map[string]any)
+
+	before, err := json.Marshal(postRebootDiffOutput{
+		BootID:        h.marker.BootID,
+		KernelVersion: h.marker.KernelVersion,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("error marshalling before diff output: %v", err))
+	}
+	diff["before"] = string(before)
+
+	after, err := json.Marshal(h.afterReboot)
+	if err != nil {
+		panic(fmt.Sprintf("error marshalling after diff output: %v", err))
+	}
+	diff["after"] = string(after)
+
+	checks, err := json.Marshal(h.checkResults)
+	if err != nil {
+		panic(fmt.Sprintf("error marshalling post-reboot checks diff output: %v", err))
+	}
+	diff[postRebootChecksDiffField] = string(checks)
+	diff[rebootOperationIDDiffField] = h.marker.OperationID
+
+	return diff
+}
+
+func (h *HostRebootV2) after(ctx context.Context) {
+	h.scheduler.after(ctx)
+	if h.systemdConnector != nil {
+		h.systemdConnector.Close()
+	}
+}
+
+// runPostRebootChecks checks the host state after the reboot against the marker,
+// returning an error describing the failed checks
+func (h *HostRebootV2) runPostRebootChecks(ctx context.Context) error {
+	kernelVersion, err := readKernelValue(h.kernelReleasePath)
+	if err != nil {
+		return fmt.Errorf("failed to read kernel version: %w", err)
+	}
+
+	h.afterReboot = postRebootDiffOutput{
+		BootID:        h.bootID,
+		KernelVersion: kernelVersion,
+	}
+
+	h.checkResults = []postRebootCheckResult{{
+		Name:   newBootCheck,
+		Passed: h.bootID != h.marker.BootID,
+	}}
+
+	if expected := h.marker.ExpectedKernelVersion; expected != "" {
+		result := postRebootCheckResult{Name: kernelVersionCheck, Passed: kernelVersion == expected}
+		if !result.Passed {
+			result.Message = fmt.Sprintf("expected kernel version %s, running %s", expected, kernelVersion)
+		}
+		h.checkResults = append(h.checkResults, result)
+	}
+
+	if len(h.marker.RequiredUnits) > 0 {
+		h.checkResults = append(h.checkResults, h.checkRequiredUnits(ctx))
+	}
+
+	if h.marker.ClusterRejoin {
+		h.checkResults = append(h.checkResults, h.checkClusterRejoined(ctx))
+	}
+
+	reasons := []string{}
+	for _, result := range h.checkResults {
+		if !result.Passed {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", result.Name, result.Message))
+		}
+	}
+
+	if len(reasons) > 0 {
+		return fmt.Errorf("post-reboot checks failed: %s", strings.Join(reasons, "; "))
+	}
+
+	return nil
+}
+
+func (h *HostRebootV2) checkRequiredUnits(ctx context.Context) postRebootCheckResult {
+	h.afterReboot.Units = make(map[string]string)
+	notActive := []string{}
+
+	for _, unit := range h.marker.RequiredUnits {
+		// units might still be starting right after the boot
+		activeState, err := h.systemdConnector.WaitForActiveState(ctx, unit, systemd.WaitForActiveStateOptions{
+			States:  []string{unitActive},
+			Timeout: defaultServiceStateTimeout,
+		})
+		h.afterReboot.Units[unit] = activeState
+		if err != nil {
+			h.logger.Info("required unit is not active", "unit", unit, "state", activeState, "error", err)
+			notActive = append(notActive, fmt.Sprintf("%s is %s", unit, activeState))
+		}
+	}
+
+	if len(notActive) > 0 {
+		return postRebootCheckResult{
+			Name:    unitsActiveCheck,
+			Message: strings.Join(notActive, ", "),
+		}
+	}
+
+	return postRebootCheckResult{Name: unitsActiveCheck, Passed: true}
+}
+
+func (h *HostRebootV2) checkClusterRejoined(ctx context.Context) postRebootCheckResult {
+	result := <-support.AsyncExponentialBackoff(
+		ctx,
+		h.retryOptions,
+		func() (bool, error) {
+			if !h.scheduler.clusterClient.IsHostOnline(ctx) {
+				return false, errors.New("host has not rejoined the cluster")
+			}
+			return true, nil
+		},
+	)
+
+	clusterOnline := result.Err == nil
+	h.afterReboot.ClusterOnline = &clusterOnline
+
+	if result.Err != nil {
+		return postRebootCheckResult{Name: clusterRejoinedCheck, Message: result.Err.Error()}
+	}
+
+	return postRebootCheckResult{Name: clusterRejoinedCheck, Passed: true}
+}
+
+func (h *HostRebootV2) writeRebootMarker() error {
+	kernelVersion, err := readKernelValue(h.kernelReleasePath)
+	if err != nil {
+		return fmt.Errorf("failed to read kernel version: %w", err)
+	}
+
+	content, err := json.Marshal(rebootMarker{
+		OperationID:           h.operationID,
+		BootID:                h.bootID,
+		KernelVersion:         kernelVersion,
+		ExpectedKernelVersion: h.parsedArguments.expectedKernelVersion,
+		RequiredUnits:         h.parsedArguments.requiredUnits,
+		ClusterRejoin:         h.scheduler.clusterOnline,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshalling reboot marker: %w", err)
+	}
+
+	if err := os.MkdirAll(h.markerDirectory, 0o700); err != nil {
+		return fmt.Errorf("error creating reboot marker directory: %w", err)
+	}
+
+	path, err := h.markerPath(h.operationID)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		return fmt.Errorf("failed to write reboot marker %s: %w", path, err)
+	}
+
+	h.logger.Info("Reboot marker written", "path", path, "boot_id", h.bootID)
+
+	return nil
+}
+
+func (h *HostRebootV2) markerPath(operationID string) (string, error) {
+	if !rebootOperationIDPatternCompiled.MatchString(operationID) {
+		return "", fmt.Errorf("invalid operation ID %s for the reboot marker", operationID)
+	}
+	return filepath.Join(h.markerDirectory, fmt.Sprintf("reboot-%s.json", operationID)), nil
+}
+
+// readRebootMarker reads the marker file, returning nil if it doesn't exist
+func readRebootMarker(path string) (*rebootMarker, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reboot marker %s: %w", path, err)
+	}
+
+	marker := &rebootMarker{}
+	if err := json.Unmarshal(content, marker); err != nil {
+		return nil, fmt.Errorf("invalid reboot marker %s: %w", path, err)
+	}
+
+	return marker, nil
+}
+
+func readKernelValue(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+func parseHostRebootV2Arguments(rawArguments Arguments) (*hostRebootV2Arguments, error) {
+	opArguments := &hostRebootV2Arguments{
+		requiredUnits: []string{},
+	}
+
+	if argument, found := rawArguments["expected_kernel_version"]; found {
+		kernelVersion, ok := argument.(string)
+		if !ok || kernelVersion == "" {
+			return nil, fmt.Errorf(
+				"could not parse expected_kernel_version argument as string, argument provided: %v",
+				argument,
+			)
+		}
+		opArguments.expectedKernelVersion = kernelVersion
+	}
+
+	if argument, found := rawArguments["reboot_operation_id"]; found {
+		operationID, ok := argument.(string)
+		if !ok || operationID == "" {
+			return nil, fmt.Errorf(
+				"could not parse reboot_operation_id argument as string, argument provided: %v",
+				argument,
+			)
+		}
+		if !rebootOperationIDPatternCompiled.MatchString(operationID) {
+			return nil, fmt.Errorf(
+				"invalid reboot_operation_id value %s, only letters, digits, '-' and '_' are allowed",
+				operationID,
+			)
+		}
+		opArguments.rebootOperationID = operationID
+	}
+
+	argument, found := rawArguments["required_units"]
+	if !found {
+		return opArguments, nil
+	}
+
+	var rawUnits []any
+	switch value := argument.(type) {
+	case []any:
+		rawUnits = value
+	case []string:
+		for _, unit := range value {
+			rawUnits = append(rawUnits, unit)
+		}
+	default:
+		return nil, fmt.Errorf("could not parse required_units argument as a list, argument provided: %v", argument)
+	}
+
+	for _, rawUnit := range rawUnits {
+		unit, ok := rawUnit.(string)
+		if !ok || unit == "" {
+			return nil, fmt.Errorf("invalid required_units value: %v", rawUnit)
+		}
+		if !slices.Contains(opArguments.requiredUnits, unit) {
+			opArguments.requiredUnits = append(opArguments.requiredUnits, unit)
+		}
+	}
+
+	return opArguments, nil
+}
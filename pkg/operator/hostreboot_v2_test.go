@@ -0,0 +1,464 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	clusterMocks "github.com/trento-project/workbench/internal/cluster/mocks"
+	dbusMocks "github.com/trento-project/workbench/internal/dbus/mocks"
+	"github.com/trento-project/workbench/internal/logind"
+	logindMocks "github.com/trento-project/workbench/internal/logind/mocks"
+	"github.com/trento-project/workbench/internal/support"
+	supportMocks "github.com/trento-project/workbench/internal/support/mocks"
+	"github.com/trento-project/workbench/internal/systemd"
+	systemdMocks "github.com/trento-project/workbench/internal/systemd/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+
+	baseDbus "github.com/coreos/go-systemd/v22/dbus"
+)
+
+const (
+	previousBootID = "0a6e8e8c-8f5b-4d3e-9a41-7b3c0f6a1d11"
+	currentBootID  = "5d2f1c3b-2e4a-4b6c-8d7e-9f0a1b2c3d44"
+	oldKernel      = "6.4.0-150600.23.50-default"
+	newKernel      = "6.4.0-150600.23.53-default"
+)
+
+type HostRebootV2OperatorTestSuite struct {
+	suite.Suite
+	logger            *slog.Logger
+	mockCmdExecutor   *supportMocks.MockCmdExecutor
+	mockDbusConnector *dbusMocks.MockConnector
+	mockClusterClient *clusterMocks.MockCluster
+	mockLogind        *logindMocks.MockLogind
+	mockSystemdLoader *systemdMocks.MockLoader
+	mockSystemd       *systemdMocks.MockSystemd
+	markerDirectory   string
+	bootIDPath        string
+	kernelReleasePath string
+}
+
+func TestHostRebootV2Operator(t *testing.T) {
+	suite.Run(t, new(HostRebootV2OperatorTestSuite))
+}
+
+func (suite *HostRebootV2OperatorTestSuite) SetupTest() {
+	suite.logger = support.NewDefaultLogger(slog.LevelInfo)
+	suite.mockCmdExecutor = supportMocks.NewMockCmdExecutor(suite.T())
+	suite.mockDbusConnector = dbusMocks.NewMockConnector(suite.T())
+	suite.mockClusterClient = clusterMocks.NewMockCluster(suite.T())
+	suite.mockLogind = logindMocks.NewMockLogind(suite.T())
+	suite.mockSystemdLoader = systemdMocks.NewMockLoader(suite.T())
+	suite.mockSystemd = systemdMocks.NewMockSystemd(suite.T())
+
+	tempDir := suite.T().TempDir()
+	suite.markerDirectory = filepath.Join(tempDir, "reboot")
+	suite.bootIDPath = filepath.Join(tempDir, "boot_id")
+	suite.kernelReleasePath = filepath.Join(tempDir, "osrelease")
+}
+
+func (suite *HostRebootV2OperatorTestSuite) buildOperator(operationID string, arguments operator.Arguments) *operator.Executor {
+	return operator.NewHostRebootV2(
+		arguments,
+		operationID,
+		operator.Options[operator.HostRebootV2]{
+			BaseOperatorOptions: []operator.BaseOperatorOption{
+				operator.WithCustomLogger(suite.logger),
+			},
+			OperatorOptions: []operator.Option[operator.HostRebootV2]{
+				operator.Option[operator.HostRebootV2](operator.WithHostRebootV2SchedulerOptions(
+					operator.WithCustomHostRebootExecutor(suite.mockCmdExecutor),
+					operator.WithStaticDbusConnector(suite.mockDbusConnector),
+					operator.WithCustomHostRebootClusterClient(suite.mockClusterClient),
+					operator.WithStaticLogind(suite.mockLogind),
+				)),
+				operator.Option[operator.HostRebootV2](operator.WithCustomHostRebootV2SystemdLoader(suite.mockSystemdLoader)),
+				operator.Option[operator.HostRebootV2](operator.WithCustomRetryRebootV2(2, 0, 0, 1)),
+				operator.Option[operator.HostRebootV2](operator.WithCustomRebootMarkerDirectory(suite.markerDirectory)),
+				operator.Option[operator.HostRebootV2](
+					operator.WithCustomKernelPaths(suite.bootIDPath, suite.kernelReleasePath),
+				),
+			},
+		},
+	)
+}
+
+func (suite *HostRebootV2OperatorTestSuite) setHostState(bootID, kernelVersion string) {
+	suite.Require().NoError(os.WriteFile(suite.bootIDPath, []byte(bootID+"\n"), 0o600))
+	suite.Require().NoError(os.WriteFile(suite.kernelReleasePath, []byte(kernelVersion+"\n"), 0o600))
+}
+
+func (suite *HostRebootV2OperatorTestSuite) writeMarker(operationID string, content string) {
+	suite.Require().NoError(os.MkdirAll(suite.markerDirectory, 0o700))
+	suite.Require().NoError(os.WriteFile(suite.markerPath(operationID), []byte(content), 0o600))
+}
+
+func (suite *HostRebootV2OperatorTestSuite) markerPath(operationID string) string {
+	return filepath.Join(suite.markerDirectory, "reboot-"+operationID+".json")
+}
+
+func (suite *HostRebootV2OperatorTestSuite) expectNoScheduledReboot(ctx context.Context) {
+	suite.mockLogind.On("GetScheduledShutdown", ctx).
+		Return(logind.ScheduledShutdown{}, nil).
+		Once()
+
+	suite.mockLogind.On("GetWallMessage", ctx).
+		Return(previousWallMessage, nil).
+		Once()
+
+	suite.mockDbusConnector.On("ListJobsContext", ctx).
+		Return([]baseDbus.JobStatus{}, nil).
+		Once()
+
+	suite.mockDbusConnector.On("ListUnitsContext", ctx).
+		Return([]baseDbus.UnitStatus{}, nil).
+		Once()
+
+	suite.mockDbusConnector.On("Close").
+		Return().
+		Once()
+
+	suite.mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "shutdown").
+		Return([]byte(""), errors.New("no process found")).
+		Once()
+
+	suite.mockCmdExecutor.On("Exec", ctx, "pgrep", "-f", "systemd-shutdown").
+		Return([]byte(""), errors.New("no process found")).
+		Once()
+
+	suite.mockCmdExecutor.On("Exec", ctx, "test", "-f", "/run/systemd/shutdown/scheduled").
+		Return([]byte(""), errors.New("file not found")).
+		Once()
+}
+
+func (suite *HostRebootV2OperatorTestSuite) TestHostRebootV2ScheduleWritesMarker() {
+	ctx := context.Background()
+	suite.setHostState(previousBootID, oldKernel)
+
+	suite.expectNoScheduledReboot(ctx)
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+	suite.mockClusterClient.On("RunPreflightChecks", ctx, cluster.PreflightOptions{
+		Overrides: []cluster.PreflightCheckName{},
+	}).Return(passedPreflightReport(), nil).Once()
+
+	suite.mockLogind.On("SetWallMessage", ctx, logind.WallMessage{Message: "Host reboot scheduled by automation", Enabled: true}).
+		Return(nil).
+		Once()
+
+	suite.mockLogind.On("ScheduleShutdown", ctx, "reboot", mock.AnythingOfType("time.Time")).
+		Return(nil).
+		Once()
+
+	scheduledTime := time.Date(2026, 3, 1, 22, 1, 0, 0, time.UTC)
+	suite.mockLogind.On("GetScheduledShutdown", ctx).
+		Return(logind.ScheduledShutdown{Type: "reboot", Time: scheduledTime}, nil).
+		Once()
+
+	suite.mockLogind.On("Close").Return().Once()
+
+	report := suite.buildOperator("reboot-op", operator.Arguments{
+		"expected_kernel_version": newKernel,
+		"required_units":          []any{"pacemaker.service", "sbd.service"},
+	}).Run(ctx)
+
+	expectedDiff := map[string]any{
+		"before":           `{"scheduled":false}`,
+		"after":            `{"scheduled":true,"scheduled_time":"2026-03-01T22:01:00Z"}`,
+		"preflight_checks": passedPreflightChecksDiff,
+	}
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(expectedDiff, report.Success.Diff)
+
+	content, err := os.ReadFile(suite.markerPath("reboot-op"))
+	suite.Require().NoError(err)
+
+	var marker map[string]any
+	suite.Require().NoError(json.Unmarshal(content, &marker))
+	suite.Equal(map[string]any{
+		"operation_id":            "reboot-op",
+		"boot_id":                 previousBootID,
+		"kernel_version":          oldKernel,
+		"expected_kernel_version": newKernel,
+		"required_units":          []any{"pacemaker.service", "sbd.service"},
+		"cluster_rejoin":          true,
+	}, marker)
+}
+
+func (suite *HostRebootV2OperatorTestSuite) TestHostRebootV2ScheduleRollbackRemovesMarker() {
+	ctx := context.Background()
+	suite.setHostState(previousBootID, oldKernel)
+
+	suite.expectNoScheduledReboot(ctx)
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Once()
+
+	suite.mockLogind.On("SetWallMessage", ctx, logind.WallMessage{Message: "Host reboot scheduled by automation", Enabled: true}).
+		Return(nil).
+		Once()
+
+	suite.mockLogind.On("ScheduleShutdown", ctx, "reboot", mock.AnythingOfType("time.Time")).
+		Return(errors.New("failed to schedule reboot")).
+		Once()
+
+	suite.mockLogind.On("CancelScheduledShutdown", ctx).
+		Return(false, nil).
+		Once()
+
+	suite.mockLogind.On("SetWallMessage", ctx, previousWallMessage).
+		Return(nil).
+		Once()
+
+	suite.mockLogind.On("Close").Return().Once()
+
+	report := suite.buildOperator("reboot-op", operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.Equal("failed to schedule reboot", report.Error.Message)
+	suite.NoFileExists(suite.markerPath("reboot-op"))
+}
+
+func (suite *HostRebootV2OperatorTestSuite) TestHostRebootV2PostRebootVerificationSuccess() {
+	ctx := context.Background()
+	suite.setHostState(currentBootID, newKernel)
+	suite.writeMarker("reboot-op", `{
+		"operation_id": "reboot-op",
+		"boot_id": "`+previousBootID+`",
+		"kernel_version": "`+oldKernel+`",
+		"expected_kernel_version": "`+newKernel+`",
+		"required_units": ["pacemaker.service"],
+		"cluster_rejoin": true
+	}`)
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	suite.mockSystemd.On("WaitForActiveState", ctx, "pacemaker.service", systemd.WaitForActiveStateOptions{
+		States:  []string{"active"},
+		Timeout: 30 * time.Second,
+	}).Return("active", nil).Once()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Once()
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(true).Once()
+
+	suite.mockSystemd.On("Close").Return().Once()
+
+	report := suite.buildOperator("verify-op", operator.Arguments{
+		"reboot_operation_id": "reboot-op",
+	}).Run(ctx)
+
+	expectedDiff := map[string]any{
+		"before": `{"boot_id":"` + previousBootID + `","kernel_version":"` + oldKernel + `"}`,
+		"after": `{"boot_id":"` + currentBootID + `","kernel_version":"` + newKernel + `",` +
+			`"units":{"pacemaker.service":"active"},"cluster_online":true}`,
+		"post_reboot_checks": `[{"name":"new_boot","passed":true},{"name":"kernel_version","passed":true},` +
+			`{"name":"units_active","passed":true},{"name":"cluster_rejoined","passed":true}]`,
+		"reboot_operation_id": "reboot-op",
+	}
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(expectedDiff, report.Success.Diff)
+	suite.NoFileExists(suite.markerPath("reboot-op"))
+}
+
+func (suite *HostRebootV2OperatorTestSuite) TestHostRebootV2PostRebootVerificationSameOperation() {
+	ctx := context.Background()
+	suite.setHostState(currentBootID, newKernel)
+	suite.writeMarker("reboot-op", `{
+		"operation_id": "reboot-op",
+		"boot_id": "`+previousBootID+`",
+		"kernel_version": "`+oldKernel+`",
+		"cluster_rejoin": false
+	}`)
+
+	report := suite.buildOperator("reboot-op", operator.Arguments{}).Run(ctx)
+
+	expectedDiff := map[string]any{
+		"before":              `{"boot_id":"` + previousBootID + `","kernel_version":"` + oldKernel + `"}`,
+		"after":               `{"boot_id":"` + currentBootID + `","kernel_version":"` + newKernel + `"}`,
+		"post_reboot_checks":  `[{"name":"new_boot","passed":true}]`,
+		"reboot_operation_id": "reboot-op",
+	}
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(expectedDiff, report.Success.Diff)
+	suite.NoFileExists(suite.markerPath("reboot-op"))
+}
+
+func (suite *HostRebootV2OperatorTestSuite) TestHostRebootV2PostRebootChecksFailed() {
+	ctx := context.Background()
+	suite.setHostState(currentBootID, oldKernel)
+	suite.writeMarker("reboot-op", `{
+		"operation_id": "reboot-op",
+		"boot_id": "`+previousBootID+`",
+		"kernel_version": "`+oldKernel+`",
+		"expected_kernel_version": "`+newKernel+`",
+		"required_units": ["pacemaker.service", "sbd.service"],
+		"cluster_rejoin": true
+	}`)
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	suite.mockSystemd.On("WaitForActiveState", ctx, "pacemaker.service", mock.Anything).
+		Return("activating", errors.New("timeout waiting for service pacemaker.service to be active")).
+		Once()
+
+	suite.mockSystemd.On("WaitForActiveState", ctx, "sbd.service", mock.Anything).
+		Return("active", nil).
+		Once()
+
+	suite.mockClusterClient.On("IsHostOnline", ctx).Return(false).Twice()
+
+	suite.mockSystemd.On("Close").Return().Once()
+
+	report := suite.buildOperator("verify-op", operator.Arguments{
+		"reboot_operation_id": "reboot-op",
+	}).Run(ctx)
+
+	expectedDiff := map[string]any{
+		"before": `{"boot_id":"` + previousBootID + `","kernel_version":"` + oldKernel + `"}`,
+		"after": `{"boot_id":"` + currentBootID + `","kernel_version":"` + oldKernel + `",` +
+			`"units":{"pacemaker.service":"activating","sbd.service":"active"},"cluster_online":false}`,
+		"post_reboot_checks": `[{"name":"new_boot","passed":true},` +
+			`{"name":"kernel_version","passed":false,` +
+			`"message":"expected kernel version ` + newKernel + `, running ` + oldKernel + `"},` +
+			`{"name":"units_active","passed":false,"message":"pacemaker.service is activating"},` +
+			`{"name":"cluster_rejoined","passed":false,` +
+			`"message":"operation failed after 2 attempts: host has not rejoined the cluster"}]`,
+		"reboot_operation_id": "reboot-op",
+	}
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.Equal(
+		"post-reboot checks failed: "+
+			"kernel_version: expected kernel version "+newKernel+", running "+oldKernel+"; "+
+			"units_active: pacemaker.service is activating; "+
+			"cluster_rejoined: operation failed after 2 attempts: host has not rejoined the cluster",
+		report.Error.Message,
+	)
+	suite.EqualValues(expectedDiff, report.Error.Diff)
+	suite.FileExists(suite.markerPath("reboot-op"))
+}
+
+func (suite *HostRebootV2OperatorTestSuite) TestHostRebootV2InvalidOperationID() {
+	ctx := context.Background()
+	suite.setHostState(currentBootID, newKernel)
+
+	report := suite.buildOperator("../reboot-op", operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.Equal("invalid operation ID ../reboot-op for the reboot marker", report.Error.Message)
+}
+
+func (suite *HostRebootV2OperatorTestSuite) TestHostRebootV2NotRebootedYet() {
+	ctx := context.Background()
+	suite.setHostState(previousBootID, oldKernel)
+	suite.writeMarker("reboot-op", `{
+		"operation_id": "reboot-op",
+		"boot_id": "`+previousBootID+`",
+		"kernel_version": "`+oldKernel+`",
+		"cluster_rejoin": false
+	}`)
+
+	report := suite.buildOperator("verify-op", operator.Arguments{
+		"reboot_operation_id": "reboot-op",
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.Equal("host has not been rebooted since operation reboot-op", report.Error.Message)
+}
+
+func (suite *HostRebootV2OperatorTestSuite) TestHostRebootV2MarkerNotFound() {
+	ctx := context.Background()
+	suite.setHostState(currentBootID, newKernel)
+
+	report := suite.buildOperator("verify-op", operator.Arguments{
+		"reboot_operation_id": "reboot-op",
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.Equal("no reboot marker found for operation reboot-op", report.Error.Message)
+}
+
+func (suite *HostRebootV2OperatorTestSuite) TestHostRebootV2InvalidMarker() {
+	ctx := context.Background()
+	suite.setHostState(currentBootID, newKernel)
+	suite.writeMarker("reboot-op", "not a json")
+
+	report := suite.buildOperator("reboot-op", operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.Contains(report.Error.Message, "invalid reboot marker "+suite.markerPath("reboot-op"))
+}
+
+func (suite *HostRebootV2OperatorTestSuite) TestHostRebootV2InvalidArguments() {
+	ctx := context.Background()
+
+	cases := []struct {
+		name          string
+		arguments     operator.Arguments
+		expectedError string
+	}{
+		{
+			name:          "expected kernel version is not a string",
+			arguments:     operator.Arguments{"expected_kernel_version": float64(6)},
+			expectedError: "could not parse expected_kernel_version argument as string, argument provided: 6",
+		},
+		{
+			name:          "reboot operation ID is empty",
+			arguments:     operator.Arguments{"reboot_operation_id": ""},
+			expectedError: "could not parse reboot_operation_id argument as string, argument provided: ",
+		},
+		{
+			name:      "reboot operation ID is a path",
+			arguments: operator.Arguments{"reboot_operation_id": "../../../etc/config"},
+			expectedError: "invalid reboot_operation_id value ../../../etc/config, " +
+				"only letters, digits, '-' and '_' are allowed",
+		},
+		{
+			name:          "required units is not a list",
+			arguments:     operator.Arguments{"required_units": "pacemaker.service"},
+			expectedError: "could not parse required_units argument as a list, argument provided: pacemaker.service",
+		},
+		{
+			name:          "required unit is not a string",
+			arguments:     operator.Arguments{"required_units": []any{"pacemaker.service", true}},
+			expectedError: "invalid required_units value: true",
+		},
+	}
+
+	for _, tt := range cases {
+		suite.Run(tt.name, func() {
+			report := suite.buildOperator("reboot-op", tt.arguments).Run(ctx)
+
+			suite.Nil(report.Success)
+			suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+			suite.Equal(tt.expectedError, report.Error.Message)
+		})
+	}
+}
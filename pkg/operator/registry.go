@@ -39,12 +39,30 @@ func extractOperatorNameAndVersion(operatorName string) (string, string, error)
 
 type Registry struct {
 	operators BuildersTree
+	// map[operatorName]operatorVersion used when the operator is requested without version
+	defaultVersions map[string]string
 }
 
-func NewRegistry(operators BuildersTree) *Registry {
-	return &Registry{
+type RegistryOption func(*Registry)
+
+// WithDefaultVersions pins the version used when an operator is requested without version.
+// Operators not included in the map default to their latest version.
+func WithDefaultVersions(defaultVersions map[string]string) RegistryOption {
+	return func(r *Registry) {
+		r.defaultVersions = defaultVersions
+	}
+}
+
+func NewRegistry(operators BuildersTree, options ...RegistryOption) *Registry {
+	registry := &Registry{
 		operators: operators,
 	}
+
+	for _, opt := range options {
+		opt(registry)
+	}
+
+	return registry
 }
 
 func (m *Registry) GetOperatorBuilder(name string) (Builder, error) {
@@ -52,6 +70,9 @@ func (m *Registry) GetOperatorBuilder(name string) (Builder, error) {
 	if err != nil {
 		return nil, err
 	}
+	if defaultVersion, found := m.defaultVersions[operatorName]; version == "" && found {
+		version = defaultVersion
+	}
 	if version == "" {
 		latestVersion, err := m.getLatestVersionForOperator(name)
 		if err != nil {
@@ -101,7 +122,35 @@ func (m *Registry) getLatestVersionForOperator(name string) (string, error) {
 
 func StandardRegistry(options ...BaseOperatorOption) *Registry {
 	return &Registry{
+		// new major versions changing the operator behaviour are opt-in,
+		// so the operators requested without version keep working as before
+		defaultVersions: map[string]string{
+			CrmClusterStartOperatorName: "v1",
+			CrmClusterStopOperatorName:  "v1",
+			HostRebootOperatorName:      "v1",
+		},
 		operators: BuildersTree{
+			ClusterCIBBackupOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewClusterCIBBackup(arguments, operationID, Options[ClusterCIBBackup]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			ClusterCIBRestoreOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewClusterCIBRestore(arguments, operationID, Options[ClusterCIBRestore]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			ClusterConstraintOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewClusterConstraint(arguments, operationID, Options[ClusterConstraint]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
 			ClusterMaintenanceChangeOperatorName: map[string]Builder{
 				"v1": func(operationID string, arguments Arguments) Operator {
 					return NewClusterMaintenanceChange(arguments, operationID, Options[ClusterMaintenanceChange]{
@@ -109,6 +158,27 @@ func StandardRegistry(options ...BaseOperatorOption) *Registry {
 					})
 				},
 			},
+			ClusterNodeAttributeOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewClusterNodeAttribute(arguments, operationID, Options[ClusterNodeAttribute]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			ClusterPropertyChangeOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewClusterPropertyChange(arguments, operationID, Options[ClusterPropertyChange]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			ClusterResourceCleanupOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewClusterResourceCleanup(arguments, operationID, Options[ClusterResourceCleanup]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
 			ClusterResourceRefreshOperatorName: map[string]Builder{
 				"v1": func(operationID string, arguments Arguments) Operator {
 					return NewClusterResourceRefresh(arguments, operationID, Options[ClusterResourceRefresh]{
@@ -122,6 +192,11 @@ func StandardRegistry(options ...BaseOperatorOption) *Registry {
 						BaseOperatorOptions: options,
 					})
 				},
+				"v2": func(operationID string, arguments Arguments) Operator {
+					return NewCrmClusterStartV2(arguments, operationID, Options[CrmClusterStartV2]{
+						BaseOperatorOptions: options,
+					})
+				},
 			},
 			CrmClusterStopOperatorName: map[string]Builder{
 				"v1": func(operationID string, arguments Arguments) Operator {
@@ -129,6 +204,11 @@ func StandardRegistry(options ...BaseOperatorOption) *Registry {
 						BaseOperatorOptions: options,
 					})
 				},
+				"v2": func(operationID string, arguments Arguments) Operator {
+					return NewCrmClusterStopV2(arguments, operationID, Options[CrmClusterStopV2]{
+						BaseOperatorOptions: options,
+					})
+				},
 			},
 			HostRebootOperatorName: map[string]Builder{
 				"v1": func(operationID string, arguments Arguments) Operator {
@@ -136,6 +216,11 @@ func StandardRegistry(options ...BaseOperatorOption) *Registry {
 						BaseOperatorOptions: options,
 					})
 				},
+				"v2": func(operationID string, arguments Arguments) Operator {
+					return NewHostRebootV2(arguments, operationID, Options[HostRebootV2]{
+						BaseOperatorOptions: options,
+					})
+				},
 			},
 			SapInstanceStartOperatorName: map[string]Builder{
 				"v1": func(operationID string, arguments Arguments) Operator {
@@ -179,6 +264,104 @@ func StandardRegistry(options ...BaseOperatorOption) *Registry {
 					})
 				},
 			},
+			SaptuneNoteApplyOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewSaptuneNoteApply(arguments, operationID, Options[SaptuneNoteApply]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			SaptuneNoteRevertOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewSaptuneNoteRevert(arguments, operationID, Options[SaptuneNoteRevert]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			SaptuneOverrideOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewSaptuneOverride(arguments, operationID, Options[SaptuneOverride]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			SaptuneServiceOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewSaptuneService(arguments, operationID, Options[SaptuneService]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			SaptuneStagingOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewSaptuneStaging(arguments, operationID, Options[SaptuneStaging]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			SaptuneVerifyOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewSaptuneVerify(arguments, operationID, Options[SaptuneVerify]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			SBDHealthOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewSBDHealth(arguments, operationID, Options[SBDHealth]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			ServiceDisableOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewServiceDisable(ServiceDisableOperatorName, arguments, operationID, Options[ServiceDisable]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			ServiceEnableOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewServiceEnable(ServiceEnableOperatorName, arguments, operationID, Options[ServiceEnable]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			ServiceRestartOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewServiceRestart(arguments, operationID, Options[ServiceRestart]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			ServiceStartOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewServiceStart(arguments, operationID, Options[ServiceStart]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			ServiceStopOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewServiceStop(arguments, operationID, Options[ServiceStop]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			StonithTestOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewStonithTest(arguments, operationID, Options[StonithTest]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
+			SystemdDropInOperatorName: map[string]Builder{
+				"v1": func(operationID string, arguments Arguments) Operator {
+					return NewSystemdDropIn(arguments, operationID, Options[SystemdDropIn]{
+						BaseOperatorOptions: options,
+					})
+				},
+			},
 			PacemakerEnableOperatorName: map[string]Builder{
 				"v1": func(operationID string, arguments Arguments) Operator {
 					return NewServiceEnable(PacemakerEnableOperatorName, arguments, operationID, Options[ServiceEnable]{
@@ -4,6 +4,7 @@
 package operator_test
 
 import (
+	"reflect"
 	"sort"
 	"testing"
 
@@ -112,3 +113,51 @@ func (suite *RegistryTest) TestGetOperatorBuilderFoundWithoutVersionGetLast() {
 	suite.NoError(err)
 	suite.Equal(b("", nil), foundOperator)
 }
+
+func (suite *RegistryTest) TestGetOperatorBuilderFoundWithoutVersionGetDefault() {
+	foundOperator := mocks.NewMockOperator(suite.T())
+	registry := operator.NewRegistry(operator.BuildersTree{
+		"test": map[string]operator.Builder{
+			"v1": func(_ string, _ operator.Arguments) operator.Operator { return foundOperator },
+			"v2": func(_ string, _ operator.Arguments) operator.Operator { return nil },
+		},
+		"test2": map[string]operator.Builder{
+			"v1": func(_ string, _ operator.Arguments) operator.Operator { return nil },
+			"v2": func(_ string, _ operator.Arguments) operator.Operator { return foundOperator },
+		},
+	}, operator.WithDefaultVersions(map[string]string{"test": "v1"}))
+
+	b, err := registry.GetOperatorBuilder("test")
+	suite.NoError(err)
+	suite.Equal(foundOperator, b("", nil))
+
+	b, err = registry.GetOperatorBuilder("test@v2")
+	suite.NoError(err)
+	suite.Nil(b("", nil))
+
+	b, err = registry.GetOperatorBuilder("test2")
+	suite.NoError(err)
+	suite.Equal(foundOperator, b("", nil))
+}
+
+func (suite *RegistryTest) TestStandardRegistryDefaultVersions() {
+	registry := operator.StandardRegistry()
+
+	cases := map[string]string{
+		operator.CrmClusterStartOperatorName: "v1",
+		operator.CrmClusterStopOperatorName:  "v1",
+		operator.HostRebootOperatorName:      "v1",
+	}
+
+	for name, version := range cases {
+		suite.Run(name, func() {
+			defaultBuilder, err := registry.GetOperatorBuilder(name)
+			suite.NoError(err)
+
+			versionedBuilder, err := registry.GetOperatorBuilder(name + "@" + version)
+			suite.NoError(err)
+
+			suite.Equal(reflect.ValueOf(versionedBuilder).Pointer(), reflect.ValueOf(defaultBuilder).Pointer())
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/trento-project/workbench/internal/saptune"
+	"github.com/trento-project/workbench/internal/support"
+)
+
+type saptuneNoteArguments struct {
+	noteID string
+}
+
+type saptuneNotesDiffOutput struct {
+	Notes []string `json:"notes"`
+}
+
+func parseSaptuneNoteArguments(rawArguments Arguments) (*saptuneNoteArguments, error) {
+	argument, found := rawArguments["note_id"]
+	if !found {
+		return nil, errors.New("argument note_id not provided, could not use the operator")
+	}
+
+	noteID, ok := argument.(string)
+	if !ok {
+		return nil, fmt.Errorf(
+			"could not parse note_id argument as string, argument provided: %v",
+			argument,
+		)
+	}
+
+	if noteID == "" {
+		return nil, errors.New("note_id argument is empty")
+	}
+
+	return &saptuneNoteArguments{
+		noteID: noteID,
+	}, nil
+}
+
+// saptuneNotesDiff builds the operation diff with the applied notes stored in the operator resources
+func saptuneNotesDiff(resources map[string]any) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		notes, ok := resources[field].([]string)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to notes list", field, resources[field]))
+		}
+
+		output, err := json.Marshal(saptuneNotesDiffOutput{Notes: notes})
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	return diff
+}
+
+const SaptuneNoteApplyOperatorName = "saptunenoteapply"
+
+type SaptuneNoteApplyOption Option[SaptuneNoteApply]
+
+// SaptuneNoteApply is an operator responsible for applying a single saptune note.
+//
+// The operator requires an argument in the form of a map containing a key named "note_id".
+// This value will be passed to the saptune command-line tool.
+//
+// All considerations related to applying a note using the saptune CLI apply here as well.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   The operator checks for the presence of the saptune binary and verifies its version.
+//   The minimum required version is 3.1.0. If saptune is not installed or the version does not meet the minimum
+//   requirement, the operation will fail.
+//
+//   The initially applied notes are collected as the "before" diff.
+//   The operator checks if the requested note is already applied. If it is, no action is taken,
+//   ensuring idempotency without returning an error.
+//
+// - COMMIT:
+//   The saptune command to apply the note is executed.
+//
+// - VERIFY:
+//   The operator verifies whether the note is listed by `saptune note applied`.
+//   If not, an error is raised. If successful, the currently applied notes are collected as
+//   the "after" diff.
+//
+// - ROLLBACK:
+//   If an error occurs during the COMMIT or VERIFY phase, the note is reverted.
+
+type SaptuneNoteApply struct {
+	baseOperator
+	saptune         saptune.Saptune
+	parsedArguments *saptuneNoteArguments
+}
+
+func WithSaptuneClientNoteApply(saptuneClient saptune.Saptune) SaptuneNoteApplyOption {
+	return func(o *SaptuneNoteApply) {
+		o.saptune = saptuneClient
+	}
+}
+
+func NewSaptuneNoteApply(
+	arguments Arguments,
+	operationID string,
+	options Options[SaptuneNoteApply],
+) *Executor {
+	saptuneNoteApply := &SaptuneNoteApply{
+		baseOperator: newBaseOperator(
+			SaptuneNoteApplyOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+	}
+
+	saptuneNoteApply.saptune = saptune.NewSaptuneClient(
+		support.CliExecutor{},
+		saptuneNoteApply.logger,
+	)
+
+	for _, opt := range options.OperatorOptions {
+		opt(saptuneNoteApply)
+	}
+
+	return &Executor{
+		phaser:      saptuneNoteApply,
+		operationID: operationID,
+		logger:      saptuneNoteApply.logger,
+	}
+}
+
+func (sa *SaptuneNoteApply) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseSaptuneNoteArguments(sa.arguments)
+	if err != nil {
+		return false, err
+	}
+	sa.parsedArguments = opArguments
+
+	if err = sa.saptune.CheckVersionSupport(ctx); err != nil {
+		return false, err
+	}
+
+	initiallyAppliedNotes, err := sa.saptune.GetAppliedNotes(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	sa.resources[beforeDiffField] = initiallyAppliedNotes
+
+	if slices.Contains(initiallyAppliedNotes, sa.parsedArguments.noteID) {
+		sa.logger.Info("note is already applied, skipping operation", "note", sa.parsedArguments.noteID)
+		sa.resources[afterDiffField] = initiallyAppliedNotes
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (sa *SaptuneNoteApply) commit(ctx context.Context) error {
+	return sa.saptune.ApplyNote(ctx, sa.parsedArguments.noteID)
+}
+
+func (sa *SaptuneNoteApply) verify(ctx context.Context) error {
+	appliedNotes, err := sa.saptune.GetAppliedNotes(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(appliedNotes, sa.parsedArguments.noteID) {
+		return fmt.Errorf(
+			"verify saptune note apply failing, the note %s was not applied in commit phase",
+			sa.parsedArguments.noteID,
+		)
+	}
+	sa.resources[afterDiffField] = appliedNotes
+	return nil
+}
+
+func (sa *SaptuneNoteApply) rollback(ctx context.Context) error {
+	return sa.saptune.RevertNote(ctx, sa.parsedArguments.noteID)
+}
+
+func (sa *SaptuneNoteApply) operationDiff(_ context.Context) map[string]any {
+	return saptuneNotesDiff(sa.resources)
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/saptune/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+type SaptuneNoteApplyOperatorTestSuite struct {
+	suite.Suite
+	mockSaptuneClient *mocks.MockSaptune
+}
+
+func TestSaptuneNoteApplyOperator(t *testing.T) {
+	suite.Run(t, new(SaptuneNoteApplyOperatorTestSuite))
+}
+
+func (suite *SaptuneNoteApplyOperatorTestSuite) SetupTest() {
+	suite.mockSaptuneClient = mocks.NewMockSaptune(suite.T())
+}
+
+func (suite *SaptuneNoteApplyOperatorTestSuite) buildOperator(arguments operator.Arguments) *operator.Executor {
+	return operator.NewSaptuneNoteApply(
+		arguments,
+		"test-op",
+		operator.Options[operator.SaptuneNoteApply]{
+			OperatorOptions: []operator.Option[operator.SaptuneNoteApply]{
+				operator.Option[operator.SaptuneNoteApply](operator.WithSaptuneClientNoteApply(suite.mockSaptuneClient)),
+			},
+		},
+	)
+}
+
+func (suite *SaptuneNoteApplyOperatorTestSuite) TestSaptuneNoteApplyPlanErrorParsingArguments() {
+	ctx := context.Background()
+
+	cases := []struct {
+		arguments operator.Arguments
+		err       string
+	}{
+		{
+			arguments: operator.Arguments{"note": "1868829"},
+			err:       "argument note_id not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{"note_id": 1868829},
+			err:       "could not parse note_id argument as string, argument provided: 1868829",
+		},
+		{
+			arguments: operator.Arguments{"note_id": ""},
+			err:       "note_id argument is empty",
+		},
+	}
+
+	for _, tc := range cases {
+		report := suite.buildOperator(tc.arguments).Run(ctx)
+
+		suite.Nil(report.Success)
+		suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+		suite.EqualValues(tc.err, report.Error.Message)
+	}
+}
+
+func (suite *SaptuneNoteApplyOperatorTestSuite) TestSaptuneNoteApplyPlanErrorVersionCheck() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).
+		Return(errors.New("saptune version not supported")).Once()
+
+	report := suite.buildOperator(operator.Arguments{"note_id": "1868829"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("saptune version not supported", report.Error.Message)
+}
+
+func (suite *SaptuneNoteApplyOperatorTestSuite) TestSaptuneNoteApplyAlreadyApplied() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"941735", "1868829"}, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"note_id": "1868829"}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"notes":["941735","1868829"]}`,
+		"after":  `{"notes":["941735","1868829"]}`,
+	}, report.Success.Diff)
+}
+
+func (suite *SaptuneNoteApplyOperatorTestSuite) TestSaptuneNoteApplyCommitErrorRollback() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"941735"}, nil).Once()
+	suite.mockSaptuneClient.On("ApplyNote", ctx, "1868829").
+		Return(errors.New("could not perform saptune note apply 1868829, error: exit status 1")).Once()
+	suite.mockSaptuneClient.On("RevertNote", ctx, "1868829").Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"note_id": "1868829"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues("could not perform saptune note apply 1868829, error: exit status 1", report.Error.Message)
+}
+
+func (suite *SaptuneNoteApplyOperatorTestSuite) TestSaptuneNoteApplyVerifyErrorRollback() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"941735"}, nil).Twice()
+	suite.mockSaptuneClient.On("ApplyNote", ctx, "1868829").Return(nil).Once()
+	suite.mockSaptuneClient.On("RevertNote", ctx, "1868829").Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"note_id": "1868829"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"verify saptune note apply failing, the note 1868829 was not applied in commit phase",
+		report.Error.Message,
+	)
+}
+
+func (suite *SaptuneNoteApplyOperatorTestSuite) TestSaptuneNoteApplySuccess() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{}, nil).Once()
+	suite.mockSaptuneClient.On("ApplyNote", ctx, "1868829").Return(nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"1868829"}, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"note_id": "1868829"}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"notes":[]}`,
+		"after":  `{"notes":["1868829"]}`,
+	}, report.Success.Diff)
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/trento-project/workbench/internal/saptune"
+	"github.com/trento-project/workbench/internal/support"
+)
+
+const SaptuneNoteRevertOperatorName = "saptunenoterevert"
+
+type SaptuneNoteRevertOption Option[SaptuneNoteRevert]
+
+// SaptuneNoteRevert is an operator responsible for reverting a single saptune note.
+//
+// It requires the same kind of argument needed for SaptuneNoteApply: a map containing a key named "note_id".
+//
+// # Execution Phases
+//
+// - PLAN:
+//   The operator checks for the presence of the saptune binary and verifies its version, the same way
+//   SaptuneNoteApply does.
+//
+//   The initially applied notes are collected as the "before" diff.
+//   If the requested note is not applied, no action is taken, ensuring idempotency without returning an error.
+//
+// - COMMIT:
+//   The saptune command to revert the note is executed.
+//
+// - VERIFY:
+//   The operator verifies whether the note is not listed by `saptune note applied` anymore.
+//   If it is, an error is raised. If successful, the currently applied notes are collected as
+//   the "after" diff.
+//
+// - ROLLBACK:
+//   If an error occurs during the COMMIT or VERIFY phase, the note is applied again.
+
+type SaptuneNoteRevert struct {
+	baseOperator
+	saptune         saptune.Saptune
+	parsedArguments *saptuneNoteArguments
+}
+
+func WithSaptuneClientNoteRevert(saptuneClient saptune.Saptune) SaptuneNoteRevertOption {
+	return func(o *SaptuneNoteRevert) {
+		o.saptune = saptuneClient
+	}
+}
+
+func NewSaptuneNoteRevert(
+	arguments Arguments,
+	operationID string,
+	options Options[SaptuneNoteRevert],
+) *Executor {
+	saptuneNoteRevert := &SaptuneNoteRevert{
+		baseOperator: newBaseOperator(
+			SaptuneNoteRevertOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+	}
+
+	saptuneNoteRevert.saptune = saptune.NewSaptuneClient(
+		support.CliExecutor{},
+		saptuneNoteRevert.logger,
+	)
+
+	for _, opt := range options.OperatorOptions {
+		opt(saptuneNoteRevert)
+	}
+
+	return &Executor{
+		phaser:      saptuneNoteRevert,
+		operationID: operationID,
+		logger:      saptuneNoteRevert.logger,
+	}
+}
+
+func (sr *SaptuneNoteRevert) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseSaptuneNoteArguments(sr.arguments)
+	if err != nil {
+		return false, err
+	}
+	sr.parsedArguments = opArguments
+
+	if err = sr.saptune.CheckVersionSupport(ctx); err != nil {
+		return false, err
+	}
+
+	initiallyAppliedNotes, err := sr.saptune.GetAppliedNotes(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	sr.resources[beforeDiffField] = initiallyAppliedNotes
+
+	if !slices.Contains(initiallyAppliedNotes, sr.parsedArguments.noteID) {
+		sr.logger.Info("note is not applied, skipping operation", "note", sr.parsedArguments.noteID)
+		sr.resources[afterDiffField] = initiallyAppliedNotes
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (sr *SaptuneNoteRevert) commit(ctx context.Context) error {
+	return sr.saptune.RevertNote(ctx, sr.parsedArguments.noteID)
+}
+
+func (sr *SaptuneNoteRevert) verify(ctx context.Context) error {
+	appliedNotes, err := sr.saptune.GetAppliedNotes(ctx)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(appliedNotes, sr.parsedArguments.noteID) {
+		return fmt.Errorf(
+			"verify saptune note revert failing, the note %s was not reverted in commit phase",
+			sr.parsedArguments.noteID,
+		)
+	}
+	sr.resources[afterDiffField] = appliedNotes
+	return nil
+}
+
+func (sr *SaptuneNoteRevert) rollback(ctx context.Context) error {
+	return sr.saptune.ApplyNote(ctx, sr.parsedArguments.noteID)
+}
+
+func (sr *SaptuneNoteRevert) operationDiff(_ context.Context) map[string]any {
+	return saptuneNotesDiff(sr.resources)
+}
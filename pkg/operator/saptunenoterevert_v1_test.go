@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/saptune/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+type SaptuneNoteRevertOperatorTestSuite struct {
+	suite.Suite
+	mockSaptuneClient *mocks.MockSaptune
+}
+
+func TestSaptuneNoteRevertOperator(t *testing.T) {
+	suite.Run(t, new(SaptuneNoteRevertOperatorTestSuite))
+}
+
+func (suite *SaptuneNoteRevertOperatorTestSuite) SetupTest() {
+	suite.mockSaptuneClient = mocks.NewMockSaptune(suite.T())
+}
+
+func (suite *SaptuneNoteRevertOperatorTestSuite) buildOperator(arguments operator.Arguments) *operator.Executor {
+	return operator.NewSaptuneNoteRevert(
+		arguments,
+		"test-op",
+		operator.Options[operator.SaptuneNoteRevert]{
+			OperatorOptions: []operator.Option[operator.SaptuneNoteRevert]{
+				operator.Option[operator.SaptuneNoteRevert](operator.WithSaptuneClientNoteRevert(suite.mockSaptuneClient)),
+			},
+		},
+	)
+}
+
+func (suite *SaptuneNoteRevertOperatorTestSuite) TestSaptuneNoteRevertPlanErrorGettingNotes() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).
+		Return(nil, errors.New("could not call saptune note applied: exit status 1")).Once()
+
+	report := suite.buildOperator(operator.Arguments{"note_id": "1868829"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("could not call saptune note applied: exit status 1", report.Error.Message)
+}
+
+func (suite *SaptuneNoteRevertOperatorTestSuite) TestSaptuneNoteRevertNotApplied() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"941735"}, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"note_id": "1868829"}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"notes":["941735"]}`,
+		"after":  `{"notes":["941735"]}`,
+	}, report.Success.Diff)
+}
+
+func (suite *SaptuneNoteRevertOperatorTestSuite) TestSaptuneNoteRevertVerifyErrorRollback() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"941735", "1868829"}, nil).Twice()
+	suite.mockSaptuneClient.On("RevertNote", ctx, "1868829").Return(nil).Once()
+	suite.mockSaptuneClient.On("ApplyNote", ctx, "1868829").Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"note_id": "1868829"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"verify saptune note revert failing, the note 1868829 was not reverted in commit phase",
+		report.Error.Message,
+	)
+}
+
+func (suite *SaptuneNoteRevertOperatorTestSuite) TestSaptuneNoteRevertSuccess() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"941735", "1868829"}, nil).Once()
+	suite.mockSaptuneClient.On("RevertNote", ctx, "1868829").Return(nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"941735"}, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"note_id": "1868829"}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"notes":["941735","1868829"]}`,
+		"after":  `{"notes":["941735"]}`,
+	}, report.Success.Diff)
+}
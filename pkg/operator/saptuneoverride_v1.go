@@ -0,0 +1,435 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/trento-project/workbench/internal/saptune"
+	"github.com/trento-project/workbench/internal/support"
+)
+
+const SaptuneOverrideOperatorName = "saptuneoverride"
+
+type SaptuneOverrideOption Option[SaptuneOverride]
+
+type saptuneOverrideParameter struct {
+	// section is the note definition section of the parameter, e.g. sysctl
+	section string
+	name    string
+	// value is nil if the parameter has to be removed from the override
+	value *string
+}
+
+type saptuneOverrideArguments struct {
+	noteID     string
+	parameters []saptuneOverrideParameter
+}
+
+type saptuneOverrideDiffOutput struct {
+	NoteID string `json:"note_id"`
+	// Override is the override file content, nil if the file doesn't exist
+	Override *string `json:"override"`
+}
+
+// SaptuneOverride operator sets or removes parameters of a saptune note override file,
+// stored in /etc/saptune/override/<note_id>.
+//
+// Arguments:
+//  note_id (required): ID of the note
+//  parameters (required): Map with the note parameters to override, e.g. {"kernel.shmmax": "68719476736"}.
+//                         A null value removes the parameter from the override file.
+//
+// The parameter names must be defined in the note. The override file is removed if it doesn't
+// have any parameter left. Comments in the existing override file are not kept.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   The operator checks for the presence of the saptune binary and verifies its version.
+//   The minimum required version is 3.1.0.
+//   The parameter names are validated against the note definition given by `saptune note show`.
+//   The current override file content is collected as the "before" diff. If all the parameters already
+//   have the requested values, the operation is skipped.
+//
+// - COMMIT:
+//   The override file is written or removed. If the note is applied, it is reverted and applied again
+//   so the override takes effect.
+//
+// - VERIFY:
+//   Checks that the override file has the requested values and, if the note is applied, that the
+//   overridden parameters are compliant according to `saptune note verify`.
+//   The override file content is collected as the "after" diff.
+//
+// - ROLLBACK:
+//   The previous override file content is restored, or the file is removed if it didn't exist.
+//   If the note is applied, it is reverted and applied again.
+
+type SaptuneOverride struct {
+	baseOperator
+	saptune         saptune.Saptune
+	parsedArguments *saptuneOverrideArguments
+	noteApplied     bool
+	overrideContent []byte
+}
+
+func WithSaptuneClientOverride(saptuneClient saptune.Saptune) SaptuneOverrideOption {
+	return func(o *SaptuneOverride) {
+		o.saptune = saptuneClient
+	}
+}
+
+func NewSaptuneOverride(
+	arguments Arguments,
+	operationID string,
+	options Options[SaptuneOverride],
+) *Executor {
+	saptuneOverride := &SaptuneOverride{
+		baseOperator: newBaseOperator(
+			SaptuneOverrideOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+	}
+
+	saptuneOverride.saptune = saptune.NewSaptuneClient(
+		support.CliExecutor{},
+		saptuneOverride.logger,
+	)
+
+	for _, opt := range options.OperatorOptions {
+		opt(saptuneOverride)
+	}
+
+	return &Executor{
+		phaser:      saptuneOverride,
+		operationID: operationID,
+		logger:      saptuneOverride.logger,
+	}
+}
+
+func (so *SaptuneOverride) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseSaptuneOverrideArguments(so.arguments)
+	if err != nil {
+		return false, err
+	}
+	so.parsedArguments = opArguments
+
+	if err = so.saptune.CheckVersionSupport(ctx); err != nil {
+		return false, err
+	}
+
+	definition, err := so.saptune.GetNoteDefinition(ctx, so.parsedArguments.noteID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := so.resolveParameterSections(definition); err != nil {
+		return false, err
+	}
+
+	content, found, err := so.saptune.GetNoteOverride(ctx, so.parsedArguments.noteID)
+	if err != nil {
+		return false, err
+	}
+	so.resources[beforeDiffField] = overrideContentPointer(content, found)
+
+	currentSections := saptune.ParseNoteFile(content)
+	if so.overrideApplied(currentSections) {
+		so.logger.Info("saptune override already set, skipping operation", "note", so.parsedArguments.noteID)
+		so.resources[afterDiffField] = so.resources[beforeDiffField]
+		return true, nil
+	}
+	so.overrideContent, err = saptune.FormatNoteFile(applySaptuneOverrideParameters(
+		currentSections, so.parsedArguments.parameters,
+	))
+	if err != nil {
+		return false, err
+	}
+
+	appliedNotes, err := so.saptune.GetAppliedNotes(ctx)
+	if err != nil {
+		return false, err
+	}
+	so.noteApplied = slices.Contains(appliedNotes, so.parsedArguments.noteID)
+
+	return false, nil
+}
+
+func (so *SaptuneOverride) commit(ctx context.Context) error {
+	if err := so.writeOverride(ctx, so.overrideContent); err != nil {
+		return err
+	}
+
+	return so.reapplyNote(ctx)
+}
+
+func (so *SaptuneOverride) verify(ctx context.Context) error {
+	content, found, err := so.saptune.GetNoteOverride(ctx, so.parsedArguments.noteID)
+	if err != nil {
+		return err
+	}
+
+	if !so.overrideApplied(saptune.ParseNoteFile(content)) {
+		return fmt.Errorf(
+			"verify saptune override failing, the override of note %s was not written in commit phase",
+			so.parsedArguments.noteID,
+		)
+	}
+
+	if so.noteApplied {
+		verifyResult, err := so.saptune.VerifyNote(ctx, so.parsedArguments.noteID)
+		if err != nil {
+			return err
+		}
+
+		if parameter, ok := so.nonCompliantParameter(verifyResult); ok {
+			return fmt.Errorf(
+				"verify saptune override failing, parameter %s of note %s is not compliant",
+				parameter,
+				so.parsedArguments.noteID,
+			)
+		}
+	}
+
+	so.resources[afterDiffField] = overrideContentPointer(content, found)
+	return nil
+}
+
+func (so *SaptuneOverride) rollback(ctx context.Context) error {
+	initialContent, _ := so.resources[beforeDiffField].(*string)
+
+	var err error
+	if initialContent == nil {
+		err = so.saptune.DeleteNoteOverride(ctx, so.parsedArguments.noteID)
+	} else {
+		err = so.saptune.WriteNoteOverride(ctx, so.parsedArguments.noteID, []byte(*initialContent))
+	}
+	if err != nil {
+		return err
+	}
+
+	return so.reapplyNote(ctx)
+}
+
+func (so *SaptuneOverride) operationDiff(_ context.Context) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		content, ok := so.resources[field].(*string)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to override content",
+				field, so.resources[field]))
+		}
+
+		output, err := json.Marshal(saptuneOverrideDiffOutput{
+			NoteID:   so.parsedArguments.noteID,
+			Override: content,
+		})
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	return diff
+}
+
+// resolveParameterSections sets the section of each parameter from the note definition
+func (so *SaptuneOverride) resolveParameterSections(definition []saptune.NoteSection) error {
+	for i, parameter := range so.parsedArguments.parameters {
+		section, found := findNoteParameterSection(definition, parameter.name)
+		if !found {
+			return fmt.Errorf("parameter %s is not defined in note %s", parameter.name, so.parsedArguments.noteID)
+		}
+		so.parsedArguments.parameters[i].section = section
+	}
+
+	return nil
+}
+
+func (so *SaptuneOverride) overrideApplied(sections []saptune.NoteSection) bool {
+	for _, parameter := range so.parsedArguments.parameters {
+		value, found := findNoteParameterValue(sections, parameter.section, parameter.name)
+		switch {
+		case parameter.value == nil && found:
+			return false
+		case parameter.value != nil && (!found || value != *parameter.value):
+			return false
+		}
+	}
+	return true
+}
+
+// nonCompliantParameter returns the first overridden parameter that is not compliant.
+// saptune verify reports some parameters with a section prefix or a device suffix,
+// e.g. grub:intel_idle.max_cstate or IO_SCHEDULER_vda.
+func (so *SaptuneOverride) nonCompliantParameter(verifyResult *saptune.VerifyResult) (string, bool) {
+	for _, parameter := range so.parsedArguments.parameters {
+		if parameter.value == nil {
+			continue
+		}
+
+		for _, record := range verifyResult.Records {
+			matches := record.Parameter == parameter.name ||
+				record.Parameter == parameter.section+":"+parameter.name ||
+				strings.HasPrefix(record.Parameter, parameter.name+"_")
+			if matches && !record.Compliant {
+				return record.Parameter, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (so *SaptuneOverride) writeOverride(ctx context.Context, content []byte) error {
+	if len(content) == 0 {
+		return so.saptune.DeleteNoteOverride(ctx, so.parsedArguments.noteID)
+	}
+	return so.saptune.WriteNoteOverride(ctx, so.parsedArguments.noteID, content)
+}
+
+// reapplyNote reverts and applies the note again if it is applied, so the override takes effect
+func (so *SaptuneOverride) reapplyNote(ctx context.Context) error {
+	if !so.noteApplied {
+		return nil
+	}
+
+	if err := so.saptune.RevertNote(ctx, so.parsedArguments.noteID); err != nil {
+		return err
+	}
+
+	return so.saptune.ApplyNote(ctx, so.parsedArguments.noteID)
+}
+
+func overrideContentPointer(content []byte, found bool) *string {
+	if !found {
+		return nil
+	}
+	value := string(content)
+	return &value
+}
+
+func findNoteParameterSection(sections []saptune.NoteSection, name string) (string, bool) {
+	for _, section := range sections {
+		for _, parameter := range section.Parameters {
+			if parameter.Name == name {
+				return section.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+func findNoteParameterValue(sections []saptune.NoteSection, sectionName, name string) (string, bool) {
+	for _, section := range sections {
+		if section.Name != sectionName {
+			continue
+		}
+		for _, parameter := range section.Parameters {
+			if parameter.Name == name {
+				return parameter.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// applySaptuneOverrideParameters returns a copy of the override sections with the parameters set or removed
+func applySaptuneOverrideParameters(
+	sections []saptune.NoteSection,
+	parameters []saptuneOverrideParameter,
+) []saptune.NoteSection {
+	result := make([]saptune.NoteSection, 0, len(sections))
+	for _, section := range sections {
+		result = append(result, saptune.NoteSection{
+			Name:       section.Name,
+			Parameters: slices.Clone(section.Parameters),
+		})
+	}
+
+	for _, parameter := range parameters {
+		sectionIndex := slices.IndexFunc(result, func(s saptune.NoteSection) bool {
+			return s.Name == parameter.section
+		})
+		if sectionIndex == -1 {
+			if parameter.value == nil {
+				continue
+			}
+			result = append(result, saptune.NoteSection{Name: parameter.section})
+			sectionIndex = len(result) - 1
+		}
+
+		section := &result[sectionIndex]
+		parameterIndex := slices.IndexFunc(section.Parameters, func(p saptune.NoteParameter) bool {
+			return p.Name == parameter.name
+		})
+
+		switch {
+		case parameter.value == nil && parameterIndex != -1:
+			section.Parameters = slices.Delete(section.Parameters, parameterIndex, parameterIndex+1)
+		case parameter.value != nil && parameterIndex != -1:
+			section.Parameters[parameterIndex].Value = *parameter.value
+		case parameter.value != nil:
+			section.Parameters = append(section.Parameters, saptune.NoteParameter{
+				Name:  parameter.name,
+				Value: *parameter.value,
+			})
+		}
+	}
+
+	return result
+}
+
+func parseSaptuneOverrideArguments(rawArguments Arguments) (*saptuneOverrideArguments, error) {
+	noteArguments, err := parseSaptuneNoteArguments(rawArguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := saptune.ValidateNoteID(noteArguments.noteID); err != nil {
+		return nil, err
+	}
+
+	rawParameters, found := rawArguments["parameters"]
+	if !found {
+		return nil, errors.New("argument parameters not provided, could not use the operator")
+	}
+
+	parametersMap, ok := rawParameters.(map[string]any)
+	if !ok || len(parametersMap) == 0 {
+		return nil, fmt.Errorf(
+			"could not parse parameters argument as a non empty map, argument provided: %v", rawParameters,
+		)
+	}
+
+	parameters := []saptuneOverrideParameter{}
+	for name, rawValue := range parametersMap {
+		parameter := saptuneOverrideParameter{name: name}
+
+		if rawValue != nil {
+			value, err := parsePropertyValue(rawValue)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse %s parameter value: %w", name, err)
+			}
+			if strings.ContainsAny(value, "\r\n") {
+				return nil, fmt.Errorf("invalid %s parameter value: line breaks are not allowed", name)
+			}
+			parameter.value = &value
+		}
+
+		parameters = append(parameters, parameter)
+	}
+
+	// keep a stable order to write the override file
+	slices.SortFunc(parameters, func(a, b saptuneOverrideParameter) int {
+		return cmp.Compare(a.name, b.name)
+	})
+
+	return &saptuneOverrideArguments{noteID: noteArguments.noteID, parameters: parameters}, nil
+}
@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/saptune"
+	"github.com/trento-project/workbench/internal/saptune/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+type SaptuneOverrideOperatorTestSuite struct {
+	suite.Suite
+	mockSaptuneClient *mocks.MockSaptune
+	noteDefinition    []saptune.NoteSection
+}
+
+func TestSaptuneOverrideOperator(t *testing.T) {
+	suite.Run(t, new(SaptuneOverrideOperatorTestSuite))
+}
+
+func (suite *SaptuneOverrideOperatorTestSuite) SetupTest() {
+	suite.mockSaptuneClient = mocks.NewMockSaptune(suite.T())
+	suite.noteDefinition = []saptune.NoteSection{
+		{
+			Name:       "mem",
+			Parameters: []saptune.NoteParameter{{Name: "ShmFileSystemSizeMB", Value: "0"}},
+		},
+		{
+			Name: "sysctl",
+			Parameters: []saptune.NoteParameter{
+				{Name: "kernel.shmall", Value: "1152921504606846720"},
+				{Name: "kernel.shmmax", Value: "18446744073709551615"},
+			},
+		},
+	}
+}
+
+func (suite *SaptuneOverrideOperatorTestSuite) buildOperator(arguments operator.Arguments) *operator.Executor {
+	return operator.NewSaptuneOverride(
+		arguments,
+		"test-op",
+		operator.Options[operator.SaptuneOverride]{
+			OperatorOptions: []operator.Option[operator.SaptuneOverride]{
+				operator.Option[operator.SaptuneOverride](operator.WithSaptuneClientOverride(suite.mockSaptuneClient)),
+			},
+		},
+	)
+}
+
+func (suite *SaptuneOverrideOperatorTestSuite) TestSaptuneOverridePlanErrorParsingArguments() {
+	ctx := context.Background()
+
+	cases := []struct {
+		arguments operator.Arguments
+		err       string
+	}{
+		{
+			arguments: operator.Arguments{"parameters": map[string]any{"kernel.shmmax": "1"}},
+			err:       "argument note_id not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{"note_id": "941735"},
+			err:       "argument parameters not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{"note_id": "941735", "parameters": map[string]any{}},
+			err:       "could not parse parameters argument as a non empty map, argument provided: map[]",
+		},
+		{
+			arguments: operator.Arguments{"note_id": "941735", "parameters": map[string]any{"kernel.shmmax": []int{}}},
+			err: "could not parse kernel.shmmax parameter value: unsupported value type, " +
+				"value provided: []",
+		},
+		{
+			arguments: operator.Arguments{"note_id": "../941735", "parameters": map[string]any{"kernel.shmmax": "1"}},
+			err:       `invalid note ID "../941735", only letters, numbers, dots, dashes and underscores are allowed`,
+		},
+		{
+			arguments: operator.Arguments{
+				"note_id":    "941735",
+				"parameters": map[string]any{"kernel.shmmax": "1\n[block]\nIO_SCHEDULER=none"},
+			},
+			err: "invalid kernel.shmmax parameter value: line breaks are not allowed",
+		},
+	}
+
+	for _, tc := range cases {
+		report := suite.buildOperator(tc.arguments).Run(ctx)
+
+		suite.Nil(report.Success)
+		suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+		suite.EqualValues(tc.err, report.Error.Message)
+	}
+}
+
+func (suite *SaptuneOverrideOperatorTestSuite) TestSaptuneOverridePlanErrorUnknownParameter() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("GetNoteDefinition", ctx, "941735").Return(suite.noteDefinition, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"note_id":    "941735",
+		"parameters": map[string]any{"vm.swappiness": float64(10)},
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("parameter vm.swappiness is not defined in note 941735", report.Error.Message)
+}
+
+func (suite *SaptuneOverrideOperatorTestSuite) TestSaptuneOverrideAlreadyApplied() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("GetNoteDefinition", ctx, "941735").Return(suite.noteDefinition, nil).Once()
+	suite.mockSaptuneClient.On("GetNoteOverride", ctx, "941735").
+		Return([]byte("[sysctl]\nkernel.shmmax = 68719476736\n"), true, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"note_id":    "941735",
+		"parameters": map[string]any{"kernel.shmmax": "68719476736", "ShmFileSystemSizeMB": nil},
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"note_id":"941735","override":"[sysctl]\nkernel.shmmax = 68719476736\n"}`,
+		"after":  `{"note_id":"941735","override":"[sysctl]\nkernel.shmmax = 68719476736\n"}`,
+	}, report.Success.Diff)
+}
+
+func (suite *SaptuneOverrideOperatorTestSuite) TestSaptuneOverrideSuccess() {
+	ctx := context.Background()
+	expectedContent := "[sysctl]\nkernel.shmmax=68719476736\n\n[mem]\nShmFileSystemSizeMB=25605\n"
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("GetNoteDefinition", ctx, "941735").Return(suite.noteDefinition, nil).Once()
+	suite.mockSaptuneClient.On("GetNoteOverride", ctx, "941735").
+		Return([]byte("[sysctl]\nkernel.shmall=1\nkernel.shmmax=1\n"), true, nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"941735"}, nil).Once()
+	suite.mockSaptuneClient.On("WriteNoteOverride", ctx, "941735", []byte(expectedContent)).Return(nil).Once()
+	suite.mockSaptuneClient.On("RevertNote", ctx, "941735").Return(nil).Once()
+	suite.mockSaptuneClient.On("ApplyNote", ctx, "941735").Return(nil).Once()
+	suite.mockSaptuneClient.On("GetNoteOverride", ctx, "941735").Return([]byte(expectedContent), true, nil).Once()
+	suite.mockSaptuneClient.On("VerifyNote", ctx, "941735").Return(&saptune.VerifyResult{
+		Compliant: true,
+		Records: []saptune.ComplianceRecord{
+			{NoteID: "941735", Parameter: "ShmFileSystemSizeMB", Compliant: true},
+			{NoteID: "941735", Parameter: "kernel.shmmax", Compliant: true},
+		},
+	}, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"note_id": "941735",
+		"parameters": map[string]any{
+			"kernel.shmmax":       float64(68719476736),
+			"kernel.shmall":       nil,
+			"ShmFileSystemSizeMB": float64(25605),
+		},
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"note_id":"941735","override":"[sysctl]\nkernel.shmall=1\nkernel.shmmax=1\n"}`,
+		"after": `{"note_id":"941735","override":"[sysctl]\nkernel.shmmax=68719476736\n\n` +
+			`[mem]\nShmFileSystemSizeMB=25605\n"}`,
+	}, report.Success.Diff)
+}
+
+func (suite *SaptuneOverrideOperatorTestSuite) TestSaptuneOverrideRemoveFileNoteNotApplied() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("GetNoteDefinition", ctx, "941735").Return(suite.noteDefinition, nil).Once()
+	suite.mockSaptuneClient.On("GetNoteOverride", ctx, "941735").
+		Return([]byte("[sysctl]\nkernel.shmmax=1\n"), true, nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{}, nil).Once()
+	suite.mockSaptuneClient.On("DeleteNoteOverride", ctx, "941735").Return(nil).Once()
+	suite.mockSaptuneClient.On("GetNoteOverride", ctx, "941735").Return(nil, false, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"note_id":    "941735",
+		"parameters": map[string]any{"kernel.shmmax": nil},
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"note_id":"941735","override":"[sysctl]\nkernel.shmmax=1\n"}`,
+		"after":  `{"note_id":"941735","override":null}`,
+	}, report.Success.Diff)
+}
+
+func (suite *SaptuneOverrideOperatorTestSuite) TestSaptuneOverrideVerifyErrorRollback() {
+	ctx := context.Background()
+	expectedContent := "[sysctl]\nkernel.shmmax=68719476736\n"
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("GetNoteDefinition", ctx, "941735").Return(suite.noteDefinition, nil).Once()
+	suite.mockSaptuneClient.On("GetNoteOverride", ctx, "941735").Return(nil, false, nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"941735"}, nil).Once()
+	suite.mockSaptuneClient.On("WriteNoteOverride", ctx, "941735", []byte(expectedContent)).Return(nil).Once()
+	suite.mockSaptuneClient.On("RevertNote", ctx, "941735").Return(nil).Twice()
+	suite.mockSaptuneClient.On("ApplyNote", ctx, "941735").Return(nil).Twice()
+	suite.mockSaptuneClient.On("GetNoteOverride", ctx, "941735").Return([]byte(expectedContent), true, nil).Once()
+	suite.mockSaptuneClient.On("VerifyNote", ctx, "941735").Return(&saptune.VerifyResult{
+		Records: []saptune.ComplianceRecord{
+			{NoteID: "941735", Parameter: "kernel.shmmax", Compliant: false},
+		},
+	}, nil).Once()
+	suite.mockSaptuneClient.On("DeleteNoteOverride", ctx, "941735").Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"note_id":    "941735",
+		"parameters": map[string]any{"kernel.shmmax": "68719476736"},
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"verify saptune override failing, parameter kernel.shmmax of note 941735 is not compliant",
+		report.Error.Message,
+	)
+}
+
+func (suite *SaptuneOverrideOperatorTestSuite) TestSaptuneOverrideCommitErrorRollback() {
+	ctx := context.Background()
+	initialContent := "[sysctl]\nkernel.shmmax=1\n"
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("GetNoteDefinition", ctx, "941735").Return(suite.noteDefinition, nil).Once()
+	suite.mockSaptuneClient.On("GetNoteOverride", ctx, "941735").Return([]byte(initialContent), true, nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{}, nil).Once()
+	suite.mockSaptuneClient.On("WriteNoteOverride", ctx, "941735", []byte("[sysctl]\nkernel.shmmax=2\n")).
+		Return(errors.New("could not write saptune override file")).Once()
+	suite.mockSaptuneClient.On("WriteNoteOverride", ctx, "941735", []byte(initialContent)).Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"note_id":    "941735",
+		"parameters": map[string]any{"kernel.shmmax": "2"},
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues("could not write saptune override file", report.Error.Message)
+}
@@ -0,0 +1,293 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/trento-project/workbench/internal/saptune"
+	"github.com/trento-project/workbench/internal/support"
+	"github.com/trento-project/workbench/internal/systemd"
+)
+
+const (
+	SaptuneServiceOperatorName = "saptuneservice"
+	saptuneServiceName         = "saptune.service"
+	sapconfServiceName         = "sapconf.service"
+	tunedServiceName           = "tuned.service"
+	// saptuneTunedProfile is the tuned profile used by old saptune versions, it doesn't conflict with saptune
+	saptuneTunedProfile = "saptune"
+)
+
+// saptuneServiceOrder is the order used to change the services, conflicting services are stopped first
+var saptuneServiceOrder = []string{sapconfServiceName, tunedServiceName, saptuneServiceName}
+
+type SaptuneServiceOption Option[SaptuneService]
+
+type saptuneServiceState struct {
+	Enabled bool `json:"enabled"`
+	Active  bool `json:"active"`
+}
+
+type saptuneServicesState struct {
+	Services     map[string]saptuneServiceState `json:"services"`
+	TunedProfile string                         `json:"tuned_profile"`
+}
+
+// SaptuneService operator enables and starts saptune.service, disabling and stopping the
+// services that conflict with it: sapconf.service, and tuned.service when it runs a profile
+// other than the saptune one.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   The operator checks for the presence of the saptune binary and verifies its version.
+//   The minimum required version is 3.1.0.
+//   The enabled and active states of the saptune, sapconf and tuned services and the active
+//   tuned profile are collected as the "before" diff.
+//   The operation is skipped if saptune.service is enabled and active and no conflicting service is found.
+//
+// - COMMIT:
+//   The conflicting services are stopped and disabled, then saptune.service is enabled and started.
+//
+// - VERIFY:
+//   Checks the service states and runs `saptune service status`, that fails if saptune.service
+//   is not running properly. The service states are collected as the "after" diff.
+//
+// - ROLLBACK:
+//   The initial enabled and active states of the services are restored.
+
+type SaptuneService struct {
+	baseOperator
+	saptune          saptune.Saptune
+	systemdLoader    systemd.Loader
+	systemdConnector systemd.Systemd
+	requestedState   map[string]saptuneServiceState
+}
+
+func WithSaptuneClientService(saptuneClient saptune.Saptune) SaptuneServiceOption {
+	return func(o *SaptuneService) {
+		o.saptune = saptuneClient
+	}
+}
+
+func WithCustomSaptuneServiceSystemdLoader(systemdLoader systemd.Loader) SaptuneServiceOption {
+	return func(o *SaptuneService) {
+		o.systemdLoader = systemdLoader
+	}
+}
+
+func NewSaptuneService(
+	arguments Arguments,
+	operationID string,
+	options Options[SaptuneService],
+) *Executor {
+	saptuneService := &SaptuneService{
+		baseOperator: newBaseOperator(
+			SaptuneServiceOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+		systemdLoader: systemd.NewDefaultSystemdLoader(),
+	}
+
+	saptuneService.saptune = saptune.NewSaptuneClient(
+		support.CliExecutor{},
+		saptuneService.logger,
+	)
+
+	for _, opt := range options.OperatorOptions {
+		opt(saptuneService)
+	}
+
+	return &Executor{
+		phaser:      saptuneService,
+		operationID: operationID,
+		logger:      saptuneService.logger,
+	}
+}
+
+func (ss *SaptuneService) plan(ctx context.Context) (bool, error) {
+	if err := ss.saptune.CheckVersionSupport(ctx); err != nil {
+		return false, err
+	}
+
+	systemdConnector, err := ss.systemdLoader.NewSystemd(ctx, ss.logger)
+	if err != nil {
+		ss.logger.Error("unable to initialize systemd connector", "error", err)
+		return false, fmt.Errorf("unable to initialize systemd connector: %w", err)
+	}
+	ss.systemdConnector = systemdConnector
+
+	initialState, err := ss.collectState(ctx)
+	if err != nil {
+		return false, err
+	}
+	ss.resources[beforeDiffField] = initialState
+
+	ss.requestedState = maps.Clone(initialState.Services)
+	ss.requestedState[saptuneServiceName] = saptuneServiceState{Enabled: true, Active: true}
+	ss.requestedState[sapconfServiceName] = saptuneServiceState{}
+
+	tunedState := initialState.Services[tunedServiceName]
+	if (tunedState.Enabled || tunedState.Active) && initialState.TunedProfile != saptuneTunedProfile {
+		ss.logger.Info("conflicting tuned profile found", "profile", initialState.TunedProfile)
+		ss.requestedState[tunedServiceName] = saptuneServiceState{}
+	}
+
+	if maps.Equal(initialState.Services, ss.requestedState) {
+		ss.logger.Info("saptune service already running without conflicts, skipping operation")
+		ss.resources[afterDiffField] = initialState
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (ss *SaptuneService) commit(ctx context.Context) error {
+	initialState, _ := ss.resources[beforeDiffField].(*saptuneServicesState)
+
+	for _, service := range saptuneServiceOrder {
+		err := ss.changeServiceState(ctx, service, initialState.Services[service], ss.requestedState[service])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ss *SaptuneService) verify(ctx context.Context) error {
+	currentState, err := ss.collectState(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, service := range saptuneServiceOrder {
+		if currentState.Services[service] != ss.requestedState[service] {
+			return fmt.Errorf(
+				"verify saptune service failing, service %s is not in the requested state",
+				service,
+			)
+		}
+	}
+
+	if err := ss.saptune.CheckServiceStatus(ctx); err != nil {
+		return err
+	}
+
+	ss.resources[afterDiffField] = currentState
+	return nil
+}
+
+func (ss *SaptuneService) rollback(ctx context.Context) error {
+	initialState, _ := ss.resources[beforeDiffField].(*saptuneServicesState)
+
+	currentState, err := ss.collectState(ctx)
+	if err != nil {
+		return err
+	}
+
+	var rollbackErr error
+	for _, service := range slices.Backward(saptuneServiceOrder) {
+		rollbackErr = errors.Join(rollbackErr, ss.changeServiceState(
+			ctx, service, currentState.Services[service], initialState.Services[service],
+		))
+	}
+
+	if rollbackErr != nil {
+		return fmt.Errorf("error rolling back saptune service states: %w", rollbackErr)
+	}
+
+	return nil
+}
+
+func (ss *SaptuneService) operationDiff(_ context.Context) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		state, ok := ss.resources[field].(*saptuneServicesState)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to services state",
+				field, ss.resources[field]))
+		}
+
+		output, err := json.Marshal(state)
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	return diff
+}
+
+func (ss *SaptuneService) after(_ context.Context) {
+	if ss.systemdConnector != nil {
+		ss.systemdConnector.Close()
+	}
+}
+
+func (ss *SaptuneService) collectState(ctx context.Context) (*saptuneServicesState, error) {
+	state := &saptuneServicesState{
+		Services: make(map[string]saptuneServiceState),
+	}
+
+	for _, service := range saptuneServiceOrder {
+		enabled, err := ss.systemdConnector.IsEnabled(ctx, service)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if %s service is enabled: %w", service, err)
+		}
+
+		active, err := ss.systemdConnector.IsActive(ctx, service)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if %s service is active: %w", service, err)
+		}
+
+		state.Services[service] = saptuneServiceState{Enabled: enabled, Active: active}
+	}
+
+	tunedProfile, err := ss.saptune.GetActiveTunedProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state.TunedProfile = tunedProfile
+
+	return state, nil
+}
+
+func (ss *SaptuneService) changeServiceState(
+	ctx context.Context,
+	service string,
+	from, to saptuneServiceState,
+) error {
+	if from.Active && !to.Active {
+		if err := ss.systemdConnector.Stop(ctx, service); err != nil {
+			return err
+		}
+	}
+
+	if !from.Enabled && to.Enabled {
+		if _, err := ss.systemdConnector.Enable(ctx, service); err != nil {
+			return err
+		}
+	}
+
+	if from.Enabled && !to.Enabled {
+		if _, err := ss.systemdConnector.Disable(ctx, service); err != nil {
+			return err
+		}
+	}
+
+	if !from.Active && to.Active {
+		if err := ss.systemdConnector.Start(ctx, service); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	saptuneMocks "github.com/trento-project/workbench/internal/saptune/mocks"
+	systemdMocks "github.com/trento-project/workbench/internal/systemd/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+type serviceState struct {
+	enabled bool
+	active  bool
+}
+
+type SaptuneServiceOperatorTestSuite struct {
+	suite.Suite
+	mockSaptuneClient *saptuneMocks.MockSaptune
+	mockSystemd       *systemdMocks.MockSystemd
+	mockSystemdLoader *systemdMocks.MockLoader
+}
+
+func TestSaptuneServiceOperator(t *testing.T) {
+	suite.Run(t, new(SaptuneServiceOperatorTestSuite))
+}
+
+func (suite *SaptuneServiceOperatorTestSuite) SetupTest() {
+	suite.mockSaptuneClient = saptuneMocks.NewMockSaptune(suite.T())
+	suite.mockSystemd = systemdMocks.NewMockSystemd(suite.T())
+	suite.mockSystemdLoader = systemdMocks.NewMockLoader(suite.T())
+}
+
+func (suite *SaptuneServiceOperatorTestSuite) buildOperator() *operator.Executor {
+	return operator.NewSaptuneService(
+		operator.Arguments{},
+		"test-op",
+		operator.Options[operator.SaptuneService]{
+			OperatorOptions: []operator.Option[operator.SaptuneService]{
+				operator.Option[operator.SaptuneService](operator.WithSaptuneClientService(suite.mockSaptuneClient)),
+				operator.Option[operator.SaptuneService](
+					operator.WithCustomSaptuneServiceSystemdLoader(suite.mockSystemdLoader),
+				),
+			},
+		},
+	)
+}
+
+func (suite *SaptuneServiceOperatorTestSuite) expectPlanStart(ctx context.Context) {
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).Once()
+	suite.mockSystemd.On("Close").Return().Once()
+}
+
+func (suite *SaptuneServiceOperatorTestSuite) expectState(
+	ctx context.Context,
+	states map[string]serviceState,
+	tunedProfile string,
+) {
+	for _, service := range []string{"sapconf.service", "tuned.service", "saptune.service"} {
+		suite.mockSystemd.On("IsEnabled", ctx, service).Return(states[service].enabled, nil).Once()
+		suite.mockSystemd.On("IsActive", ctx, service).Return(states[service].active, nil).Once()
+	}
+	suite.mockSaptuneClient.On("GetActiveTunedProfile", ctx).Return(tunedProfile, nil).Once()
+}
+
+func (suite *SaptuneServiceOperatorTestSuite) TestSaptuneServicePlanErrorSystemdConnection() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(nil, errors.New("dbus connection error")).Once()
+
+	report := suite.buildOperator().Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("unable to initialize systemd connector: dbus connection error", report.Error.Message)
+}
+
+func (suite *SaptuneServiceOperatorTestSuite) TestSaptuneServiceAlreadyRunning() {
+	ctx := context.Background()
+
+	suite.expectPlanStart(ctx)
+	suite.expectState(ctx, map[string]serviceState{
+		"saptune.service": {enabled: true, active: true},
+	}, "")
+
+	report := suite.buildOperator().Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	expectedDiff := `{"services":{"sapconf.service":{"enabled":false,"active":false},` +
+		`"saptune.service":{"enabled":true,"active":true},` +
+		`"tuned.service":{"enabled":false,"active":false}},"tuned_profile":""}`
+	suite.EqualValues(map[string]any{
+		"before": expectedDiff,
+		"after":  expectedDiff,
+	}, report.Success.Diff)
+}
+
+func (suite *SaptuneServiceOperatorTestSuite) TestSaptuneServiceSuccess() {
+	ctx := context.Background()
+
+	suite.expectPlanStart(ctx)
+	suite.expectState(ctx, map[string]serviceState{
+		"sapconf.service": {enabled: true, active: true},
+		"tuned.service":   {enabled: true, active: true},
+	}, "throughput-performance")
+	suite.mockSystemd.On("Stop", ctx, "sapconf.service").Return(nil).Once()
+	suite.mockSystemd.On("Disable", ctx, "sapconf.service").Return(nil, nil).Once()
+	suite.mockSystemd.On("Stop", ctx, "tuned.service").Return(nil).Once()
+	suite.mockSystemd.On("Disable", ctx, "tuned.service").Return(nil, nil).Once()
+	suite.mockSystemd.On("Enable", ctx, "saptune.service").Return(nil, nil).Once()
+	suite.mockSystemd.On("Start", ctx, "saptune.service").Return(nil).Once()
+	suite.expectState(ctx, map[string]serviceState{
+		"saptune.service": {enabled: true, active: true},
+	}, "")
+	suite.mockSaptuneClient.On("CheckServiceStatus", ctx).Return(nil).Once()
+
+	report := suite.buildOperator().Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"services":{"sapconf.service":{"enabled":true,"active":true},` +
+			`"saptune.service":{"enabled":false,"active":false},` +
+			`"tuned.service":{"enabled":true,"active":true}},"tuned_profile":"throughput-performance"}`,
+		"after": `{"services":{"sapconf.service":{"enabled":false,"active":false},` +
+			`"saptune.service":{"enabled":true,"active":true},` +
+			`"tuned.service":{"enabled":false,"active":false}},"tuned_profile":""}`,
+	}, report.Success.Diff)
+}
+
+func (suite *SaptuneServiceOperatorTestSuite) TestSaptuneServiceKeepsSaptuneTunedProfile() {
+	ctx := context.Background()
+
+	suite.expectPlanStart(ctx)
+	suite.expectState(ctx, map[string]serviceState{
+		"tuned.service":   {enabled: true, active: true},
+		"saptune.service": {enabled: true},
+	}, "saptune")
+	suite.mockSystemd.On("Start", ctx, "saptune.service").Return(nil).Once()
+	suite.expectState(ctx, map[string]serviceState{
+		"tuned.service":   {enabled: true, active: true},
+		"saptune.service": {enabled: true, active: true},
+	}, "saptune")
+	suite.mockSaptuneClient.On("CheckServiceStatus", ctx).Return(nil).Once()
+
+	report := suite.buildOperator().Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+}
+
+func (suite *SaptuneServiceOperatorTestSuite) TestSaptuneServiceVerifyErrorRollback() {
+	ctx := context.Background()
+
+	suite.expectPlanStart(ctx)
+	suite.expectState(ctx, map[string]serviceState{
+		"sapconf.service": {enabled: true, active: true},
+	}, "")
+	suite.mockSystemd.On("Stop", ctx, "sapconf.service").Return(nil).Once()
+	suite.mockSystemd.On("Disable", ctx, "sapconf.service").Return(nil, nil).Once()
+	suite.mockSystemd.On("Enable", ctx, "saptune.service").Return(nil, nil).Once()
+	suite.mockSystemd.On("Start", ctx, "saptune.service").Return(nil).Once()
+	// verify and rollback
+	suite.expectState(ctx, map[string]serviceState{
+		"saptune.service": {enabled: true, active: true},
+	}, "")
+	suite.mockSaptuneClient.On("CheckServiceStatus", ctx).
+		Return(errors.New("saptune service status failed: exit status 1")).Once()
+	suite.expectState(ctx, map[string]serviceState{
+		"saptune.service": {enabled: true, active: true},
+	}, "")
+	suite.mockSystemd.On("Stop", ctx, "saptune.service").Return(nil).Once()
+	suite.mockSystemd.On("Disable", ctx, "saptune.service").Return(nil, nil).Once()
+	suite.mockSystemd.On("Enable", ctx, "sapconf.service").Return(nil, nil).Once()
+	suite.mockSystemd.On("Start", ctx, "sapconf.service").Return(errors.New("failed to start service")).Once()
+
+	report := suite.buildOperator().Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.ROLLBACK, report.Error.ErrorPhase)
+	suite.EqualValues("error rolling back saptune service states: failed to start service\n"+
+		"saptune service status failed: exit status 1", report.Error.Message)
+}
@@ -0,0 +1,296 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/trento-project/workbench/internal/saptune"
+	"github.com/trento-project/workbench/internal/support"
+)
+
+const SaptuneStagingOperatorName = "saptunestaging"
+
+type SaptuneStagingOption Option[SaptuneStaging]
+
+type saptuneStagingArguments struct {
+	items []string
+}
+
+type saptuneStagedDefinition struct {
+	ID   string                 `json:"id"`
+	Type saptune.StagedItemType `json:"type"`
+	// Definition is the working area definition, nil if it doesn't exist
+	Definition *string `json:"definition"`
+}
+
+type saptuneStagingDiffOutput struct {
+	Items []saptuneStagedDefinition `json:"items"`
+}
+
+// SaptuneStaging operator releases notes and solutions from the saptune staging area.
+//
+// Arguments:
+//  items (required): List of note and solution IDs to release, e.g. ["1656250", "HANA"]
+//
+// # Execution Phases
+//
+// - PLAN:
+//   The operator checks for the presence of the saptune binary and verifies its version.
+//   The minimum required version is 3.1.0.
+//   The staged items are listed with `saptune staging list`. Requested items that are not staged
+//   but have a working definition are considered already released and skipped. The operation fails
+//   if any item is neither staged nor released. If none of the items is staged, the operation is skipped.
+//   The working and staged definitions of the items are saved, and the working definitions
+//   are collected as the "before" diff.
+//
+// - COMMIT:
+//   The items are released with `saptune staging release --force`.
+//
+// - VERIFY:
+//   Checks that the released items are not in the staging area anymore.
+//   The new working definitions are collected as the "after" diff.
+//
+// - ROLLBACK:
+//   The saved working definitions are restored and the items are put back in the staging area.
+
+type SaptuneStaging struct {
+	baseOperator
+	saptune         saptune.Saptune
+	parsedArguments *saptuneStagingArguments
+	snapshots       []*saptune.StagingSnapshot
+}
+
+func WithSaptuneClientStaging(saptuneClient saptune.Saptune) SaptuneStagingOption {
+	return func(o *SaptuneStaging) {
+		o.saptune = saptuneClient
+	}
+}
+
+func NewSaptuneStaging(
+	arguments Arguments,
+	operationID string,
+	options Options[SaptuneStaging],
+) *Executor {
+	saptuneStaging := &SaptuneStaging{
+		baseOperator: newBaseOperator(
+			SaptuneStagingOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+	}
+
+	saptuneStaging.saptune = saptune.NewSaptuneClient(
+		support.CliExecutor{},
+		saptuneStaging.logger,
+	)
+
+	for _, opt := range options.OperatorOptions {
+		opt(saptuneStaging)
+	}
+
+	return &Executor{
+		phaser:      saptuneStaging,
+		operationID: operationID,
+		logger:      saptuneStaging.logger,
+	}
+}
+
+func (ss *SaptuneStaging) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseSaptuneStagingArguments(ss.arguments)
+	if err != nil {
+		return false, err
+	}
+	ss.parsedArguments = opArguments
+
+	if err = ss.saptune.CheckVersionSupport(ctx); err != nil {
+		return false, err
+	}
+
+	stagedItems, err := ss.saptune.ListStaging(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	before := []saptuneStagedDefinition{}
+	unknownItems := []string{}
+	for _, id := range ss.parsedArguments.items {
+		index := slices.IndexFunc(stagedItems, func(item saptune.StagedItem) bool { return item.ID == id })
+		if index == -1 {
+			released, err := ss.isReleased(ctx, id)
+			if err != nil {
+				return false, err
+			}
+
+			if !released {
+				unknownItems = append(unknownItems, id)
+				continue
+			}
+
+			ss.logger.Info("item is already released, skipping it", "id", id)
+			continue
+		}
+
+		snapshot, err := ss.saptune.SnapshotStaging(ctx, stagedItems[index])
+		if err != nil {
+			return false, err
+		}
+		ss.snapshots = append(ss.snapshots, snapshot)
+		before = append(before, newSaptuneStagedDefinition(snapshot.Item, snapshot.Working, snapshot.Working != nil))
+	}
+	ss.resources[beforeDiffField] = before
+
+	if len(unknownItems) > 0 {
+		return false, fmt.Errorf("the items %s are neither staged nor released", strings.Join(unknownItems, ", "))
+	}
+
+	if len(ss.snapshots) == 0 {
+		ss.logger.Info("no requested item is staged, skipping operation")
+		ss.resources[afterDiffField] = before
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (ss *SaptuneStaging) commit(ctx context.Context) error {
+	return ss.saptune.ReleaseStaging(ctx, ss.snapshotIDs()...)
+}
+
+func (ss *SaptuneStaging) verify(ctx context.Context) error {
+	stagedItems, err := ss.saptune.ListStaging(ctx)
+	if err != nil {
+		return err
+	}
+
+	notReleased := []string{}
+	for _, snapshot := range ss.snapshots {
+		if slices.ContainsFunc(stagedItems, func(item saptune.StagedItem) bool { return item.ID == snapshot.Item.ID }) {
+			notReleased = append(notReleased, snapshot.Item.ID)
+		}
+	}
+
+	if len(notReleased) > 0 {
+		return fmt.Errorf(
+			"verify saptune staging release failing, the items %s were not released in commit phase",
+			strings.Join(notReleased, ", "),
+		)
+	}
+
+	after := []saptuneStagedDefinition{}
+	for _, snapshot := range ss.snapshots {
+		content, found, err := ss.saptune.GetWorkingDefinition(ctx, snapshot.Item)
+		if err != nil {
+			return err
+		}
+		after = append(after, newSaptuneStagedDefinition(snapshot.Item, content, found))
+	}
+
+	ss.resources[afterDiffField] = after
+	return nil
+}
+
+func (ss *SaptuneStaging) rollback(ctx context.Context) error {
+	var rollbackErr error
+	for _, snapshot := range ss.snapshots {
+		rollbackErr = errors.Join(rollbackErr, ss.saptune.RevertStagingRelease(ctx, snapshot))
+	}
+
+	if rollbackErr != nil {
+		return fmt.Errorf("error rolling back saptune staging release: %w", rollbackErr)
+	}
+
+	return nil
+}
+
+func (ss *SaptuneStaging) operationDiff(_ context.Context) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		definitions, ok := ss.resources[field].([]saptuneStagedDefinition)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to staged definitions",
+				field, ss.resources[field]))
+		}
+
+		output, err := json.Marshal(saptuneStagingDiffOutput{Items: definitions})
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	return diff
+}
+
+func (ss *SaptuneStaging) snapshotIDs() []string {
+	ids := []string{}
+	for _, snapshot := range ss.snapshots {
+		ids = append(ids, snapshot.Item.ID)
+	}
+	return ids
+}
+
+func newSaptuneStagedDefinition(item saptune.StagedItem, content []byte, found bool) saptuneStagedDefinition {
+	definition := saptuneStagedDefinition{ID: item.ID, Type: item.Type}
+	if found {
+		value := string(content)
+		definition.Definition = &value
+	}
+	return definition
+}
+
+// isReleased checks if the item has a note or solution definition in the working area
+func (ss *SaptuneStaging) isReleased(ctx context.Context, id string) (bool, error) {
+	for _, itemType := range []saptune.StagedItemType{saptune.StagedNote, saptune.StagedSolution} {
+		_, found, err := ss.saptune.GetWorkingDefinition(ctx, saptune.StagedItem{ID: id, Type: itemType})
+		if err != nil || found {
+			return found, err
+		}
+	}
+
+	return false, nil
+}
+
+func parseSaptuneStagingArguments(rawArguments Arguments) (*saptuneStagingArguments, error) {
+	argument, found := rawArguments["items"]
+	if !found {
+		return nil, errors.New("argument items not provided, could not use the operator")
+	}
+
+	var rawItems []any
+	switch value := argument.(type) {
+	case []any:
+		rawItems = value
+	case []string:
+		for _, item := range value {
+			rawItems = append(rawItems, item)
+		}
+	default:
+		return nil, fmt.Errorf(
+			"could not parse items argument as a list, argument provided: %v",
+			argument,
+		)
+	}
+
+	if len(rawItems) == 0 {
+		return nil, errors.New("items argument is empty")
+	}
+
+	items := []string{}
+	for _, rawItem := range rawItems {
+		item, ok := rawItem.(string)
+		if !ok || saptune.ValidateNoteID(item) != nil {
+			return nil, fmt.Errorf("invalid items value: %v", rawItem)
+		}
+		if !slices.Contains(items, item) {
+			items = append(items, item)
+		}
+	}
+
+	return &saptuneStagingArguments{items: items}, nil
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/saptune"
+	"github.com/trento-project/workbench/internal/saptune/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+type SaptuneStagingOperatorTestSuite struct {
+	suite.Suite
+	mockSaptuneClient *mocks.MockSaptune
+	stagedNote        saptune.StagedItem
+	stagedSolution    saptune.StagedItem
+}
+
+func TestSaptuneStagingOperator(t *testing.T) {
+	suite.Run(t, new(SaptuneStagingOperatorTestSuite))
+}
+
+func (suite *SaptuneStagingOperatorTestSuite) SetupTest() {
+	suite.mockSaptuneClient = mocks.NewMockSaptune(suite.T())
+	suite.stagedNote = saptune.StagedItem{ID: "1656250", Type: saptune.StagedNote, Version: "47"}
+	suite.stagedSolution = saptune.StagedItem{ID: "HANA", Type: saptune.StagedSolution}
+}
+
+func (suite *SaptuneStagingOperatorTestSuite) buildOperator(arguments operator.Arguments) *operator.Executor {
+	return operator.NewSaptuneStaging(
+		arguments,
+		"test-op",
+		operator.Options[operator.SaptuneStaging]{
+			OperatorOptions: []operator.Option[operator.SaptuneStaging]{
+				operator.Option[operator.SaptuneStaging](operator.WithSaptuneClientStaging(suite.mockSaptuneClient)),
+			},
+		},
+	)
+}
+
+func (suite *SaptuneStagingOperatorTestSuite) TestSaptuneStagingPlanErrorParsingArguments() {
+	ctx := context.Background()
+
+	cases := []struct {
+		arguments operator.Arguments
+		err       string
+	}{
+		{
+			arguments: operator.Arguments{},
+			err:       "argument items not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{"items": "HANA"},
+			err:       "could not parse items argument as a list, argument provided: HANA",
+		},
+		{
+			arguments: operator.Arguments{"items": []any{}},
+			err:       "items argument is empty",
+		},
+		{
+			arguments: operator.Arguments{"items": []any{"HANA", 1656250}},
+			err:       "invalid items value: 1656250",
+		},
+		{
+			arguments: operator.Arguments{"items": []any{"HANA", "../1656250"}},
+			err:       "invalid items value: ../1656250",
+		},
+	}
+
+	for _, tc := range cases {
+		report := suite.buildOperator(tc.arguments).Run(ctx)
+
+		suite.Nil(report.Success)
+		suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+		suite.EqualValues(tc.err, report.Error.Message)
+	}
+}
+
+func (suite *SaptuneStagingOperatorTestSuite) TestSaptuneStagingNothingStaged() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("ListStaging", ctx).Return([]saptune.StagedItem{suite.stagedSolution}, nil).Once()
+	suite.mockSaptuneClient.On("GetWorkingDefinition", ctx, saptune.StagedItem{ID: "1656250", Type: saptune.StagedNote}).
+		Return([]byte("VERSION=47\n"), true, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"items": []string{"1656250"}}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"items":[]}`,
+		"after":  `{"items":[]}`,
+	}, report.Success.Diff)
+}
+
+func (suite *SaptuneStagingOperatorTestSuite) TestSaptuneStagingUnknownItems() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("ListStaging", ctx).Return([]saptune.StagedItem{}, nil).Once()
+	suite.mockSaptuneClient.On("GetWorkingDefinition", ctx, saptune.StagedItem{ID: "1656250", Type: saptune.StagedNote}).
+		Return([]byte("VERSION=47\n"), true, nil).Once()
+	for _, id := range []string{"165625", "HAAN"} {
+		suite.mockSaptuneClient.On("GetWorkingDefinition", ctx, saptune.StagedItem{ID: id, Type: saptune.StagedNote}).
+			Return(nil, false, nil).Once()
+		suite.mockSaptuneClient.On("GetWorkingDefinition", ctx, saptune.StagedItem{ID: id, Type: saptune.StagedSolution}).
+			Return(nil, false, nil).Once()
+	}
+
+	report := suite.buildOperator(operator.Arguments{"items": []string{"1656250", "165625", "HAAN"}}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("the items 165625, HAAN are neither staged nor released", report.Error.Message)
+}
+
+func (suite *SaptuneStagingOperatorTestSuite) TestSaptuneStagingSuccess() {
+	ctx := context.Background()
+	noteSnapshot := &saptune.StagingSnapshot{
+		Item:    suite.stagedNote,
+		Working: []byte("VERSION=46\n"),
+		Staged:  []byte("VERSION=47\n"),
+	}
+	solutionSnapshot := &saptune.StagingSnapshot{
+		Item:   suite.stagedSolution,
+		Staged: []byte("[ArchX86]\n941735\n"),
+	}
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("ListStaging", ctx).
+		Return([]saptune.StagedItem{suite.stagedNote, suite.stagedSolution}, nil).Once()
+	suite.mockSaptuneClient.On("SnapshotStaging", ctx, suite.stagedNote).Return(noteSnapshot, nil).Once()
+	suite.mockSaptuneClient.On("SnapshotStaging", ctx, suite.stagedSolution).Return(solutionSnapshot, nil).Once()
+	suite.mockSaptuneClient.On("ReleaseStaging", ctx, "1656250", "HANA").Return(nil).Once()
+	suite.mockSaptuneClient.On("ListStaging", ctx).Return([]saptune.StagedItem{}, nil).Once()
+	suite.mockSaptuneClient.On("GetWorkingDefinition", ctx, suite.stagedNote).
+		Return([]byte("VERSION=47\n"), true, nil).Once()
+	suite.mockSaptuneClient.On("GetWorkingDefinition", ctx, suite.stagedSolution).
+		Return([]byte("[ArchX86]\n941735\n"), true, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"items": []any{"1656250", "HANA", "1656250"}}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"items":[{"id":"1656250","type":"note","definition":"VERSION=46\n"},` +
+			`{"id":"HANA","type":"solution","definition":null}]}`,
+		"after": `{"items":[{"id":"1656250","type":"note","definition":"VERSION=47\n"},` +
+			`{"id":"HANA","type":"solution","definition":"[ArchX86]\n941735\n"}]}`,
+	}, report.Success.Diff)
+}
+
+func (suite *SaptuneStagingOperatorTestSuite) TestSaptuneStagingVerifyErrorRollback() {
+	ctx := context.Background()
+	noteSnapshot := &saptune.StagingSnapshot{
+		Item:    suite.stagedNote,
+		Working: []byte("VERSION=46\n"),
+		Staged:  []byte("VERSION=47\n"),
+	}
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("ListStaging", ctx).Return([]saptune.StagedItem{suite.stagedNote}, nil).Twice()
+	suite.mockSaptuneClient.On("SnapshotStaging", ctx, suite.stagedNote).Return(noteSnapshot, nil).Once()
+	suite.mockSaptuneClient.On("ReleaseStaging", ctx, "1656250").Return(nil).Once()
+	suite.mockSaptuneClient.On("RevertStagingRelease", ctx, noteSnapshot).
+		Return(errors.New("could not write definition")).Once()
+
+	report := suite.buildOperator(operator.Arguments{"items": []any{"1656250"}}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.ROLLBACK, report.Error.ErrorPhase)
+	suite.EqualValues("error rolling back saptune staging release: could not write definition\n"+
+		"verify saptune staging release failing, the items 1656250 were not released in commit phase",
+		report.Error.Message)
+}
@@ -0,0 +1,430 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/trento-project/workbench/internal/saptune"
+	"github.com/trento-project/workbench/internal/support"
+)
+
+const (
+	SaptuneVerifyOperatorName       = "saptuneverify"
+	saptuneRemediatedNotesField     = "remediated_notes"
+	saptuneInitialAppliedNotesField = "initial_applied_notes"
+)
+
+type SaptuneVerifyOption Option[SaptuneVerify]
+
+type saptuneVerifyArguments struct {
+	remediate bool
+}
+
+type saptuneParameterDrift struct {
+	NoteID    string `json:"note_id"`
+	Parameter string `json:"parameter"`
+	Expected  string `json:"expected"`
+	Actual    string `json:"actual"`
+	Compliant bool   `json:"compliant"`
+}
+
+type saptuneDriftReport struct {
+	Compliant         bool                    `json:"compliant"`
+	NonCompliantNotes []string                `json:"non_compliant_notes"`
+	Parameters        []saptuneParameterDrift `json:"parameters"`
+}
+
+// SaptuneVerify operator checks the compliance of the host with the enabled saptune solution and notes.
+// By default it is a read-only operator, nothing is changed in the host.
+//
+// Arguments:
+//  remediate (optional): Re-apply the non compliant notes. Default: false
+//
+// The operation diff includes a drift report with the global compliance, the non compliant notes
+// and the expected and actual value of every verified parameter.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   The operator checks for the presence of the saptune binary and verifies its version.
+//   The minimum required version is 3.1.0.
+//   It runs `saptune note verify` and collects the drift report as the "before" diff.
+//   If the system is compliant or remediate is not requested, the operation finishes without running
+//   the rest of phases and the same report is used as the "after" diff.
+//
+// - COMMIT:
+//   Every non compliant note is reverted, if it is applied, and applied again.
+//
+// - VERIFY:
+//   Runs `saptune note verify` again and checks that the remediated notes are compliant.
+//   The new drift report is collected as the "after" diff.
+//
+// - ROLLBACK:
+//   Restores the applied state and the parameter values of the remediated notes. Notes that were not
+//   applied initially are reverted, which restores the values saved by saptune when they were applied.
+//   Notes that were applied initially are applied again with the drifted values collected in the plan
+//   phase, temporarily set in the note override file. The initial override file is restored afterwards.
+//   Drifted parameters that are not found in the note definition are not restored.
+
+// saptuneNoteSnapshot stores the state of an applied note before remediating it
+type saptuneNoteSnapshot struct {
+	// override is the initial override file content, nil if the file doesn't exist
+	override *string
+	// driftedParameters are the non compliant parameters with their initial values
+	driftedParameters []saptuneOverrideParameter
+}
+
+type SaptuneVerify struct {
+	baseOperator
+	saptune         saptune.Saptune
+	parsedArguments *saptuneVerifyArguments
+	noteSnapshots   map[string]*saptuneNoteSnapshot
+}
+
+func WithSaptuneClientVerify(saptuneClient saptune.Saptune) SaptuneVerifyOption {
+	return func(o *SaptuneVerify) {
+		o.saptune = saptuneClient
+	}
+}
+
+func NewSaptuneVerify(
+	arguments Arguments,
+	operationID string,
+	options Options[SaptuneVerify],
+) *Executor {
+	saptuneVerify := &SaptuneVerify{
+		baseOperator: newBaseOperator(
+			SaptuneVerifyOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+	}
+
+	saptuneVerify.saptune = saptune.NewSaptuneClient(
+		support.CliExecutor{},
+		saptuneVerify.logger,
+	)
+
+	for _, opt := range options.OperatorOptions {
+		opt(saptuneVerify)
+	}
+
+	return &Executor{
+		phaser:      saptuneVerify,
+		operationID: operationID,
+		logger:      saptuneVerify.logger,
+	}
+}
+
+func (sv *SaptuneVerify) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseSaptuneVerifyArguments(sv.arguments)
+	if err != nil {
+		return false, err
+	}
+	sv.parsedArguments = opArguments
+
+	if err = sv.saptune.CheckVersionSupport(ctx); err != nil {
+		return false, err
+	}
+
+	verifyResult, err := sv.saptune.Verify(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	report := newSaptuneDriftReport(verifyResult)
+	sv.resources[beforeDiffField] = report
+
+	if report.Compliant || !sv.parsedArguments.remediate {
+		sv.logger.Info("saptune verification finished",
+			"compliant", report.Compliant,
+			"non_compliant_notes", report.NonCompliantNotes)
+		sv.resources[afterDiffField] = report
+		return true, nil
+	}
+
+	appliedNotes, err := sv.saptune.GetAppliedNotes(ctx)
+	if err != nil {
+		return false, err
+	}
+	sv.resources[saptuneInitialAppliedNotesField] = appliedNotes
+
+	sv.noteSnapshots = make(map[string]*saptuneNoteSnapshot)
+	for _, noteID := range report.NonCompliantNotes {
+		if !slices.Contains(appliedNotes, noteID) {
+			continue
+		}
+
+		snapshot, err := sv.snapshotNote(ctx, noteID, verifyResult)
+		if err != nil {
+			return false, err
+		}
+		sv.noteSnapshots[noteID] = snapshot
+	}
+
+	return false, nil
+}
+
+func (sv *SaptuneVerify) commit(ctx context.Context) error {
+	report, _ := sv.resources[beforeDiffField].(*saptuneDriftReport)
+	appliedNotes, _ := sv.resources[saptuneInitialAppliedNotesField].([]string)
+
+	remediatedNotes := []string{}
+	for _, noteID := range report.NonCompliantNotes {
+		remediatedNotes = append(remediatedNotes, noteID)
+		sv.resources[saptuneRemediatedNotesField] = remediatedNotes
+
+		if slices.Contains(appliedNotes, noteID) {
+			if err := sv.saptune.RevertNote(ctx, noteID); err != nil {
+				return err
+			}
+		}
+
+		if err := sv.saptune.ApplyNote(ctx, noteID); err != nil {
+			return err
+		}
+		sv.logger.Info("saptune note remediated", "note", noteID)
+	}
+
+	return nil
+}
+
+func (sv *SaptuneVerify) verify(ctx context.Context) error {
+	verifyResult, err := sv.saptune.Verify(ctx)
+	if err != nil {
+		return err
+	}
+
+	report := newSaptuneDriftReport(verifyResult)
+	remediatedNotes, _ := sv.resources[saptuneRemediatedNotesField].([]string)
+
+	stillNonCompliant := []string{}
+	for _, noteID := range remediatedNotes {
+		if slices.Contains(report.NonCompliantNotes, noteID) {
+			stillNonCompliant = append(stillNonCompliant, noteID)
+		}
+	}
+
+	if len(stillNonCompliant) > 0 {
+		return fmt.Errorf(
+			"verify saptune remediation failing, the notes %s are not compliant after commit phase",
+			strings.Join(stillNonCompliant, ", "),
+		)
+	}
+
+	sv.resources[afterDiffField] = report
+	return nil
+}
+
+func (sv *SaptuneVerify) rollback(ctx context.Context) error {
+	initiallyAppliedNotes, _ := sv.resources[saptuneInitialAppliedNotesField].([]string)
+	remediatedNotes, _ := sv.resources[saptuneRemediatedNotesField].([]string)
+
+	appliedNotes, err := sv.saptune.GetAppliedNotes(ctx)
+	if err != nil {
+		return err
+	}
+
+	var rollbackErr error
+	for _, noteID := range remediatedNotes {
+		wasApplied := slices.Contains(initiallyAppliedNotes, noteID)
+		isApplied := slices.Contains(appliedNotes, noteID)
+
+		switch {
+		case wasApplied:
+			rollbackErr = errors.Join(rollbackErr, sv.restoreNote(ctx, noteID, isApplied))
+		case isApplied:
+			rollbackErr = errors.Join(rollbackErr, sv.saptune.RevertNote(ctx, noteID))
+		}
+	}
+
+	if rollbackErr != nil {
+		return fmt.Errorf("error rolling back saptune remediation: %w", rollbackErr)
+	}
+
+	return nil
+}
+
+func (sv *SaptuneVerify) operationDiff(_ context.Context) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		report, ok := sv.resources[field].(*saptuneDriftReport)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to saptune drift report",
+				field, sv.resources[field]))
+		}
+
+		output, err := json.Marshal(report)
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	return diff
+}
+
+// snapshotNote collects the initial override file and the values of the drifted parameters of an applied note
+func (sv *SaptuneVerify) snapshotNote(
+	ctx context.Context,
+	noteID string,
+	verifyResult *saptune.VerifyResult,
+) (*saptuneNoteSnapshot, error) {
+	definition, err := sv.saptune.GetNoteDefinition(ctx, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, found, err := sv.saptune.GetNoteOverride(ctx, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &saptuneNoteSnapshot{
+		override:          overrideContentPointer(content, found),
+		driftedParameters: []saptuneOverrideParameter{},
+	}
+
+	for _, record := range verifyResult.Records {
+		if record.NoteID != noteID || record.Compliant {
+			continue
+		}
+
+		section, name, found := findVerifiedParameter(definition, record.Parameter)
+		if !found {
+			sv.logger.Warn("drifted parameter not found in the note definition, it is not restored on rollback",
+				"note", noteID, "parameter", record.Parameter)
+			continue
+		}
+
+		value := record.ActualValue
+		snapshot.driftedParameters = append(snapshot.driftedParameters, saptuneOverrideParameter{
+			section: section,
+			name:    name,
+			value:   &value,
+		})
+	}
+
+	return snapshot, nil
+}
+
+// restoreNote applies an initially applied note again with its drifted parameter values,
+// setting them temporarily in the override file
+func (sv *SaptuneVerify) restoreNote(ctx context.Context, noteID string, isApplied bool) error {
+	snapshot, found := sv.noteSnapshots[noteID]
+	if !found || len(snapshot.driftedParameters) == 0 {
+		if isApplied {
+			return nil
+		}
+		return sv.saptune.ApplyNote(ctx, noteID)
+	}
+
+	initialSections := []saptune.NoteSection{}
+	if snapshot.override != nil {
+		initialSections = saptune.ParseNoteFile([]byte(*snapshot.override))
+	}
+
+	driftedOverride, err := saptune.FormatNoteFile(
+		applySaptuneOverrideParameters(initialSections, snapshot.driftedParameters),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := sv.saptune.WriteNoteOverride(ctx, noteID, driftedOverride); err != nil {
+		return err
+	}
+
+	var reapplyErr error
+	if isApplied {
+		reapplyErr = sv.saptune.RevertNote(ctx, noteID)
+	}
+	if reapplyErr == nil {
+		reapplyErr = sv.saptune.ApplyNote(ctx, noteID)
+	}
+
+	var restoreErr error
+	if snapshot.override == nil {
+		restoreErr = sv.saptune.DeleteNoteOverride(ctx, noteID)
+	} else {
+		restoreErr = sv.saptune.WriteNoteOverride(ctx, noteID, []byte(*snapshot.override))
+	}
+
+	return errors.Join(reapplyErr, restoreErr)
+}
+
+// findVerifiedParameter returns the note definition section and name of a parameter reported by saptune verify.
+// Some parameters are reported with a section prefix or a device suffix, e.g. grub:intel_idle.max_cstate
+// or IO_SCHEDULER_vda.
+func findVerifiedParameter(definition []saptune.NoteSection, parameter string) (string, string, bool) {
+	if section, found := findNoteParameterSection(definition, parameter); found {
+		return section, parameter, true
+	}
+
+	if prefix, name, found := strings.Cut(parameter, ":"); found {
+		if _, found := findNoteParameterValue(definition, prefix, name); found {
+			return prefix, name, true
+		}
+	}
+
+	for _, section := range definition {
+		for _, definitionParameter := range section.Parameters {
+			if strings.HasPrefix(parameter, definitionParameter.Name+"_") {
+				return section.Name, definitionParameter.Name, true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+func newSaptuneDriftReport(verifyResult *saptune.VerifyResult) *saptuneDriftReport {
+	parameters := []saptuneParameterDrift{}
+	for _, record := range verifyResult.Records {
+		// the override value takes precedence over the note value
+		expected := record.ExpectedValue
+		if record.OverrideValue != "" {
+			expected = record.OverrideValue
+		}
+
+		parameters = append(parameters, saptuneParameterDrift{
+			NoteID:    record.NoteID,
+			Parameter: record.Parameter,
+			Expected:  expected,
+			Actual:    record.ActualValue,
+			Compliant: record.Compliant,
+		})
+	}
+
+	return &saptuneDriftReport{
+		Compliant:         verifyResult.Compliant,
+		NonCompliantNotes: verifyResult.NonCompliantNotes(),
+		Parameters:        parameters,
+	}
+}
+
+func parseSaptuneVerifyArguments(rawArguments Arguments) (*saptuneVerifyArguments, error) {
+	arguments := &saptuneVerifyArguments{}
+
+	argument, found := rawArguments["remediate"]
+	if !found {
+		return arguments, nil
+	}
+
+	remediate, ok := argument.(bool)
+	if !ok {
+		return nil, fmt.Errorf(
+			"could not parse remediate argument as bool, argument provided: %v",
+			argument,
+		)
+	}
+	arguments.remediate = remediate
+
+	return arguments, nil
+}
@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/saptune"
+	"github.com/trento-project/workbench/internal/saptune/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+const (
+	saptuneNonCompliantReport = `{"compliant":false,"non_compliant_notes":["941735"],"parameters":[` +
+		`{"note_id":"941735","parameter":"kernel.shmmax","expected":"18446744073709551615",` +
+		`"actual":"68719476736","compliant":false},` +
+		`{"note_id":"1868829","parameter":"grub:intel_idle.max_cstate","expected":"1","actual":"1","compliant":true}]}`
+	saptuneCompliantReport = `{"compliant":true,"non_compliant_notes":[],"parameters":[` +
+		`{"note_id":"941735","parameter":"kernel.shmmax","expected":"18446744073709551615",` +
+		`"actual":"18446744073709551615","compliant":true},` +
+		`{"note_id":"1868829","parameter":"grub:intel_idle.max_cstate","expected":"1","actual":"1","compliant":true}]}`
+)
+
+type SaptuneVerifyOperatorTestSuite struct {
+	suite.Suite
+	mockSaptuneClient *mocks.MockSaptune
+}
+
+func TestSaptuneVerifyOperator(t *testing.T) {
+	suite.Run(t, new(SaptuneVerifyOperatorTestSuite))
+}
+
+func (suite *SaptuneVerifyOperatorTestSuite) SetupTest() {
+	suite.mockSaptuneClient = mocks.NewMockSaptune(suite.T())
+}
+
+func (suite *SaptuneVerifyOperatorTestSuite) buildOperator(arguments operator.Arguments) *operator.Executor {
+	return operator.NewSaptuneVerify(
+		arguments,
+		"test-op",
+		operator.Options[operator.SaptuneVerify]{
+			OperatorOptions: []operator.Option[operator.SaptuneVerify]{
+				operator.Option[operator.SaptuneVerify](operator.WithSaptuneClientVerify(suite.mockSaptuneClient)),
+			},
+		},
+	)
+}
+
+func saptuneVerifyResult(compliant bool) *saptune.VerifyResult {
+	actual := "68719476736"
+	if compliant {
+		actual = "18446744073709551615"
+	}
+
+	return &saptune.VerifyResult{
+		Compliant: compliant,
+		Records: []saptune.ComplianceRecord{
+			{
+				NoteID:        "941735",
+				Parameter:     "kernel.shmmax",
+				ExpectedValue: "18446744073709551615",
+				ActualValue:   actual,
+				Compliant:     compliant,
+			},
+			{
+				NoteID:        "1868829",
+				Parameter:     "grub:intel_idle.max_cstate",
+				ExpectedValue: "1",
+				ActualValue:   "1",
+				Compliant:     true,
+			},
+		},
+	}
+}
+
+func saptuneNoteDefinition() []saptune.NoteSection {
+	return []saptune.NoteSection{
+		{
+			Name: "sysctl",
+			Parameters: []saptune.NoteParameter{
+				{Name: "kernel.shmmax", Value: "18446744073709551615"},
+			},
+		},
+	}
+}
+
+func (suite *SaptuneVerifyOperatorTestSuite) TestSaptuneVerifyPlanErrorParsingArguments() {
+	ctx := context.Background()
+
+	report := suite.buildOperator(operator.Arguments{"remediate": "yes"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("could not parse remediate argument as bool, argument provided: yes", report.Error.Message)
+}
+
+func (suite *SaptuneVerifyOperatorTestSuite) TestSaptuneVerifyPlanErrorVerifying() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("Verify", ctx).
+		Return(nil, errors.New("could not call saptune note verify: exit status 1")).Once()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("could not call saptune note verify: exit status 1", report.Error.Message)
+}
+
+func (suite *SaptuneVerifyOperatorTestSuite) TestSaptuneVerifyReadOnly() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("Verify", ctx).Return(saptuneVerifyResult(false), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": saptuneNonCompliantReport,
+		"after":  saptuneNonCompliantReport,
+	}, report.Success.Diff)
+}
+
+func (suite *SaptuneVerifyOperatorTestSuite) TestSaptuneVerifyReadOnlyOverrideValue() {
+	ctx := context.Background()
+
+	verifyResult := saptuneVerifyResult(false)
+	verifyResult.Records[0].OverrideValue = "68719476736"
+	verifyResult.Records[0].Compliant = true
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("Verify", ctx).Return(verifyResult, nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.Contains(report.Success.Diff["before"],
+		`{"note_id":"941735","parameter":"kernel.shmmax","expected":"68719476736",`+
+			`"actual":"68719476736","compliant":true}`)
+}
+
+func (suite *SaptuneVerifyOperatorTestSuite) TestSaptuneVerifyRemediateCompliant() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("Verify", ctx).Return(saptuneVerifyResult(true), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"remediate": true}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": saptuneCompliantReport,
+		"after":  saptuneCompliantReport,
+	}, report.Success.Diff)
+}
+
+func (suite *SaptuneVerifyOperatorTestSuite) TestSaptuneVerifyRemediateSuccess() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("Verify", ctx).Return(saptuneVerifyResult(false), nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"941735", "1868829"}, nil).Once()
+	suite.mockSaptuneClient.On("GetNoteDefinition", ctx, "941735").Return(saptuneNoteDefinition(), nil).Once()
+	suite.mockSaptuneClient.On("GetNoteOverride", ctx, "941735").Return(nil, false, nil).Once()
+	suite.mockSaptuneClient.On("RevertNote", ctx, "941735").Return(nil).Once()
+	suite.mockSaptuneClient.On("ApplyNote", ctx, "941735").Return(nil).Once()
+	suite.mockSaptuneClient.On("Verify", ctx).Return(saptuneVerifyResult(true), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"remediate": true}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": saptuneNonCompliantReport,
+		"after":  saptuneCompliantReport,
+	}, report.Success.Diff)
+}
+
+func (suite *SaptuneVerifyOperatorTestSuite) TestSaptuneVerifyRemediateCommitErrorRollback() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("Verify", ctx).Return(saptuneVerifyResult(false), nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"941735", "1868829"}, nil).Once()
+	suite.mockSaptuneClient.On("GetNoteDefinition", ctx, "941735").Return(saptuneNoteDefinition(), nil).Once()
+	suite.mockSaptuneClient.On("GetNoteOverride", ctx, "941735").
+		Return([]byte("# custom values\n[sysctl]\nvm.swappiness=10\n"), true, nil).Once()
+	suite.mockSaptuneClient.On("RevertNote", ctx, "941735").Return(nil).Once()
+	suite.mockSaptuneClient.On("ApplyNote", ctx, "941735").
+		Return(errors.New("could not perform saptune note apply 941735, error: exit status 1")).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"1868829"}, nil).Once()
+	suite.mockSaptuneClient.On("WriteNoteOverride", ctx, "941735",
+		[]byte("[sysctl]\nvm.swappiness=10\nkernel.shmmax=68719476736\n")).Return(nil).Once()
+	suite.mockSaptuneClient.On("ApplyNote", ctx, "941735").Return(nil).Once()
+	suite.mockSaptuneClient.On("WriteNoteOverride", ctx, "941735",
+		[]byte("# custom values\n[sysctl]\nvm.swappiness=10\n")).Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"remediate": true}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues("could not perform saptune note apply 941735, error: exit status 1", report.Error.Message)
+}
+
+func (suite *SaptuneVerifyOperatorTestSuite) TestSaptuneVerifyRemediateVerifyErrorRestoreValues() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("Verify", ctx).Return(saptuneVerifyResult(false), nil).Twice()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"941735", "1868829"}, nil).Twice()
+	suite.mockSaptuneClient.On("GetNoteDefinition", ctx, "941735").Return(saptuneNoteDefinition(), nil).Once()
+	suite.mockSaptuneClient.On("GetNoteOverride", ctx, "941735").Return(nil, false, nil).Once()
+	suite.mockSaptuneClient.On("RevertNote", ctx, "941735").Return(nil).Twice()
+	suite.mockSaptuneClient.On("ApplyNote", ctx, "941735").Return(nil).Twice()
+	suite.mockSaptuneClient.On("WriteNoteOverride", ctx, "941735",
+		[]byte("[sysctl]\nkernel.shmmax=68719476736\n")).Return(nil).Once()
+	suite.mockSaptuneClient.On("DeleteNoteOverride", ctx, "941735").Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"remediate": true}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"verify saptune remediation failing, the notes 941735 are not compliant after commit phase",
+		report.Error.Message,
+	)
+}
+
+func (suite *SaptuneVerifyOperatorTestSuite) TestSaptuneVerifyRemediateVerifyError() {
+	ctx := context.Background()
+
+	suite.mockSaptuneClient.On("CheckVersionSupport", ctx).Return(nil).Once()
+	suite.mockSaptuneClient.On("Verify", ctx).Return(saptuneVerifyResult(false), nil).Twice()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"1868829"}, nil).Once()
+	suite.mockSaptuneClient.On("ApplyNote", ctx, "941735").Return(nil).Once()
+	suite.mockSaptuneClient.On("GetAppliedNotes", ctx).Return([]string{"941735", "1868829"}, nil).Once()
+	suite.mockSaptuneClient.On("RevertNote", ctx, "941735").Return(nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"remediate": true}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"verify saptune remediation failing, the notes 941735 are not compliant after commit phase",
+		report.Error.Message,
+	)
+}
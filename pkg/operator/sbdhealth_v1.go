@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/sbd"
+)
+
+const (
+	SBDHealthOperatorName = "sbdhealth"
+	sbdHealthReportField  = "sbd_health_report"
+)
+
+type SBDHealthOption Option[SBDHealth]
+
+type sbdDeviceHealth struct {
+	Header *sbd.DeviceHeader `json:"header"`
+	Slots  []sbd.Slot        `json:"slots"`
+}
+
+type sbdHealthReport struct {
+	Configuration *sbd.Configuration `json:"configuration"`
+	Devices       []sbdDeviceHealth  `json:"devices"`
+	// Watchdog is the configured watchdog device. It is nil if the device is not found in the host.
+	Watchdog       *sbd.WatchdogDevice `json:"watchdog"`
+	StonithDevices []string            `json:"stonith_devices"`
+	Issues         []string            `json:"issues"`
+	Healthy        bool                `json:"healthy"`
+}
+
+// SBDHealth operator verifies the health of the SBD fencing setup in the host.
+// It is a read-only operator, nothing is changed in the host.
+//
+// The operation diff includes the collected SBD information in both the before and after fields:
+// the SBD configuration, the header and node slots of each SBD device, the configured watchdog
+// device and the fencing devices registered in the cluster. The report is included in the diff
+// of the error report as well, with the found issues and the healthy flag set to false.
+//
+// The following issues make the operation fail:
+//  - The local node doesn't have a slot in an SBD device
+//  - An SBD device has a pending message for a node
+//  - An SBD device msgwait timeout is lower than twice its watchdog timeout
+//  - The configured watchdog device is not found or it is not used by the sbd daemon
+//  - No fencing device is registered in the cluster
+//
+// # Execution Phases
+//
+// - PLAN:
+//   Checks that the cluster is running in the host and collects the SBD configuration,
+//   the `sbd -d <device> dump` and `sbd -d <device> list` output of every SBD device,
+//   the `sbd query-watchdog` output and the `stonith_admin --list-registered` output.
+//   The operation fails if any issue is found. Otherwise, it finishes without running the rest of phases.
+//
+// - COMMIT:
+//   Nothing is done.
+//
+// - VERIFY:
+//   Nothing is done.
+//
+// - ROLLBACK:
+//   Nothing is done.
+
+type SBDHealth struct {
+	baseOperator
+	sbdClient     sbd.SBD
+	clusterClient cluster.Cluster
+}
+
+func WithCustomSBDHealthSBDClient(sbdClient sbd.SBD) SBDHealthOption {
+	return func(o *SBDHealth) {
+		o.sbdClient = sbdClient
+	}
+}
+
+func WithCustomSBDHealthClusterClient(clusterClient cluster.Cluster) SBDHealthOption {
+	return func(o *SBDHealth) {
+		o.clusterClient = clusterClient
+	}
+}
+
+func NewSBDHealth(
+	arguments Arguments,
+	operationID string,
+	options Options[SBDHealth],
+) *Executor {
+	sbdHealth := &SBDHealth{
+		baseOperator:  newBaseOperator(SBDHealthOperatorName, operationID, arguments, options.BaseOperatorOptions...),
+		sbdClient:     sbd.NewDefaultSBDClient(),
+		clusterClient: cluster.NewDefaultClusterClient(),
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(sbdHealth)
+	}
+
+	return &Executor{
+		phaser:      sbdHealth,
+		operationID: operationID,
+		logger:      sbdHealth.logger,
+	}
+}
+
+func (s *SBDHealth) plan(ctx context.Context) (bool, error) {
+	if !s.clusterClient.IsHostOnline(ctx) {
+		return false, errors.New("cluster is not running on host")
+	}
+
+	report, err := s.collectHealthReport(ctx)
+	if err != nil {
+		return false, err
+	}
+	s.resources[sbdHealthReportField] = report
+
+	report.Healthy = len(report.Issues) == 0
+	if !report.Healthy {
+		err := fmt.Errorf("SBD health verification failed: %s", strings.Join(report.Issues, "; "))
+		return false, withDiff(err, s.operationDiff(ctx))
+	}
+
+	s.logger.Info("SBD health verification passed")
+	return true, nil
+}
+
+func (s *SBDHealth) commit(_ context.Context) error {
+	return nil
+}
+
+func (s *SBDHealth) verify(_ context.Context) error {
+	return nil
+}
+
+func (s *SBDHealth) rollback(_ context.Context) error {
+	return nil
+}
+
+func (s *SBDHealth) operationDiff(_ context.Context) map[string]any {
+	diff := make(map[string]any)
+
+	report, ok := s.resources[sbdHealthReportField].(*sbdHealthReport)
+	if !ok {
+		panic(fmt.Sprintf("invalid SBD health report value: cannot parse '%v' to SBD health report",
+			s.resources[sbdHealthReportField]))
+	}
+
+	output, err := json.Marshal(report)
+	if err != nil {
+		panic(fmt.Sprintf("error marshalling SBD health report: %v", err))
+	}
+
+	// read-only operator, the state before and after the operation is the same
+	diff[beforeDiffField] = string(output)
+	diff[afterDiffField] = string(output)
+
+	return diff
+}
+
+func (s *SBDHealth) collectHealthReport(ctx context.Context) (*sbdHealthReport, error) {
+	config, err := s.sbdClient.GetConfiguration(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	localNode, err := s.clusterClient.LocalNodeName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &sbdHealthReport{
+		Configuration: config,
+		Devices:       []sbdDeviceHealth{},
+		Issues:        []string{},
+	}
+
+	for _, device := range config.Devices {
+		header, err := s.sbdClient.DumpHeader(ctx, device)
+		if err != nil {
+			return nil, err
+		}
+
+		slots, err := s.sbdClient.ListSlots(ctx, device)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Devices = append(report.Devices, sbdDeviceHealth{Header: header, Slots: slots})
+		report.Issues = append(report.Issues, sbdDeviceIssues(localNode, header, slots)...)
+	}
+
+	watchdogs, err := s.sbdClient.QueryWatchdogs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, watchdog := range watchdogs {
+		if watchdog.Path == config.WatchdogDevice {
+			report.Watchdog = &watchdog
+			break
+		}
+	}
+
+	switch {
+	case report.Watchdog == nil:
+		report.Issues = append(report.Issues, fmt.Sprintf("watchdog device %s not found", config.WatchdogDevice))
+	case !report.Watchdog.Busy:
+		report.Issues = append(report.Issues,
+			fmt.Sprintf("watchdog device %s is not used by the sbd daemon", config.WatchdogDevice))
+	}
+
+	stonithDevices, err := s.clusterClient.ListStonithDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.StonithDevices = stonithDevices
+
+	if len(stonithDevices) == 0 {
+		report.Issues = append(report.Issues, "no fencing device registered in the cluster")
+	}
+
+	return report, nil
+}
+
+func sbdDeviceIssues(localNode string, header *sbd.DeviceHeader, slots []sbd.Slot) []string {
+	issues := []string{}
+	localSlotFound := false
+
+	for _, slot := range slots {
+		if slot.Node == localNode {
+			localSlotFound = true
+		}
+
+		if slot.Message != sbd.ClearMessage {
+			issues = append(issues, fmt.Sprintf("SBD device %s has a pending %s message for node %s",
+				header.Device, slot.Message, slot.Node))
+		}
+	}
+
+	if !localSlotFound {
+		issues = append(issues, fmt.Sprintf("node %s doesn't have a slot in SBD device %s", localNode, header.Device))
+	}
+
+	if header.MsgwaitTimeout < 2*header.WatchdogTimeout {
+		issues = append(issues, fmt.Sprintf(
+			"SBD device %s msgwait timeout %ds is lower than twice the watchdog timeout %ds",
+			header.Device, header.MsgwaitTimeout, header.WatchdogTimeout,
+		))
+	}
+
+	return issues
+}
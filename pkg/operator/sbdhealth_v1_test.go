@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tidwall/gjson"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/sbd"
+	"github.com/trento-project/workbench/internal/support/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+const (
+	firstSBDDevice  = "/dev/disk/by-id/scsi-SLIO-ORG_IBLOCK_1b2c3d4e"
+	secondSBDDevice = "/dev/disk/by-id/scsi-SLIO-ORG_IBLOCK_5f6a7b8c"
+)
+
+type SBDHealthOperatorTestSuite struct {
+	suite.Suite
+	mockExecutor *mocks.MockCmdExecutor
+}
+
+func TestSBDHealthOperator(t *testing.T) {
+	suite.Run(t, new(SBDHealthOperatorTestSuite))
+}
+
+func (suite *SBDHealthOperatorTestSuite) SetupTest() {
+	suite.mockExecutor = mocks.NewMockCmdExecutor(suite.T())
+}
+
+func (suite *SBDHealthOperatorTestSuite) buildOperator() *operator.Executor {
+	return operator.NewSBDHealth(
+		operator.Arguments{},
+		"test-op",
+		operator.Options[operator.SBDHealth]{
+			OperatorOptions: []operator.Option[operator.SBDHealth]{
+				operator.Option[operator.SBDHealth](operator.WithCustomSBDHealthSBDClient(
+					sbd.NewSBDClient(suite.mockExecutor, helpers.GetFixturePath("sbd/sysconfig_sbd"), slog.Default()),
+				)),
+				operator.Option[operator.SBDHealth](operator.WithCustomSBDHealthClusterClient(
+					cluster.NewClusterClient(suite.mockExecutor, slog.Default()),
+				)),
+			},
+		},
+	)
+}
+
+func (suite *SBDHealthOperatorTestSuite) mockClusterOnline(ctx context.Context) {
+	suite.mockExecutor.On("Exec", ctx, "crm", "status").Return([]byte("Online"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm_node", "-n").Return([]byte("vmhana01\n"), nil).Once()
+}
+
+func (suite *SBDHealthOperatorTestSuite) mockSBDDevices(ctx context.Context, secondDeviceList string) {
+	for device, list := range map[string]string{
+		firstSBDDevice:  "sbd/sbd_list.output",
+		secondSBDDevice: secondDeviceList,
+	} {
+		suite.mockExecutor.On("Exec", ctx, "sbd", "-d", device, "dump").
+			Return(helpers.ReadFixture("sbd/sbd_dump.output"), nil).Once()
+		suite.mockExecutor.On("Exec", ctx, "sbd", "-d", device, "list").
+			Return(helpers.ReadFixture(list), nil).Once()
+	}
+}
+
+func (suite *SBDHealthOperatorTestSuite) TestSBDHealthClusterNotRunning() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "crm", "status").Return([]byte(""), errors.New("not running")).Once()
+
+	report := suite.buildOperator().Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("cluster is not running on host", report.Error.Message)
+}
+
+func (suite *SBDHealthOperatorTestSuite) TestSBDHealthCollectError() {
+	ctx := context.Background()
+
+	suite.mockClusterOnline(ctx)
+	suite.mockExecutor.On("Exec", ctx, "sbd", "-d", firstSBDDevice, "dump").
+		Return([]byte("== disk unreadable!"), errors.New("exit status 1")).Once()
+
+	report := suite.buildOperator().Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"could not dump SBD device "+firstSBDDevice+" header: exit status 1, output: == disk unreadable!",
+		report.Error.Message,
+	)
+}
+
+func (suite *SBDHealthOperatorTestSuite) TestSBDHealthIssuesFound() {
+	ctx := context.Background()
+
+	suite.mockClusterOnline(ctx)
+	suite.mockSBDDevices(ctx, "sbd/sbd_list_pending_message.output")
+	suite.mockExecutor.On("Exec", ctx, "sbd", "query-watchdog").
+		Return(helpers.ReadFixture("sbd/sbd_query_watchdog_not_busy.output"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "stonith_admin", "--list-registered").
+		Return([]byte("0 fence devices found\n"), nil).Once()
+
+	report := suite.buildOperator().Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"SBD health verification failed: "+
+			"SBD device "+secondSBDDevice+" has a pending test message for node vmhana02; "+
+			"watchdog device /dev/watchdog is not used by the sbd daemon; "+
+			"no fencing device registered in the cluster",
+		report.Error.Message,
+	)
+
+	diff, ok := report.Error.Diff["before"].(string)
+	suite.True(ok)
+	suite.Equal(report.Error.Diff["before"], report.Error.Diff["after"])
+	suite.False(gjson.Get(diff, "healthy").Bool())
+	suite.Equal(int64(3), gjson.Get(diff, "issues.#").Int())
+	suite.False(gjson.Get(diff, "watchdog.busy").Bool())
+	suite.Equal(`[]`, gjson.Get(diff, "stonith_devices").Raw)
+}
+
+func (suite *SBDHealthOperatorTestSuite) TestSBDHealthSuccess() {
+	ctx := context.Background()
+
+	suite.mockClusterOnline(ctx)
+	suite.mockSBDDevices(ctx, "sbd/sbd_list.output")
+	suite.mockExecutor.On("Exec", ctx, "sbd", "query-watchdog").
+		Return(helpers.ReadFixture("sbd/sbd_query_watchdog.output"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "stonith_admin", "--list-registered").
+		Return(helpers.ReadFixture("cluster/stonith_admin_list_registered.output"), nil).Once()
+
+	report := suite.buildOperator().Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.Equal(report.Success.Diff["before"], report.Success.Diff["after"])
+
+	diff, ok := report.Success.Diff["before"].(string)
+	suite.True(ok)
+	suite.Equal(
+		`["`+firstSBDDevice+`","`+secondSBDDevice+`"]`,
+		gjson.Get(diff, "configuration.devices").Raw,
+	)
+	suite.Equal("/dev/watchdog", gjson.Get(diff, "configuration.watchdog_device").String())
+	suite.Equal(int64(2), gjson.Get(diff, "devices.#").Int())
+	suite.Equal(int64(30), gjson.Get(diff, "devices.0.header.msgwait_timeout").Int())
+	suite.Equal(int64(15), gjson.Get(diff, "devices.0.header.watchdog_timeout").Int())
+	suite.Equal(`["vmhana01","vmhana02"]`, gjson.Get(diff, "devices.1.slots.#.node").Raw)
+	suite.True(gjson.Get(diff, "watchdog.busy").Bool())
+	suite.Equal(`["stonith-sbd"]`, gjson.Get(diff, "stonith_devices").Raw)
+	suite.Equal(`[]`, gjson.Get(diff, "issues").Raw)
+	suite.True(gjson.Get(diff, "healthy").Bool())
+}
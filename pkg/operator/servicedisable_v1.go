@@ -5,37 +5,61 @@ package operator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/trento-project/workbench/internal/systemd"
 )
 
-const PacemakerDisableOperatorName = "pacemakerdisable"
+const (
+	ServiceDisableOperatorName   = "servicedisable"
+	PacemakerDisableOperatorName = "pacemakerdisable"
+	serviceMaskArgument          = "mask"
+	unitInactive                 = "inactive"
+	unitFailed                   = "failed"
+)
 
 type ServiceDisableOption Option[ServiceDisable]
 
 // ServiceDisable operator disables a systemd unit.
 //
+// The unit is fixed with the WithServiceToDisable option, as in the pacemakerdisable operator,
+// or given in the arguments of the generic servicedisable operator.
+//
+// Arguments (servicedisable only):
+//  service (required): Name of the unit, e.g. sbd.service. Only some cluster and tuning
+//                      units are allowed
+//  now: Stop the unit in addition to disabling it. Default false
+//  runtime: Remove the runtime enablement of the unit, and mask it only until the next reboot. Default false
+//  mask: Mask the unit, so it cannot be started at all. Default false
+//
 // # Execution Phases
 //
 // - PLAN:
-//   The operator connects to systemd and determines if the service is enabled.
-//   The operation is skipped if the service is already disabled.
+//   The operator connects to systemd and determines if the service is enabled, masked if mask is set
+//   and active if now is set. The operation is skipped if the service is already in the requested state.
 //
 // - COMMIT:
-//   It disables the systemd unit.
+//   It stops the unit if now is set, disables the systemd unit and masks it if mask is set.
+//   The symlinks removed by systemd are reported in the after diff.
 //
 // - VERIFY:
-//   The operator checks if the service is disabled after the commit phase.
+//   The operator checks if the service is disabled, masked if mask is set and stopped if now is set,
+//   after the commit phase.
 //
 // - ROLLBACK:
-//   If an error occurs during the COMMIT or VERIFY phase, the service is enabled back again.
+//   If an error occurs during the COMMIT or VERIFY phase, the service is unmasked, enabled and
+//   started back again, depending on its initial state. If the unit was disabled, exactly the
+//   symlinks removed in the commit phase are restored.
 
 type ServiceDisable struct {
 	baseOperator
 	systemdLoader    systemd.Loader
 	systemdConnector systemd.Systemd
 	service          string
+	parsedArguments  *serviceUnitArguments
+	// unitFileChanges are the symlinks changed when disabling the unit, nil if it was not disabled
+	unitFileChanges []systemd.UnitFileChange
 }
 
 func WithCustomServiceDisableSystemdLoader(systemdLoader systemd.Loader) ServiceDisableOption {
@@ -75,6 +99,13 @@ func NewServiceDisable(
 }
 
 func (sd *ServiceDisable) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseServiceUnitArguments(sd.arguments, sd.service, serviceMaskArgument)
+	if err != nil {
+		return false, err
+	}
+	sd.parsedArguments = opArguments
+	service := opArguments.service
+
 	systemdConnector, err := sd.systemdLoader.NewSystemd(ctx, sd.logger)
 	if err != nil {
 		sd.logger.Error("unable to initialize systemd connector", "error", err)
@@ -82,56 +113,138 @@ func (sd *ServiceDisable) plan(ctx context.Context) (bool, error) {
 	}
 	sd.systemdConnector = systemdConnector
 
-	serviceEnabled, err := sd.systemdConnector.IsEnabled(ctx, sd.service)
+	state, err := getServiceUnitState(ctx, sd.systemdConnector, sd.parsedArguments)
 	if err != nil {
-		sd.logger.Error("failed to check if service is enabled", "service", sd.service, "error", err)
-		return false, fmt.Errorf("failed to check if %s service is enabled: %w", sd.service, err)
+		sd.logger.Error("failed to check if service is enabled", "service", service, "error", err)
+		return false, fmt.Errorf("failed to check if %s service is enabled: %w", service, err)
 	}
 
-	sd.resources[beforeDiffField] = serviceEnabled
+	sd.resources[beforeDiffField] = state
 
-	if !serviceEnabled {
-		sd.logger.Info("service is already disabled, skipping operation", "service", sd.service)
-		sd.resources[afterDiffField] = serviceEnabled
+	if sd.unitFileDisabled(state) && (!sd.parsedArguments.now || isUnitStopped(state.ActiveState)) {
+		sd.logger.Info("service is already disabled, skipping operation", "service", service)
+		sd.resources[afterDiffField] = state
 		return true, nil
 	}
 	return false, nil
 }
 
 func (sd *ServiceDisable) commit(ctx context.Context) error {
-	if err := sd.systemdConnector.Disable(ctx, sd.service); err != nil {
-		sd.logger.Error("failed to disable service", "service", sd.service, "error", err)
-		return fmt.Errorf("failed to disable service %s: %w", sd.service, err)
+	service := sd.parsedArguments.service
+	initialState, _ := sd.resources[beforeDiffField].(systemd.UnitState)
+
+	if sd.parsedArguments.now && !isUnitStopped(initialState.ActiveState) {
+		if err := sd.systemdConnector.Stop(ctx, service); err != nil {
+			sd.logger.Error("failed to stop service", "service", service, "error", err)
+			return fmt.Errorf("failed to stop service %s: %w", service, err)
+		}
+	}
+
+	if initialState.UnitFileState == sd.enabledUnitFileState() {
+		disable := sd.systemdConnector.Disable
+		if sd.parsedArguments.runtime {
+			disable = sd.systemdConnector.DisableRuntime
+		}
+
+		changes, err := disable(ctx, service)
+		if err != nil {
+			sd.logger.Error("failed to disable service", "service", service, "error", err)
+			return fmt.Errorf("failed to disable service %s: %w", service, err)
+		}
+		sd.unitFileChanges = changes
+	}
+
+	if sd.parsedArguments.mask && !isUnitFileMasked(initialState.UnitFileState, sd.parsedArguments.runtime) {
+		if err := sd.systemdConnector.Mask(ctx, service, sd.parsedArguments.runtime); err != nil {
+			sd.logger.Error("failed to mask service", "service", service, "error", err)
+			return fmt.Errorf("failed to mask service %s: %w", service, err)
+		}
 	}
 
 	return nil
 }
 
 func (sd *ServiceDisable) verify(ctx context.Context) error {
-	serviceEnabled, err := sd.systemdConnector.IsEnabled(ctx, sd.service)
+	service := sd.parsedArguments.service
+
+	state, err := getServiceUnitState(ctx, sd.systemdConnector, sd.parsedArguments)
 	if err != nil {
-		sd.logger.Error("failed to check if service is enabled", "service", sd.service, "error", err)
-		return fmt.Errorf("failed to check if service %s is enabled: %w", sd.service, err)
+		sd.logger.Error("failed to check if service is enabled", "service", service, "error", err)
+		return fmt.Errorf("failed to check if service %s is enabled: %w", service, err)
 	}
 
-	if serviceEnabled {
-		sd.logger.Info("service is not disabled, rolling back", "service", sd.service)
-		return fmt.Errorf("service %s is not disabled", sd.service)
+	if !sd.unitFileDisabled(state) {
+		sd.logger.Info("service is not disabled, rolling back", "service", service)
+		if sd.parsedArguments.mask {
+			return fmt.Errorf("service %s is not masked", service)
+		}
+		return fmt.Errorf("service %s is not disabled", service)
 	}
 
-	sd.resources[afterDiffField] = serviceEnabled
+	if sd.parsedArguments.now && !isUnitStopped(state.ActiveState) {
+		sd.logger.Info("service is not stopped, rolling back", "service", service)
+		return fmt.Errorf("service %s is not stopped", service)
+	}
+
+	sd.resources[afterDiffField] = state
 
 	return nil
 }
 
 func (sd *ServiceDisable) rollback(ctx context.Context) error {
-	return sd.systemdConnector.Enable(ctx, sd.service)
+	service := sd.parsedArguments.service
+	initialState, _ := sd.resources[beforeDiffField].(systemd.UnitState)
+
+	var rollbackErr error
+	if sd.parsedArguments.mask && !isUnitFileMasked(initialState.UnitFileState, sd.parsedArguments.runtime) {
+		rollbackErr = errors.Join(rollbackErr, sd.systemdConnector.Unmask(ctx, service, sd.parsedArguments.runtime))
+	}
+
+	switch {
+	case sd.unitFileChanges != nil:
+		rollbackErr = errors.Join(rollbackErr, sd.systemdConnector.RevertUnitFileChanges(
+			ctx, service, sd.unitFileChanges,
+		))
+	case initialState.UnitFileState == sd.enabledUnitFileState():
+		enable := sd.systemdConnector.Enable
+		if sd.parsedArguments.runtime {
+			enable = sd.systemdConnector.EnableRuntime
+		}
+		_, err := enable(ctx, service)
+		rollbackErr = errors.Join(rollbackErr, err)
+	}
+
+	if sd.parsedArguments.now && !isUnitStopped(initialState.ActiveState) {
+		rollbackErr = errors.Join(rollbackErr, sd.systemdConnector.Start(ctx, service))
+	}
+
+	return rollbackErr
 }
 
 func (sd *ServiceDisable) operationDiff(_ context.Context) map[string]any {
-	return computeOperationDiff(sd.resources)
+	return computeOperationDiff(sd.resources, sd.parsedArguments.reportUnitState, sd.unitFileChanges)
 }
 
 func (sd *ServiceDisable) after(_ context.Context) {
 	sd.systemdConnector.Close()
 }
+
+// enabledUnitFileState is the unit file state removed by the operator, the runtime enablement
+// if runtime is set, or the persistent one otherwise
+func (sd *ServiceDisable) enabledUnitFileState() string {
+	if sd.parsedArguments.runtime {
+		return unitFileEnabledRuntime
+	}
+	return unitFileEnabled
+}
+
+func (sd *ServiceDisable) unitFileDisabled(state systemd.UnitState) bool {
+	if sd.parsedArguments.mask {
+		return isUnitFileMasked(state.UnitFileState, sd.parsedArguments.runtime)
+	}
+	return state.UnitFileState != sd.enabledUnitFileState()
+}
+
+func isUnitStopped(activeState string) bool {
+	return activeState == unitInactive || activeState == unitFailed
+}
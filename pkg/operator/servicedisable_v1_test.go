@@ -12,6 +12,7 @@ import (
 	mock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/trento-project/workbench/internal/support"
+	"github.com/trento-project/workbench/internal/systemd"
 	"github.com/trento-project/workbench/internal/systemd/mocks"
 	"github.com/trento-project/workbench/pkg/operator"
 )
@@ -23,6 +24,21 @@ type ServiceDisableOperatorTestSuite struct {
 	mockSystemdLoader *mocks.MockLoader
 }
 
+var (
+	pacemakerDisableChanges = []systemd.UnitFileChange{
+		{
+			Type:     systemd.UnitFileChangeUnlink,
+			Filename: "/etc/systemd/system/multi-user.target.wants/pacemaker.service",
+		},
+	}
+	tunedDisableRuntimeChanges = []systemd.UnitFileChange{
+		{
+			Type:     systemd.UnitFileChangeUnlink,
+			Filename: "/run/systemd/system/multi-user.target.wants/tuned.service",
+		},
+	}
+)
+
 func buildServiceDisableOperator(suite *ServiceDisableOperatorTestSuite) operator.Operator {
 	return operator.NewServiceDisable(
 		"servicedisableoperator",
@@ -123,12 +139,12 @@ func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorCommitEr
 		NotBefore(systemdLoaderCall)
 
 	disableCall := suite.mockSystemd.On("Disable", ctx, "pacemaker.service").
-		Return(errors.New("systemd disable error")).
+		Return(nil, errors.New("systemd disable error")).
 		Once().
 		NotBefore(isEnabledCall)
 
 	enableCall := suite.mockSystemd.On("Enable", ctx, "pacemaker.service").
-		Return(errors.New("systemd enable error")).
+		Return(nil, errors.New("systemd enable error")).
 		Once().
 		NotBefore(disableCall)
 
@@ -157,12 +173,12 @@ func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorCommitEr
 		NotBefore(systemdLoaderCall)
 
 	disableCall := suite.mockSystemd.On("Disable", ctx, "pacemaker.service").
-		Return(errors.New("systemd disable error")).
+		Return(nil, errors.New("systemd disable error")).
 		Once().
 		NotBefore(isEnabledCall)
 
 	enableCall := suite.mockSystemd.On("Enable", ctx, "pacemaker.service").
-		Return(nil).
+		Return([]systemd.UnitFileChange{}, nil).
 		Once().
 		NotBefore(disableCall)
 
@@ -191,7 +207,7 @@ func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorVerifyEr
 		NotBefore(systemdLoaderCall)
 
 	disableCall := suite.mockSystemd.On("Disable", ctx, "pacemaker.service").
-		Return(nil).
+		Return(pacemakerDisableChanges, nil).
 		Once().
 		NotBefore(isEnabledCall)
 
@@ -200,8 +216,8 @@ func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorVerifyEr
 		Once().
 		NotBefore(disableCall)
 
-	enableCall := suite.mockSystemd.On("Enable", ctx, "pacemaker.service").
-		Return(errors.New("systemd enable error")).
+	enableCall := suite.mockSystemd.On("RevertUnitFileChanges", ctx, "pacemaker.service", pacemakerDisableChanges).
+		Return(errors.New("systemd revert error")).
 		Once().
 		NotBefore(verifyIsEnabledCall)
 
@@ -214,7 +230,7 @@ func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorVerifyEr
 
 	suite.Nil(report.Success)
 	suite.Equal(operator.ROLLBACK, report.Error.ErrorPhase)
-	suite.EqualValues("systemd enable error\nfailed to check if service pacemaker.service is enabled: error verifying is disabled", report.Error.Message)
+	suite.EqualValues("systemd revert error\nfailed to check if service pacemaker.service is enabled: error verifying is disabled", report.Error.Message)
 }
 
 func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorVerifyErrorIsDisabledSuccessfulRollback() {
@@ -230,7 +246,7 @@ func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorVerifyEr
 		NotBefore(systemdLoaderCall)
 
 	disableCall := suite.mockSystemd.On("Disable", ctx, "pacemaker.service").
-		Return(nil).
+		Return(pacemakerDisableChanges, nil).
 		Once().
 		NotBefore(isEnabledCall)
 
@@ -239,7 +255,7 @@ func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorVerifyEr
 		Once().
 		NotBefore(disableCall)
 
-	enableCall := suite.mockSystemd.On("Enable", ctx, "pacemaker.service").
+	enableCall := suite.mockSystemd.On("RevertUnitFileChanges", ctx, "pacemaker.service", pacemakerDisableChanges).
 		Return(nil).
 		Once().
 		NotBefore(verifyIsEnabledCall)
@@ -269,7 +285,7 @@ func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorVerifyNo
 		NotBefore(systemdLoaderCall)
 
 	disableCall := suite.mockSystemd.On("Disable", ctx, "pacemaker.service").
-		Return(nil).
+		Return(pacemakerDisableChanges, nil).
 		Once().
 		NotBefore(isEnabledCall)
 
@@ -278,8 +294,8 @@ func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorVerifyNo
 		Once().
 		NotBefore(disableCall)
 
-	enableCall := suite.mockSystemd.On("Enable", ctx, "pacemaker.service").
-		Return(errors.New("systemd enable error")).
+	enableCall := suite.mockSystemd.On("RevertUnitFileChanges", ctx, "pacemaker.service", pacemakerDisableChanges).
+		Return(errors.New("systemd revert error")).
 		Once().
 		NotBefore(verifyIsEnabledCall)
 
@@ -292,7 +308,7 @@ func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorVerifyNo
 
 	suite.Nil(report.Success)
 	suite.Equal(operator.ROLLBACK, report.Error.ErrorPhase)
-	suite.EqualValues("systemd enable error\nservice pacemaker.service is not disabled", report.Error.Message)
+	suite.EqualValues("systemd revert error\nservice pacemaker.service is not disabled", report.Error.Message)
 }
 
 func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorVerifyNotDisabledSuccessfulRollback() {
@@ -308,7 +324,7 @@ func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorVerifyNo
 		NotBefore(systemdLoaderCall)
 
 	disableCall := suite.mockSystemd.On("Disable", ctx, "pacemaker.service").
-		Return(nil).
+		Return(pacemakerDisableChanges, nil).
 		Once().
 		NotBefore(isEnabledCall)
 
@@ -317,7 +333,7 @@ func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorVerifyNo
 		Once().
 		NotBefore(disableCall)
 
-	enableCall := suite.mockSystemd.On("Enable", ctx, "pacemaker.service").
+	enableCall := suite.mockSystemd.On("RevertUnitFileChanges", ctx, "pacemaker.service", pacemakerDisableChanges).
 		Return(nil).
 		Once().
 		NotBefore(verifyIsEnabledCall)
@@ -347,7 +363,7 @@ func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorSuccess(
 		NotBefore(systemdLoaderCall)
 
 	disableCall := suite.mockSystemd.On("Disable", ctx, "pacemaker.service").
-		Return(nil).
+		Return(pacemakerDisableChanges, nil).
 		Once().
 		NotBefore(isEnabledCall)
 
@@ -365,10 +381,196 @@ func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorSuccess(
 
 	expectedDiff := map[string]any{
 		"before": `{"enabled":true}`,
-		"after":  `{"enabled":false}`,
+		"after": `{"enabled":false,"changes":[{"type":"unlink",` +
+			`"file":"/etc/systemd/system/multi-user.target.wants/pacemaker.service"}]}`,
 	}
 
 	suite.Nil(report.Error)
 	suite.Equal(operator.VERIFY, report.Success.LastPhase)
 	suite.EqualValues(expectedDiff, report.Success.Diff)
 }
+
+func buildGenericServiceDisableOperator(
+	suite *ServiceDisableOperatorTestSuite,
+	arguments operator.Arguments,
+) operator.Operator {
+	return operator.NewServiceDisable(
+		operator.ServiceDisableOperatorName,
+		arguments,
+		"test-op",
+		operator.Options[operator.ServiceDisable]{
+			BaseOperatorOptions: []operator.BaseOperatorOption{
+				operator.WithCustomLogger(suite.logger),
+			},
+			OperatorOptions: []operator.Option[operator.ServiceDisable]{
+				operator.Option[operator.ServiceDisable](operator.WithCustomServiceDisableSystemdLoader(suite.mockSystemdLoader)),
+			},
+		},
+	)
+}
+
+func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorInvalidArguments() {
+	ctx := context.Background()
+
+	cases := []struct {
+		arguments operator.Arguments
+		err       string
+	}{
+		{
+			arguments: operator.Arguments{"service": "sshd"},
+			err: "service sshd is not allowed, allowed services: corosync.service, hawk.service, " +
+				"pacemaker.service, sapconf.service, saptune.service, sbd.service, tuned.service",
+		},
+		{
+			arguments: operator.Arguments{"service": "sapconf.service", "mask": "true"},
+			err:       "could not parse mask argument as bool, argument provided: true",
+		},
+		{
+			arguments: operator.Arguments{"service": "sapconf.service", "runtime": 0},
+			err:       "could not parse runtime argument as bool, argument provided: 0",
+		},
+	}
+
+	for _, tc := range cases {
+		report := buildGenericServiceDisableOperator(suite, tc.arguments).Run(ctx)
+
+		suite.Nil(report.Success)
+		suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+		suite.EqualValues(tc.err, report.Error.Message)
+	}
+}
+
+func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorAlreadyDisabled() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	suite.mockSystemd.On("GetUnitState", ctx, "sapconf.service").
+		Return(systemd.UnitState{UnitFileState: "disabled", ActiveState: "failed"}, nil).
+		Once()
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once()
+
+	report := buildGenericServiceDisableOperator(suite, operator.Arguments{
+		"service": "sapconf.service",
+		"now":     true,
+	}).Run(ctx)
+
+	expectedDiff := map[string]any{
+		"before": `{"unit_file_state":"disabled","active_state":"failed"}`,
+		"after":  `{"unit_file_state":"disabled","active_state":"failed"}`,
+	}
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(expectedDiff, report.Success.Diff)
+}
+
+func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorNowMaskSuccess() {
+	ctx := context.Background()
+
+	systemdLoaderCall := suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	getStateCall := suite.mockSystemd.On("GetUnitState", ctx, "sapconf.service").
+		Return(systemd.UnitState{UnitFileState: "enabled", ActiveState: "active"}, nil).
+		Once().
+		NotBefore(systemdLoaderCall)
+
+	stopCall := suite.mockSystemd.On("Stop", ctx, "sapconf.service").
+		Return(nil).
+		Once().
+		NotBefore(getStateCall)
+
+	disableCall := suite.mockSystemd.On("Disable", ctx, "sapconf.service").
+		Return([]systemd.UnitFileChange{}, nil).
+		Once().
+		NotBefore(stopCall)
+
+	maskCall := suite.mockSystemd.On("Mask", ctx, "sapconf.service", false).
+		Return(nil).
+		Once().
+		NotBefore(disableCall)
+
+	verifyStateCall := suite.mockSystemd.On("GetUnitState", ctx, "sapconf.service").
+		Return(systemd.UnitState{UnitFileState: "masked", ActiveState: "inactive"}, nil).
+		Once().
+		NotBefore(maskCall)
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once().
+		NotBefore(verifyStateCall)
+
+	report := buildGenericServiceDisableOperator(suite, operator.Arguments{
+		"service": "sapconf.service",
+		"now":     true,
+		"mask":    true,
+	}).Run(ctx)
+
+	expectedDiff := map[string]any{
+		"before": `{"unit_file_state":"enabled","active_state":"active"}`,
+		"after":  `{"unit_file_state":"masked","active_state":"inactive"}`,
+	}
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(expectedDiff, report.Success.Diff)
+}
+
+func (suite *ServiceDisableOperatorTestSuite) TestServiceDisableOperatorRuntimeNotStoppedRollback() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	getStateCall := suite.mockSystemd.On("GetUnitState", ctx, "tuned.service").
+		Return(systemd.UnitState{UnitFileState: "enabled-runtime", ActiveState: "active"}, nil).
+		Once()
+
+	stopCall := suite.mockSystemd.On("Stop", ctx, "tuned.service").
+		Return(nil).
+		Once().
+		NotBefore(getStateCall)
+
+	disableCall := suite.mockSystemd.On("DisableRuntime", ctx, "tuned.service").
+		Return(tunedDisableRuntimeChanges, nil).
+		Once().
+		NotBefore(stopCall)
+
+	verifyStateCall := suite.mockSystemd.On("GetUnitState", ctx, "tuned.service").
+		Return(systemd.UnitState{UnitFileState: "disabled", ActiveState: "active"}, nil).
+		Once().
+		NotBefore(disableCall)
+
+	enableCall := suite.mockSystemd.On("RevertUnitFileChanges", ctx, "tuned.service", tunedDisableRuntimeChanges).
+		Return(nil).
+		Once().
+		NotBefore(verifyStateCall)
+
+	startCall := suite.mockSystemd.On("Start", ctx, "tuned.service").
+		Return(nil).
+		Once().
+		NotBefore(enableCall)
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once().
+		NotBefore(startCall)
+
+	report := buildGenericServiceDisableOperator(suite, operator.Arguments{
+		"service": "tuned.service",
+		"now":     true,
+		"runtime": true,
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.EqualValues("service tuned.service is not stopped", report.Error.Message)
+}
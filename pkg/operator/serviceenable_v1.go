@@ -6,44 +6,107 @@ package operator
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/trento-project/workbench/internal/systemd"
 )
 
 const (
+	ServiceEnableOperatorName   = "serviceenable"
 	PacemakerEnableOperatorName = "pacemakerenable"
 	pacemakerServiceName        = "pacemaker.service"
+	serviceUnmaskArgument       = "unmask"
+	unitFileEnabled             = "enabled"
+	unitFileEnabledRuntime      = "enabled-runtime"
+	unitFileDisabled            = "disabled"
+	unitFileMasked              = "masked"
+	unitFileMaskedRuntime       = "masked-runtime"
+	unitActive                  = "active"
 )
 
+// allowedServiceUnits are the units that can be managed with the generic service operators
+var allowedServiceUnits = []string{
+	"corosync.service",
+	"hawk.service",
+	"pacemaker.service",
+	"sapconf.service",
+	"saptune.service",
+	"sbd.service",
+	"tuned.service",
+}
+
+type serviceUnitArguments struct {
+	service string
+	now     bool
+	runtime bool
+	// mask is the mask argument of the disable operator and the unmask argument of the enable operator
+	mask bool
+	// reportUnitState is set for the generic operators, which report the unit file and active
+	// states in the diff instead of the enabled flag
+	reportUnitState bool
+}
+
 type serviceEnablementDiffOutput struct {
-	Enabled bool `json:"enabled"`
+	Enabled bool                       `json:"enabled"`
+	Changes []unitFileChangeDiffOutput `json:"changes,omitempty"`
+}
+
+type serviceUnitStateDiffOutput struct {
+	UnitFileState string                     `json:"unit_file_state"`
+	ActiveState   string                     `json:"active_state"`
+	Changes       []unitFileChangeDiffOutput `json:"changes,omitempty"`
+}
+
+type unitFileChangeDiffOutput struct {
+	Type        string `json:"type"`
+	File        string `json:"file"`
+	Destination string `json:"destination,omitempty"`
 }
 
 type ServiceEnableOption Option[ServiceEnable]
 
 // ServiceEnable operator enables a systemd unit.
 //
+// The unit is fixed with the WithServiceToEnable option, as in the pacemakerenable operator,
+// or given in the arguments of the generic serviceenable operator.
+//
+// Arguments (serviceenable only):
+//  service (required): Name of the unit, e.g. sbd.service. Only some cluster and tuning
+//                      units are allowed
+//  now: Start the unit in addition to enabling it. Default false
+//  runtime: Enable the unit only until the next reboot. Default false
+//  unmask: Unmask the unit if it is masked. Otherwise masked units cannot be enabled. Default false
+//
 // # Execution Phases
 //
 // - PLAN:
-//   The operator connects to systemd and determines if the service is enabled.
-//   The operation is skipped if the service is already enabled.
+//   The operator connects to systemd and determines if the service is enabled, and active if
+//   now is set. The operation is skipped if the service is already in the requested state.
+//   The operation fails if the unit is masked and unmask is not set.
 //
 // - COMMIT:
-//   It enables the systemd unit.
+//   It unmasks the unit if needed, enables the systemd unit and starts it if now is set.
+//   The symlinks created by systemd are reported in the after diff.
 //
 // - VERIFY:
-//   The operator checks if the service is enabled after the commit phase.
+//   The operator checks if the service is enabled, and active if now is set, after the commit phase.
 //
 // - ROLLBACK:
-//   If an error occurs during the COMMIT or VERIFY phase, the service is disabled back again.
+//   If an error occurs during the COMMIT or VERIFY phase, the service is stopped, disabled and
+//   masked back again, depending on its initial state. If the unit was enabled, exactly the
+//   symlinks created in the commit phase are removed.
 
 type ServiceEnable struct {
 	baseOperator
 	systemdLoader    systemd.Loader
 	systemdConnector systemd.Systemd
 	service          string
+	parsedArguments  *serviceUnitArguments
+	// unitFileChanges are the symlinks changed when enabling the unit, nil if it was not enabled
+	unitFileChanges []systemd.UnitFileChange
 }
 
 func WithCustomServiceEnableSystemdLoader(systemdLoader systemd.Loader) ServiceEnableOption {
@@ -83,6 +146,13 @@ func NewServiceEnable(
 }
 
 func (se *ServiceEnable) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseServiceUnitArguments(se.arguments, se.service, serviceUnmaskArgument)
+	if err != nil {
+		return false, err
+	}
+	se.parsedArguments = opArguments
+	service := opArguments.service
+
 	systemdConnector, err := se.systemdLoader.NewSystemd(ctx, se.logger)
 	if err != nil {
 		se.logger.Error("unable to initialize systemd connector", "error", err)
@@ -90,17 +160,22 @@ func (se *ServiceEnable) plan(ctx context.Context) (bool, error) {
 	}
 	se.systemdConnector = systemdConnector
 
-	serviceEnabled, err := se.systemdConnector.IsEnabled(ctx, se.service)
+	state, err := getServiceUnitState(ctx, se.systemdConnector, se.parsedArguments)
 	if err != nil {
-		se.logger.Error("failed to check if service is enabled", "service", se.service, "error", err)
-		return false, fmt.Errorf("failed to check if %s service is enabled: %w", se.service, err)
+		se.logger.Error("failed to check if service is enabled", "service", service, "error", err)
+		return false, fmt.Errorf("failed to check if %s service is enabled: %w", service, err)
 	}
 
-	se.resources[beforeDiffField] = serviceEnabled
+	se.resources[beforeDiffField] = state
+
+	if isUnitFileMasked(state.UnitFileState, true) && !se.parsedArguments.mask {
+		return false, fmt.Errorf("service %s is masked, set the unmask argument to enable it", service)
+	}
 
-	if serviceEnabled {
-		se.logger.Info("service already enabled, skipping operation", "service", se.service)
-		se.resources[afterDiffField] = serviceEnabled
+	if isUnitFileEnabled(state.UnitFileState, se.parsedArguments.runtime) &&
+		(!se.parsedArguments.now || state.ActiveState == unitActive) {
+		se.logger.Info("service already enabled, skipping operation", "service", service)
+		se.resources[afterDiffField] = state
 		return true, nil
 	}
 
@@ -108,74 +183,246 @@ func (se *ServiceEnable) plan(ctx context.Context) (bool, error) {
 }
 
 func (se *ServiceEnable) commit(ctx context.Context) error {
-	if err := se.systemdConnector.Enable(ctx, se.service); err != nil {
-		se.logger.Error("failed to enable service", "service", se.service, "error", err)
-		return fmt.Errorf("failed to enable service %s: %w", se.service, err)
+	service := se.parsedArguments.service
+	initialState, _ := se.resources[beforeDiffField].(systemd.UnitState)
+
+	if isUnitFileMasked(initialState.UnitFileState, true) {
+		err := se.systemdConnector.Unmask(ctx, service, initialState.UnitFileState == unitFileMaskedRuntime)
+		if err != nil {
+			se.logger.Error("failed to unmask service", "service", service, "error", err)
+			return fmt.Errorf("failed to unmask service %s: %w", service, err)
+		}
+	}
+
+	if !isUnitFileEnabled(initialState.UnitFileState, se.parsedArguments.runtime) {
+		enable := se.systemdConnector.Enable
+		if se.parsedArguments.runtime {
+			enable = se.systemdConnector.EnableRuntime
+		}
+
+		changes, err := enable(ctx, service)
+		if err != nil {
+			se.logger.Error("failed to enable service", "service", service, "error", err)
+			return fmt.Errorf("failed to enable service %s: %w", service, err)
+		}
+		se.unitFileChanges = changes
 	}
+
+	if se.parsedArguments.now && initialState.ActiveState != unitActive {
+		if err := se.systemdConnector.Start(ctx, service); err != nil {
+			se.logger.Error("failed to start service", "service", service, "error", err)
+			return fmt.Errorf("failed to start service %s: %w", service, err)
+		}
+	}
+
 	return nil
 }
 
 func (se *ServiceEnable) verify(ctx context.Context) error {
-	serviceEnabled, err := se.systemdConnector.IsEnabled(ctx, se.service)
+	service := se.parsedArguments.service
+
+	state, err := getServiceUnitState(ctx, se.systemdConnector, se.parsedArguments)
 	if err != nil {
-		se.logger.Error("failed to check if service is enabled", "service", se.service, "error", err)
-		return fmt.Errorf("failed to check if service %s is enabled: %w", se.service, err)
+		se.logger.Error("failed to check if service is enabled", "service", service, "error", err)
+		return fmt.Errorf("failed to check if service %s is enabled: %w", service, err)
 	}
 
-	if !serviceEnabled {
-		se.logger.Info("service %s is not enabled, rolling back", "service", se.service)
-		return fmt.Errorf("service %s is not enabled", se.service)
+	if !isUnitFileEnabled(state.UnitFileState, se.parsedArguments.runtime) {
+		se.logger.Info("service is not enabled, rolling back", "service", service)
+		return fmt.Errorf("service %s is not enabled", service)
 	}
 
-	se.resources[afterDiffField] = serviceEnabled
+	if se.parsedArguments.now && state.ActiveState != unitActive {
+		se.logger.Info("service is not active, rolling back", "service", service)
+		return fmt.Errorf("service %s is not active", service)
+	}
+
+	se.resources[afterDiffField] = state
 
 	return nil
 }
 
 func (se *ServiceEnable) rollback(ctx context.Context) error {
-	return se.systemdConnector.Disable(ctx, se.service)
+	service := se.parsedArguments.service
+	initialState, _ := se.resources[beforeDiffField].(systemd.UnitState)
+
+	var rollbackErr error
+	if se.parsedArguments.now && initialState.ActiveState != unitActive {
+		rollbackErr = errors.Join(rollbackErr, se.systemdConnector.Stop(ctx, service))
+	}
+
+	switch {
+	case se.unitFileChanges != nil:
+		rollbackErr = errors.Join(rollbackErr, se.systemdConnector.RevertUnitFileChanges(
+			ctx, service, se.unitFileChanges,
+		))
+	case !isUnitFileEnabled(initialState.UnitFileState, se.parsedArguments.runtime):
+		disable := se.systemdConnector.Disable
+		if se.parsedArguments.runtime {
+			disable = se.systemdConnector.DisableRuntime
+		}
+		_, err := disable(ctx, service)
+		rollbackErr = errors.Join(rollbackErr, err)
+	}
+
+	if isUnitFileMasked(initialState.UnitFileState, true) {
+		rollbackErr = errors.Join(rollbackErr, se.systemdConnector.Mask(
+			ctx, service, initialState.UnitFileState == unitFileMaskedRuntime,
+		))
+	}
+
+	return rollbackErr
 }
 
 func (se *ServiceEnable) operationDiff(_ context.Context) map[string]any {
-	return computeOperationDiff(se.resources)
+	return computeOperationDiff(se.resources, se.parsedArguments.reportUnitState, se.unitFileChanges)
 }
 
 func (se *ServiceEnable) after(_ context.Context) {
 	se.systemdConnector.Close()
 }
 
-func computeOperationDiff(resources map[string]any) map[string]any {
-	diff := make(map[string]any)
+// parseServiceUnitArguments parses the arguments of the generic service operators.
+// If the service is fixed by the operator, the arguments are ignored.
+func parseServiceUnitArguments(
+	rawArguments Arguments,
+	service string,
+	maskArgument string,
+) (*serviceUnitArguments, error) {
+	if service != "" {
+		return &serviceUnitArguments{service: service}, nil
+	}
 
-	beforeEnabled, ok := resources[beforeDiffField].(bool)
-	if !ok {
-		panic(fmt.Sprintf("invalid beforeEnabled value: cannot parse '%s' to bool",
-			resources[beforeDiffField]))
+	serviceName, err := parseServiceArgument(rawArguments)
+	if err != nil {
+		return nil, err
+	}
+
+	opArguments := &serviceUnitArguments{service: serviceName, reportUnitState: true}
+
+	for _, flag := range []struct {
+		argument string
+		value    *bool
+	}{
+		{argument: "now", value: &opArguments.now},
+		{argument: "runtime", value: &opArguments.runtime},
+		{argument: maskArgument, value: &opArguments.mask},
+	} {
+		rawValue, found := rawArguments[flag.argument]
+		if !found {
+			continue
+		}
+
+		value, ok := rawValue.(bool)
+		if !ok {
+			return nil, fmt.Errorf(
+				"could not parse %s argument as bool, argument provided: %v", flag.argument, rawValue,
+			)
+		}
+		*flag.value = value
 	}
 
-	afterEnabled, ok := resources[afterDiffField].(bool)
+	return opArguments, nil
+}
+
+// parseServiceArgument parses the service argument of the generic service operators,
+// checking that the unit is in the allow-list
+func parseServiceArgument(rawArguments Arguments) (string, error) {
+	serviceArgument, found := rawArguments["service"]
+	if !found {
+		return "", errors.New("argument service not provided, could not use the operator")
+	}
+
+	serviceName, ok := serviceArgument.(string)
 	if !ok {
-		panic(fmt.Sprintf("invalid afterEnabled value: cannot parse '%s' to bool",
-			resources[afterDiffField]))
+		return "", fmt.Errorf("could not parse service argument as string, argument provided: %v", serviceArgument)
 	}
 
-	beforeDiffOutput := serviceEnablementDiffOutput{
-		Enabled: beforeEnabled,
+	if !slices.Contains(allowedServiceUnits, serviceName) {
+		return "", fmt.Errorf("service %s is not allowed, allowed services: %s",
+			serviceName, strings.Join(allowedServiceUnits, ", "))
 	}
-	before, err := json.Marshal(beforeDiffOutput)
+
+	return serviceName, nil
+}
+
+// getServiceUnitState gets the state of the unit. The operators with a fixed service only
+// check if the unit is enabled.
+func getServiceUnitState(
+	ctx context.Context,
+	systemdConnector systemd.Systemd,
+	opArguments *serviceUnitArguments,
+) (systemd.UnitState, error) {
+	if opArguments.reportUnitState {
+		return systemdConnector.GetUnitState(ctx, opArguments.service)
+	}
+
+	enabled, err := systemdConnector.IsEnabled(ctx, opArguments.service)
 	if err != nil {
-		panic(fmt.Sprintf("error marshalling before diff output: %v", err))
+		return systemd.UnitState{}, err
 	}
-	diff[beforeDiffField] = string(before)
 
-	afterDiffOutput := serviceEnablementDiffOutput{
-		Enabled: afterEnabled,
+	if enabled {
+		return systemd.UnitState{UnitFileState: unitFileEnabled}, nil
 	}
-	after, err := json.Marshal(afterDiffOutput)
-	if err != nil {
-		panic(fmt.Sprintf("error marshalling after diff output: %v", err))
+
+	return systemd.UnitState{UnitFileState: unitFileDisabled}, nil
+}
+
+// isUnitFileEnabled checks if the unit is enabled. Runtime enablement is accepted if runtime is set.
+func isUnitFileEnabled(unitFileState string, runtime bool) bool {
+	return unitFileState == unitFileEnabled || (runtime && unitFileState == unitFileEnabledRuntime)
+}
+
+// isUnitFileMasked checks if the unit is masked. Runtime masks are accepted if runtime is set.
+func isUnitFileMasked(unitFileState string, runtime bool) bool {
+	return unitFileState == unitFileMasked || (runtime && unitFileState == unitFileMaskedRuntime)
+}
+
+// computeOperationDiff builds the diff of the service operators. The unit file changes applied
+// in the commit phase are reported in the after diff.
+func computeOperationDiff(
+	resources map[string]any,
+	reportUnitState bool,
+	unitFileChanges []systemd.UnitFileChange,
+) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		state, ok := resources[field].(systemd.UnitState)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to unit state", field, resources[field]))
+		}
+
+		var changes []unitFileChangeDiffOutput
+		if field == afterDiffField {
+			for _, change := range unitFileChanges {
+				changes = append(changes, unitFileChangeDiffOutput{
+					Type:        change.Type,
+					File:        change.Filename,
+					Destination: change.Destination,
+				})
+			}
+		}
+
+		var diffOutput any = serviceEnablementDiffOutput{
+			Enabled: state.UnitFileState == unitFileEnabled,
+			Changes: changes,
+		}
+		if reportUnitState {
+			diffOutput = serviceUnitStateDiffOutput{
+				UnitFileState: state.UnitFileState,
+				ActiveState:   state.ActiveState,
+				Changes:       changes,
+			}
+		}
+
+		output, err := json.Marshal(diffOutput)
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
 	}
-	diff[afterDiffField] = string(after)
 
 	return diff
 }
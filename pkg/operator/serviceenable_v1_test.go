@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/trento-project/workbench/internal/support"
+	"github.com/trento-project/workbench/internal/systemd"
 	"github.com/trento-project/workbench/internal/systemd/mocks"
 	"github.com/trento-project/workbench/pkg/operator"
 )
@@ -23,6 +24,23 @@ type ServiceEnableOperatorTestSuite struct {
 	mockSystemdLoader *mocks.MockLoader
 }
 
+var (
+	pacemakerEnableChanges = []systemd.UnitFileChange{
+		{
+			Type:        systemd.UnitFileChangeSymlink,
+			Filename:    "/etc/systemd/system/multi-user.target.wants/pacemaker.service",
+			Destination: "/usr/lib/systemd/system/pacemaker.service",
+		},
+	}
+	sbdEnableRuntimeChanges = []systemd.UnitFileChange{
+		{
+			Type:        systemd.UnitFileChangeSymlink,
+			Filename:    "/run/systemd/system/corosync.service.requires/sbd.service",
+			Destination: "/usr/lib/systemd/system/sbd.service",
+		},
+	}
+)
+
 func buildServiceEnableOperator(suite *ServiceEnableOperatorTestSuite) operator.Operator {
 	return operator.NewServiceEnable(
 		"serviceenableoperator",
@@ -123,12 +141,12 @@ func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorCommitErro
 		NotBefore(systemdLoaderCall)
 
 	enableCall := suite.mockSystemd.On("Enable", ctx, "pacemaker.service").
-		Return(errors.New("systemd enable error")).
+		Return(nil, errors.New("systemd enable error")).
 		Once().
 		NotBefore(isEnabledCall)
 
 	disableCall := suite.mockSystemd.On("Disable", ctx, "pacemaker.service").
-		Return(errors.New("systemd disable error")).
+		Return(nil, errors.New("systemd disable error")).
 		Once().
 		NotBefore(enableCall)
 
@@ -157,12 +175,12 @@ func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorCommitErro
 		NotBefore(systemdLoaderCall)
 
 	enableCall := suite.mockSystemd.On("Enable", ctx, "pacemaker.service").
-		Return(errors.New("systemd enable error")).
+		Return(nil, errors.New("systemd enable error")).
 		Once().
 		NotBefore(isEnabledCall)
 
 	disableCall := suite.mockSystemd.On("Disable", ctx, "pacemaker.service").
-		Return(nil).
+		Return([]systemd.UnitFileChange{}, nil).
 		Once().
 		NotBefore(enableCall)
 
@@ -191,7 +209,7 @@ func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorVerifyErro
 		NotBefore(systemdLoaderCall)
 
 	enableCall := suite.mockSystemd.On("Enable", ctx, "pacemaker.service").
-		Return(nil).
+		Return(pacemakerEnableChanges, nil).
 		Once().
 		NotBefore(isEnabledCall)
 
@@ -200,8 +218,8 @@ func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorVerifyErro
 		Once().
 		NotBefore(enableCall)
 
-	disableCall := suite.mockSystemd.On("Disable", ctx, "pacemaker.service").
-		Return(errors.New("systemd disable error")).
+	disableCall := suite.mockSystemd.On("RevertUnitFileChanges", ctx, "pacemaker.service", pacemakerEnableChanges).
+		Return(errors.New("systemd revert error")).
 		Once().
 		NotBefore(verifyIsEnabledCall)
 
@@ -214,7 +232,7 @@ func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorVerifyErro
 
 	suite.Nil(report.Success)
 	suite.Equal(operator.ROLLBACK, report.Error.ErrorPhase)
-	suite.EqualValues("systemd disable error\nfailed to check if service pacemaker.service is enabled: error verifying is enabled", report.Error.Message)
+	suite.EqualValues("systemd revert error\nfailed to check if service pacemaker.service is enabled: error verifying is enabled", report.Error.Message)
 }
 
 func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorVerifyErrorIsEnabledSuccessfulRollback() {
@@ -230,7 +248,7 @@ func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorVerifyErro
 		NotBefore(systemdLoaderCall)
 
 	enableCall := suite.mockSystemd.On("Enable", ctx, "pacemaker.service").
-		Return(nil).
+		Return(pacemakerEnableChanges, nil).
 		Once().
 		NotBefore(isEnabledCall)
 
@@ -239,7 +257,7 @@ func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorVerifyErro
 		Once().
 		NotBefore(enableCall)
 
-	disableCall := suite.mockSystemd.On("Disable", ctx, "pacemaker.service").
+	disableCall := suite.mockSystemd.On("RevertUnitFileChanges", ctx, "pacemaker.service", pacemakerEnableChanges).
 		Return(nil).
 		Once().
 		NotBefore(verifyIsEnabledCall)
@@ -269,7 +287,7 @@ func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorVerifyNotE
 		NotBefore(systemdLoaderCall)
 
 	enableCall := suite.mockSystemd.On("Enable", ctx, "pacemaker.service").
-		Return(nil).
+		Return(pacemakerEnableChanges, nil).
 		Once().
 		NotBefore(isEnabledCall)
 
@@ -278,8 +296,8 @@ func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorVerifyNotE
 		Once().
 		NotBefore(enableCall)
 
-	disableCall := suite.mockSystemd.On("Disable", ctx, "pacemaker.service").
-		Return(errors.New("systemd disable error")).
+	disableCall := suite.mockSystemd.On("RevertUnitFileChanges", ctx, "pacemaker.service", pacemakerEnableChanges).
+		Return(errors.New("systemd revert error")).
 		Once().
 		NotBefore(verifyIsEnabledCall)
 
@@ -292,7 +310,7 @@ func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorVerifyNotE
 
 	suite.Nil(report.Success)
 	suite.Equal(operator.ROLLBACK, report.Error.ErrorPhase)
-	suite.EqualValues("systemd disable error\nservice pacemaker.service is not enabled", report.Error.Message)
+	suite.EqualValues("systemd revert error\nservice pacemaker.service is not enabled", report.Error.Message)
 }
 
 func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorVerifyNotEnabledSuccessfulRollback() {
@@ -308,7 +326,7 @@ func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorVerifyNotE
 		NotBefore(systemdLoaderCall)
 
 	enableCall := suite.mockSystemd.On("Enable", ctx, "pacemaker.service").
-		Return(nil).
+		Return(pacemakerEnableChanges, nil).
 		Once().
 		NotBefore(isEnabledCall)
 
@@ -317,7 +335,7 @@ func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorVerifyNotE
 		Once().
 		NotBefore(enableCall)
 
-	disableCall := suite.mockSystemd.On("Disable", ctx, "pacemaker.service").
+	disableCall := suite.mockSystemd.On("RevertUnitFileChanges", ctx, "pacemaker.service", pacemakerEnableChanges).
 		Return(nil).
 		Once().
 		NotBefore(verifyIsEnabledCall)
@@ -347,7 +365,7 @@ func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorSuccess()
 		NotBefore(systemdLoaderCall)
 
 	enableCall := suite.mockSystemd.On("Enable", ctx, "pacemaker.service").
-		Return(nil).
+		Return(pacemakerEnableChanges, nil).
 		Once().
 		NotBefore(isEnabledCall)
 
@@ -365,10 +383,229 @@ func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorSuccess()
 
 	expectedDiff := map[string]any{
 		"before": `{"enabled":false}`,
-		"after":  `{"enabled":true}`,
+		"after": `{"enabled":true,"changes":[{"type":"symlink",` +
+			`"file":"/etc/systemd/system/multi-user.target.wants/pacemaker.service",` +
+			`"destination":"/usr/lib/systemd/system/pacemaker.service"}]}`,
 	}
 
 	suite.Nil(report.Error)
 	suite.Equal(operator.VERIFY, report.Success.LastPhase)
 	suite.EqualValues(expectedDiff, report.Success.Diff)
 }
+
+func buildGenericServiceEnableOperator(
+	suite *ServiceEnableOperatorTestSuite,
+	arguments operator.Arguments,
+) operator.Operator {
+	return operator.NewServiceEnable(
+		operator.ServiceEnableOperatorName,
+		arguments,
+		"test-op",
+		operator.Options[operator.ServiceEnable]{
+			BaseOperatorOptions: []operator.BaseOperatorOption{
+				operator.WithCustomLogger(suite.logger),
+			},
+			OperatorOptions: []operator.Option[operator.ServiceEnable]{
+				operator.Option[operator.ServiceEnable](operator.WithCustomServiceEnableSystemdLoader(suite.mockSystemdLoader)),
+			},
+		},
+	)
+}
+
+func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorInvalidArguments() {
+	ctx := context.Background()
+
+	cases := []struct {
+		arguments operator.Arguments
+		err       string
+	}{
+		{
+			arguments: operator.Arguments{},
+			err:       "argument service not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{"service": 1},
+			err:       "could not parse service argument as string, argument provided: 1",
+		},
+		{
+			arguments: operator.Arguments{"service": "sshd.service"},
+			err: "service sshd.service is not allowed, allowed services: corosync.service, hawk.service, " +
+				"pacemaker.service, sapconf.service, saptune.service, sbd.service, tuned.service",
+		},
+		{
+			arguments: operator.Arguments{"service": "sbd.service", "now": "yes"},
+			err:       "could not parse now argument as bool, argument provided: yes",
+		},
+		{
+			arguments: operator.Arguments{"service": "sbd.service", "unmask": 1},
+			err:       "could not parse unmask argument as bool, argument provided: 1",
+		},
+	}
+
+	for _, tc := range cases {
+		report := buildGenericServiceEnableOperator(suite, tc.arguments).Run(ctx)
+
+		suite.Nil(report.Success)
+		suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+		suite.EqualValues(tc.err, report.Error.Message)
+	}
+}
+
+func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorMasked() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	suite.mockSystemd.On("GetUnitState", ctx, "sbd.service").
+		Return(systemd.UnitState{UnitFileState: "masked", ActiveState: "inactive"}, nil).
+		Once()
+
+	report := buildGenericServiceEnableOperator(suite, operator.Arguments{"service": "sbd.service"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("service sbd.service is masked, set the unmask argument to enable it", report.Error.Message)
+}
+
+func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorRuntimeAlreadyEnabled() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	suite.mockSystemd.On("GetUnitState", ctx, "tuned.service").
+		Return(systemd.UnitState{UnitFileState: "enabled-runtime", ActiveState: "active"}, nil).
+		Once()
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once()
+
+	report := buildGenericServiceEnableOperator(suite, operator.Arguments{
+		"service": "tuned.service",
+		"runtime": true,
+		"now":     true,
+	}).Run(ctx)
+
+	expectedDiff := map[string]any{
+		"before": `{"unit_file_state":"enabled-runtime","active_state":"active"}`,
+		"after":  `{"unit_file_state":"enabled-runtime","active_state":"active"}`,
+	}
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(expectedDiff, report.Success.Diff)
+}
+
+func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorNowUnmaskSuccess() {
+	ctx := context.Background()
+
+	systemdLoaderCall := suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	getStateCall := suite.mockSystemd.On("GetUnitState", ctx, "sbd.service").
+		Return(systemd.UnitState{UnitFileState: "masked", ActiveState: "inactive"}, nil).
+		Once().
+		NotBefore(systemdLoaderCall)
+
+	unmaskCall := suite.mockSystemd.On("Unmask", ctx, "sbd.service", false).
+		Return(nil).
+		Once().
+		NotBefore(getStateCall)
+
+	enableCall := suite.mockSystemd.On("Enable", ctx, "sbd.service").
+		Return([]systemd.UnitFileChange{}, nil).
+		Once().
+		NotBefore(unmaskCall)
+
+	startCall := suite.mockSystemd.On("Start", ctx, "sbd.service").
+		Return(nil).
+		Once().
+		NotBefore(enableCall)
+
+	verifyStateCall := suite.mockSystemd.On("GetUnitState", ctx, "sbd.service").
+		Return(systemd.UnitState{UnitFileState: "enabled", ActiveState: "active"}, nil).
+		Once().
+		NotBefore(startCall)
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once().
+		NotBefore(verifyStateCall)
+
+	report := buildGenericServiceEnableOperator(suite, operator.Arguments{
+		"service": "sbd.service",
+		"now":     true,
+		"unmask":  true,
+	}).Run(ctx)
+
+	expectedDiff := map[string]any{
+		"before": `{"unit_file_state":"masked","active_state":"inactive"}`,
+		"after":  `{"unit_file_state":"enabled","active_state":"active"}`,
+	}
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(expectedDiff, report.Success.Diff)
+}
+
+func (suite *ServiceEnableOperatorTestSuite) TestServiceEnableOperatorNowStartFailedRollback() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	suite.mockSystemd.On("GetUnitState", ctx, "sbd.service").
+		Return(systemd.UnitState{UnitFileState: "masked-runtime", ActiveState: "inactive"}, nil).
+		Once()
+
+	unmaskCall := suite.mockSystemd.On("Unmask", ctx, "sbd.service", true).
+		Return(nil).
+		Once()
+
+	enableCall := suite.mockSystemd.On("EnableRuntime", ctx, "sbd.service").
+		Return(sbdEnableRuntimeChanges, nil).
+		Once().
+		NotBefore(unmaskCall)
+
+	startCall := suite.mockSystemd.On("Start", ctx, "sbd.service").
+		Return(errors.New("job result failed")).
+		Once().
+		NotBefore(enableCall)
+
+	stopCall := suite.mockSystemd.On("Stop", ctx, "sbd.service").
+		Return(nil).
+		Once().
+		NotBefore(startCall)
+
+	disableCall := suite.mockSystemd.On("RevertUnitFileChanges", ctx, "sbd.service", sbdEnableRuntimeChanges).
+		Return(nil).
+		Once().
+		NotBefore(stopCall)
+
+	maskCall := suite.mockSystemd.On("Mask", ctx, "sbd.service", true).
+		Return(errors.New("systemd mask error")).
+		Once().
+		NotBefore(disableCall)
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once().
+		NotBefore(maskCall)
+
+	report := buildGenericServiceEnableOperator(suite, operator.Arguments{
+		"service": "sbd.service",
+		"now":     true,
+		"runtime": true,
+		"unmask":  true,
+	}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.ROLLBACK, report.Error.ErrorPhase)
+	suite.EqualValues("systemd mask error\nfailed to start service sbd.service: job result failed", report.Error.Message)
+}
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/trento-project/workbench/internal/systemd"
+)
+
+const ServiceRestartOperatorName = "servicerestart"
+
+type ServiceRestartOption Option[ServiceRestart]
+
+// ServiceRestart operator restarts or reloads a systemd unit.
+//
+// Arguments:
+//  service (required): Name of the unit, e.g. sbd.service. Only some cluster and tuning
+//                      units are allowed
+//  reload: Reload the configuration of the running unit instead of restarting it. Default false
+//
+// # Execution Phases
+//
+// - PLAN:
+//   The operator connects to systemd and gets the active state of the service. The operation
+//   is never skipped. It fails if reload is set and the service is not active.
+//
+// - COMMIT:
+//   It restarts, or reloads, the systemd unit and waits until the job finishes. Inactive units
+//   are started by the restart.
+//
+// - VERIFY:
+//   The operator checks if the service is active after the commit phase.
+//
+// - ROLLBACK:
+//   If an error occurs during the COMMIT or VERIFY phase, the initial state is restored:
+//   the service is stopped if it was not active, or started otherwise.
+
+type ServiceRestart struct {
+	baseOperator
+	systemdLoader    systemd.Loader
+	systemdConnector systemd.Systemd
+	service          string
+	reload           bool
+}
+
+func WithCustomServiceRestartSystemdLoader(systemdLoader systemd.Loader) ServiceRestartOption {
+	return func(sr *ServiceRestart) {
+		sr.systemdLoader = systemdLoader
+	}
+}
+
+func NewServiceRestart(
+	arguments Arguments,
+	operationID string,
+	options Options[ServiceRestart],
+) *Executor {
+	serviceRestart := &ServiceRestart{
+		baseOperator: newBaseOperator(
+			ServiceRestartOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+		systemdLoader: systemd.NewDefaultSystemdLoader(),
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(serviceRestart)
+	}
+
+	return &Executor{
+		phaser:      serviceRestart,
+		operationID: operationID,
+		logger:      serviceRestart.logger,
+	}
+}
+
+func (sr *ServiceRestart) plan(ctx context.Context) (bool, error) {
+	service, err := parseServiceArgument(sr.arguments)
+	if err != nil {
+		return false, err
+	}
+	sr.service = service
+
+	if rawReload, found := sr.arguments["reload"]; found {
+		reload, ok := rawReload.(bool)
+		if !ok {
+			return false, fmt.Errorf("could not parse reload argument as bool, argument provided: %v", rawReload)
+		}
+		sr.reload = reload
+	}
+
+	systemdConnector, err := sr.systemdLoader.NewSystemd(ctx, sr.logger)
+	if err != nil {
+		sr.logger.Error("unable to initialize systemd connector", "error", err)
+		return false, fmt.Errorf("unable to initialize systemd connector: %w", err)
+	}
+	sr.systemdConnector = systemdConnector
+
+	state, err := getServiceActivityState(ctx, sr.systemdConnector, sr.service)
+	if err != nil {
+		sr.logger.Error("failed to get service state", "service", sr.service, "error", err)
+		return false, fmt.Errorf("failed to get %s service state: %w", sr.service, err)
+	}
+
+	sr.resources[beforeDiffField] = state
+
+	if sr.reload && state.ActiveState != unitActive {
+		return false, fmt.Errorf("service %s is not active, it cannot be reloaded", sr.service)
+	}
+
+	return false, nil
+}
+
+func (sr *ServiceRestart) commit(ctx context.Context) error {
+	if sr.reload {
+		if err := sr.systemdConnector.Reload(ctx, sr.service); err != nil {
+			sr.logger.Error("failed to reload service", "service", sr.service, "error", err)
+			return fmt.Errorf("failed to reload service %s: %w", sr.service, err)
+		}
+		return nil
+	}
+
+	if err := sr.systemdConnector.Restart(ctx, sr.service); err != nil {
+		sr.logger.Error("failed to restart service", "service", sr.service, "error", err)
+		return fmt.Errorf("failed to restart service %s: %w", sr.service, err)
+	}
+	return nil
+}
+
+func (sr *ServiceRestart) verify(ctx context.Context) error {
+	state, err := getServiceActivityState(ctx, sr.systemdConnector, sr.service)
+	if err != nil {
+		sr.logger.Error("failed to get service state", "service", sr.service, "error", err)
+		return fmt.Errorf("failed to get %s service state: %w", sr.service, err)
+	}
+
+	if state.ActiveState != unitActive {
+		sr.logger.Info("service is not active, rolling back", "service", sr.service, "state", state.ActiveState)
+		return fmt.Errorf("service %s is not active, active state: %s", sr.service, state.ActiveState)
+	}
+
+	sr.resources[afterDiffField] = state
+
+	return nil
+}
+
+func (sr *ServiceRestart) rollback(ctx context.Context) error {
+	initialState, _ := sr.resources[beforeDiffField].(serviceActivityState)
+
+	if initialState.ActiveState != unitActive {
+		return sr.systemdConnector.Stop(ctx, sr.service)
+	}
+
+	return sr.systemdConnector.Start(ctx, sr.service)
+}
+
+func (sr *ServiceRestart) operationDiff(_ context.Context) map[string]any {
+	return computeServiceActivityDiff(sr.resources)
+}
+
+func (sr *ServiceRestart) after(_ context.Context) {
+	sr.systemdConnector.Close()
+}
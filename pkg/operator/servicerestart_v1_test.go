@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/support"
+	"github.com/trento-project/workbench/internal/systemd/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+type ServiceRestartOperatorTestSuite struct {
+	suite.Suite
+	logger            *slog.Logger
+	mockSystemd       *mocks.MockSystemd
+	mockSystemdLoader *mocks.MockLoader
+}
+
+func TestServiceRestartOperator(t *testing.T) {
+	suite.Run(t, new(ServiceRestartOperatorTestSuite))
+}
+
+func (suite *ServiceRestartOperatorTestSuite) SetupTest() {
+	suite.logger = support.NewDefaultLogger(slog.LevelInfo)
+	suite.mockSystemd = mocks.NewMockSystemd(suite.T())
+	suite.mockSystemdLoader = mocks.NewMockLoader(suite.T())
+}
+
+func (suite *ServiceRestartOperatorTestSuite) buildOperator(arguments operator.Arguments) operator.Operator {
+	return operator.NewServiceRestart(
+		arguments,
+		"test-op",
+		operator.Options[operator.ServiceRestart]{
+			BaseOperatorOptions: []operator.BaseOperatorOption{
+				operator.WithCustomLogger(suite.logger),
+			},
+			OperatorOptions: []operator.Option[operator.ServiceRestart]{
+				operator.Option[operator.ServiceRestart](
+					operator.WithCustomServiceRestartSystemdLoader(suite.mockSystemdLoader),
+				),
+			},
+		},
+	)
+}
+
+func (suite *ServiceRestartOperatorTestSuite) mockState(ctx context.Context, activeState, subState string) *mock.Call {
+	activeStateCall := suite.mockSystemd.On("GetActiveState", ctx, "hawk.service").
+		Return(activeState, nil).
+		Once()
+
+	return suite.mockSystemd.On("GetSubState", ctx, "hawk.service").
+		Return(subState, nil).
+		Once().
+		NotBefore(activeStateCall)
+}
+
+func (suite *ServiceRestartOperatorTestSuite) TestServiceRestartOperatorInvalidArguments() {
+	ctx := context.Background()
+
+	report := suite.buildOperator(operator.Arguments{"service": "hawk.service", "reload": "yes"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("could not parse reload argument as bool, argument provided: yes", report.Error.Message)
+}
+
+func (suite *ServiceRestartOperatorTestSuite) TestServiceRestartOperatorReloadNotActive() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	suite.mockState(ctx, "inactive", "dead")
+
+	report := suite.buildOperator(operator.Arguments{"service": "hawk.service", "reload": true}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("service hawk.service is not active, it cannot be reloaded", report.Error.Message)
+}
+
+func (suite *ServiceRestartOperatorTestSuite) TestServiceRestartOperatorReloadSuccess() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	stateCall := suite.mockState(ctx, "active", "running")
+
+	reloadCall := suite.mockSystemd.On("Reload", ctx, "hawk.service").
+		Return(nil).
+		Once().
+		NotBefore(stateCall)
+
+	verifyStateCall := suite.mockState(ctx, "active", "running").NotBefore(reloadCall)
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once().
+		NotBefore(verifyStateCall)
+
+	report := suite.buildOperator(operator.Arguments{"service": "hawk.service", "reload": true}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"active_state":"active","sub_state":"running"}`,
+		"after":  `{"active_state":"active","sub_state":"running"}`,
+	}, report.Success.Diff)
+}
+
+func (suite *ServiceRestartOperatorTestSuite) TestServiceRestartOperatorCommitErrorRollbackStarted() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	stateCall := suite.mockState(ctx, "active", "running")
+
+	restartCall := suite.mockSystemd.On("Restart", ctx, "hawk.service").
+		Return(errors.New("job result failed")).
+		Once().
+		NotBefore(stateCall)
+
+	startCall := suite.mockSystemd.On("Start", ctx, "hawk.service").
+		Return(nil).
+		Once().
+		NotBefore(restartCall)
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once().
+		NotBefore(startCall)
+
+	report := suite.buildOperator(operator.Arguments{"service": "hawk.service"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues("failed to restart service hawk.service: job result failed", report.Error.Message)
+}
+
+func (suite *ServiceRestartOperatorTestSuite) TestServiceRestartOperatorVerifyErrorRollbackStopped() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	stateCall := suite.mockState(ctx, "inactive", "dead")
+
+	restartCall := suite.mockSystemd.On("Restart", ctx, "hawk.service").
+		Return(nil).
+		Once().
+		NotBefore(stateCall)
+
+	verifyStateCall := suite.mockState(ctx, "failed", "failed").NotBefore(restartCall)
+
+	stopCall := suite.mockSystemd.On("Stop", ctx, "hawk.service").
+		Return(nil).
+		Once().
+		NotBefore(verifyStateCall)
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once().
+		NotBefore(stopCall)
+
+	report := suite.buildOperator(operator.Arguments{"service": "hawk.service"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.EqualValues("service hawk.service is not active, active state: failed", report.Error.Message)
+}
+
+func (suite *ServiceRestartOperatorTestSuite) TestServiceRestartOperatorSuccess() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	stateCall := suite.mockState(ctx, "failed", "failed")
+
+	restartCall := suite.mockSystemd.On("Restart", ctx, "hawk.service").
+		Return(nil).
+		Once().
+		NotBefore(stateCall)
+
+	verifyStateCall := suite.mockState(ctx, "active", "running").NotBefore(restartCall)
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once().
+		NotBefore(verifyStateCall)
+
+	report := suite.buildOperator(operator.Arguments{"service": "hawk.service"}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"active_state":"failed","sub_state":"failed"}`,
+		"after":  `{"active_state":"active","sub_state":"running"}`,
+	}, report.Success.Diff)
+}
@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/trento-project/workbench/internal/systemd"
+)
+
+const (
+	ServiceStartOperatorName   = "servicestart"
+	serviceTimeoutArgument     = "timeout"
+	defaultServiceStateTimeout = 30 * time.Second
+)
+
+// serviceActivityState is the activation state of a unit, e.g. active/running or inactive/dead
+type serviceActivityState struct {
+	ActiveState string `json:"active_state"`
+	SubState    string `json:"sub_state"`
+}
+
+type ServiceStartOption Option[ServiceStart]
+
+// ServiceStart operator starts a systemd unit.
+//
+// Arguments:
+//  service (required): Name of the unit, e.g. sbd.service. Only some cluster and tuning
+//                      units are allowed
+//  timeout: Time in seconds to wait until the service is active in the verify phase. Default 30
+//
+// # Execution Phases
+//
+// - PLAN:
+//   The operator connects to systemd and gets the active state of the service.
+//   The operation is skipped if the service is already active.
+//
+// - COMMIT:
+//   It starts the systemd unit and waits until the start job finishes.
+//
+// - VERIFY:
+//   The operator watches the state changes of the service until it is active, failing if the
+//   timeout expires first. Units that take a while to settle are not reported as failed.
+//
+// - ROLLBACK:
+//   If an error occurs during the COMMIT or VERIFY phase, the service is stopped back again.
+
+type ServiceStart struct {
+	baseOperator
+	systemdLoader    systemd.Loader
+	systemdConnector systemd.Systemd
+	service          string
+	timeout          time.Duration
+}
+
+func WithCustomServiceStartSystemdLoader(systemdLoader systemd.Loader) ServiceStartOption {
+	return func(ss *ServiceStart) {
+		ss.systemdLoader = systemdLoader
+	}
+}
+
+func NewServiceStart(
+	arguments Arguments,
+	operationID string,
+	options Options[ServiceStart],
+) *Executor {
+	serviceStart := &ServiceStart{
+		baseOperator: newBaseOperator(
+			ServiceStartOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+		systemdLoader: systemd.NewDefaultSystemdLoader(),
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(serviceStart)
+	}
+
+	return &Executor{
+		phaser:      serviceStart,
+		operationID: operationID,
+		logger:      serviceStart.logger,
+	}
+}
+
+func (ss *ServiceStart) plan(ctx context.Context) (bool, error) {
+	service, err := parseServiceArgument(ss.arguments)
+	if err != nil {
+		return false, err
+	}
+	ss.service = service
+
+	timeout, err := parseServiceTimeoutArgument(ss.arguments)
+	if err != nil {
+		return false, err
+	}
+	ss.timeout = timeout
+
+	systemdConnector, err := ss.systemdLoader.NewSystemd(ctx, ss.logger)
+	if err != nil {
+		ss.logger.Error("unable to initialize systemd connector", "error", err)
+		return false, fmt.Errorf("unable to initialize systemd connector: %w", err)
+	}
+	ss.systemdConnector = systemdConnector
+
+	state, err := getServiceActivityState(ctx, ss.systemdConnector, ss.service)
+	if err != nil {
+		ss.logger.Error("failed to get service state", "service", ss.service, "error", err)
+		return false, fmt.Errorf("failed to get %s service state: %w", ss.service, err)
+	}
+
+	ss.resources[beforeDiffField] = state
+
+	if state.ActiveState == unitActive {
+		ss.logger.Info("service already active, skipping operation", "service", ss.service)
+		ss.resources[afterDiffField] = state
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (ss *ServiceStart) commit(ctx context.Context) error {
+	if err := ss.systemdConnector.Start(ctx, ss.service); err != nil {
+		ss.logger.Error("failed to start service", "service", ss.service, "error", err)
+		return fmt.Errorf("failed to start service %s: %w", ss.service, err)
+	}
+	return nil
+}
+
+func (ss *ServiceStart) verify(ctx context.Context) error {
+	activeState, err := ss.systemdConnector.WaitForActiveState(ctx, ss.service, systemd.WaitForActiveStateOptions{
+		States:  []string{unitActive},
+		Timeout: ss.timeout,
+	})
+	if err != nil {
+		ss.logger.Info("service is not active, rolling back", "service", ss.service, "state", activeState, "error", err)
+		return fmt.Errorf("service %s is not active: %w", ss.service, err)
+	}
+
+	state, err := getServiceActivityState(ctx, ss.systemdConnector, ss.service)
+	if err != nil {
+		ss.logger.Error("failed to get service state", "service", ss.service, "error", err)
+		return fmt.Errorf("failed to get %s service state: %w", ss.service, err)
+	}
+
+	ss.resources[afterDiffField] = state
+
+	return nil
+}
+
+func (ss *ServiceStart) rollback(ctx context.Context) error {
+	return ss.systemdConnector.Stop(ctx, ss.service)
+}
+
+func (ss *ServiceStart) operationDiff(_ context.Context) map[string]any {
+	return computeServiceActivityDiff(ss.resources)
+}
+
+func (ss *ServiceStart) after(_ context.Context) {
+	ss.systemdConnector.Close()
+}
+
+// parseServiceTimeoutArgument parses the optional timeout argument, the time in seconds to wait
+// until the service reaches the requested state
+func parseServiceTimeoutArgument(rawArguments Arguments) (time.Duration, error) {
+	argument, found := rawArguments[serviceTimeoutArgument]
+	if !found {
+		return defaultServiceStateTimeout, nil
+	}
+
+	seconds, ok := argument.(float64)
+	if !ok {
+		return 0, fmt.Errorf(
+			"could not parse %s argument as a number, argument provided: %v",
+			serviceTimeoutArgument,
+			argument,
+		)
+	}
+
+	if seconds <= 0 {
+		return 0, fmt.Errorf("invalid %s value: %v, it must be a positive number", serviceTimeoutArgument, argument)
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+func getServiceActivityState(
+	ctx context.Context,
+	systemdConnector systemd.Systemd,
+	service string,
+) (serviceActivityState, error) {
+	activeState, err := systemdConnector.GetActiveState(ctx, service)
+	if err != nil {
+		return serviceActivityState{}, err
+	}
+
+	subState, err := systemdConnector.GetSubState(ctx, service)
+	if err != nil {
+		return serviceActivityState{}, err
+	}
+
+	return serviceActivityState{ActiveState: activeState, SubState: subState}, nil
+}
+
+func computeServiceActivityDiff(resources map[string]any) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		state, ok := resources[field].(serviceActivityState)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to service state", field, resources[field]))
+		}
+
+		output, err := json.Marshal(state)
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	return diff
+}
@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/support"
+	"github.com/trento-project/workbench/internal/systemd"
+	"github.com/trento-project/workbench/internal/systemd/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+type ServiceStartOperatorTestSuite struct {
+	suite.Suite
+	logger            *slog.Logger
+	mockSystemd       *mocks.MockSystemd
+	mockSystemdLoader *mocks.MockLoader
+}
+
+func TestServiceStartOperator(t *testing.T) {
+	suite.Run(t, new(ServiceStartOperatorTestSuite))
+}
+
+func (suite *ServiceStartOperatorTestSuite) SetupTest() {
+	suite.logger = support.NewDefaultLogger(slog.LevelInfo)
+	suite.mockSystemd = mocks.NewMockSystemd(suite.T())
+	suite.mockSystemdLoader = mocks.NewMockLoader(suite.T())
+}
+
+func (suite *ServiceStartOperatorTestSuite) buildOperator(arguments operator.Arguments) operator.Operator {
+	return operator.NewServiceStart(
+		arguments,
+		"test-op",
+		operator.Options[operator.ServiceStart]{
+			BaseOperatorOptions: []operator.BaseOperatorOption{
+				operator.WithCustomLogger(suite.logger),
+			},
+			OperatorOptions: []operator.Option[operator.ServiceStart]{
+				operator.Option[operator.ServiceStart](operator.WithCustomServiceStartSystemdLoader(suite.mockSystemdLoader)),
+			},
+		},
+	)
+}
+
+func (suite *ServiceStartOperatorTestSuite) mockState(ctx context.Context, activeState, subState string) *mock.Call {
+	activeStateCall := suite.mockSystemd.On("GetActiveState", ctx, "sbd.service").
+		Return(activeState, nil).
+		Once()
+
+	return suite.mockSystemd.On("GetSubState", ctx, "sbd.service").
+		Return(subState, nil).
+		Once().
+		NotBefore(activeStateCall)
+}
+
+func (suite *ServiceStartOperatorTestSuite) TestServiceStartOperatorInvalidArguments() {
+	ctx := context.Background()
+
+	report := suite.buildOperator(operator.Arguments{"service": "sshd.service"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("service sshd.service is not allowed, allowed services: corosync.service, hawk.service, "+
+		"pacemaker.service, sapconf.service, saptune.service, sbd.service, tuned.service", report.Error.Message)
+}
+
+func (suite *ServiceStartOperatorTestSuite) TestServiceStartOperatorInvalidTimeout() {
+	ctx := context.Background()
+
+	cases := []struct {
+		timeout any
+		err     string
+	}{
+		{
+			timeout: "30s",
+			err:     "could not parse timeout argument as a number, argument provided: 30s",
+		},
+		{
+			timeout: float64(0),
+			err:     "invalid timeout value: 0, it must be a positive number",
+		},
+	}
+
+	for _, tc := range cases {
+		report := suite.buildOperator(operator.Arguments{"service": "sbd.service", "timeout": tc.timeout}).Run(ctx)
+
+		suite.Nil(report.Success)
+		suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+		suite.EqualValues(tc.err, report.Error.Message)
+	}
+}
+
+func (suite *ServiceStartOperatorTestSuite) TestServiceStartOperatorPlanErrorState() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	suite.mockSystemd.On("GetActiveState", ctx, "sbd.service").
+		Return("", errors.New("systemd error")).
+		Once()
+
+	report := suite.buildOperator(operator.Arguments{"service": "sbd.service"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("failed to get sbd.service service state: systemd error", report.Error.Message)
+}
+
+func (suite *ServiceStartOperatorTestSuite) TestServiceStartOperatorAlreadyActive() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	suite.mockState(ctx, "active", "running")
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once()
+
+	report := suite.buildOperator(operator.Arguments{"service": "sbd.service"}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"active_state":"active","sub_state":"running"}`,
+		"after":  `{"active_state":"active","sub_state":"running"}`,
+	}, report.Success.Diff)
+}
+
+func (suite *ServiceStartOperatorTestSuite) TestServiceStartOperatorCommitErrorRollback() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	stateCall := suite.mockState(ctx, "failed", "failed")
+
+	startCall := suite.mockSystemd.On("Start", ctx, "sbd.service").
+		Return(errors.New("job result timeout")).
+		Once().
+		NotBefore(stateCall)
+
+	stopCall := suite.mockSystemd.On("Stop", ctx, "sbd.service").
+		Return(nil).
+		Once().
+		NotBefore(startCall)
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once().
+		NotBefore(stopCall)
+
+	report := suite.buildOperator(operator.Arguments{"service": "sbd.service"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues("failed to start service sbd.service: job result timeout", report.Error.Message)
+}
+
+func (suite *ServiceStartOperatorTestSuite) TestServiceStartOperatorVerifyErrorRollback() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	stateCall := suite.mockState(ctx, "inactive", "dead")
+
+	startCall := suite.mockSystemd.On("Start", ctx, "sbd.service").
+		Return(nil).
+		Once().
+		NotBefore(stateCall)
+
+	waitCall := suite.mockSystemd.On("WaitForActiveState", ctx, "sbd.service", systemd.WaitForActiveStateOptions{
+		States:  []string{"active"},
+		Timeout: 30 * time.Second,
+	}).
+		Return("activating", errors.New("timeout waiting for service sbd.service to be active, active state: activating")).
+		Once().
+		NotBefore(startCall)
+
+	stopCall := suite.mockSystemd.On("Stop", ctx, "sbd.service").
+		Return(errors.New("job result failed")).
+		Once().
+		NotBefore(waitCall)
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once().
+		NotBefore(stopCall)
+
+	report := suite.buildOperator(operator.Arguments{"service": "sbd.service"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.ROLLBACK, report.Error.ErrorPhase)
+	suite.EqualValues("job result failed\nservice sbd.service is not active: "+
+		"timeout waiting for service sbd.service to be active, active state: activating", report.Error.Message)
+}
+
+func (suite *ServiceStartOperatorTestSuite) TestServiceStartOperatorSuccess() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	stateCall := suite.mockState(ctx, "inactive", "dead")
+
+	startCall := suite.mockSystemd.On("Start", ctx, "sbd.service").
+		Return(nil).
+		Once().
+		NotBefore(stateCall)
+
+	waitCall := suite.mockSystemd.On("WaitForActiveState", ctx, "sbd.service", systemd.WaitForActiveStateOptions{
+		States:  []string{"active"},
+		Timeout: 90 * time.Second,
+	}).
+		Return("active", nil).
+		Once().
+		NotBefore(startCall)
+
+	verifyStateCall := suite.mockState(ctx, "active", "running").NotBefore(waitCall)
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once().
+		NotBefore(verifyStateCall)
+
+	report := suite.buildOperator(operator.Arguments{"service": "sbd.service", "timeout": float64(90)}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"active_state":"inactive","sub_state":"dead"}`,
+		"after":  `{"active_state":"active","sub_state":"running"}`,
+	}, report.Success.Diff)
+}
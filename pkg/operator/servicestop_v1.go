@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/trento-project/workbench/internal/systemd"
+)
+
+const ServiceStopOperatorName = "servicestop"
+
+type ServiceStopOption Option[ServiceStop]
+
+// ServiceStop operator stops a systemd unit.
+//
+// Arguments:
+//  service (required): Name of the unit, e.g. sbd.service. Only some cluster and tuning
+//                      units are allowed
+//  timeout: Time in seconds to wait until the service is stopped in the verify phase. Default 30
+//
+// # Execution Phases
+//
+// - PLAN:
+//   The operator connects to systemd and gets the active state of the service.
+//   The operation is skipped if the service is already inactive or failed.
+//
+// - COMMIT:
+//   It stops the systemd unit and waits until the stop job finishes.
+//
+// - VERIFY:
+//   The operator watches the state changes of the service until it is inactive or failed,
+//   failing if the timeout expires first.
+//
+// - ROLLBACK:
+//   If an error occurs during the COMMIT or VERIFY phase, the service is started back again.
+
+type ServiceStop struct {
+	baseOperator
+	systemdLoader    systemd.Loader
+	systemdConnector systemd.Systemd
+	service          string
+	timeout          time.Duration
+}
+
+func WithCustomServiceStopSystemdLoader(systemdLoader systemd.Loader) ServiceStopOption {
+	return func(ss *ServiceStop) {
+		ss.systemdLoader = systemdLoader
+	}
+}
+
+func NewServiceStop(
+	arguments Arguments,
+	operationID string,
+	options Options[ServiceStop],
+) *Executor {
+	serviceStop := &ServiceStop{
+		baseOperator: newBaseOperator(
+			ServiceStopOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+		systemdLoader: systemd.NewDefaultSystemdLoader(),
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(serviceStop)
+	}
+
+	return &Executor{
+		phaser:      serviceStop,
+		operationID: operationID,
+		logger:      serviceStop.logger,
+	}
+}
+
+func (ss *ServiceStop) plan(ctx context.Context) (bool, error) {
+	service, err := parseServiceArgument(ss.arguments)
+	if err != nil {
+		return false, err
+	}
+	ss.service = service
+
+	timeout, err := parseServiceTimeoutArgument(ss.arguments)
+	if err != nil {
+		return false, err
+	}
+	ss.timeout = timeout
+
+	systemdConnector, err := ss.systemdLoader.NewSystemd(ctx, ss.logger)
+	if err != nil {
+		ss.logger.Error("unable to initialize systemd connector", "error", err)
+		return false, fmt.Errorf("unable to initialize systemd connector: %w", err)
+	}
+	ss.systemdConnector = systemdConnector
+
+	state, err := getServiceActivityState(ctx, ss.systemdConnector, ss.service)
+	if err != nil {
+		ss.logger.Error("failed to get service state", "service", ss.service, "error", err)
+		return false, fmt.Errorf("failed to get %s service state: %w", ss.service, err)
+	}
+
+	ss.resources[beforeDiffField] = state
+
+	if isUnitStopped(state.ActiveState) {
+		ss.logger.Info("service already stopped, skipping operation", "service", ss.service)
+		ss.resources[afterDiffField] = state
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (ss *ServiceStop) commit(ctx context.Context) error {
+	if err := ss.systemdConnector.Stop(ctx, ss.service); err != nil {
+		ss.logger.Error("failed to stop service", "service", ss.service, "error", err)
+		return fmt.Errorf("failed to stop service %s: %w", ss.service, err)
+	}
+	return nil
+}
+
+func (ss *ServiceStop) verify(ctx context.Context) error {
+	activeState, err := ss.systemdConnector.WaitForActiveState(ctx, ss.service, systemd.WaitForActiveStateOptions{
+		States:  []string{unitInactive, unitFailed},
+		Timeout: ss.timeout,
+	})
+	if err != nil {
+		ss.logger.Info("service is not stopped, rolling back", "service", ss.service, "state", activeState, "error", err)
+		return fmt.Errorf("service %s is not stopped: %w", ss.service, err)
+	}
+
+	state, err := getServiceActivityState(ctx, ss.systemdConnector, ss.service)
+	if err != nil {
+		ss.logger.Error("failed to get service state", "service", ss.service, "error", err)
+		return fmt.Errorf("failed to get %s service state: %w", ss.service, err)
+	}
+
+	ss.resources[afterDiffField] = state
+
+	return nil
+}
+
+func (ss *ServiceStop) rollback(ctx context.Context) error {
+	return ss.systemdConnector.Start(ctx, ss.service)
+}
+
+func (ss *ServiceStop) operationDiff(_ context.Context) map[string]any {
+	return computeServiceActivityDiff(ss.resources)
+}
+
+func (ss *ServiceStop) after(_ context.Context) {
+	ss.systemdConnector.Close()
+}
@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/support"
+	"github.com/trento-project/workbench/internal/systemd"
+	"github.com/trento-project/workbench/internal/systemd/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+type ServiceStopOperatorTestSuite struct {
+	suite.Suite
+	logger            *slog.Logger
+	mockSystemd       *mocks.MockSystemd
+	mockSystemdLoader *mocks.MockLoader
+}
+
+func TestServiceStopOperator(t *testing.T) {
+	suite.Run(t, new(ServiceStopOperatorTestSuite))
+}
+
+func (suite *ServiceStopOperatorTestSuite) SetupTest() {
+	suite.logger = support.NewDefaultLogger(slog.LevelInfo)
+	suite.mockSystemd = mocks.NewMockSystemd(suite.T())
+	suite.mockSystemdLoader = mocks.NewMockLoader(suite.T())
+}
+
+func (suite *ServiceStopOperatorTestSuite) buildOperator(arguments operator.Arguments) operator.Operator {
+	return operator.NewServiceStop(
+		arguments,
+		"test-op",
+		operator.Options[operator.ServiceStop]{
+			BaseOperatorOptions: []operator.BaseOperatorOption{
+				operator.WithCustomLogger(suite.logger),
+			},
+			OperatorOptions: []operator.Option[operator.ServiceStop]{
+				operator.Option[operator.ServiceStop](operator.WithCustomServiceStopSystemdLoader(suite.mockSystemdLoader)),
+			},
+		},
+	)
+}
+
+func (suite *ServiceStopOperatorTestSuite) mockState(ctx context.Context, activeState, subState string) *mock.Call {
+	activeStateCall := suite.mockSystemd.On("GetActiveState", ctx, "tuned.service").
+		Return(activeState, nil).
+		Once()
+
+	return suite.mockSystemd.On("GetSubState", ctx, "tuned.service").
+		Return(subState, nil).
+		Once().
+		NotBefore(activeStateCall)
+}
+
+func (suite *ServiceStopOperatorTestSuite) TestServiceStopOperatorInvalidArguments() {
+	ctx := context.Background()
+
+	report := suite.buildOperator(operator.Arguments{}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("argument service not provided, could not use the operator", report.Error.Message)
+}
+
+func (suite *ServiceStopOperatorTestSuite) TestServiceStopOperatorAlreadyStopped() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	suite.mockState(ctx, "failed", "failed")
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once()
+
+	report := suite.buildOperator(operator.Arguments{"service": "tuned.service"}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"active_state":"failed","sub_state":"failed"}`,
+		"after":  `{"active_state":"failed","sub_state":"failed"}`,
+	}, report.Success.Diff)
+}
+
+func (suite *ServiceStopOperatorTestSuite) TestServiceStopOperatorVerifyErrorRollback() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	stateCall := suite.mockState(ctx, "active", "running")
+
+	stopCall := suite.mockSystemd.On("Stop", ctx, "tuned.service").
+		Return(nil).
+		Once().
+		NotBefore(stateCall)
+
+	waitCall := suite.mockSystemd.On("WaitForActiveState", ctx, "tuned.service", systemd.WaitForActiveStateOptions{
+		States:  []string{"inactive", "failed"},
+		Timeout: 30 * time.Second,
+	}).
+		Return("deactivating", errors.New("timeout waiting for service tuned.service to be inactive or failed, "+
+			"active state: deactivating")).
+		Once().
+		NotBefore(stopCall)
+
+	startCall := suite.mockSystemd.On("Start", ctx, "tuned.service").
+		Return(nil).
+		Once().
+		NotBefore(waitCall)
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once().
+		NotBefore(startCall)
+
+	report := suite.buildOperator(operator.Arguments{"service": "tuned.service"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.EqualValues("service tuned.service is not stopped: timeout waiting for service tuned.service "+
+		"to be inactive or failed, active state: deactivating", report.Error.Message)
+}
+
+func (suite *ServiceStopOperatorTestSuite) TestServiceStopOperatorCommitErrorRollback() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	stateCall := suite.mockState(ctx, "active", "running")
+
+	stopCall := suite.mockSystemd.On("Stop", ctx, "tuned.service").
+		Return(errors.New("job result canceled")).
+		Once().
+		NotBefore(stateCall)
+
+	startCall := suite.mockSystemd.On("Start", ctx, "tuned.service").
+		Return(errors.New("job result failed")).
+		Once().
+		NotBefore(stopCall)
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once().
+		NotBefore(startCall)
+
+	report := suite.buildOperator(operator.Arguments{"service": "tuned.service"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.ROLLBACK, report.Error.ErrorPhase)
+	suite.EqualValues("job result failed\nfailed to stop service tuned.service: job result canceled",
+		report.Error.Message)
+}
+
+func (suite *ServiceStopOperatorTestSuite) TestServiceStopOperatorSuccess() {
+	ctx := context.Background()
+
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	stateCall := suite.mockState(ctx, "active", "running")
+
+	stopCall := suite.mockSystemd.On("Stop", ctx, "tuned.service").
+		Return(nil).
+		Once().
+		NotBefore(stateCall)
+
+	waitCall := suite.mockSystemd.On("WaitForActiveState", ctx, "tuned.service", systemd.WaitForActiveStateOptions{
+		States:  []string{"inactive", "failed"},
+		Timeout: 30 * time.Second,
+	}).
+		Return("inactive", nil).
+		Once().
+		NotBefore(stopCall)
+
+	verifyStateCall := suite.mockState(ctx, "inactive", "dead").NotBefore(waitCall)
+
+	suite.mockSystemd.On("Close").
+		Return().
+		Once().
+		NotBefore(verifyStateCall)
+
+	report := suite.buildOperator(operator.Arguments{"service": "tuned.service"}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"active_state":"active","sub_state":"running"}`,
+		"after":  `{"active_state":"inactive","sub_state":"dead"}`,
+	}, report.Success.Diff)
+}
@@ -0,0 +1,319 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/sbd"
+	"github.com/trento-project/workbench/internal/support"
+)
+
+const (
+	StonithTestOperatorName = "stonithtest"
+	testMessageSentField    = "test_message_sent"
+)
+
+type StonithTestOption Option[StonithTest]
+
+type stonithTestArguments struct {
+	node   string
+	device string
+}
+
+type stonithTestDiffOutput struct {
+	Device       string      `json:"device"`
+	Node         string      `json:"node"`
+	Message      sbd.Message `json:"message"`
+	TestReceived bool        `json:"test_received"`
+}
+
+// StonithTest operator verifies the SBD fencing communication with a peer node sending
+// a test message to its SBD slot. The test message is harmless, the peer node only logs it.
+//
+// Arguments:
+//  node (required): Peer node receiving the test message
+//  device: SBD device used to send the message. Defaults to the first configured SBD device
+//
+// The operation is guarded: it is refused if the node is the local node, if it is not online
+// in the cluster, or if its slot has a pending message, which would be overwritten otherwise.
+// Diskless SBD is not supported, as it doesn't have slots to exchange messages.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   Checks that the cluster is running in the host, reads the SBD configuration and the node
+//   slots of the device, and runs the guard checks.
+//
+// - COMMIT:
+//   Sends the test message using `sbd -d <device> message <node> test`.
+//
+// - VERIFY:
+//   Waits until the peer node clears its slot, which means that the test message was received,
+//   using exponential backoff retries.
+//
+// - ROLLBACK:
+//   Clears the node slot if the test message is still pending.
+
+type StonithTest struct {
+	baseOperator
+	sbdClient       sbd.SBD
+	clusterClient   cluster.Cluster
+	retryOptions    support.BackoffOptions
+	parsedArguments *stonithTestArguments
+}
+
+func WithCustomStonithTestSBDClient(sbdClient sbd.SBD) StonithTestOption {
+	return func(o *StonithTest) {
+		o.sbdClient = sbdClient
+	}
+}
+
+func WithCustomStonithTestClusterClient(clusterClient cluster.Cluster) StonithTestOption {
+	return func(o *StonithTest) {
+		o.clusterClient = clusterClient
+	}
+}
+
+func WithCustomRetryStonithTest(maxRetries int, initialDelay, maxDelay time.Duration, factor int) StonithTestOption {
+	return func(o *StonithTest) {
+		o.retryOptions = support.BackoffOptions{
+			InitialDelay: initialDelay,
+			MaxDelay:     maxDelay,
+			MaxRetries:   maxRetries,
+			Factor:       factor,
+		}
+	}
+}
+
+func NewStonithTest(
+	arguments Arguments,
+	operationID string,
+	options Options[StonithTest],
+) *Executor {
+	stonithTest := &StonithTest{
+		baseOperator:  newBaseOperator(StonithTestOperatorName, operationID, arguments, options.BaseOperatorOptions...),
+		sbdClient:     sbd.NewDefaultSBDClient(),
+		clusterClient: cluster.NewDefaultClusterClient(),
+		// wait before each execution: 0s, 1.5s, 4.5s, 13.5s, 40.5s
+		retryOptions: support.BackoffOptions{
+			InitialDelay: 500 * time.Millisecond,
+			MaxDelay:     1 * time.Minute,
+			MaxRetries:   5,
+			Factor:       3,
+		},
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(stonithTest)
+	}
+
+	return &Executor{
+		phaser:      stonithTest,
+		operationID: operationID,
+		logger:      stonithTest.logger,
+	}
+}
+
+func (s *StonithTest) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseStonithTestArguments(s.arguments)
+	if err != nil {
+		return false, err
+	}
+	s.parsedArguments = opArguments
+
+	if !s.clusterClient.IsHostOnline(ctx) {
+		return false, errors.New("cluster is not running on host")
+	}
+
+	config, err := s.sbdClient.GetConfiguration(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if len(config.Devices) == 0 {
+		return false, errors.New("no SBD device configured, diskless SBD is not supported")
+	}
+
+	if s.parsedArguments.device == "" {
+		s.parsedArguments.device = config.Devices[0]
+	} else if !slices.Contains(config.Devices, s.parsedArguments.device) {
+		return false, fmt.Errorf("device %s is not a configured SBD device", s.parsedArguments.device)
+	}
+
+	if err := s.checkPeerNode(ctx); err != nil {
+		return false, err
+	}
+
+	slot, err := s.getNodeSlot(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if slot.Message != sbd.ClearMessage {
+		return false, fmt.Errorf("node %s slot has a pending %s message, it cannot be overwritten",
+			slot.Node, slot.Message)
+	}
+
+	s.resources[beforeDiffField] = s.newDiffOutput(slot, false)
+
+	return false, nil
+}
+
+func (s *StonithTest) commit(ctx context.Context) error {
+	s.resources[testMessageSentField] = true
+
+	return s.sbdClient.SendMessage(ctx, s.parsedArguments.device, s.parsedArguments.node, sbd.TestMessage)
+}
+
+func (s *StonithTest) verify(ctx context.Context) error {
+	result := <-support.AsyncExponentialBackoff(
+		ctx,
+		s.retryOptions,
+		func() (*sbd.Slot, error) {
+			slot, err := s.getNodeSlot(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if slot.Message != sbd.ClearMessage {
+				return nil, fmt.Errorf("node %s slot has a pending %s message", slot.Node, slot.Message)
+			}
+			return slot, nil
+		},
+	)
+	if result.Err != nil {
+		return fmt.Errorf("test message not received by node %s: %w", s.parsedArguments.node, result.Err)
+	}
+
+	s.resources[afterDiffField] = s.newDiffOutput(result.Result, true)
+
+	return nil
+}
+
+func (s *StonithTest) rollback(ctx context.Context) error {
+	if sent, _ := s.resources[testMessageSentField].(bool); !sent {
+		return nil
+	}
+
+	slot, err := s.getNodeSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	// only the test message is cleared, other messages are written by the cluster
+	if slot.Message != sbd.TestMessage {
+		return nil
+	}
+
+	return s.sbdClient.SendMessage(ctx, s.parsedArguments.device, s.parsedArguments.node, sbd.ClearMessage)
+}
+
+func (s *StonithTest) operationDiff(_ context.Context) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		diffOutput, ok := s.resources[field].(stonithTestDiffOutput)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to stonith test diff output",
+				field, s.resources[field]))
+		}
+
+		output, err := json.Marshal(diffOutput)
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	return diff
+}
+
+func (s *StonithTest) checkPeerNode(ctx context.Context) error {
+	localNode, err := s.clusterClient.LocalNodeName(ctx)
+	if err != nil {
+		return err
+	}
+
+	if localNode == s.parsedArguments.node {
+		return fmt.Errorf("node %s is the local node, the test message must be sent to a peer node", localNode)
+	}
+
+	status, err := s.clusterClient.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(status.Nodes, func(node cluster.NodeStatus) bool {
+		return node.Name == s.parsedArguments.node
+	})
+	if index == -1 {
+		return fmt.Errorf("node %s is not a cluster node", s.parsedArguments.node)
+	}
+
+	if !status.Nodes[index].Online {
+		return fmt.Errorf("node %s is not online in the cluster", s.parsedArguments.node)
+	}
+
+	return nil
+}
+
+func (s *StonithTest) getNodeSlot(ctx context.Context) (*sbd.Slot, error) {
+	slots, err := s.sbdClient.ListSlots(ctx, s.parsedArguments.device)
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.IndexFunc(slots, func(slot sbd.Slot) bool {
+		return slot.Node == s.parsedArguments.node
+	})
+	if index == -1 {
+		return nil, fmt.Errorf("node %s doesn't have a slot in SBD device %s",
+			s.parsedArguments.node, s.parsedArguments.device)
+	}
+
+	return &slots[index], nil
+}
+
+func (s *StonithTest) newDiffOutput(slot *sbd.Slot, received bool) stonithTestDiffOutput {
+	return stonithTestDiffOutput{
+		Device:       s.parsedArguments.device,
+		Node:         slot.Node,
+		Message:      slot.Message,
+		TestReceived: received,
+	}
+}
+
+func parseStonithTestArguments(rawArguments Arguments) (*stonithTestArguments, error) {
+	nodeArgument, found := rawArguments["node"]
+	if !found {
+		return nil, errors.New("argument node not provided, could not use the operator")
+	}
+
+	node, ok := nodeArgument.(string)
+	if !ok || node == "" {
+		return nil, fmt.Errorf("could not parse node argument as a non empty string, argument provided: %v",
+			nodeArgument)
+	}
+
+	parsedArguments := &stonithTestArguments{node: node}
+
+	deviceArgument, found := rawArguments["device"]
+	if !found {
+		return parsedArguments, nil
+	}
+
+	device, ok := deviceArgument.(string)
+	if !ok {
+		return nil, fmt.Errorf("could not parse device argument as string, argument provided: %v", deviceArgument)
+	}
+	parsedArguments.device = device
+
+	return parsedArguments, nil
+}
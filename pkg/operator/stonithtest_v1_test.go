@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/cluster"
+	"github.com/trento-project/workbench/internal/sbd"
+	"github.com/trento-project/workbench/internal/support/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+	"github.com/trento-project/workbench/test/helpers"
+)
+
+type StonithTestOperatorTestSuite struct {
+	suite.Suite
+	mockExecutor *mocks.MockCmdExecutor
+}
+
+func TestStonithTestOperator(t *testing.T) {
+	suite.Run(t, new(StonithTestOperatorTestSuite))
+}
+
+func (suite *StonithTestOperatorTestSuite) SetupTest() {
+	suite.mockExecutor = mocks.NewMockCmdExecutor(suite.T())
+}
+
+func (suite *StonithTestOperatorTestSuite) buildOperator(arguments operator.Arguments) *operator.Executor {
+	return operator.NewStonithTest(
+		arguments,
+		"test-op",
+		operator.Options[operator.StonithTest]{
+			OperatorOptions: []operator.Option[operator.StonithTest]{
+				operator.Option[operator.StonithTest](operator.WithCustomStonithTestSBDClient(
+					sbd.NewSBDClient(suite.mockExecutor, helpers.GetFixturePath("sbd/sysconfig_sbd"), slog.Default()),
+				)),
+				operator.Option[operator.StonithTest](operator.WithCustomStonithTestClusterClient(
+					cluster.NewClusterClient(suite.mockExecutor, slog.Default()),
+				)),
+				operator.Option[operator.StonithTest](operator.WithCustomRetryStonithTest(2, 0, 0, 1)),
+			},
+		},
+	)
+}
+
+func (suite *StonithTestOperatorTestSuite) mockPlan(ctx context.Context) {
+	suite.mockExecutor.On("Exec", ctx, "crm", "status").Return([]byte("Online"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm_node", "-n").Return([]byte("vmhana01\n"), nil).Once()
+	suite.mockExecutor.On(
+		"Exec", ctx, "crm_mon", "--output-as=xml", "--inactive", "--failcounts", "--fence-history=1",
+	).Return(helpers.ReadFixture("cluster/crm_mon_healthy.output"), nil).Once()
+}
+
+func (suite *StonithTestOperatorTestSuite) mockListSlots(ctx context.Context, fixture string) {
+	suite.mockExecutor.On("Exec", ctx, "sbd", "-d", firstSBDDevice, "list").
+		Return(helpers.ReadFixture(fixture), nil).Once()
+}
+
+func (suite *StonithTestOperatorTestSuite) TestStonithTestInvalidArguments() {
+	ctx := context.Background()
+
+	cases := []struct {
+		arguments operator.Arguments
+		err       string
+	}{
+		{
+			arguments: operator.Arguments{},
+			err:       "argument node not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{"node": ""},
+			err:       "could not parse node argument as a non empty string, argument provided: ",
+		},
+		{
+			arguments: operator.Arguments{"node": "vmhana02", "device": 1},
+			err:       "could not parse device argument as string, argument provided: 1",
+		},
+	}
+
+	for _, tc := range cases {
+		report := suite.buildOperator(tc.arguments).Run(ctx)
+
+		suite.Nil(report.Success)
+		suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+		suite.EqualValues(tc.err, report.Error.Message)
+	}
+}
+
+func (suite *StonithTestOperatorTestSuite) TestStonithTestUnknownDevice() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "crm", "status").Return([]byte("Online"), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"node": "vmhana02", "device": "/dev/sdx"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("device /dev/sdx is not a configured SBD device", report.Error.Message)
+}
+
+func (suite *StonithTestOperatorTestSuite) TestStonithTestLocalNode() {
+	ctx := context.Background()
+
+	suite.mockExecutor.On("Exec", ctx, "crm", "status").Return([]byte("Online"), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "crm_node", "-n").Return([]byte("vmhana01\n"), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"node": "vmhana01"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"node vmhana01 is the local node, the test message must be sent to a peer node",
+		report.Error.Message,
+	)
+}
+
+func (suite *StonithTestOperatorTestSuite) TestStonithTestUnknownNode() {
+	ctx := context.Background()
+
+	suite.mockPlan(ctx)
+
+	report := suite.buildOperator(operator.Arguments{"node": "vmhana03"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("node vmhana03 is not a cluster node", report.Error.Message)
+}
+
+func (suite *StonithTestOperatorTestSuite) TestStonithTestPendingMessage() {
+	ctx := context.Background()
+
+	suite.mockPlan(ctx)
+	suite.mockListSlots(ctx, "sbd/sbd_list_pending_message.output")
+
+	report := suite.buildOperator(operator.Arguments{"node": "vmhana02"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+	suite.EqualValues("node vmhana02 slot has a pending test message, it cannot be overwritten", report.Error.Message)
+}
+
+func (suite *StonithTestOperatorTestSuite) TestStonithTestSuccess() {
+	ctx := context.Background()
+
+	suite.mockPlan(ctx)
+	suite.mockListSlots(ctx, "sbd/sbd_list.output")
+	suite.mockExecutor.On("Exec", ctx, "sbd", "-d", firstSBDDevice, "message", "vmhana02", "test").
+		Return([]byte(""), nil).Once()
+	suite.mockListSlots(ctx, "sbd/sbd_list_pending_message.output")
+	suite.mockListSlots(ctx, "sbd/sbd_list.output")
+
+	report := suite.buildOperator(operator.Arguments{"node": "vmhana02"}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"device":"` + firstSBDDevice + `","node":"vmhana02","message":"clear","test_received":false}`,
+		"after":  `{"device":"` + firstSBDDevice + `","node":"vmhana02","message":"clear","test_received":true}`,
+	}, report.Success.Diff)
+}
+
+func (suite *StonithTestOperatorTestSuite) TestStonithTestCommitError() {
+	ctx := context.Background()
+
+	suite.mockPlan(ctx)
+	suite.mockListSlots(ctx, "sbd/sbd_list.output")
+	suite.mockExecutor.On("Exec", ctx, "sbd", "-d", firstSBDDevice, "message", "vmhana02", "test").
+		Return([]byte("error"), errors.New("exit status 1")).Once()
+	suite.mockListSlots(ctx, "sbd/sbd_list.output")
+
+	report := suite.buildOperator(operator.Arguments{"node": "vmhana02"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.COMMIT, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"could not send SBD message test to node vmhana02 using device "+firstSBDDevice+
+			": exit status 1, output: error",
+		report.Error.Message,
+	)
+}
+
+func (suite *StonithTestOperatorTestSuite) TestStonithTestNotReceivedRollback() {
+	ctx := context.Background()
+
+	suite.mockPlan(ctx)
+	suite.mockListSlots(ctx, "sbd/sbd_list.output")
+	suite.mockExecutor.On("Exec", ctx, "sbd", "-d", firstSBDDevice, "message", "vmhana02", "test").
+		Return([]byte(""), nil).Once()
+	suite.mockExecutor.On("Exec", ctx, "sbd", "-d", firstSBDDevice, "list").
+		Return(helpers.ReadFixture("sbd/sbd_list_pending_message.output"), nil).Times(3)
+	suite.mockExecutor.On("Exec", ctx, "sbd", "-d", firstSBDDevice, "message", "vmhana02", "clear").
+		Return([]byte(""), nil).Once()
+
+	report := suite.buildOperator(operator.Arguments{"node": "vmhana02"}).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.EqualValues(
+		"test message not received by node vmhana02: "+
+			"operation failed after 2 attempts: node vmhana02 slot has a pending test message",
+		report.Error.Message,
+	)
+}
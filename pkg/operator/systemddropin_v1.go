@@ -0,0 +1,374 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/trento-project/workbench/internal/systemd"
+)
+
+const SystemdDropInOperatorName = "systemddropin"
+
+// sapInstanceUnitPatternCompiled matches the systemd units of the SAP instances, e.g. SAPPRD_00.service
+var sapInstanceUnitPatternCompiled = regexp.MustCompile(`^SAP[A-Z][A-Z0-9]{2}_\d{2}\.service$`)
+
+// allowedDropInUnits are the units whose drop-ins can be managed, besides the SAP instance units
+var allowedDropInUnits = append(slices.Clone(allowedServiceUnits), "sapinit.service")
+
+type SystemdDropInOption Option[SystemdDropIn]
+
+type systemdDropInArguments struct {
+	unit string
+	name string
+	// sections is empty if the drop-in has to be removed
+	sections []systemd.DropInSection
+	remove   bool
+}
+
+type systemdDropInDiffOutput struct {
+	Unit string `json:"unit"`
+	Path string `json:"path"`
+	// Content is the drop-in file content, nil if the file doesn't exist
+	Content *string `json:"content"`
+}
+
+// SystemdDropIn operator writes or removes a drop-in file of a systemd unit, stored in
+// /etc/systemd/system/<unit>.d/<name>.conf.
+//
+// Arguments:
+//  unit (required): Name of the unit, e.g. sapinit.service. Only the units managed by the service operators,
+//                   sapinit.service and the SAP instance units, e.g. SAPPRD_00.service, are allowed
+//  name (required): Name of the drop-in file, e.g. 50-timeout. The .conf extension is added if missing
+//  settings: Map with the directives by section, e.g. {"Service": {"TimeoutStopSec": "300"}}.
+//            The drop-in file content is replaced with these directives.
+//  remove: Remove the drop-in file. Default false
+//
+// Either settings or remove must be given. Only the Service section and the directives verified through
+// the service properties are supported: TimeoutStartSec, TimeoutStopSec, RestartSec, LimitNOFILE, LimitNPROC,
+// LimitMEMLOCK, LimitCORE, Restart and KillMode. The drop-in file is owned by the operator, comments
+// and other directives are not kept.
+//
+// # Execution Phases
+//
+// - PLAN:
+//   The operator validates the directives and gets the current drop-in file content as the "before" diff.
+//   If the file already has the requested content, or it doesn't exist and it has to be removed,
+//   the operation is skipped.
+//
+// - COMMIT:
+//   The drop-in file is written or removed and the systemd configuration is reloaded.
+//
+// - VERIFY:
+//   Checks that systemd loaded, or unloaded, the drop-in file and that the service properties of
+//   the known directives, like TimeoutStopSec or LimitNOFILE, have the requested values.
+//   The drop-in file content is collected as the "after" diff.
+//
+// - ROLLBACK:
+//   The previous drop-in file content is restored, or the file is removed if it didn't exist,
+//   and the systemd configuration is reloaded.
+
+type SystemdDropIn struct {
+	baseOperator
+	systemdLoader    systemd.Loader
+	systemdConnector systemd.Systemd
+	parsedArguments  *systemdDropInArguments
+}
+
+func WithCustomSystemdDropInSystemdLoader(systemdLoader systemd.Loader) SystemdDropInOption {
+	return func(o *SystemdDropIn) {
+		o.systemdLoader = systemdLoader
+	}
+}
+
+func NewSystemdDropIn(
+	arguments Arguments,
+	operationID string,
+	options Options[SystemdDropIn],
+) *Executor {
+	systemdDropIn := &SystemdDropIn{
+		baseOperator: newBaseOperator(
+			SystemdDropInOperatorName, operationID, arguments, options.BaseOperatorOptions...,
+		),
+		systemdLoader: systemd.NewDefaultSystemdLoader(),
+	}
+
+	for _, opt := range options.OperatorOptions {
+		opt(systemdDropIn)
+	}
+
+	return &Executor{
+		phaser:      systemdDropIn,
+		operationID: operationID,
+		logger:      systemdDropIn.logger,
+	}
+}
+
+func (sd *SystemdDropIn) plan(ctx context.Context) (bool, error) {
+	opArguments, err := parseSystemdDropInArguments(sd.arguments)
+	if err != nil {
+		return false, err
+	}
+	sd.parsedArguments = opArguments
+
+	systemdConnector, err := sd.systemdLoader.NewSystemd(ctx, sd.logger)
+	if err != nil {
+		sd.logger.Error("unable to initialize systemd connector", "error", err)
+		return false, fmt.Errorf("unable to initialize systemd connector: %w", err)
+	}
+	sd.systemdConnector = systemdConnector
+
+	content, found, err := sd.systemdConnector.GetDropIn(ctx, opArguments.unit, opArguments.name)
+	if err != nil {
+		return false, err
+	}
+	sd.resources[beforeDiffField] = overrideContentPointer(content, found)
+
+	if sd.dropInApplied(content, found) {
+		sd.logger.Info("drop-in already applied, skipping operation", "unit", opArguments.unit, "name", opArguments.name)
+		sd.resources[afterDiffField] = sd.resources[beforeDiffField]
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (sd *SystemdDropIn) commit(ctx context.Context) error {
+	var err error
+	if sd.parsedArguments.remove {
+		err = sd.systemdConnector.RemoveDropIn(ctx, sd.parsedArguments.unit, sd.parsedArguments.name)
+	} else {
+		err = sd.systemdConnector.WriteDropIn(
+			ctx, sd.parsedArguments.unit, sd.parsedArguments.name, systemd.FormatDropIn(sd.parsedArguments.sections),
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	return sd.systemdConnector.DaemonReload(ctx)
+}
+
+func (sd *SystemdDropIn) verify(ctx context.Context) error {
+	unit := sd.parsedArguments.unit
+	path := sd.systemdConnector.DropInPath(unit, sd.parsedArguments.name)
+
+	content, found, err := sd.systemdConnector.GetDropIn(ctx, unit, sd.parsedArguments.name)
+	if err != nil {
+		return err
+	}
+
+	if !sd.dropInApplied(content, found) {
+		return fmt.Errorf("verify systemd drop-in failing, the drop-in %s was not written in commit phase", path)
+	}
+
+	loadedPaths, err := sd.systemdConnector.GetDropInPaths(ctx, unit)
+	if err != nil {
+		return err
+	}
+
+	loaded := slices.Contains(loadedPaths, path)
+	if loaded && sd.parsedArguments.remove {
+		return fmt.Errorf("verify systemd drop-in failing, the drop-in %s is still loaded by systemd", path)
+	}
+
+	if !loaded && !sd.parsedArguments.remove {
+		return fmt.Errorf("verify systemd drop-in failing, the drop-in %s was not loaded by systemd", path)
+	}
+
+	for _, section := range sd.parsedArguments.sections {
+		for _, directive := range section.Directives {
+			applied, err := sd.systemdConnector.IsDirectiveApplied(ctx, unit, section.Name, directive)
+			if err != nil {
+				return err
+			}
+
+			if !applied {
+				return fmt.Errorf(
+					"verify systemd drop-in failing, directive %s of unit %s does not have the value %s",
+					directive.Name, unit, directive.Value,
+				)
+			}
+		}
+	}
+
+	sd.resources[afterDiffField] = overrideContentPointer(content, found)
+	return nil
+}
+
+func (sd *SystemdDropIn) rollback(ctx context.Context) error {
+	initialContent, _ := sd.resources[beforeDiffField].(*string)
+
+	var err error
+	if initialContent == nil {
+		err = sd.systemdConnector.RemoveDropIn(ctx, sd.parsedArguments.unit, sd.parsedArguments.name)
+	} else {
+		err = sd.systemdConnector.WriteDropIn(
+			ctx, sd.parsedArguments.unit, sd.parsedArguments.name, []byte(*initialContent),
+		)
+	}
+
+	err = errors.Join(err, sd.systemdConnector.DaemonReload(ctx))
+	if err != nil {
+		return fmt.Errorf("error rolling back systemd drop-in: %w", err)
+	}
+
+	return nil
+}
+
+func (sd *SystemdDropIn) operationDiff(_ context.Context) map[string]any {
+	diff := make(map[string]any)
+
+	for _, field := range []string{beforeDiffField, afterDiffField} {
+		content, ok := sd.resources[field].(*string)
+		if !ok {
+			panic(fmt.Sprintf("invalid %s value: cannot parse '%v' to drop-in content",
+				field, sd.resources[field]))
+		}
+
+		output, err := json.Marshal(systemdDropInDiffOutput{
+			Unit:    sd.parsedArguments.unit,
+			Path:    sd.systemdConnector.DropInPath(sd.parsedArguments.unit, sd.parsedArguments.name),
+			Content: content,
+		})
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling %s diff output: %v", field, err))
+		}
+		diff[field] = string(output)
+	}
+
+	return diff
+}
+
+func (sd *SystemdDropIn) after(_ context.Context) {
+	sd.systemdConnector.Close()
+}
+
+func (sd *SystemdDropIn) dropInApplied(content []byte, found bool) bool {
+	if sd.parsedArguments.remove {
+		return !found
+	}
+	return found && string(content) == string(systemd.FormatDropIn(sd.parsedArguments.sections))
+}
+
+func parseSystemdDropInArguments(rawArguments Arguments) (*systemdDropInArguments, error) {
+	opArguments := &systemdDropInArguments{}
+
+	for _, argument := range []struct {
+		name  string
+		value *string
+	}{
+		{name: "unit", value: &opArguments.unit},
+		{name: "name", value: &opArguments.name},
+	} {
+		rawValue, found := rawArguments[argument.name]
+		if !found {
+			return nil, fmt.Errorf("argument %s not provided, could not use the operator", argument.name)
+		}
+
+		value, ok := rawValue.(string)
+		if !ok {
+			return nil, fmt.Errorf(
+				"could not parse %s argument as string, argument provided: %v", argument.name, rawValue,
+			)
+		}
+		*argument.value = value
+	}
+
+	if !strings.HasSuffix(opArguments.name, ".conf") {
+		opArguments.name += ".conf"
+	}
+
+	if err := systemd.ValidateDropInUnit(opArguments.unit); err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(allowedDropInUnits, opArguments.unit) &&
+		!sapInstanceUnitPatternCompiled.MatchString(opArguments.unit) {
+		return nil, fmt.Errorf("unit %s is not allowed, allowed units: %s and the SAP instance units",
+			opArguments.unit, strings.Join(allowedDropInUnits, ", "))
+	}
+
+	if err := systemd.ValidateDropInName(opArguments.name); err != nil {
+		return nil, err
+	}
+
+	if rawRemove, found := rawArguments["remove"]; found {
+		remove, ok := rawRemove.(bool)
+		if !ok {
+			return nil, fmt.Errorf("could not parse remove argument as bool, argument provided: %v", rawRemove)
+		}
+		opArguments.remove = remove
+	}
+
+	rawSettings, found := rawArguments["settings"]
+	switch {
+	case found && opArguments.remove:
+		return nil, errors.New("arguments settings and remove cannot be used together")
+	case !found && !opArguments.remove:
+		return nil, errors.New("arguments settings or remove not provided, could not use the operator")
+	case !found:
+		return opArguments, nil
+	}
+
+	sections, err := parseSystemdDropInSettings(rawSettings)
+	if err != nil {
+		return nil, err
+	}
+	opArguments.sections = sections
+
+	return opArguments, nil
+}
+
+func parseSystemdDropInSettings(rawSettings any) ([]systemd.DropInSection, error) {
+	settings, ok := rawSettings.(map[string]any)
+	if !ok || len(settings) == 0 {
+		return nil, fmt.Errorf(
+			"could not parse settings argument as a non empty map, argument provided: %v", rawSettings,
+		)
+	}
+
+	sections := []systemd.DropInSection{}
+	for sectionName, rawDirectives := range settings {
+		directives, ok := rawDirectives.(map[string]any)
+		if !ok || len(directives) == 0 {
+			return nil, fmt.Errorf(
+				"could not parse %s settings as a non empty map, argument provided: %v", sectionName, rawDirectives,
+			)
+		}
+
+		section := systemd.DropInSection{Name: sectionName, Directives: []systemd.DropInDirective{}}
+		for name, rawValue := range directives {
+			value, err := parsePropertyValue(rawValue)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse %s directive value: %w", name, err)
+			}
+
+			directive := systemd.DropInDirective{Name: name, Value: value}
+			if err := systemd.ValidateDropInDirective(sectionName, directive); err != nil {
+				return nil, err
+			}
+			section.Directives = append(section.Directives, directive)
+		}
+
+		// keep a stable order to write the drop-in file
+		slices.SortFunc(section.Directives, func(a, b systemd.DropInDirective) int {
+			return cmp.Compare(a.Name, b.Name)
+		})
+		sections = append(sections, section)
+	}
+
+	slices.SortFunc(sections, func(a, b systemd.DropInSection) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return sections, nil
+}
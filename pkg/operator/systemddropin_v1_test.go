@@ -0,0 +1,342 @@
+// SPDX-FileCopyrightText: SUSE LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/workbench/internal/support"
+	"github.com/trento-project/workbench/internal/systemd"
+	"github.com/trento-project/workbench/internal/systemd/mocks"
+	"github.com/trento-project/workbench/pkg/operator"
+)
+
+const (
+	sapinitDropInPath    = "/etc/systemd/system/sapinit.service.d/50-timeout.conf"
+	sapinitDropInContent = "[Service]\nRestart=no\nTimeoutStopSec=300\n"
+)
+
+type SystemdDropInOperatorTestSuite struct {
+	suite.Suite
+	logger            *slog.Logger
+	mockSystemd       *mocks.MockSystemd
+	mockSystemdLoader *mocks.MockLoader
+}
+
+func TestSystemdDropInOperator(t *testing.T) {
+	suite.Run(t, new(SystemdDropInOperatorTestSuite))
+}
+
+func (suite *SystemdDropInOperatorTestSuite) SetupTest() {
+	suite.logger = support.NewDefaultLogger(slog.LevelInfo)
+	suite.mockSystemd = mocks.NewMockSystemd(suite.T())
+	suite.mockSystemdLoader = mocks.NewMockLoader(suite.T())
+}
+
+func (suite *SystemdDropInOperatorTestSuite) buildOperator(arguments operator.Arguments) operator.Operator {
+	return operator.NewSystemdDropIn(
+		arguments,
+		"test-op",
+		operator.Options[operator.SystemdDropIn]{
+			BaseOperatorOptions: []operator.BaseOperatorOption{
+				operator.WithCustomLogger(suite.logger),
+			},
+			OperatorOptions: []operator.Option[operator.SystemdDropIn]{
+				operator.Option[operator.SystemdDropIn](
+					operator.WithCustomSystemdDropInSystemdLoader(suite.mockSystemdLoader),
+				),
+			},
+		},
+	)
+}
+
+func (suite *SystemdDropInOperatorTestSuite) mockConnector(ctx context.Context) {
+	suite.mockSystemdLoader.On("NewSystemd", ctx, mock.AnythingOfType("*slog.Logger")).
+		Return(suite.mockSystemd, nil).
+		Once()
+
+	suite.mockSystemd.On("DropInPath", "sapinit.service", "50-timeout.conf").
+		Return(sapinitDropInPath).
+		Maybe()
+}
+
+func sapinitDropInArguments() operator.Arguments {
+	return operator.Arguments{
+		"unit": "sapinit.service",
+		"name": "50-timeout",
+		"settings": map[string]any{
+			"Service": map[string]any{"TimeoutStopSec": float64(300), "Restart": "no"},
+		},
+	}
+}
+
+func (suite *SystemdDropInOperatorTestSuite) TestSystemdDropInInvalidArguments() {
+	ctx := context.Background()
+
+	cases := []struct {
+		arguments operator.Arguments
+		err       string
+	}{
+		{
+			arguments: operator.Arguments{"name": "50-timeout"},
+			err:       "argument unit not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{"unit": "sapinit.service", "name": 50},
+			err:       "could not parse name argument as string, argument provided: 50",
+		},
+		{
+			arguments: operator.Arguments{"unit": "sapinit", "name": "50-timeout", "remove": true},
+			err:       "invalid unit name sapinit",
+		},
+		{
+			arguments: operator.Arguments{"unit": "sshd.service", "name": "50-timeout", "remove": true},
+			err: "unit sshd.service is not allowed, allowed units: corosync.service, hawk.service, " +
+				"pacemaker.service, sapconf.service, saptune.service, sbd.service, tuned.service, " +
+				"sapinit.service and the SAP instance units",
+		},
+		{
+			arguments: operator.Arguments{"unit": "sapinit.service", "name": "../50-timeout", "remove": true},
+			err:       "invalid drop-in name ../50-timeout.conf",
+		},
+		{
+			arguments: operator.Arguments{"unit": "sapinit.service", "name": "50-timeout"},
+			err:       "arguments settings or remove not provided, could not use the operator",
+		},
+		{
+			arguments: operator.Arguments{
+				"unit": "sapinit.service", "name": "50-timeout", "remove": true, "settings": map[string]any{},
+			},
+			err: "arguments settings and remove cannot be used together",
+		},
+		{
+			arguments: operator.Arguments{"unit": "sapinit.service", "name": "50-timeout", "settings": []string{}},
+			err:       "could not parse settings argument as a non empty map, argument provided: []",
+		},
+		{
+			arguments: operator.Arguments{
+				"unit": "sapinit.service", "name": "50-timeout", "settings": map[string]any{"Service": "x"},
+			},
+			err: "could not parse Service settings as a non empty map, argument provided: x",
+		},
+		{
+			arguments: operator.Arguments{
+				"unit":     "sapinit.service",
+				"name":     "50-timeout",
+				"settings": map[string]any{"Install": map[string]any{"WantedBy": "multi-user.target"}},
+			},
+			err: "invalid drop-in section Install, only the Service section is supported",
+		},
+		{
+			arguments: operator.Arguments{
+				"unit":     "sapinit.service",
+				"name":     "50-timeout",
+				"settings": map[string]any{"Service": map[string]any{"ExecStartPre": "/tmp/script.sh"}},
+			},
+			err: "directive ExecStartPre is not allowed, allowed directives: KillMode, LimitCORE, LimitMEMLOCK, " +
+				"LimitNOFILE, LimitNPROC, Restart, RestartSec, TimeoutStartSec, TimeoutStopSec",
+		},
+		{
+			arguments: operator.Arguments{
+				"unit":     "sapinit.service",
+				"name":     "50-timeout",
+				"settings": map[string]any{"Service": map[string]any{"TimeoutStopSec": "forever"}},
+			},
+			err: "invalid value forever for directive TimeoutStopSec: invalid time span forever",
+		},
+	}
+
+	for _, tc := range cases {
+		report := suite.buildOperator(tc.arguments).Run(ctx)
+
+		suite.Nil(report.Success)
+		suite.Equal(operator.PLAN, report.Error.ErrorPhase)
+		suite.EqualValues(tc.err, report.Error.Message)
+	}
+}
+
+func (suite *SystemdDropInOperatorTestSuite) TestSystemdDropInAlreadyApplied() {
+	ctx := context.Background()
+
+	suite.mockConnector(ctx)
+	suite.mockSystemd.On("GetDropIn", ctx, "sapinit.service", "50-timeout.conf").
+		Return([]byte(sapinitDropInContent), true, nil).
+		Once()
+	suite.mockSystemd.On("Close").Return().Once()
+
+	report := suite.buildOperator(sapinitDropInArguments()).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.PLAN, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"unit":"sapinit.service","path":"` + sapinitDropInPath +
+			`","content":"[Service]\nRestart=no\nTimeoutStopSec=300\n"}`,
+		"after": `{"unit":"sapinit.service","path":"` + sapinitDropInPath +
+			`","content":"[Service]\nRestart=no\nTimeoutStopSec=300\n"}`,
+	}, report.Success.Diff)
+}
+
+func (suite *SystemdDropInOperatorTestSuite) TestSystemdDropInSuccess() {
+	ctx := context.Background()
+
+	suite.mockConnector(ctx)
+	getCall := suite.mockSystemd.On("GetDropIn", ctx, "sapinit.service", "50-timeout.conf").
+		Return([]byte("[Service]\nTimeoutStopSec=60\n"), true, nil).
+		Once()
+	writeCall := suite.mockSystemd.On("WriteDropIn", ctx, "sapinit.service", "50-timeout.conf",
+		[]byte(sapinitDropInContent)).
+		Return(nil).
+		Once().
+		NotBefore(getCall)
+	reloadCall := suite.mockSystemd.On("DaemonReload", ctx).
+		Return(nil).
+		Once().
+		NotBefore(writeCall)
+	suite.mockSystemd.On("GetDropIn", ctx, "sapinit.service", "50-timeout.conf").
+		Return([]byte(sapinitDropInContent), true, nil).
+		Once().
+		NotBefore(reloadCall)
+	suite.mockSystemd.On("GetDropInPaths", ctx, "sapinit.service").
+		Return([]string{"/usr/lib/systemd/system/sapinit.service.d/10-vendor.conf", sapinitDropInPath}, nil).
+		Once()
+	suite.mockSystemd.On("IsDirectiveApplied", ctx, "sapinit.service", "Service",
+		systemd.DropInDirective{Name: "Restart", Value: "no"}).
+		Return(true, nil).
+		Once()
+	suite.mockSystemd.On("IsDirectiveApplied", ctx, "sapinit.service", "Service",
+		systemd.DropInDirective{Name: "TimeoutStopSec", Value: "300"}).
+		Return(true, nil).
+		Once()
+	suite.mockSystemd.On("Close").Return().Once()
+
+	report := suite.buildOperator(sapinitDropInArguments()).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"unit":"sapinit.service","path":"` + sapinitDropInPath +
+			`","content":"[Service]\nTimeoutStopSec=60\n"}`,
+		"after": `{"unit":"sapinit.service","path":"` + sapinitDropInPath +
+			`","content":"[Service]\nRestart=no\nTimeoutStopSec=300\n"}`,
+	}, report.Success.Diff)
+}
+
+func (suite *SystemdDropInOperatorTestSuite) TestSystemdDropInRemoveSuccess() {
+	ctx := context.Background()
+
+	suite.mockConnector(ctx)
+	getCall := suite.mockSystemd.On("GetDropIn", ctx, "sapinit.service", "50-timeout.conf").
+		Return([]byte(sapinitDropInContent), true, nil).
+		Once()
+	removeCall := suite.mockSystemd.On("RemoveDropIn", ctx, "sapinit.service", "50-timeout.conf").
+		Return(nil).
+		Once().
+		NotBefore(getCall)
+	reloadCall := suite.mockSystemd.On("DaemonReload", ctx).
+		Return(nil).
+		Once().
+		NotBefore(removeCall)
+	suite.mockSystemd.On("GetDropIn", ctx, "sapinit.service", "50-timeout.conf").
+		Return(nil, false, nil).
+		Once().
+		NotBefore(reloadCall)
+	suite.mockSystemd.On("GetDropInPaths", ctx, "sapinit.service").
+		Return([]string{}, nil).
+		Once()
+	suite.mockSystemd.On("Close").Return().Once()
+
+	report := suite.buildOperator(operator.Arguments{
+		"unit":   "sapinit.service",
+		"name":   "50-timeout.conf",
+		"remove": true,
+	}).Run(ctx)
+
+	suite.Nil(report.Error)
+	suite.Equal(operator.VERIFY, report.Success.LastPhase)
+	suite.EqualValues(map[string]any{
+		"before": `{"unit":"sapinit.service","path":"` + sapinitDropInPath +
+			`","content":"[Service]\nRestart=no\nTimeoutStopSec=300\n"}`,
+		"after": `{"unit":"sapinit.service","path":"` + sapinitDropInPath + `","content":null}`,
+	}, report.Success.Diff)
+}
+
+func (suite *SystemdDropInOperatorTestSuite) TestSystemdDropInVerifyNotAppliedRollback() {
+	ctx := context.Background()
+
+	suite.mockConnector(ctx)
+	suite.mockSystemd.On("GetDropIn", ctx, "sapinit.service", "50-timeout.conf").
+		Return(nil, false, nil).
+		Once()
+	suite.mockSystemd.On("WriteDropIn", ctx, "sapinit.service", "50-timeout.conf",
+		[]byte(sapinitDropInContent)).
+		Return(nil).
+		Once()
+	suite.mockSystemd.On("DaemonReload", ctx).
+		Return(nil).
+		Once()
+	suite.mockSystemd.On("GetDropIn", ctx, "sapinit.service", "50-timeout.conf").
+		Return([]byte(sapinitDropInContent), true, nil).
+		Once()
+	suite.mockSystemd.On("GetDropInPaths", ctx, "sapinit.service").
+		Return([]string{sapinitDropInPath}, nil).
+		Once()
+	suite.mockSystemd.On("IsDirectiveApplied", ctx, "sapinit.service", "Service",
+		systemd.DropInDirective{Name: "Restart", Value: "no"}).
+		Return(false, nil).
+		Once()
+	suite.mockSystemd.On("RemoveDropIn", ctx, "sapinit.service", "50-timeout.conf").
+		Return(nil).
+		Once()
+	suite.mockSystemd.On("DaemonReload", ctx).
+		Return(errors.New("access denied")).
+		Once()
+	suite.mockSystemd.On("Close").Return().Once()
+
+	report := suite.buildOperator(sapinitDropInArguments()).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.ROLLBACK, report.Error.ErrorPhase)
+	suite.EqualValues("error rolling back systemd drop-in: access denied\n"+
+		"verify systemd drop-in failing, directive Restart of unit sapinit.service does not have the value no",
+		report.Error.Message)
+}
+
+func (suite *SystemdDropInOperatorTestSuite) TestSystemdDropInVerifyNotLoadedRollback() {
+	ctx := context.Background()
+
+	suite.mockConnector(ctx)
+	suite.mockSystemd.On("GetDropIn", ctx, "sapinit.service", "50-timeout.conf").
+		Return([]byte("[Service]\nTimeoutStopSec=60\n"), true, nil).
+		Once()
+	suite.mockSystemd.On("WriteDropIn", ctx, "sapinit.service", "50-timeout.conf",
+		[]byte(sapinitDropInContent)).
+		Return(nil).
+		Once()
+	suite.mockSystemd.On("DaemonReload", ctx).
+		Return(nil).
+		Twice()
+	suite.mockSystemd.On("GetDropIn", ctx, "sapinit.service", "50-timeout.conf").
+		Return([]byte(sapinitDropInContent), true, nil).
+		Once()
+	suite.mockSystemd.On("GetDropInPaths", ctx, "sapinit.service").
+		Return([]string{}, nil).
+		Once()
+	suite.mockSystemd.On("WriteDropIn", ctx, "sapinit.service", "50-timeout.conf",
+		[]byte("[Service]\nTimeoutStopSec=60\n")).
+		Return(nil).
+		Once()
+	suite.mockSystemd.On("Close").Return().Once()
+
+	report := suite.buildOperator(sapinitDropInArguments()).Run(ctx)
+
+	suite.Nil(report.Success)
+	suite.Equal(operator.VERIFY, report.Error.ErrorPhase)
+	suite.EqualValues("verify systemd drop-in failing, the drop-in "+sapinitDropInPath+
+		" was not loaded by systemd", report.Error.Message)
+}